@@ -13,6 +13,7 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/api"
 	"github.com/concourse/atc/api/auth"
 	"github.com/concourse/atc/creds/credsfakes"
@@ -48,6 +49,8 @@ var (
 	dbWorkerLifecycle       *dbfakes.FakeWorkerLifecycle
 	build                   *dbfakes.FakeBuild
 	dbBuildFactory          *dbfakes.FakeBuildFactory
+	dbAuditLog              *dbfakes.FakeAuditLog
+	dbKeyRotator            *dbfakes.FakeKeyRotator
 	dbTeam                  *dbfakes.FakeTeam
 	fakeSchedulerFactory    *jobserverfakes.FakeSchedulerFactory
 	fakeScannerFactory      *resourceserverfakes.FakeScannerFactory
@@ -91,6 +94,8 @@ var _ = BeforeEach(func() {
 	dbTeamFactory = new(dbfakes.FakeTeamFactory)
 	dbPipelineFactory = new(dbfakes.FakePipelineFactory)
 	dbBuildFactory = new(dbfakes.FakeBuildFactory)
+	dbAuditLog = new(dbfakes.FakeAuditLog)
+	dbKeyRotator = new(dbfakes.FakeKeyRotator)
 
 	interceptTimeoutFactory = new(containerserverfakes.FakeInterceptTimeoutFactory)
 	interceptTimeout = new(containerserverfakes.FakeInterceptTimeout)
@@ -109,6 +114,7 @@ var _ = BeforeEach(func() {
 
 	jwtValidator = new(authfakes.FakeValidator)
 	userContextReader = new(authfakes.FakeUserContextReader)
+	userContextReader.GetRoleReturns(atc.OwnerRole, true)
 
 	peerAddr = "127.0.0.1:1234"
 	drain = make(chan struct{})
@@ -162,6 +168,7 @@ var _ = BeforeEach(func() {
 			checkBuildReadAccessHandlerFactory,
 			checkBuildWriteAccessHandlerFactory,
 			checkWorkerTeamAccessHandlerFactory,
+			dbTeamFactory,
 		),
 
 		oAuthBaseURL,
@@ -172,6 +179,8 @@ var _ = BeforeEach(func() {
 		fakeVolumeFactory,
 		fakeContainerRepository,
 		dbBuildFactory,
+		dbAuditLog,
+		dbKeyRotator,
 
 		peerAddr,
 		constructedEventHandler.Construct,
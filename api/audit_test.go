@@ -0,0 +1,151 @@
+package api_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/concourse/atc/db"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Audit API", func() {
+	Describe("GET /api/v1/audit", func() {
+		var (
+			queryParams string
+			response    *http.Response
+		)
+
+		BeforeEach(func() {
+			queryParams = ""
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(server.URL + "/api/v1/audit" + queryParams)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				jwtValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+
+		Context("when authenticated as a non-admin team", func() {
+			BeforeEach(func() {
+				jwtValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("some-team", false, true)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when authenticated as an admin team", func() {
+			BeforeEach(func() {
+				jwtValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("main", true, true)
+			})
+
+			Context("when no params are passed", func() {
+				It("fetches the default page of events", func() {
+					Expect(dbAuditLog.EventsCallCount()).To(Equal(1))
+					Expect(dbAuditLog.EventsArgsForCall(0)).To(Equal(db.Page{Limit: 100}))
+				})
+			})
+
+			Context("when pagination params are passed", func() {
+				BeforeEach(func() {
+					queryParams = "?since=42&limit=2"
+				})
+
+				It("fetches the requested page of events", func() {
+					Expect(dbAuditLog.EventsCallCount()).To(Equal(1))
+					Expect(dbAuditLog.EventsArgsForCall(0)).To(Equal(db.Page{Since: 42, Limit: 2}))
+				})
+			})
+
+			Context("when getting the events succeeds", func() {
+				BeforeEach(func() {
+					dbAuditLog.EventsReturns([]db.AuditEvent{
+						{
+							ID:       4,
+							Time:     time.Unix(1, 0),
+							User:     "some-user",
+							TeamName: "some-team",
+							Route:    "PausePipeline",
+							Params:   map[string]string{"pipeline_name": "some-pipeline"},
+							Status:   200,
+						},
+						{
+							ID:     3,
+							Time:   time.Unix(2, 0),
+							Route:  "RegisterWorker",
+							Status: 403,
+						},
+					}, db.Pagination{
+						Previous: &db.Page{Until: 4, Limit: 2},
+						Next:     &db.Page{Since: 3, Limit: 2},
+					}, nil)
+				})
+
+				It("returns 200 OK", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				It("returns Content-Type 'application/json'", func() {
+					Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+				})
+
+				It("returns the events", func() {
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`[
+						{
+							"id": 4,
+							"time": 1,
+							"user": "some-user",
+							"team_name": "some-team",
+							"route": "PausePipeline",
+							"params": {"pipeline_name": "some-pipeline"},
+							"status": 200
+						},
+						{
+							"id": 3,
+							"time": 2,
+							"route": "RegisterWorker",
+							"status": 403
+						}
+					]`))
+				})
+
+				It("returns Link headers per rfc5988", func() {
+					Expect(response.Header["Link"]).To(ConsistOf([]string{
+						`<https://example.com/api/v1/audit?until=4&limit=2>; rel="previous"`,
+						`<https://example.com/api/v1/audit?since=3&limit=2>; rel="next"`,
+					}))
+				})
+			})
+
+			Context("when getting the events fails", func() {
+				BeforeEach(func() {
+					dbAuditLog.EventsReturns(nil, db.Pagination{}, errors.New("nope"))
+				})
+
+				It("returns 500 Internal Server Error", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
+})
@@ -0,0 +1,77 @@
+package auditserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/present"
+	"github.com/concourse/atc/db"
+)
+
+func (s *Server) ListAuditEvents(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("list-audit-events")
+
+	until, _ := strconv.Atoi(r.FormValue(atc.PaginationQueryUntil))
+	since, _ := strconv.Atoi(r.FormValue(atc.PaginationQuerySince))
+
+	limit, _ := strconv.Atoi(r.FormValue(atc.PaginationQueryLimit))
+	if limit == 0 {
+		limit = atc.PaginationAPIDefaultLimit
+	}
+
+	events, pagination, err := s.auditLog.Events(db.Page{Until: until, Since: since, Limit: limit})
+	if err != nil {
+		logger.Error("failed-to-get-audit-events", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if pagination.Next != nil {
+		s.addNextLink(w, *pagination.Next)
+	}
+
+	if pagination.Previous != nil {
+		s.addPreviousLink(w, *pagination.Previous)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	presentedEvents := make([]atc.AuditEvent, len(events))
+	for i, event := range events {
+		presentedEvents[i] = present.AuditEvent(event)
+	}
+
+	err = json.NewEncoder(w).Encode(presentedEvents)
+	if err != nil {
+		logger.Error("failed-to-encode-audit-events", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+func (s *Server) addNextLink(w http.ResponseWriter, page db.Page) {
+	w.Header().Add("Link", fmt.Sprintf(
+		`<%s/api/v1/audit?%s=%d&%s=%d>; rel="%s"`,
+		s.externalURL,
+		atc.PaginationQuerySince,
+		page.Since,
+		atc.PaginationQueryLimit,
+		page.Limit,
+		atc.LinkRelNext,
+	))
+}
+
+func (s *Server) addPreviousLink(w http.ResponseWriter, page db.Page) {
+	w.Header().Add("Link", fmt.Sprintf(
+		`<%s/api/v1/audit?%s=%d&%s=%d>; rel="%s"`,
+		s.externalURL,
+		atc.PaginationQueryUntil,
+		page.Until,
+		atc.PaginationQueryLimit,
+		page.Limit,
+		atc.LinkRelPrevious,
+	))
+}
@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/concourse/atc"
 	jwt "github.com/dgrijalva/jwt-go"
 )
 
@@ -62,18 +63,38 @@ func GetTeam(r *http.Request) (Team, bool) {
 		return nil, false
 	}
 
-	return &team{name: teamName, isAdmin: isAdmin}, true
+	// tokens without a role claim have their role resolved from the team's
+	// roles; until then they are granted nothing
+	role, _ := r.Context().Value(roleKey).(string)
+
+	return &team{name: teamName, isAdmin: isAdmin, role: role}, true
+}
+
+// Identity is who a token was issued to, as matched against the members of
+// a team's roles.
+type Identity struct {
+	Provider string
+	User     string
+	Groups   []string
+}
+
+func GetIdentity(r *http.Request) (Identity, bool) {
+	identity, present := r.Context().Value(identityKey).(Identity)
+	return identity, present
 }
 
 type Team interface {
 	Name() string
 	IsAdmin() bool
 	IsAuthorized(teamName string) bool
+	Role() string
+	HasRole(role string) bool
 }
 
 type team struct {
 	name    string
 	isAdmin bool
+	role    string
 }
 
 func (t *team) Name() string {
@@ -87,3 +108,11 @@ func (t *team) IsAdmin() bool {
 func (t *team) IsAuthorized(teamName string) bool {
 	return t.name == teamName
 }
+
+func (t *team) Role() string {
+	return t.role
+}
+
+func (t *team) HasRole(role string) bool {
+	return atc.RoleGrants(t.role, role)
+}
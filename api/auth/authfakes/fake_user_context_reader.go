@@ -50,6 +50,45 @@ type FakeUserContextReader struct {
 		result1 string
 		result2 bool
 	}
+	GetRoleStub        func(r *http.Request) (string, bool)
+	getRoleMutex       sync.RWMutex
+	getRoleArgsForCall []struct {
+		r *http.Request
+	}
+	getRoleReturns struct {
+		result1 string
+		result2 bool
+	}
+	getRoleReturnsOnCall map[int]struct {
+		result1 string
+		result2 bool
+	}
+	GetUserStub        func(r *http.Request) (string, bool)
+	getUserMutex       sync.RWMutex
+	getUserArgsForCall []struct {
+		r *http.Request
+	}
+	getUserReturns struct {
+		result1 string
+		result2 bool
+	}
+	getUserReturnsOnCall map[int]struct {
+		result1 string
+		result2 bool
+	}
+	GetIdentityStub        func(r *http.Request) (auth.Identity, bool)
+	getIdentityMutex       sync.RWMutex
+	getIdentityArgsForCall []struct {
+		r *http.Request
+	}
+	getIdentityReturns struct {
+		result1 auth.Identity
+		result2 bool
+	}
+	getIdentityReturnsOnCall map[int]struct {
+		result1 auth.Identity
+		result2 bool
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -210,6 +249,159 @@ func (fake *FakeUserContextReader) GetCSRFTokenReturnsOnCall(i int, result1 stri
 	}{result1, result2}
 }
 
+func (fake *FakeUserContextReader) GetRole(r *http.Request) (string, bool) {
+	fake.getRoleMutex.Lock()
+	ret, specificReturn := fake.getRoleReturnsOnCall[len(fake.getRoleArgsForCall)]
+	fake.getRoleArgsForCall = append(fake.getRoleArgsForCall, struct {
+		r *http.Request
+	}{r})
+	fake.recordInvocation("GetRole", []interface{}{r})
+	fake.getRoleMutex.Unlock()
+	if fake.GetRoleStub != nil {
+		return fake.GetRoleStub(r)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.getRoleReturns.result1, fake.getRoleReturns.result2
+}
+
+func (fake *FakeUserContextReader) GetRoleCallCount() int {
+	fake.getRoleMutex.RLock()
+	defer fake.getRoleMutex.RUnlock()
+	return len(fake.getRoleArgsForCall)
+}
+
+func (fake *FakeUserContextReader) GetRoleArgsForCall(i int) *http.Request {
+	fake.getRoleMutex.RLock()
+	defer fake.getRoleMutex.RUnlock()
+	return fake.getRoleArgsForCall[i].r
+}
+
+func (fake *FakeUserContextReader) GetRoleReturns(result1 string, result2 bool) {
+	fake.GetRoleStub = nil
+	fake.getRoleReturns = struct {
+		result1 string
+		result2 bool
+	}{result1, result2}
+}
+
+func (fake *FakeUserContextReader) GetRoleReturnsOnCall(i int, result1 string, result2 bool) {
+	fake.GetRoleStub = nil
+	if fake.getRoleReturnsOnCall == nil {
+		fake.getRoleReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 bool
+		})
+	}
+	fake.getRoleReturnsOnCall[i] = struct {
+		result1 string
+		result2 bool
+	}{result1, result2}
+}
+
+func (fake *FakeUserContextReader) GetUser(r *http.Request) (string, bool) {
+	fake.getUserMutex.Lock()
+	ret, specificReturn := fake.getUserReturnsOnCall[len(fake.getUserArgsForCall)]
+	fake.getUserArgsForCall = append(fake.getUserArgsForCall, struct {
+		r *http.Request
+	}{r})
+	fake.recordInvocation("GetUser", []interface{}{r})
+	fake.getUserMutex.Unlock()
+	if fake.GetUserStub != nil {
+		return fake.GetUserStub(r)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.getUserReturns.result1, fake.getUserReturns.result2
+}
+
+func (fake *FakeUserContextReader) GetUserCallCount() int {
+	fake.getUserMutex.RLock()
+	defer fake.getUserMutex.RUnlock()
+	return len(fake.getUserArgsForCall)
+}
+
+func (fake *FakeUserContextReader) GetUserArgsForCall(i int) *http.Request {
+	fake.getUserMutex.RLock()
+	defer fake.getUserMutex.RUnlock()
+	return fake.getUserArgsForCall[i].r
+}
+
+func (fake *FakeUserContextReader) GetUserReturns(result1 string, result2 bool) {
+	fake.GetUserStub = nil
+	fake.getUserReturns = struct {
+		result1 string
+		result2 bool
+	}{result1, result2}
+}
+
+func (fake *FakeUserContextReader) GetUserReturnsOnCall(i int, result1 string, result2 bool) {
+	fake.GetUserStub = nil
+	if fake.getUserReturnsOnCall == nil {
+		fake.getUserReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 bool
+		})
+	}
+	fake.getUserReturnsOnCall[i] = struct {
+		result1 string
+		result2 bool
+	}{result1, result2}
+}
+
+func (fake *FakeUserContextReader) GetIdentity(r *http.Request) (auth.Identity, bool) {
+	fake.getIdentityMutex.Lock()
+	ret, specificReturn := fake.getIdentityReturnsOnCall[len(fake.getIdentityArgsForCall)]
+	fake.getIdentityArgsForCall = append(fake.getIdentityArgsForCall, struct {
+		r *http.Request
+	}{r})
+	fake.recordInvocation("GetIdentity", []interface{}{r})
+	fake.getIdentityMutex.Unlock()
+	if fake.GetIdentityStub != nil {
+		return fake.GetIdentityStub(r)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.getIdentityReturns.result1, fake.getIdentityReturns.result2
+}
+
+func (fake *FakeUserContextReader) GetIdentityCallCount() int {
+	fake.getIdentityMutex.RLock()
+	defer fake.getIdentityMutex.RUnlock()
+	return len(fake.getIdentityArgsForCall)
+}
+
+func (fake *FakeUserContextReader) GetIdentityArgsForCall(i int) *http.Request {
+	fake.getIdentityMutex.RLock()
+	defer fake.getIdentityMutex.RUnlock()
+	return fake.getIdentityArgsForCall[i].r
+}
+
+func (fake *FakeUserContextReader) GetIdentityReturns(result1 auth.Identity, result2 bool) {
+	fake.GetIdentityStub = nil
+	fake.getIdentityReturns = struct {
+		result1 auth.Identity
+		result2 bool
+	}{result1, result2}
+}
+
+func (fake *FakeUserContextReader) GetIdentityReturnsOnCall(i int, result1 auth.Identity, result2 bool) {
+	fake.GetIdentityStub = nil
+	if fake.getIdentityReturnsOnCall == nil {
+		fake.getIdentityReturnsOnCall = make(map[int]struct {
+			result1 auth.Identity
+			result2 bool
+		})
+	}
+	fake.getIdentityReturnsOnCall[i] = struct {
+		result1 auth.Identity
+		result2 bool
+	}{result1, result2}
+}
+
 func (fake *FakeUserContextReader) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -219,6 +411,12 @@ func (fake *FakeUserContextReader) Invocations() map[string][][]interface{} {
 	defer fake.getSystemMutex.RUnlock()
 	fake.getCSRFTokenMutex.RLock()
 	defer fake.getCSRFTokenMutex.RUnlock()
+	fake.getRoleMutex.RLock()
+	defer fake.getRoleMutex.RUnlock()
+	fake.getUserMutex.RLock()
+	defer fake.getUserMutex.RUnlock()
+	fake.getIdentityMutex.RLock()
+	defer fake.getIdentityMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
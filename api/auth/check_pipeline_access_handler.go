@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/http"
 
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
 )
 
@@ -54,7 +55,14 @@ func (h checkPipelineAccessHandler) ServeHTTP(w http.ResponseWriter, r *http.Req
 		w.WriteHeader(http.StatusNotFound)
 		return
 	}
-	pipeline, found, err := team.Pipeline(pipelineName)
+
+	instanceVars, err := atc.ParseInstanceVars(r.FormValue(atc.InstanceVarsQueryParam))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	pipeline, found, err := FindPipeline(team, pipelineName, instanceVars)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		return
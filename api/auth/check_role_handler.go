@@ -0,0 +1,74 @@
+package auth
+
+import (
+	"net/http"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+)
+
+type checkRoleHandler struct {
+	handler     http.Handler
+	role        string
+	teamFactory db.TeamFactory
+	rejector    Rejector
+}
+
+// CheckRoleHandler rejects requests made by team members whose role does not
+// grant the given role. Admins are let through. Members whose token carries no
+// role are given the role the team's configured roles grant them, which for
+// teams without roles, or tokens without an identity, is the role RoleFor
+// gives anyone. Requests that are not made on behalf of a team member are left
+// for the other handlers to check.
+func CheckRoleHandler(
+	handler http.Handler,
+	role string,
+	teamFactory db.TeamFactory,
+	rejector Rejector,
+) http.Handler {
+	return checkRoleHandler{
+		handler:     handler,
+		role:        role,
+		teamFactory: teamFactory,
+		rejector:    rejector,
+	}
+}
+
+func (h checkRoleHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	authTeam, authTeamFound := GetTeam(r)
+	if !authTeamFound {
+		h.handler.ServeHTTP(w, r)
+		return
+	}
+
+	if authTeam.IsAdmin() {
+		h.handler.ServeHTTP(w, r)
+		return
+	}
+
+	role := authTeam.Role()
+	if role == "" {
+		// tokens issued without an identity still get the team's default role
+		identity, _ := GetIdentity(r)
+
+		team, found, err := h.teamFactory.FindTeam(authTeam.Name())
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			h.rejector.Forbidden(w, r)
+			return
+		}
+
+		role = atc.Team{Roles: team.Roles()}.RoleFor(identity.Provider, identity.User, identity.Groups)
+	}
+
+	if !atc.RoleGrants(role, h.role) {
+		h.rejector.Forbidden(w, r)
+		return
+	}
+
+	h.handler.ServeHTTP(w, r)
+}
@@ -0,0 +1,255 @@
+package auth_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/auth"
+	"github.com/concourse/atc/api/auth/authfakes"
+	"github.com/concourse/atc/db/dbfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CheckRoleHandler", func() {
+	var (
+		fakeValidator         *authfakes.FakeValidator
+		fakeUserContextReader *authfakes.FakeUserContextReader
+		fakeRejector          *authfakes.FakeRejector
+		fakeTeamFactory       *dbfakes.FakeTeamFactory
+		fakeTeam              *dbfakes.FakeTeam
+
+		server *httptest.Server
+		client *http.Client
+	)
+
+	simpleHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		buffer := bytes.NewBufferString("simple ")
+
+		io.Copy(w, buffer)
+		io.Copy(w, r.Body)
+	})
+
+	BeforeEach(func() {
+		fakeValidator = new(authfakes.FakeValidator)
+		fakeUserContextReader = new(authfakes.FakeUserContextReader)
+		fakeRejector = new(authfakes.FakeRejector)
+		fakeTeamFactory = new(dbfakes.FakeTeamFactory)
+		fakeTeam = new(dbfakes.FakeTeam)
+
+		fakeRejector.ForbiddenStub = func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "nope", http.StatusForbidden)
+		}
+
+		server = httptest.NewServer(
+			auth.WrapHandler( // for setting context on the request
+				auth.CheckRoleHandler(
+					simpleHandler,
+					atc.MemberRole,
+					fakeTeamFactory,
+					fakeRejector,
+				),
+				fakeValidator,
+				fakeUserContextReader,
+			),
+		)
+
+		client = &http.Client{
+			Transport: &http.Transport{},
+		}
+	})
+
+	Context("when a request is made", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			request, err := http.NewRequest("GET", server.URL, bytes.NewBufferString("hello"))
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when the request is made by a team member", func() {
+			BeforeEach(func() {
+				fakeUserContextReader.GetTeamReturns("some-team", false, true)
+			})
+
+			Context("whose role grants the required role", func() {
+				BeforeEach(func() {
+					fakeUserContextReader.GetRoleReturns(atc.OwnerRole, true)
+				})
+
+				It("proxies to the handler", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+					responseBody, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(string(responseBody)).To(Equal("simple hello"))
+				})
+			})
+
+			Context("whose role does not grant the required role", func() {
+				BeforeEach(func() {
+					fakeUserContextReader.GetRoleReturns(atc.PipelineOperatorRole, true)
+				})
+
+				It("returns 403", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+
+					responseBody, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(string(responseBody)).To(Equal("nope\n"))
+				})
+			})
+
+			Context("whose token does not have a role", func() {
+				BeforeEach(func() {
+					fakeUserContextReader.GetRoleReturns("", false)
+				})
+
+				Context("and identifies the user", func() {
+					BeforeEach(func() {
+						fakeUserContextReader.GetIdentityReturns(auth.Identity{
+							Provider: "github",
+							User:     "some-user",
+							Groups:   []string{"some-org:some-team"},
+						}, true)
+					})
+
+					Context("when the team grants the user the required role", func() {
+						BeforeEach(func() {
+							fakeTeam.RolesReturns(atc.TeamRoles{
+								atc.MemberRole: {
+									"github": atc.RoleMembers{Groups: []string{"some-org:some-team"}},
+								},
+							})
+							fakeTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+						})
+
+						It("looks up the user's team", func() {
+							Expect(fakeTeamFactory.FindTeamCallCount()).To(Equal(1))
+							Expect(fakeTeamFactory.FindTeamArgsForCall(0)).To(Equal("some-team"))
+						})
+
+						It("proxies to the handler", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusOK))
+						})
+					})
+
+					Context("when the team does not grant the user a role", func() {
+						BeforeEach(func() {
+							fakeTeam.RolesReturns(atc.TeamRoles{
+								atc.OwnerRole: {
+									"github": atc.RoleMembers{Users: []string{"some-owner"}},
+								},
+							})
+							fakeTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+						})
+
+						It("treats the user as a viewer and returns 403", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+						})
+					})
+
+					Context("when the team does not configure any roles", func() {
+						BeforeEach(func() {
+							fakeTeam.RolesReturns(nil)
+							fakeTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+						})
+
+						It("proxies to the handler", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusOK))
+						})
+					})
+
+					Context("when the team is not found", func() {
+						BeforeEach(func() {
+							fakeTeamFactory.FindTeamReturns(nil, false, nil)
+						})
+
+						It("returns 403", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+						})
+					})
+
+					Context("when finding the team fails", func() {
+						BeforeEach(func() {
+							fakeTeamFactory.FindTeamReturns(nil, false, errors.New("nope"))
+						})
+
+						It("returns 500", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+						})
+					})
+				})
+
+				Context("and does not identify the user", func() {
+					BeforeEach(func() {
+						fakeUserContextReader.GetIdentityReturns(auth.Identity{}, false)
+					})
+
+					Context("when the team does not configure any roles", func() {
+						BeforeEach(func() {
+							fakeTeam.RolesReturns(nil)
+							fakeTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+						})
+
+						It("treats the user as an owner and proxies to the handler", func() {
+							Expect(fakeTeamFactory.FindTeamArgsForCall(0)).To(Equal("some-team"))
+							Expect(response.StatusCode).To(Equal(http.StatusOK))
+						})
+					})
+
+					Context("when the team configures roles", func() {
+						BeforeEach(func() {
+							fakeTeam.RolesReturns(atc.TeamRoles{
+								atc.OwnerRole: {
+									"github": atc.RoleMembers{Users: []string{"some-owner"}},
+								},
+							})
+							fakeTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+						})
+
+						It("treats the user as a viewer and returns 403", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+						})
+					})
+
+					Context("when the user is an admin", func() {
+						BeforeEach(func() {
+							fakeUserContextReader.GetTeamReturns("some-team", true, true)
+							fakeTeam.RolesReturns(atc.TeamRoles{
+								atc.OwnerRole: {
+									"github": atc.RoleMembers{Users: []string{"some-owner"}},
+								},
+							})
+							fakeTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+						})
+
+						It("proxies to the handler without looking up the team", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusOK))
+							Expect(fakeTeamFactory.FindTeamCallCount()).To(BeZero())
+						})
+					})
+				})
+			})
+		})
+
+		Context("when the request is not made by a team member", func() {
+			BeforeEach(func() {
+				fakeUserContextReader.GetTeamReturns("", false, false)
+			})
+
+			It("proxies to the handler", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+			})
+		})
+	})
+})
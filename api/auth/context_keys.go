@@ -6,6 +6,10 @@ const PipelineContextKey = "pipeline"
 const TokenTypeBearer = "Bearer"
 const teamNameClaimKey = "teamName"
 const isAdminClaimKey = "isAdmin"
+const roleClaimKey = "role"
+const userClaimKey = "sub"
+const providerClaimKey = "provider"
+const groupsClaimKey = "groups"
 const csrfTokenClaimKey = "csrf"
 
 const AuthCookieName = "ATC-Authorization"
@@ -15,5 +19,7 @@ const CSRFHeaderName = "X-Csrf-Token"
 const authenticated = "authenticated"
 const teamNameKey = "teamName"
 const isAdminKey = "isAdmin"
+const roleKey = "role"
+const identityKey = "identity"
 const isSystemKey = "system"
 const CSRFTokenKey = "csrfToken"
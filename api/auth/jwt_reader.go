@@ -4,6 +4,7 @@ import (
 	"crypto/rsa"
 	"net/http"
 
+	"github.com/concourse/atc"
 	jwt "github.com/dgrijalva/jwt-go"
 )
 
@@ -11,6 +12,9 @@ import (
 type UserContextReader interface {
 	GetTeam(r *http.Request) (string, bool, bool)
 	GetSystem(r *http.Request) (bool, bool)
+	GetRole(r *http.Request) (string, bool)
+	GetUser(r *http.Request) (string, bool)
+	GetIdentity(r *http.Request) (Identity, bool)
 	GetCSRFToken(r *http.Request) (string, bool)
 }
 
@@ -25,16 +29,13 @@ func (jr JWTReader) GetTeam(r *http.Request) (string, bool, bool) {
 	}
 
 	claims := token.Claims.(jwt.MapClaims)
-	teamNameInterface, teamNameOK := claims[teamNameClaimKey]
-	isAdminInterface, isAdminOK := claims[isAdminClaimKey]
+	teamName, teamNameOK := claims[teamNameClaimKey].(string)
+	isAdmin, isAdminOK := claims[isAdminClaimKey].(bool)
 
 	if !(teamNameOK && isAdminOK) {
 		return "", false, false
 	}
 
-	teamName := teamNameInterface.(string)
-	isAdmin := isAdminInterface.(bool)
-
 	return teamName, isAdmin, true
 }
 
@@ -53,6 +54,65 @@ func (jr JWTReader) GetSystem(r *http.Request) (bool, bool) {
 	return isSystemInterface.(bool), true
 }
 
+func (jr JWTReader) GetRole(r *http.Request) (string, bool) {
+	token, err := getJWT(r, jr.PublicKey)
+	if err != nil {
+		return "", false
+	}
+
+	claims := token.Claims.(jwt.MapClaims)
+	role, ok := claims[roleClaimKey].(string)
+	if !ok || !atc.IsValidRole(role) {
+		return "", false
+	}
+
+	return role, true
+}
+
+func (jr JWTReader) GetUser(r *http.Request) (string, bool) {
+	token, err := getJWT(r, jr.PublicKey)
+	if err != nil {
+		return "", false
+	}
+
+	claims := token.Claims.(jwt.MapClaims)
+	user, ok := claims[userClaimKey].(string)
+	if !ok {
+		return "", false
+	}
+
+	return user, true
+}
+
+func (jr JWTReader) GetIdentity(r *http.Request) (Identity, bool) {
+	token, err := getJWT(r, jr.PublicKey)
+	if err != nil {
+		return Identity{}, false
+	}
+
+	claims := token.Claims.(jwt.MapClaims)
+	user, ok := claims[userClaimKey].(string)
+	if !ok {
+		return Identity{}, false
+	}
+
+	provider, _ := claims[providerClaimKey].(string)
+
+	var groups []string
+	groupClaims, _ := claims[groupsClaimKey].([]interface{})
+	for _, groupClaim := range groupClaims {
+		if group, ok := groupClaim.(string); ok {
+			groups = append(groups, group)
+		}
+	}
+
+	return Identity{
+		Provider: provider,
+		User:     user,
+		Groups:   groups,
+	}, true
+}
+
 func (jr JWTReader) GetCSRFToken(r *http.Request) (string, bool) {
 	token, err := getJWT(r, jr.PublicKey)
 	if err != nil {
@@ -0,0 +1,17 @@
+package auth
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+)
+
+// FindPipeline finds the pipeline addressed by a request: the instance
+// identified by the instance vars if any were given, otherwise the pipeline
+// with the given name.
+func FindPipeline(team db.Team, pipelineName string, instanceVars atc.InstanceVars) (db.Pipeline, bool, error) {
+	if len(instanceVars) == 0 {
+		return team.Pipeline(pipelineName)
+	}
+
+	return team.PipelineInstance(pipelineName, instanceVars)
+}
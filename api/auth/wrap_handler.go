@@ -34,6 +34,16 @@ func (h authHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		ctx = context.WithValue(ctx, isAdminKey, isAdmin)
 	}
 
+	role, found := h.userContextReader.GetRole(r)
+	if found {
+		ctx = context.WithValue(ctx, roleKey, role)
+	}
+
+	identity, found := h.userContextReader.GetIdentity(r)
+	if found {
+		ctx = context.WithValue(ctx, identityKey, identity)
+	}
+
 	isSystem, found := h.userContextReader.GetSystem(r)
 	if found {
 		ctx = context.WithValue(ctx, isSystemKey, isSystem)
@@ -23,6 +23,8 @@ var _ = Describe("WrapHandler", func() {
 		authenticated   <-chan bool
 		teamNameChan    <-chan string
 		isAdminChan     <-chan bool
+		roleChan        <-chan string
+		identityChan    <-chan auth.Identity
 		isSystemChan    <-chan bool
 		foundChan       <-chan bool
 		systemFoundChan <-chan bool
@@ -35,6 +37,8 @@ var _ = Describe("WrapHandler", func() {
 		a := make(chan bool, 1)
 		tn := make(chan string, 1)
 		ia := make(chan bool, 1)
+		ro := make(chan string, 1)
+		id := make(chan auth.Identity, 1)
 		is := make(chan bool, 1)
 		f := make(chan bool, 1)
 		sf := make(chan bool, 1)
@@ -42,6 +46,8 @@ var _ = Describe("WrapHandler", func() {
 		authenticated = a
 		teamNameChan = tn
 		isAdminChan = ia
+		roleChan = ro
+		identityChan = id
 		isSystemChan = is
 		foundChan = f
 		systemFoundChan = sf
@@ -55,6 +61,10 @@ var _ = Describe("WrapHandler", func() {
 			if authTeam != nil {
 				tn <- authTeam.Name()
 				ia <- authTeam.IsAdmin()
+				ro <- authTeam.Role()
+			}
+			if identity, found := auth.GetIdentity(r); found {
+				id <- identity
 			}
 			if systemFound {
 				is <- isSystem
@@ -119,6 +129,48 @@ var _ = Describe("WrapHandler", func() {
 			})
 		})
 
+		Context("when the userContextReader finds a role", func() {
+			BeforeEach(func() {
+				fakeUserContextReader.GetTeamReturns("some-team", true, true)
+				fakeUserContextReader.GetRoleReturns("viewer", true)
+			})
+
+			It("passes the role along in the request object", func() {
+				Expect(<-foundChan).To(BeTrue())
+				Expect(<-roleChan).To(Equal("viewer"))
+			})
+		})
+
+		Context("when the userContextReader does not find a role", func() {
+			BeforeEach(func() {
+				fakeUserContextReader.GetTeamReturns("some-team", true, true)
+				fakeUserContextReader.GetRoleReturns("", false)
+			})
+
+			It("does not grant the team member any role", func() {
+				Expect(<-foundChan).To(BeTrue())
+				Expect(<-roleChan).To(BeEmpty())
+			})
+		})
+
+		Context("when the userContextReader finds an identity", func() {
+			BeforeEach(func() {
+				fakeUserContextReader.GetIdentityReturns(auth.Identity{
+					Provider: "github",
+					User:     "some-user",
+					Groups:   []string{"some-org:some-team"},
+				}, true)
+			})
+
+			It("passes the identity along in the request object", func() {
+				Expect(<-identityChan).To(Equal(auth.Identity{
+					Provider: "github",
+					User:     "some-user",
+					Groups:   []string{"some-org:some-team"},
+				}))
+			})
+		})
+
 		Context("when the userContextReader does not find team information", func() {
 			BeforeEach(func() {
 				fakeUserContextReader.GetTeamReturns("", false, false)
@@ -297,6 +297,23 @@ var _ = Describe("Builds API", func() {
 						"reap_time": 200
 					}`))
 					})
+
+					Context("when the build's logs have been archived", func() {
+						BeforeEach(func() {
+							build.IsArchivedReturns(true)
+						})
+
+						It("returns the build as archived", func() {
+							body, err := ioutil.ReadAll(response.Body)
+							Expect(err).NotTo(HaveOccurred())
+
+							var returnedBuild atc.Build
+							err = json.Unmarshal(body, &returnedBuild)
+							Expect(err).NotTo(HaveOccurred())
+
+							Expect(returnedBuild.Archived).To(BeTrue())
+						})
+					})
 				})
 			})
 		})
@@ -614,6 +631,57 @@ var _ = Describe("Builds API", func() {
 				})
 			})
 
+			Context("when filtering on metadata", func() {
+				BeforeEach(func() {
+					queryParams = "?metadata=sha:abc123&metadata=url:https://example.com"
+				})
+
+				It("passes the metadata through", func() {
+					Expect(dbBuildFactory.PublicBuildsCallCount()).To(Equal(1))
+
+					page := dbBuildFactory.PublicBuildsArgsForCall(0)
+					Expect(page).To(Equal(db.Page{
+						Limit: 100,
+						Metadata: map[string]string{
+							"sha": "abc123",
+							"url": "https://example.com",
+						},
+					}))
+				})
+
+				Context("when next/previous pages are available", func() {
+					BeforeEach(func() {
+						metadata := map[string]string{"sha": "abc123", "url": "https://example.com"}
+
+						dbBuildFactory.PublicBuildsReturns(returnedBuilds, db.Pagination{
+							Previous: &db.Page{Until: 4, Limit: 2, Metadata: metadata},
+							Next:     &db.Page{Since: 3, Limit: 2, Metadata: metadata},
+						}, nil)
+					})
+
+					It("keeps the filter in the Link headers", func() {
+						Expect(response.Header["Link"]).To(ConsistOf([]string{
+							fmt.Sprintf(`<%s/api/v1/builds?until=4&limit=2&metadata=sha%%3Aabc123&metadata=url%%3Ahttps%%3A%%2F%%2Fexample.com>; rel="previous"`, externalURL),
+							fmt.Sprintf(`<%s/api/v1/builds?since=3&limit=2&metadata=sha%%3Aabc123&metadata=url%%3Ahttps%%3A%%2F%%2Fexample.com>; rel="next"`, externalURL),
+						}))
+					})
+				})
+			})
+
+			Context("when the metadata filter is malformed", func() {
+				BeforeEach(func() {
+					queryParams = "?metadata=sha"
+				})
+
+				It("returns 400 Bad Request", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+
+				It("does not look up any builds", func() {
+					Expect(dbBuildFactory.PublicBuildsCallCount()).To(BeZero())
+				})
+			})
+
 			Context("when getting the builds succeeds", func() {
 				BeforeEach(func() {
 					dbBuildFactory.PublicBuildsReturns(returnedBuilds, db.Pagination{}, nil)
@@ -660,6 +728,39 @@ var _ = Describe("Builds API", func() {
 				})
 			})
 
+			Context("when a build has metadata", func() {
+				BeforeEach(func() {
+					build := new(dbfakes.FakeBuild)
+					build.IDReturns(5)
+					build.NameReturns("3")
+					build.JobNameReturns("job2")
+					build.PipelineNameReturns("pipeline2")
+					build.TeamNameReturns("some-team")
+					build.StatusReturns(db.BuildStatusSucceeded)
+					build.MetadataReturns(map[string]string{"sha": "abc123"})
+
+					dbBuildFactory.PublicBuildsReturns([]db.Build{build}, db.Pagination{}, nil)
+				})
+
+				It("includes the metadata", func() {
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`[
+						{
+							"id": 5,
+							"name": "3",
+							"job_name": "job2",
+							"pipeline_name": "pipeline2",
+							"team_name": "some-team",
+							"status": "succeeded",
+							"api_url": "/api/v1/builds/5",
+							"metadata": {"sha": "abc123"}
+						}
+					]`))
+				})
+			})
+
 			Context("when next/previous pages are available", func() {
 				BeforeEach(func() {
 					dbBuildFactory.PublicBuildsReturns(returnedBuilds, db.Pagination{
@@ -804,6 +905,268 @@ var _ = Describe("Builds API", func() {
 		})
 	})
 
+	Describe("GET /api/v1/build_logs/search", func() {
+		var (
+			response    *http.Response
+			queryParams string
+			matches     []db.BuildLogMatch
+		)
+
+		BeforeEach(func() {
+			queryParams = "?query=some-error&after=10"
+
+			build := new(dbfakes.FakeBuild)
+			build.IDReturns(4)
+			build.NameReturns("2")
+			build.JobNameReturns("job2")
+			build.PipelineNameReturns("pipeline2")
+			build.TeamNameReturns("some-team")
+			build.StatusReturns(db.BuildStatusFailed)
+			build.StartTimeReturns(time.Unix(1, 0))
+			build.EndTimeReturns(time.Unix(100, 0))
+
+			matches = []db.BuildLogMatch{
+				{
+					Build: build,
+					Lines: []db.BuildLogLine{
+						{Offset: 3, Text: "some-error happened"},
+						{Offset: 7, Text: "some-error happened again"},
+					},
+				},
+			}
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(server.URL + "/api/v1/build_logs/search" + queryParams)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				jwtValidator.IsAuthenticatedReturns(false)
+				userContextReader.GetTeamReturns("", false, false)
+			})
+
+			It("returns 401 Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+
+			It("does not search", func() {
+				Expect(dbTeam.SearchBuildLogsCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				jwtValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("some-team", false, true)
+
+				dbTeam.SearchBuildLogsReturns(matches, db.Pagination{}, nil)
+			})
+
+			It("searches the logs of the team's builds", func() {
+				Expect(dbTeamFactory.FindTeamCallCount()).To(Equal(1))
+				Expect(dbTeamFactory.FindTeamArgsForCall(0)).To(Equal("some-team"))
+
+				Expect(dbTeam.SearchBuildLogsCallCount()).To(Equal(1))
+			})
+
+			It("searches the week after the given time, a page at a time", func() {
+				search, page := dbTeam.SearchBuildLogsArgsForCall(0)
+				Expect(search).To(Equal(db.BuildLogSearch{
+					Text:          "some-error",
+					StartedAfter:  time.Unix(10, 0),
+					StartedBefore: time.Unix(10, 0).Add(7 * 24 * time.Hour),
+				}))
+				Expect(page).To(Equal(db.Page{Limit: 25}))
+			})
+
+			It("returns 200 OK", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+			})
+
+			It("returns the matching builds and lines", func() {
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(body).To(MatchJSON(`[
+					{
+						"build": {
+							"id": 4,
+							"name": "2",
+							"job_name": "job2",
+							"pipeline_name": "pipeline2",
+							"team_name": "some-team",
+							"status": "failed",
+							"api_url": "/api/v1/builds/4",
+							"start_time": 1,
+							"end_time": 100
+						},
+						"lines": [
+							{"offset": 3, "text": "some-error happened"},
+							{"offset": 7, "text": "some-error happened again"}
+						]
+					}
+				]`))
+			})
+
+			Context("when all the params are passed", func() {
+				BeforeEach(func() {
+					queryParams = "?team=some-team&pipeline=some-pipeline&job=some-job&status=failed&status=errored&after=10&before=20&query=some-error&regex=some-err.r&since=2&until=3&limit=8"
+				})
+
+				It("passes them through", func() {
+					search, page := dbTeam.SearchBuildLogsArgsForCall(0)
+					Expect(search.TeamName).To(Equal("some-team"))
+					Expect(search.PipelineName).To(Equal("some-pipeline"))
+					Expect(search.JobName).To(Equal("some-job"))
+					Expect(search.Statuses).To(Equal([]db.BuildStatus{db.BuildStatusFailed, db.BuildStatusErrored}))
+					Expect(search.StartedAfter).To(Equal(time.Unix(10, 0)))
+					Expect(search.StartedBefore).To(Equal(time.Unix(20, 0)))
+					Expect(search.Text).To(Equal("some-error"))
+					Expect(search.Regexp.String()).To(Equal("some-err.r"))
+
+					Expect(page).To(Equal(db.Page{
+						Since: 2,
+						Until: 3,
+						Limit: 8,
+					}))
+				})
+			})
+
+			Context("when the limit is over the maximum", func() {
+				BeforeEach(func() {
+					queryParams += "&limit=1000"
+				})
+
+				It("searches a page of the maximum", func() {
+					_, page := dbTeam.SearchBuildLogsArgsForCall(0)
+					Expect(page.Limit).To(Equal(25))
+				})
+			})
+
+			Context("when next/previous pages are available", func() {
+				BeforeEach(func() {
+					dbTeam.SearchBuildLogsReturns(matches, db.Pagination{
+						Previous: &db.Page{Until: 4, Limit: 2},
+						Next:     &db.Page{Since: 3, Limit: 2},
+					}, nil)
+				})
+
+				It("returns Link headers which keep the search", func() {
+					Expect(response.Header["Link"]).To(ConsistOf([]string{
+						fmt.Sprintf(`<%s/api/v1/build_logs/search?after=10&limit=2&query=some-error&until=4>; rel="previous"`, externalURL),
+						fmt.Sprintf(`<%s/api/v1/build_logs/search?after=10&limit=2&query=some-error&since=3>; rel="next"`, externalURL),
+					}))
+				})
+			})
+
+			Context("when no query is given", func() {
+				BeforeEach(func() {
+					queryParams = "?after=10&regex=some-err.r"
+				})
+
+				It("returns 400 Bad Request", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+
+				It("does not search", func() {
+					Expect(dbTeam.SearchBuildLogsCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the regex is malformed", func() {
+				BeforeEach(func() {
+					queryParams += "&regex=some-(error"
+				})
+
+				It("returns 400 Bad Request", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+			})
+
+			Context("when no start of the time window is given", func() {
+				BeforeEach(func() {
+					queryParams = "?query=some-error"
+				})
+
+				It("returns 400 Bad Request", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+
+				It("does not search", func() {
+					Expect(dbTeam.SearchBuildLogsCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the time window is longer than a week", func() {
+				BeforeEach(func() {
+					queryParams += "&before=1000000"
+				})
+
+				It("returns 400 Bad Request", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+
+				It("does not search", func() {
+					Expect(dbTeam.SearchBuildLogsCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the time window is malformed", func() {
+				BeforeEach(func() {
+					queryParams = "?query=some-error&after=yesterday"
+				})
+
+				It("returns 400 Bad Request", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+			})
+
+			Context("when the search fails", func() {
+				BeforeEach(func() {
+					dbTeam.SearchBuildLogsReturns(nil, db.Pagination{}, errors.New("oh no!"))
+				})
+
+				It("returns 500 Internal Server Error", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+
+			Context("when the user is an admin", func() {
+				BeforeEach(func() {
+					userContextReader.GetTeamReturns("main", true, true)
+
+					dbBuildFactory.SearchBuildLogsReturns(matches, db.Pagination{}, nil)
+				})
+
+				It("searches the logs of the builds of every team", func() {
+					Expect(dbBuildFactory.SearchBuildLogsCallCount()).To(Equal(1))
+
+					Expect(dbTeamFactory.FindTeamCallCount()).To(BeZero())
+					Expect(dbTeam.SearchBuildLogsCallCount()).To(BeZero())
+				})
+
+				Context("when a team is given", func() {
+					BeforeEach(func() {
+						queryParams += "&team=some-team"
+					})
+
+					It("only searches the team's builds", func() {
+						search, _ := dbBuildFactory.SearchBuildLogsArgsForCall(0)
+						Expect(search.TeamName).To(Equal("some-team"))
+					})
+				})
+
+				It("returns 200 OK", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+			})
+		})
+	})
+
 	Describe("GET /api/v1/builds/:build_id/events", func() {
 		var (
 			request  *http.Request
@@ -1125,6 +1488,149 @@ var _ = Describe("Builds API", func() {
 		})
 	})
 
+	Describe("PUT /api/v1/builds/:build_id/metadata", func() {
+		var (
+			requestBody string
+			response    *http.Response
+		)
+
+		BeforeEach(func() {
+			requestBody = `{"sha":"abc123","ticket":"REL-42"}`
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			req, err := http.NewRequest("PUT", server.URL+"/api/v1/builds/128/metadata", bytes.NewBufferString(requestBody))
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				jwtValidator.IsAuthenticatedReturns(true)
+			})
+
+			Context("when the build can be found", func() {
+				BeforeEach(func() {
+					build.IDReturns(128)
+					build.NameReturns("1")
+					build.TeamNameReturns("some-team")
+					build.StatusReturns(db.BuildStatusSucceeded)
+					dbBuildFactory.BuildReturns(build, true, nil)
+				})
+
+				Context("when accessing same team's build", func() {
+					BeforeEach(func() {
+						userContextReader.GetTeamReturns("some-team", true, true)
+					})
+
+					It("saves the metadata", func() {
+						Expect(build.SaveMetadataCallCount()).To(Equal(1))
+						Expect(build.SaveMetadataArgsForCall(0)).To(Equal(map[string]string{
+							"sha":    "abc123",
+							"ticket": "REL-42",
+						}))
+					})
+
+					Context("when saving succeeds", func() {
+						BeforeEach(func() {
+							build.MetadataReturns(map[string]string{
+								"sha":    "abc123",
+								"ticket": "REL-42",
+							})
+						})
+
+						It("returns 200", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusOK))
+						})
+
+						It("returns the build with its metadata", func() {
+							body, err := ioutil.ReadAll(response.Body)
+							Expect(err).NotTo(HaveOccurred())
+
+							Expect(body).To(MatchJSON(`{
+								"id": 128,
+								"name": "1",
+								"team_name": "some-team",
+								"status": "succeeded",
+								"api_url": "/api/v1/builds/128",
+								"metadata": {
+									"sha": "abc123",
+									"ticket": "REL-42"
+								}
+							}`))
+						})
+					})
+
+					Context("when saving fails", func() {
+						BeforeEach(func() {
+							build.SaveMetadataReturns(errors.New("oh no!"))
+						})
+
+						It("returns 500", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+						})
+					})
+
+					Context("when the metadata is not key/value pairs", func() {
+						BeforeEach(func() {
+							requestBody = `["sha"]`
+						})
+
+						It("returns 400", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+						})
+
+						It("does not save anything", func() {
+							Expect(build.SaveMetadataCallCount()).To(BeZero())
+						})
+					})
+				})
+
+				Context("when accessing other team's build", func() {
+					BeforeEach(func() {
+						userContextReader.GetTeamReturns("some-other-team", true, true)
+					})
+
+					It("returns 403", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+					})
+
+					It("does not save anything", func() {
+						Expect(build.SaveMetadataCallCount()).To(BeZero())
+					})
+				})
+			})
+
+			Context("when the build can not be found", func() {
+				BeforeEach(func() {
+					dbBuildFactory.BuildReturns(nil, false, nil)
+				})
+
+				It("returns Not Found", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				jwtValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+
+			It("does not save anything", func() {
+				Expect(build.SaveMetadataCallCount()).To(BeZero())
+			})
+		})
+	})
+
 	Describe("GET /api/v1/builds/:build_id/preparation", func() {
 		var response *http.Response
 
@@ -10,13 +10,17 @@ import (
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/logarchive"
 	"github.com/vito/go-sse/sse"
 )
 
 const ProtocolVersionHeader = "X-ATC-Stream-Version"
 const CurrentProtocolVersion = "2.0"
 
-func NewEventHandler(logger lager.Logger, build db.Build) http.Handler {
+// NewEventHandler streams the build's events. Once the events of an archived
+// build have been reaped, they are streamed from the log archive instead, if
+// one is configured.
+func NewEventHandler(logger lager.Logger, build db.Build, logArchive logarchive.Archive) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		clientNotifier := w.(http.CloseNotifier)
 
@@ -55,7 +59,14 @@ func NewEventHandler(logger lager.Logger, build db.Build) http.Handler {
 			writer.writeFlusher = gz
 		}
 
-		events, err := build.Events(eventID)
+		var events db.EventSource
+		var err error
+		if logArchive != nil && build.IsArchived() && !build.ReapTime().IsZero() {
+			events, err = logArchive.Events(build, eventID)
+		} else {
+			events, err = build.Events(eventID)
+		}
+
 		if err != nil {
 			logger.Error("failed-to-get-build-events", err, lager.Data{"build-id": build.ID(), "start": eventID})
 			w.WriteHeader(http.StatusInternalServerError)
@@ -13,6 +13,7 @@ import (
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/dbfakes"
 	"github.com/concourse/atc/event"
+	"github.com/concourse/atc/logarchive/logarchivefakes"
 	"github.com/vito/go-sse/sse"
 
 	. "github.com/onsi/ginkgo"
@@ -30,15 +31,17 @@ func fakeEvent(payload string) event.Envelope {
 
 var _ = Describe("Handler", func() {
 	var (
-		build *dbfakes.FakeBuild
+		build      *dbfakes.FakeBuild
+		logArchive *logarchivefakes.FakeArchive
 
 		server *httptest.Server
 	)
 
 	BeforeEach(func() {
 		build = new(dbfakes.FakeBuild)
+		logArchive = new(logarchivefakes.FakeArchive)
 
-		server = httptest.NewServer(NewEventHandler(lagertest.NewTestLogger("test"), build))
+		server = httptest.NewServer(NewEventHandler(lagertest.NewTestLogger("test"), build, logArchive))
 	})
 
 	Describe("GET", func() {
@@ -166,6 +169,63 @@ var _ = Describe("Handler", func() {
 					Expect(actualFrom).To(Equal(uint(2)))
 				})
 			})
+
+			Context("when the build's events have been archived", func() {
+				BeforeEach(func() {
+					build.IsArchivedReturns(true)
+				})
+
+				It("gets the events from the build while they are not reaped", func() {
+					_ = response.Body.Close()
+					Eventually(build.EventsCallCount).Should(Equal(1))
+					Expect(logArchive.EventsCallCount()).To(BeZero())
+				})
+
+				Context("when the build's events have been reaped", func() {
+					BeforeEach(func() {
+						build.ReapTimeReturns(time.Now())
+
+						eventsFromBuild := build.EventsStub
+						build.EventsStub = nil
+
+						logArchive.EventsStub = func(archivedBuild db.Build, from uint) (db.EventSource, error) {
+							return eventsFromBuild(from)
+						}
+
+						request.Header.Set("Last-Event-ID", "0")
+					})
+
+					It("streams the events from the archive, starting after the id", func() {
+						defer db.Close(response.Body)
+						reader := sse.NewReadCloser(response.Body)
+
+						Expect(reader.Next()).To(Equal(sse.Event{
+							ID:   "1",
+							Name: "event",
+							Data: []byte(`{"data":{"event":2},"event":"fake","version":"42.0"}`),
+						}))
+
+						Expect(reader.Next()).To(Equal(sse.Event{
+							ID:   "2",
+							Name: "event",
+							Data: []byte(`{"data":{"event":3},"event":"fake","version":"42.0"}`),
+						}))
+
+						Expect(reader.Next()).To(Equal(sse.Event{
+							ID:   "3",
+							Name: "end",
+							Data: []byte{},
+						}))
+
+						Expect(logArchive.EventsCallCount()).To(Equal(1))
+						archivedBuild, actualFrom := logArchive.EventsArgsForCall(0)
+						Expect(archivedBuild).To(Equal(build))
+						Expect(actualFrom).To(Equal(uint(1)))
+
+						Expect(build.EventsCallCount()).To(BeZero())
+					})
+				})
+			})
 		})
 
 		Context("when the eventsource returns an error", func() {
@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"strconv"
 
+	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/api/auth"
 	"github.com/concourse/atc/api/present"
@@ -35,7 +36,15 @@ func (s *Server) ListBuilds(w http.ResponseWriter, r *http.Request) {
 		limit = atc.PaginationAPIDefaultLimit
 	}
 
-	page := db.Page{Until: until, Since: since, Limit: limit}
+	metadata, err := atc.ParseBuildMetadataFilter(r.URL.Query()[atc.BuildQueryMetadata])
+	if err != nil {
+		logger.Info("malformed-metadata-filter", lager.Data{"error": err.Error()})
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, err.Error())
+		return
+	}
+
+	page := db.Page{Until: until, Since: since, Limit: limit, Metadata: metadata}
 
 	var builds []db.Build
 	var pagination db.Pagination
@@ -92,24 +101,26 @@ func (s *Server) ListBuilds(w http.ResponseWriter, r *http.Request) {
 
 func (s *Server) addNextLink(w http.ResponseWriter, page db.Page) {
 	w.Header().Add("Link", fmt.Sprintf(
-		`<%s/api/v1/builds?%s=%d&%s=%d>; rel="%s"`,
+		`<%s/api/v1/builds?%s=%d&%s=%d%s>; rel="%s"`,
 		s.externalURL,
 		atc.PaginationQuerySince,
 		page.Since,
 		atc.PaginationQueryLimit,
 		page.Limit,
+		atc.BuildMetadataFilterQuery(page.Metadata),
 		atc.LinkRelNext,
 	))
 }
 
 func (s *Server) addPreviousLink(w http.ResponseWriter, page db.Page) {
 	w.Header().Add("Link", fmt.Sprintf(
-		`<%s/api/v1/builds?%s=%d&%s=%d>; rel="%s"`,
+		`<%s/api/v1/builds?%s=%d&%s=%d%s>; rel="%s"`,
 		s.externalURL,
 		atc.PaginationQueryUntil,
 		page.Until,
 		atc.PaginationQueryLimit,
 		page.Limit,
+		atc.BuildMetadataFilterQuery(page.Metadata),
 		atc.LinkRelPrevious,
 	))
 }
@@ -0,0 +1,169 @@
+package buildserver
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/auth"
+	"github.com/concourse/atc/api/present"
+	"github.com/concourse/atc/db"
+)
+
+func (s *Server) SearchBuildLogs(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("search-build-logs")
+
+	search, err := parseBuildLogSearch(r)
+	if err != nil {
+		logger.Info("malformed-search", lager.Data{"error": err.Error()})
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, err.Error())
+		return
+	}
+
+	until, _ := strconv.Atoi(r.FormValue(atc.PaginationQueryUntil))
+	since, _ := strconv.Atoi(r.FormValue(atc.PaginationQuerySince))
+
+	limit, _ := strconv.Atoi(r.FormValue(atc.PaginationQueryLimit))
+	if limit <= 0 || limit > atc.BuildLogSearchMaxLimit {
+		limit = atc.BuildLogSearchMaxLimit
+	}
+
+	page := db.Page{Until: until, Since: since, Limit: limit}
+
+	authTeam, authTeamFound := auth.GetTeam(r)
+	if !authTeamFound {
+		logger.Error("team-not-found-in-context", errors.New("team-not-found-in-context"))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	// admins may read every build; others may read their team's builds and
+	// the builds of public jobs
+	searchBuildLogs := s.buildFactory.SearchBuildLogs
+	if !authTeam.IsAdmin() {
+		team, found, err := s.teamFactory.FindTeam(authTeam.Name())
+		if err != nil {
+			logger.Error("failed-to-get-team", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		searchBuildLogs = team.SearchBuildLogs
+	}
+
+	matches, pagination, err := searchBuildLogs(search, page)
+	if err != nil {
+		logger.Error("failed-to-search-build-logs", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if pagination.Next != nil {
+		s.addSearchLink(w, r.URL.Query(), atc.PaginationQuerySince, pagination.Next.Since, pagination.Next.Limit, atc.LinkRelNext)
+	}
+
+	if pagination.Previous != nil {
+		s.addSearchLink(w, r.URL.Query(), atc.PaginationQueryUntil, pagination.Previous.Until, pagination.Previous.Limit, atc.LinkRelPrevious)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	presented := make([]atc.BuildLogMatch, len(matches))
+	for i, match := range matches {
+		presented[i] = present.BuildLogMatch(match)
+	}
+
+	err = json.NewEncoder(w).Encode(presented)
+	if err != nil {
+		logger.Error("failed-to-encode-build-log-matches", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+func parseBuildLogSearch(r *http.Request) (db.BuildLogSearch, error) {
+	query := r.URL.Query()
+
+	search := db.BuildLogSearch{
+		TeamName:     query.Get(atc.BuildLogSearchQueryTeam),
+		PipelineName: query.Get(atc.BuildLogSearchQueryPipeline),
+		JobName:      query.Get(atc.BuildLogSearchQueryJob),
+		Text:         query.Get(atc.BuildLogSearchQueryText),
+	}
+
+	for _, status := range query[atc.BuildLogSearchQueryStatus] {
+		search.Statuses = append(search.Statuses, db.BuildStatus(status))
+	}
+
+	after := query.Get(atc.BuildLogSearchQueryAfter)
+	if after == "" {
+		return db.BuildLogSearch{}, fmt.Errorf("%s must be given", atc.BuildLogSearchQueryAfter)
+	}
+
+	timestamp, err := strconv.ParseInt(after, 10, 64)
+	if err != nil {
+		return db.BuildLogSearch{}, fmt.Errorf("malformed %s", atc.BuildLogSearchQueryAfter)
+	}
+
+	search.StartedAfter = time.Unix(timestamp, 0)
+	search.StartedBefore = search.StartedAfter.Add(atc.BuildLogSearchMaxWindow)
+
+	if before := query.Get(atc.BuildLogSearchQueryBefore); before != "" {
+		timestamp, err := strconv.ParseInt(before, 10, 64)
+		if err != nil {
+			return db.BuildLogSearch{}, fmt.Errorf("malformed %s", atc.BuildLogSearchQueryBefore)
+		}
+
+		search.StartedBefore = time.Unix(timestamp, 0)
+	}
+
+	if search.StartedBefore.Sub(search.StartedAfter) > atc.BuildLogSearchMaxWindow {
+		return db.BuildLogSearch{}, fmt.Errorf("%s and %s may be at most %s apart", atc.BuildLogSearchQueryAfter, atc.BuildLogSearchQueryBefore, atc.BuildLogSearchMaxWindow)
+	}
+
+	if expr := query.Get(atc.BuildLogSearchQueryRegex); expr != "" {
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return db.BuildLogSearch{}, fmt.Errorf("malformed %s: %s", atc.BuildLogSearchQueryRegex, err)
+		}
+
+		search.Regexp = re
+	}
+
+	// the database narrows the builds down by the text, so a regexp alone
+	// would have every log in the window read
+	if search.Text == "" {
+		return db.BuildLogSearch{}, fmt.Errorf("%s must be given", atc.BuildLogSearchQueryText)
+	}
+
+	return search, nil
+}
+
+// addSearchLink links to another page of the search, keeping the search's
+// query intact.
+func (s *Server) addSearchLink(w http.ResponseWriter, query url.Values, param string, id int, limit int, rel string) {
+	query.Del(atc.PaginationQuerySince)
+	query.Del(atc.PaginationQueryUntil)
+	query.Set(param, strconv.Itoa(id))
+	query.Set(atc.PaginationQueryLimit, strconv.Itoa(limit))
+
+	w.Header().Add("Link", fmt.Sprintf(
+		`<%s/api/v1/build_logs/search?%s>; rel="%s"`,
+		s.externalURL,
+		query.Encode(),
+		rel,
+	))
+}
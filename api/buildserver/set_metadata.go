@@ -0,0 +1,41 @@
+package buildserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/api/present"
+	"github.com/concourse/atc/db"
+)
+
+func (s *Server) SetBuildMetadata(build db.Build) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("set-build-metadata", lager.Data{
+			"build": build.ID(),
+		})
+
+		var metadata map[string]string
+		err := json.NewDecoder(r.Body).Decode(&metadata)
+		if err != nil {
+			logger.Info("malformed-request", lager.Data{"error": err.Error()})
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		err = build.SaveMetadata(metadata)
+		if err != nil {
+			logger.Error("failed-to-save-metadata", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		err = json.NewEncoder(w).Encode(present.Build(build))
+		if err != nil {
+			logger.Error("failed-to-encode-build", err)
+		}
+	})
+}
@@ -8,6 +8,7 @@ import (
 	"mime/multipart"
 	"net/http"
 	"net/textproto"
+	"net/url"
 
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
@@ -119,9 +120,14 @@ var _ = Describe("Config API", func() {
 
 	Describe("GET /api/v1/teams/:team_name/pipelines/:name/config", func() {
 		var (
-			response *http.Response
+			queryParams string
+			response    *http.Response
 		)
 
+		BeforeEach(func() {
+			queryParams = ""
+		})
+
 		JustBeforeEach(func() {
 			req, err := requestGenerator.CreateRequest(atc.GetConfig, rata.Params{
 				"team_name":     "a-team",
@@ -129,6 +135,8 @@ var _ = Describe("Config API", func() {
 			}, nil)
 			Expect(err).NotTo(HaveOccurred())
 
+			req.URL.RawQuery = queryParams
+
 			response, err = client.Do(req)
 			Expect(err).NotTo(HaveOccurred())
 		})
@@ -307,6 +315,41 @@ var _ = Describe("Config API", func() {
 					})
 				})
 
+				Context("when instance vars are specified", func() {
+					var fakeInstance *dbfakes.FakePipeline
+
+					BeforeEach(func() {
+						queryParams = "instance_vars=" + url.QueryEscape(`{"branch":"release-1.0"}`)
+
+						fakeInstance = new(dbfakes.FakePipeline)
+						fakeTeam.PipelineInstanceReturns(fakeInstance, true, nil)
+					})
+
+					It("looks up the instance identified by the vars", func() {
+						Expect(fakeTeam.PipelineCallCount()).To(Equal(0))
+						Expect(fakeTeam.PipelineInstanceCallCount()).To(Equal(1))
+
+						pipelineName, instanceVars := fakeTeam.PipelineInstanceArgsForCall(0)
+						Expect(pipelineName).To(Equal("something-else"))
+						Expect(instanceVars).To(Equal(atc.InstanceVars{"branch": "release-1.0"}))
+					})
+
+					It("returns the instance's config", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+						Expect(fakeInstance.JobsCallCount()).To(Equal(1))
+					})
+
+					Context("when the instance vars are malformed", func() {
+						BeforeEach(func() {
+							queryParams = "instance_vars=" + url.QueryEscape(`{"branch":`)
+						})
+
+						It("returns 400", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+						})
+					})
+				})
+
 				Context("when the pipeline is not found", func() {
 					BeforeEach(func() {
 						fakeTeam.PipelineReturns(nil, false, nil)
@@ -1052,6 +1095,69 @@ jobs:
 				})
 			})
 
+			Context("when instance vars are specified", func() {
+				BeforeEach(func() {
+					request.Header.Set(atc.ConfigVersionHeader, "42")
+					request.Header.Set("Content-Type", "application/x-yaml")
+					request.URL.RawQuery = "instance_vars=" + url.QueryEscape(`{"branch":"release-1.0"}`)
+
+					request.Body = gbytes.BufferWithBytes([]byte(`---
+resources:
+- name: some-repo
+  type: git
+  source:
+    branch: ((branch))
+    private_key: ((private-key))
+
+jobs:
+- name: some-job
+  plan:
+  - get: some-repo
+`))
+				})
+
+				It("returns 200", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				It("saves the instance with the vars interpolated into its config", func() {
+					Expect(dbTeam.SavePipelineCallCount()).To(Equal(0))
+					Expect(dbTeam.SavePipelineInstanceCallCount()).To(Equal(1))
+
+					name, instanceVars, savedConfig, id, pipelineState := dbTeam.SavePipelineInstanceArgsForCall(0)
+					Expect(name).To(Equal("a-pipeline"))
+					Expect(instanceVars).To(Equal(atc.InstanceVars{"branch": "release-1.0"}))
+					Expect(id).To(Equal(db.ConfigVersion(42)))
+					Expect(pipelineState).To(Equal(db.PipelineNoChange))
+
+					Expect(savedConfig.Resources).To(Equal(atc.ResourceConfigs{
+						{
+							Name: "some-repo",
+							Type: "git",
+							Source: atc.Source{
+								"branch":      "release-1.0",
+								"private_key": "((private-key))",
+							},
+						},
+					}))
+				})
+
+				Context("when the instance vars are malformed", func() {
+					BeforeEach(func() {
+						request.URL.RawQuery = "instance_vars=" + url.QueryEscape(`{"branch":`)
+					})
+
+					It("returns 400", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+
+					It("does not save it", func() {
+						Expect(dbTeam.SavePipelineCallCount()).To(Equal(0))
+						Expect(dbTeam.SavePipelineInstanceCallCount()).To(Equal(0))
+					})
+				})
+			})
+
 			Context("when a config version is malformed", func() {
 				BeforeEach(func() {
 					request.Header.Set(atc.ConfigVersionHeader, "forty-two")
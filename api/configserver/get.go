@@ -8,6 +8,7 @@ import (
 	"code.cloudfoundry.org/lager"
 
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/auth"
 	"github.com/tedsuo/rata"
 )
 
@@ -29,7 +30,14 @@ func (s *Server) GetConfig(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	pipeline, found, err := team.Pipeline(pipelineName)
+	instanceVars, err := atc.ParseInstanceVars(r.FormValue(atc.InstanceVarsQueryParam))
+	if err != nil {
+		logger.Info("malformed-instance-vars", lager.Data{"error": err.Error()})
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	pipeline, found, err := auth.FindPipeline(team, pipelineName, instanceVars)
 	if err != nil {
 		logger.Error("failed-to-find-pipeline", err)
 		w.WriteHeader(http.StatusInternalServerError)
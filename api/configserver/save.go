@@ -10,12 +10,11 @@ import (
 	"mime"
 	"mime/multipart"
 	"net/http"
-	"strings"
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
-	"github.com/mitchellh/mapstructure"
+	"github.com/concourse/atc/template"
 	"github.com/tedsuo/rata"
 	"gopkg.in/yaml.v2"
 )
@@ -24,9 +23,9 @@ var (
 	ErrStatusUnsupportedMediaType = errors.New("content-type is not supported")
 	ErrCannotParseContentType     = errors.New("content-type header could not be parsed")
 	ErrMalformedRequestPayload    = errors.New("data in body could not be decoded")
-	ErrFailedToConstructDecoder   = errors.New("decoder could not be constructed")
 	ErrCouldNotDecode             = errors.New("data could not be decoded into config structure")
 	ErrInvalidPausedValue         = errors.New("invalid paused value")
+	ErrCouldNotInterpolate        = errors.New("instance vars could not be interpolated into config")
 )
 
 type ExtraKeysError struct {
@@ -62,7 +61,14 @@ func (s *Server) SaveConfig(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	config, pausedState, err := saveConfigRequestUnmarshaler(r)
+	instanceVars, err := atc.ParseInstanceVars(r.FormValue(atc.InstanceVarsQueryParam))
+	if err != nil {
+		session.Error("malformed-instance-vars", err)
+		s.handleBadRequest(w, []string{"malformed instance vars"}, session)
+		return
+	}
+
+	config, pausedState, err := saveConfigRequestUnmarshaler(r, instanceVars)
 	switch err {
 	case ErrStatusUnsupportedMediaType:
 		w.WriteHeader(http.StatusUnsupportedMediaType)
@@ -74,10 +80,6 @@ func (s *Server) SaveConfig(w http.ResponseWriter, r *http.Request) {
 
 		s.handleBadRequest(w, []string{"malformed config"}, session)
 		return
-	case ErrFailedToConstructDecoder:
-		session.Error("failed-to-construct-decoder", err)
-		w.WriteHeader(http.StatusInternalServerError)
-		return
 	case ErrCouldNotDecode:
 		session.Error("could-not-decode", err)
 		s.handleBadRequest(w, []string{"failed to decode config"}, session)
@@ -86,6 +88,10 @@ func (s *Server) SaveConfig(w http.ResponseWriter, r *http.Request) {
 		session.Error("invalid-paused-value", err)
 		s.handleBadRequest(w, []string{"invalid paused value"}, session)
 		return
+	case ErrCouldNotInterpolate:
+		session.Error("could-not-interpolate", err)
+		s.handleBadRequest(w, []string{"failed to interpolate instance vars into config"}, session)
+		return
 	default:
 		if err != nil {
 			if eke, ok := err.(ExtraKeysError); ok {
@@ -124,7 +130,12 @@ func (s *Server) SaveConfig(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	_, created, err := team.SavePipeline(pipelineName, config, version, pausedState)
+	var created bool
+	if len(instanceVars) == 0 {
+		_, created, err = team.SavePipeline(pipelineName, config, version, pausedState)
+	} else {
+		_, created, err = team.SavePipelineInstance(pipelineName, instanceVars, config, version, pausedState)
+	}
 	if err != nil {
 		session.Error("failed-to-save-config", err)
 		w.WriteHeader(http.StatusInternalServerError)
@@ -234,44 +245,58 @@ func requestToConfig(contentType string, requestBody io.ReadCloser, configStruct
 	return pausedState, nil
 }
 
-func saveConfigRequestUnmarshaler(r *http.Request) (atc.Config, db.PipelinePausedState, error) {
+func saveConfigRequestUnmarshaler(r *http.Request, instanceVars atc.InstanceVars) (atc.Config, db.PipelinePausedState, error) {
 	var configStructure interface{}
 	pausedState, err := requestToConfig(r.Header.Get("Content-Type"), r.Body, &configStructure)
 	if err != nil {
 		return atc.Config{}, db.PipelineNoChange, err
 	}
 
-	var config atc.Config
-	var md mapstructure.Metadata
-	msConfig := &mapstructure.DecoderConfig{
-		Metadata:         &md,
-		Result:           &config,
-		WeaklyTypedInput: true,
-		DecodeHook: mapstructure.ComposeDecodeHookFunc(
-			atc.SanitizeDecodeHook,
-			atc.VersionConfigDecodeHook,
-		),
+	if len(instanceVars) > 0 {
+		configStructure, err = interpolateInstanceVars(configStructure, instanceVars)
+		if err != nil {
+			return atc.Config{}, db.PipelineNoChange, ErrCouldNotInterpolate
+		}
 	}
 
-	decoder, err := mapstructure.NewDecoder(msConfig)
+	config, err := atc.DecodeConfig(configStructure)
 	if err != nil {
-		return atc.Config{}, db.PipelineNoChange, ErrFailedToConstructDecoder
-	}
+		if eke, ok := err.(atc.ExtraKeysError); ok {
+			return atc.Config{}, db.PipelineNoChange, ExtraKeysError{extraKeys: eke.Keys}
+		}
 
-	if err := decoder.Decode(configStructure); err != nil {
 		return atc.Config{}, db.PipelineNoChange, ErrCouldNotDecode
 	}
 
-	nestedUnused := []string{}
-	for _, unused := range md.Unused {
-		if strings.Contains(unused, ".") {
-			nestedUnused = append(nestedUnused, unused)
-		}
+	return config, pausedState, nil
+}
+
+// interpolateInstanceVars interpolates the vars of a pipeline instance into
+// its config. Any other vars are left to be resolved by the credential
+// manager when the pipeline runs.
+func interpolateInstanceVars(configStructure interface{}, instanceVars atc.InstanceVars) (interface{}, error) {
+	params, err := yaml.Marshal(instanceVars)
+	if err != nil {
+		return nil, err
 	}
 
-	if len(nestedUnused) != 0 {
-		return atc.Config{}, db.PipelineNoChange, ExtraKeysError{extraKeys: nestedUnused}
+	content, err := yaml.Marshal(configStructure)
+	if err != nil {
+		return nil, err
 	}
 
-	return config, pausedState, nil
+	source := &template.FileVarsSource{ParamsContent: params}
+
+	evaluated, err := source.Evaluate(content)
+	if err != nil {
+		return nil, err
+	}
+
+	var interpolated interface{}
+	err = yaml.Unmarshal(evaluated, &interpolated)
+	if err != nil {
+		return nil, err
+	}
+
+	return interpolated, nil
 }
@@ -355,11 +355,55 @@ var _ = Describe("Containers API", func() {
 					_, err := client.Do(req)
 					Expect(err).NotTo(HaveOccurred())
 
-					_, pipelineName, resourceName, variablesFactory := dbTeam.FindCheckContainersArgsForCall(0)
+					_, pipelineName, instanceVars, resourceName, variablesFactory := dbTeam.FindCheckContainersArgsForCall(0)
 					Expect(pipelineName).To(Equal("some-pipeline"))
+					Expect(instanceVars).To(BeEmpty())
 					Expect(resourceName).To(Equal("some-resource"))
 					Expect(variablesFactory).To(Equal(fakeVariablesFactory))
 				})
+
+				Context("when an instance of the pipeline is given", func() {
+					BeforeEach(func() {
+						req.URL.RawQuery = url.Values{
+							"type":          []string{"check"},
+							"resource_name": []string{"some-resource"},
+							"pipeline_name": []string{"some-pipeline"},
+							"instance_vars": []string{`{"branch":"some-branch"}`},
+						}.Encode()
+					})
+
+					It("queries the instance's check containers", func() {
+						_, err := client.Do(req)
+						Expect(err).NotTo(HaveOccurred())
+
+						_, pipelineName, instanceVars, _, _ := dbTeam.FindCheckContainersArgsForCall(0)
+						Expect(pipelineName).To(Equal("some-pipeline"))
+						Expect(instanceVars).To(Equal(atc.InstanceVars{"branch": "some-branch"}))
+					})
+				})
+
+				Context("when the instance vars are malformed", func() {
+					BeforeEach(func() {
+						req.URL.RawQuery = url.Values{
+							"type":          []string{"check"},
+							"resource_name": []string{"some-resource"},
+							"pipeline_name": []string{"some-pipeline"},
+							"instance_vars": []string{"{"},
+						}.Encode()
+					})
+
+					It("returns 400 Bad Request", func() {
+						response, _ := client.Do(req)
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+
+					It("does not look for containers", func() {
+						_, err := client.Do(req)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(dbTeam.FindCheckContainersCallCount()).To(BeZero())
+					})
+				})
 			})
 		})
 	})
@@ -62,9 +62,15 @@ func createContainerLocatorFromRequest(team db.Team, r *http.Request, variablesF
 	query := r.URL.Query()
 
 	if query.Get("type") == "check" {
+		instanceVars, err := atc.ParseInstanceVars(query.Get(atc.InstanceVarsQueryParam))
+		if err != nil {
+			return nil, err
+		}
+
 		return &checkContainerLocator{
 			team:             team,
 			pipelineName:     query.Get("pipeline_name"),
+			instanceVars:     instanceVars,
 			resourceName:     query.Get("resource_name"),
 			variablesFactory: variablesFactory,
 		}, nil
@@ -117,12 +123,13 @@ func createContainerLocatorFromRequest(team db.Team, r *http.Request, variablesF
 type checkContainerLocator struct {
 	team             db.Team
 	pipelineName     string
+	instanceVars     atc.InstanceVars
 	resourceName     string
 	variablesFactory creds.VariablesFactory
 }
 
 func (l *checkContainerLocator) Locate(logger lager.Logger) ([]db.Container, error) {
-	return l.team.FindCheckContainers(logger, l.pipelineName, l.resourceName, l.variablesFactory)
+	return l.team.FindCheckContainers(logger, l.pipelineName, l.instanceVars, l.resourceName, l.variablesFactory)
 }
 
 type stepContainerLocator struct {
@@ -0,0 +1,122 @@
+package api_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/concourse/atc/db"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Encryption API", func() {
+	Describe("GET /api/v1/encryption/rotation", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(server.URL + "/api/v1/encryption/rotation")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				jwtValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+
+		Context("when authenticated as a non-admin team", func() {
+			BeforeEach(func() {
+				jwtValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("some-team", false, true)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when authenticated as an admin team", func() {
+			BeforeEach(func() {
+				jwtValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("main", true, true)
+			})
+
+			Context("when getting the status succeeds", func() {
+				BeforeEach(func() {
+					dbKeyRotator.StatusReturns([]db.KeyRotationStatus{
+						{
+							Table:       "builds",
+							Column:      "engine_metadata",
+							LastID:      500,
+							RowsRotated: 420,
+							RowsSkipped: 2,
+							Finished:    false,
+							UpdatedAt:   time.Unix(1, 0),
+						},
+						{
+							Table:       "teams",
+							Column:      "auth",
+							LastID:      3,
+							RowsRotated: 3,
+							RowsSkipped: 0,
+							Finished:    true,
+							UpdatedAt:   time.Unix(2, 0),
+						},
+					}, nil)
+				})
+
+				It("returns 200 OK", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				It("returns Content-Type 'application/json'", func() {
+					Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+				})
+
+				It("returns the progress through each table", func() {
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`[
+						{
+							"table": "builds",
+							"column": "engine_metadata",
+							"last_id": 500,
+							"rows_rotated": 420,
+							"rows_skipped": 2,
+							"finished": false,
+							"updated_at": 1
+						},
+						{
+							"table": "teams",
+							"column": "auth",
+							"last_id": 3,
+							"rows_rotated": 3,
+							"rows_skipped": 0,
+							"finished": true,
+							"updated_at": 2
+						}
+					]`))
+				})
+			})
+
+			Context("when getting the status fails", func() {
+				BeforeEach(func() {
+					dbKeyRotator.StatusReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500 Internal Server Error", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
+})
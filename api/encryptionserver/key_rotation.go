@@ -0,0 +1,36 @@
+package encryptionserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/present"
+)
+
+// GetKeyRotationStatus reports how far the rotation to the current encryption
+// key has got through each of the encrypted tables.
+func (s *Server) GetKeyRotationStatus(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("get-key-rotation-status")
+
+	statuses, err := s.keyRotator.Status()
+	if err != nil {
+		logger.Error("failed-to-get-key-rotation-status", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	presentedStatuses := make([]atc.KeyRotationStatus, len(statuses))
+	for i, status := range statuses {
+		presentedStatuses[i] = present.KeyRotationStatus(status)
+	}
+
+	err = json.NewEncoder(w).Encode(presentedStatuses)
+	if err != nil {
+		logger.Error("failed-to-encode-key-rotation-status", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
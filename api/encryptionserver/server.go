@@ -0,0 +1,21 @@
+package encryptionserver
+
+import (
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/db"
+)
+
+type Server struct {
+	logger     lager.Logger
+	keyRotator db.KeyRotator
+}
+
+func NewServer(
+	logger lager.Logger,
+	keyRotator db.KeyRotator,
+) *Server {
+	return &Server{
+		logger:     logger,
+		keyRotator: keyRotator,
+	}
+}
@@ -9,10 +9,12 @@ import (
 	"github.com/tedsuo/rata"
 
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/auditserver"
 	"github.com/concourse/atc/api/buildserver"
 	"github.com/concourse/atc/api/cliserver"
 	"github.com/concourse/atc/api/configserver"
 	"github.com/concourse/atc/api/containerserver"
+	"github.com/concourse/atc/api/encryptionserver"
 	"github.com/concourse/atc/api/infoserver"
 	"github.com/concourse/atc/api/jobserver"
 	"github.com/concourse/atc/api/legacyserver"
@@ -47,6 +49,8 @@ func NewHandler(
 	volumeFactory db.VolumeFactory,
 	containerRepository db.ContainerRepository,
 	dbBuildFactory db.BuildFactory,
+	dbAuditLog db.AuditLog,
+	dbKeyRotator db.KeyRotator,
 
 	peerURL string,
 	eventHandlerFactory buildserver.EventHandlerFactory,
@@ -96,16 +100,20 @@ func NewHandler(
 	teamServer := teamserver.NewServer(logger, dbTeamFactory)
 	infoServer := infoserver.NewServer(logger, version, workerVersion)
 	legacyServer := legacyserver.NewServer(logger)
+	auditServer := auditserver.NewServer(logger, externalURL, dbAuditLog)
+	encryptionServer := encryptionserver.NewServer(logger, dbKeyRotator)
 
 	handlers := map[string]http.Handler{
 		atc.GetConfig:  http.HandlerFunc(configServer.GetConfig),
 		atc.SaveConfig: http.HandlerFunc(configServer.SaveConfig),
 
 		atc.ListBuilds:          http.HandlerFunc(buildServer.ListBuilds),
+		atc.SearchBuildLogs:     http.HandlerFunc(buildServer.SearchBuildLogs),
 		atc.CreateBuild:         teamHandlerFactory.HandlerFor(buildServer.CreateBuild),
 		atc.GetBuild:            buildHandlerFactory.HandlerFor(buildServer.GetBuild),
 		atc.BuildResources:      buildHandlerFactory.HandlerFor(buildServer.BuildResources),
 		atc.AbortBuild:          buildHandlerFactory.HandlerFor(buildServer.AbortBuild),
+		atc.SetBuildMetadata:    buildHandlerFactory.HandlerFor(buildServer.SetBuildMetadata),
 		atc.GetBuildPlan:        buildHandlerFactory.HandlerFor(buildServer.GetBuildPlan),
 		atc.GetBuildPreparation: buildHandlerFactory.HandlerFor(buildServer.GetBuildPreparation),
 		atc.BuildEvents:         buildHandlerFactory.HandlerFor(buildServer.BuildEvents),
@@ -116,6 +124,7 @@ func NewHandler(
 		atc.ListJobInputs:  pipelineHandlerFactory.HandlerFor(jobServer.ListJobInputs),
 		atc.GetJobBuild:    pipelineHandlerFactory.HandlerFor(jobServer.GetJobBuild),
 		atc.CreateJobBuild: pipelineHandlerFactory.HandlerFor(jobServer.CreateJobBuild),
+		atc.RerunJobBuild:  pipelineHandlerFactory.HandlerFor(jobServer.RerunJobBuild),
 		atc.PauseJob:       pipelineHandlerFactory.HandlerFor(jobServer.PauseJob),
 		atc.UnpauseJob:     pipelineHandlerFactory.HandlerFor(jobServer.UnpauseJob),
 		atc.JobBadge:       pipelineHandlerFactory.HandlerFor(jobServer.JobBadge),
@@ -128,6 +137,7 @@ func NewHandler(
 		atc.OrderPipelines:      http.HandlerFunc(pipelineServer.OrderPipelines),
 		atc.PausePipeline:       pipelineHandlerFactory.HandlerFor(pipelineServer.PausePipeline),
 		atc.UnpausePipeline:     pipelineHandlerFactory.HandlerFor(pipelineServer.UnpausePipeline),
+		atc.ArchivePipeline:     pipelineHandlerFactory.HandlerFor(pipelineServer.ArchivePipeline),
 		atc.ExposePipeline:      pipelineHandlerFactory.HandlerFor(pipelineServer.ExposePipeline),
 		atc.HidePipeline:        pipelineHandlerFactory.HandlerFor(pipelineServer.HidePipeline),
 		atc.GetVersionsDB:       pipelineHandlerFactory.HandlerFor(pipelineServer.GetVersionsDB),
@@ -135,6 +145,8 @@ func NewHandler(
 		atc.CreatePipelineBuild: pipelineHandlerFactory.HandlerFor(pipelineServer.CreateBuild),
 		atc.PipelineBadge:       pipelineHandlerFactory.HandlerFor(pipelineServer.PipelineBadge),
 
+		atc.ListPipelineNotifications: pipelineHandlerFactory.HandlerFor(pipelineServer.ListNotifications),
+
 		atc.ListResources:        pipelineHandlerFactory.HandlerFor(resourceServer.ListResources),
 		atc.GetResource:          pipelineHandlerFactory.HandlerFor(resourceServer.GetResource),
 		atc.PauseResource:        pipelineHandlerFactory.HandlerFor(resourceServer.PauseResource),
@@ -146,6 +158,8 @@ func NewHandler(
 		atc.GetResourceVersion:            pipelineHandlerFactory.HandlerFor(versionServer.GetResourceVersion),
 		atc.EnableResourceVersion:         pipelineHandlerFactory.HandlerFor(versionServer.EnableResourceVersion),
 		atc.DisableResourceVersion:        pipelineHandlerFactory.HandlerFor(versionServer.DisableResourceVersion),
+		atc.PinResourceVersion:            pipelineHandlerFactory.HandlerFor(versionServer.PinResourceVersion),
+		atc.UnpinResource:                 pipelineHandlerFactory.HandlerFor(versionServer.UnpinResource),
 		atc.ListBuildsWithVersionAsInput:  pipelineHandlerFactory.HandlerFor(versionServer.ListBuildsWithVersionAsInput),
 		atc.ListBuildsWithVersionAsOutput: pipelineHandlerFactory.HandlerFor(versionServer.ListBuildsWithVersionAsOutput),
 		atc.GetResourceCausality:          pipelineHandlerFactory.HandlerFor(versionServer.GetCausality),
@@ -182,6 +196,11 @@ func NewHandler(
 		atc.SetTeam:     http.HandlerFunc(teamServer.SetTeam),
 		atc.RenameTeam:  http.HandlerFunc(teamServer.RenameTeam),
 		atc.DestroyTeam: http.HandlerFunc(teamServer.DestroyTeam),
+		atc.TeamEvents:  http.HandlerFunc(teamServer.TeamEvents),
+
+		atc.ListAuditEvents: http.HandlerFunc(auditServer.ListAuditEvents),
+
+		atc.GetKeyRotationStatus: http.HandlerFunc(encryptionServer.GetKeyRotationStatus),
 	}
 
 	return rata.NewRouter(atc.Routes, wrapper.Wrap(handlers))
@@ -1,6 +1,7 @@
 package api_test
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -16,6 +17,7 @@ import (
 	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/dbfakes"
+	"github.com/concourse/atc/scheduler/inputmapper"
 	"github.com/concourse/atc/scheduler/schedulerfakes"
 )
 
@@ -1237,6 +1239,20 @@ var _ = Describe("Jobs API", func() {
 					})
 				})
 
+				Context("when the pipeline is archived", func() {
+					BeforeEach(func() {
+						fakePipeline.ArchivedReturns(true)
+					})
+
+					It("should return 409", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusConflict))
+					})
+
+					It("does not trigger the build", func() {
+						Expect(fakeScheduler.TriggerImmediatelyCallCount()).To(Equal(0))
+					})
+				})
+
 				Context("when getting the job config succeeds", func() {
 					BeforeEach(func() {
 						fakeJob.ConfigReturns(atc.JobConfig{
@@ -1327,6 +1343,110 @@ var _ = Describe("Jobs API", func() {
 							Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
 						})
 					})
+
+					Context("when the request chooses input versions", func() {
+						BeforeEach(func() {
+							var err error
+							request, err = http.NewRequest("POST", server.URL+"/api/v1/teams/some-team/pipelines/some-pipeline/jobs/some-job/builds", bytes.NewBufferString(`{
+								"inputs": [
+									{"name": "some-input", "version": {"ref": "old"}, "ignore_passed": true}
+								]
+							}`))
+							Expect(err).NotTo(HaveOccurred())
+						})
+
+						Context("when triggering the build succeeds", func() {
+							BeforeEach(func() {
+								build := new(dbfakes.FakeBuild)
+								build.IDReturns(42)
+								build.NameReturns("1")
+								build.JobNameReturns("some-job")
+								build.PipelineNameReturns("a-pipeline")
+								build.TeamNameReturns("some-team")
+								build.StatusReturns(db.BuildStatusPending)
+								build.InputOverridesReturns([]atc.BuildInputOverride{
+									{Name: "some-input", Version: atc.Version{"ref": "old"}, IgnorePassed: true},
+								})
+								fakeScheduler.TriggerImmediatelyWithInputsReturns(build, nil, nil)
+							})
+
+							It("triggers the build with the chosen versions", func() {
+								Expect(fakeScheduler.TriggerImmediatelyCallCount()).To(BeZero())
+								Expect(fakeScheduler.TriggerImmediatelyWithInputsCallCount()).To(Equal(1))
+
+								_, job, overrides, _, resourceTypes := fakeScheduler.TriggerImmediatelyWithInputsArgsForCall(0)
+								Expect(job).To(Equal(fakeJob))
+								Expect(overrides).To(Equal([]atc.BuildInputOverride{
+									{Name: "some-input", Version: atc.Version{"ref": "old"}, IgnorePassed: true},
+								}))
+								Expect(resourceTypes).To(Equal(versionedResourceTypes))
+							})
+
+							It("returns the build with its overrides", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+								body, err := ioutil.ReadAll(response.Body)
+								Expect(err).NotTo(HaveOccurred())
+
+								Expect(body).To(MatchJSON(`{
+								"id": 42,
+								"name": "1",
+								"job_name": "some-job",
+								"status": "pending",
+								"api_url": "/api/v1/builds/42",
+								"pipeline_name": "a-pipeline",
+								"team_name": "some-team",
+								"input_overrides": [
+									{"name": "some-input", "version": {"ref": "old"}, "ignore_passed": true}
+								]
+							}`))
+							})
+						})
+
+						Context("when the chosen versions cannot be used", func() {
+							BeforeEach(func() {
+								fakeScheduler.TriggerImmediatelyWithInputsReturns(nil, nil, inputmapper.InputOverrideError{
+									Input:  "some-input",
+									Reason: "version not found",
+								})
+							})
+
+							It("returns 400 with the reason", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+
+								body, err := ioutil.ReadAll(response.Body)
+								Expect(err).NotTo(HaveOccurred())
+								Expect(string(body)).To(Equal("invalid input versions: input 'some-input': version not found"))
+							})
+						})
+
+						Context("when triggering the build fails", func() {
+							BeforeEach(func() {
+								fakeScheduler.TriggerImmediatelyWithInputsReturns(nil, nil, errors.New("oh no!"))
+							})
+
+							It("returns 500", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+							})
+						})
+					})
+
+					Context("when the request body is malformed", func() {
+						BeforeEach(func() {
+							var err error
+							request, err = http.NewRequest("POST", server.URL+"/api/v1/teams/some-team/pipelines/some-pipeline/jobs/some-job/builds", bytes.NewBufferString(`{`))
+							Expect(err).NotTo(HaveOccurred())
+						})
+
+						It("returns 400", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+						})
+
+						It("does not trigger the build", func() {
+							Expect(fakeScheduler.TriggerImmediatelyCallCount()).To(BeZero())
+							Expect(fakeScheduler.TriggerImmediatelyWithInputsCallCount()).To(BeZero())
+						})
+					})
 				})
 			})
 
@@ -1352,6 +1472,226 @@ var _ = Describe("Jobs API", func() {
 		})
 	})
 
+	Describe("POST /api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/builds/:build_name/rerun", func() {
+		var request *http.Request
+		var response *http.Response
+
+		var fakeScheduler *schedulerfakes.FakeBuildScheduler
+		var originalBuild *dbfakes.FakeBuild
+
+		BeforeEach(func() {
+			var err error
+
+			request, err = http.NewRequest("POST", server.URL+"/api/v1/teams/some-team/pipelines/some-pipeline/jobs/some-job/builds/3/rerun", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			fakeScheduler = new(schedulerfakes.FakeBuildScheduler)
+			fakeSchedulerFactory.BuildSchedulerReturns(fakeScheduler)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				jwtValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("some-other-team", true, true)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+
+			It("does not re-run the build", func() {
+				Expect(fakeScheduler.RerunImmediatelyCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				jwtValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("some-team", true, true)
+			})
+
+			Context("when getting the job succeeds", func() {
+				BeforeEach(func() {
+					fakeJob.NameReturns("some-job")
+					fakeJob.ConfigReturns(atc.JobConfig{
+						Name: "some-job",
+						Plan: atc.PlanSequence{
+							{
+								Get: "some-input",
+							},
+						},
+					})
+					fakePipeline.JobReturns(fakeJob, true, nil)
+				})
+
+				Context("when the build exists", func() {
+					BeforeEach(func() {
+						originalBuild = new(dbfakes.FakeBuild)
+						originalBuild.IDReturns(3)
+						originalBuild.NameReturns("3")
+						originalBuild.IsScheduledReturns(true)
+						fakeJob.BuildReturns(originalBuild, true, nil)
+					})
+
+					It("looks up the build by name", func() {
+						Expect(fakeJob.BuildCallCount()).To(Equal(1))
+						Expect(fakeJob.BuildArgsForCall(0)).To(Equal("3"))
+					})
+
+					Context("when manual triggering is disabled", func() {
+						BeforeEach(func() {
+							fakeJob.ConfigReturns(atc.JobConfig{
+								Name:                 "some-job",
+								DisableManualTrigger: true,
+							})
+						})
+
+						It("returns 409", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusConflict))
+						})
+
+						It("does not re-run the build", func() {
+							Expect(fakeScheduler.RerunImmediatelyCallCount()).To(Equal(0))
+						})
+					})
+
+					Context("when the pipeline is archived", func() {
+						BeforeEach(func() {
+							fakePipeline.ArchivedReturns(true)
+						})
+
+						It("returns 409", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusConflict))
+						})
+
+						It("does not re-run the build", func() {
+							Expect(fakeScheduler.RerunImmediatelyCallCount()).To(Equal(0))
+						})
+					})
+
+					Context("when the build was never scheduled", func() {
+						BeforeEach(func() {
+							originalBuild.IsScheduledReturns(false)
+						})
+
+						It("returns 409", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusConflict))
+						})
+
+						It("does not re-run the build", func() {
+							Expect(fakeScheduler.RerunImmediatelyCallCount()).To(Equal(0))
+						})
+					})
+
+					Context("when re-running the build succeeds", func() {
+						var fakeResource *dbfakes.FakeResource
+
+						BeforeEach(func() {
+							build := new(dbfakes.FakeBuild)
+							build.IDReturns(42)
+							build.NameReturns("3.1")
+							build.JobNameReturns("some-job")
+							build.PipelineNameReturns("a-pipeline")
+							build.TeamNameReturns("some-team")
+							build.StatusReturns(db.BuildStatusPending)
+							build.RerunOfReturns(3)
+							build.RerunOfNameReturns("3")
+							fakeScheduler.RerunImmediatelyReturns(build, nil, nil)
+
+							fakeResource = new(dbfakes.FakeResource)
+							fakeResource.NameReturns("resource-1")
+							fakePipeline.ResourcesReturns(db.Resources{fakeResource}, nil)
+						})
+
+						It("re-runs the build using the current config", func() {
+							Expect(fakeScheduler.RerunImmediatelyCallCount()).To(Equal(1))
+
+							_, job, build, resources, resourceTypes := fakeScheduler.RerunImmediatelyArgsForCall(0)
+							Expect(job).To(Equal(fakeJob))
+							Expect(build).To(Equal(originalBuild))
+							Expect(resources).To(Equal(db.Resources{fakeResource}))
+							Expect(resourceTypes).To(Equal(versionedResourceTypes))
+						})
+
+						It("returns 200 OK", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusOK))
+						})
+
+						It("returns Content-Type 'application/json'", func() {
+							Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+						})
+
+						It("returns the re-run build", func() {
+							body, err := ioutil.ReadAll(response.Body)
+							Expect(err).NotTo(HaveOccurred())
+
+							Expect(body).To(MatchJSON(`{
+							"id": 42,
+							"name": "3.1",
+							"job_name": "some-job",
+							"status": "pending",
+							"api_url": "/api/v1/builds/42",
+							"pipeline_name": "a-pipeline",
+							"team_name": "some-team",
+							"rerun_of": {
+								"id": 3,
+								"name": "3"
+							}
+						}`))
+						})
+					})
+
+					Context("when re-running the build fails", func() {
+						BeforeEach(func() {
+							fakeScheduler.RerunImmediatelyReturns(nil, nil, errors.New("oh no!"))
+						})
+
+						It("returns 500", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+						})
+					})
+				})
+
+				Context("when the build is not found", func() {
+					BeforeEach(func() {
+						fakeJob.BuildReturns(nil, false, nil)
+					})
+
+					It("returns a 404", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+					})
+				})
+
+				Context("when getting the build fails", func() {
+					BeforeEach(func() {
+						fakeJob.BuildReturns(nil, false, errors.New("nope"))
+					})
+
+					It("returns a 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+
+			Context("when the job is not found", func() {
+				BeforeEach(func() {
+					fakePipeline.JobReturns(nil, false, nil)
+				})
+
+				It("returns a 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+		})
+	})
+
 	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/inputs", func() {
 		var response *http.Response
 
@@ -3,10 +3,14 @@ package jobserver
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/api/present"
 	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/scheduler/inputmapper"
 )
 
 func (s *Server) CreateJobBuild(pipeline db.Pipeline) http.Handler {
@@ -16,6 +20,12 @@ func (s *Server) CreateJobBuild(pipeline db.Pipeline) http.Handler {
 
 		jobName := r.FormValue(":job_name")
 
+		if pipeline.Archived() {
+			logger.Info("pipeline-is-archived")
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+
 		job, found, err := pipeline.Job(jobName)
 		if err != nil {
 			logger.Error("failed-to-get-resource-types", err)
@@ -33,6 +43,15 @@ func (s *Server) CreateJobBuild(pipeline db.Pipeline) http.Handler {
 			return
 		}
 
+		// the body is optional; without it the latest versions are used
+		var request atc.CreateJobBuildRequest
+		err = json.NewDecoder(r.Body).Decode(&request)
+		if err != nil && err != io.EOF {
+			logger.Info("malformed-request", lager.Data{"error": err.Error()})
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
 		scheduler := s.schedulerFactory.BuildScheduler(pipeline, s.externalURL, s.variablesFactory.NewVariables(pipeline.TeamName(), pipeline.Name()))
 
 		resourceTypes, err := pipeline.ResourceTypes()
@@ -51,7 +70,19 @@ func (s *Server) CreateJobBuild(pipeline db.Pipeline) http.Handler {
 			return
 		}
 
-		build, _, err := scheduler.TriggerImmediately(logger, job, resources, versionedResourceTypes)
+		var build db.Build
+		if len(request.Inputs) > 0 {
+			build, _, err = scheduler.TriggerImmediatelyWithInputs(logger, job, request.Inputs, resources, versionedResourceTypes)
+		} else {
+			build, _, err = scheduler.TriggerImmediately(logger, job, resources, versionedResourceTypes)
+		}
+
+		if overrideErr, ok := err.(inputmapper.InputOverrideError); ok {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, "invalid input versions: %s", overrideErr)
+			return
+		}
+
 		if err != nil {
 			logger.Error("failed-to-trigger", err)
 			w.WriteHeader(http.StatusInternalServerError)
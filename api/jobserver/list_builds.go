@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"strconv"
 
+	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/api/present"
 	"github.com/concourse/atc/db"
@@ -38,6 +39,14 @@ func (s *Server) ListJobBuilds(pipeline db.Pipeline) http.Handler {
 			limit = atc.PaginationAPIDefaultLimit
 		}
 
+		metadata, err := atc.ParseBuildMetadataFilter(r.URL.Query()[atc.BuildQueryMetadata])
+		if err != nil {
+			logger.Info("malformed-metadata-filter", lager.Data{"error": err.Error()})
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, err.Error())
+			return
+		}
+
 		job, found, err := pipeline.Job(jobName)
 		if err != nil {
 			logger.Error("failed-to-get-job", err)
@@ -51,9 +60,10 @@ func (s *Server) ListJobBuilds(pipeline db.Pipeline) http.Handler {
 		}
 
 		builds, pagination, err := job.Builds(db.Page{
-			Since: since,
-			Until: until,
-			Limit: limit,
+			Since:    since,
+			Until:    until,
+			Limit:    limit,
+			Metadata: metadata,
 		})
 		if err != nil {
 			w.WriteHeader(http.StatusNotFound)
@@ -86,7 +96,7 @@ func (s *Server) ListJobBuilds(pipeline db.Pipeline) http.Handler {
 
 func (s *Server) addNextLink(w http.ResponseWriter, teamName, pipelineName, jobName string, page db.Page) {
 	w.Header().Add("Link", fmt.Sprintf(
-		`<%s/api/v1/teams/%s/pipelines/%s/jobs/%s/builds?%s=%d&%s=%d>; rel="%s"`,
+		`<%s/api/v1/teams/%s/pipelines/%s/jobs/%s/builds?%s=%d&%s=%d%s>; rel="%s"`,
 		s.externalURL,
 		teamName,
 		pipelineName,
@@ -95,13 +105,14 @@ func (s *Server) addNextLink(w http.ResponseWriter, teamName, pipelineName, jobN
 		page.Since,
 		atc.PaginationQueryLimit,
 		page.Limit,
+		atc.BuildMetadataFilterQuery(page.Metadata),
 		atc.LinkRelNext,
 	))
 }
 
 func (s *Server) addPreviousLink(w http.ResponseWriter, teamName, pipelineName, jobName string, page db.Page) {
 	w.Header().Add("Link", fmt.Sprintf(
-		`<%s/api/v1/teams/%s/pipelines/%s/jobs/%s/builds?%s=%d&%s=%d>; rel="%s"`,
+		`<%s/api/v1/teams/%s/pipelines/%s/jobs/%s/builds?%s=%d&%s=%d%s>; rel="%s"`,
 		s.externalURL,
 		teamName,
 		pipelineName,
@@ -110,6 +121,7 @@ func (s *Server) addPreviousLink(w http.ResponseWriter, teamName, pipelineName,
 		page.Until,
 		atc.PaginationQueryLimit,
 		page.Limit,
+		atc.BuildMetadataFilterQuery(page.Metadata),
 		atc.LinkRelPrevious,
 	))
 }
@@ -0,0 +1,94 @@
+package jobserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/concourse/atc/api/present"
+	"github.com/concourse/atc/db"
+)
+
+func (s *Server) RerunJobBuild(pipeline db.Pipeline) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		logger := s.logger.Session("rerun-job-build")
+
+		jobName := r.FormValue(":job_name")
+		buildName := r.FormValue(":build_name")
+
+		if pipeline.Archived() {
+			logger.Info("pipeline-is-archived")
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+
+		job, found, err := pipeline.Job(jobName)
+		if err != nil {
+			logger.Error("failed-to-get-job", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if job.Config().DisableManualTrigger {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+
+		build, found, err := job.Build(buildName)
+		if err != nil {
+			logger.Error("failed-to-get-job-build", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		// a build which was never scheduled has no inputs to re-run it with
+		if !build.IsScheduled() {
+			logger.Info("build-not-scheduled")
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+
+		scheduler := s.schedulerFactory.BuildScheduler(pipeline, s.externalURL, s.variablesFactory.NewVariables(pipeline.TeamName(), pipeline.Name()))
+
+		resourceTypes, err := pipeline.ResourceTypes()
+		if err != nil {
+			logger.Error("failed-to-get-resource-types", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		versionedResourceTypes := resourceTypes.Deserialize()
+
+		resources, err := pipeline.Resources()
+		if err != nil {
+			logger.Error("failed-to-get-resources", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		rerunBuild, _, err := scheduler.RerunImmediately(logger, job, build, resources, versionedResourceTypes)
+		if err != nil {
+			logger.Error("failed-to-rerun", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprintf(w, "failed to rerun: %s", err)
+			return
+		}
+
+		err = json.NewEncoder(w).Encode(present.Build(rerunBuild))
+		if err != nil {
+			logger.Error("failed-to-encode-build", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
@@ -134,6 +134,7 @@ var _ = Describe("Pipelines API", func() {
 					"name": "public-pipeline",
 					"paused": true,
 					"public": true,
+					"archived": false,
 					"team_name": "main",
 					"groups": [
 						{
@@ -148,6 +149,7 @@ var _ = Describe("Pipelines API", func() {
 					"name": "another-pipeline",
 					"paused": true,
 					"public": true,
+					"archived": false,
 					"team_name": "another"
 				}]`))
 			})
@@ -170,6 +172,7 @@ var _ = Describe("Pipelines API", func() {
 					"name": "private-pipeline",
 					"paused": false,
 					"public": false,
+					"archived": false,
 					"team_name": "main",
 					"groups": [
 						{
@@ -184,6 +187,7 @@ var _ = Describe("Pipelines API", func() {
 					"name": "public-pipeline",
 					"paused": true,
 					"public": true,
+					"archived": false,
 					"team_name": "main",
 					"groups": [
 						{
@@ -198,6 +202,7 @@ var _ = Describe("Pipelines API", func() {
 					"name": "another-pipeline",
 					"paused": true,
 					"public": true,
+					"archived": false,
 					"team_name": "another"
 				}]`))
 			})
@@ -215,10 +220,17 @@ var _ = Describe("Pipelines API", func() {
 	})
 
 	Describe("GET /api/v1/teams/:team_name/pipelines", func() {
-		var response *http.Response
+		var (
+			queryParams string
+			response    *http.Response
+		)
+
+		BeforeEach(func() {
+			queryParams = ""
+		})
 
 		JustBeforeEach(func() {
-			req, err := http.NewRequest("GET", server.URL+"/api/v1/teams/main/pipelines", nil)
+			req, err := http.NewRequest("GET", server.URL+"/api/v1/teams/main/pipelines"+queryParams, nil)
 			Expect(err).NotTo(HaveOccurred())
 
 			req.Header.Set("Content-Type", "application/json")
@@ -257,6 +269,7 @@ var _ = Describe("Pipelines API", func() {
 						"name": "private-pipeline",
 						"paused": false,
 						"public": false,
+						"archived": false,
 						"team_name": "main",
 						"groups": [
 							{
@@ -271,6 +284,7 @@ var _ = Describe("Pipelines API", func() {
 						"name": "public-pipeline",
 						"paused": true,
 						"public": true,
+						"archived": false,
 						"team_name": "main",
 						"groups": [
 							{
@@ -282,6 +296,82 @@ var _ = Describe("Pipelines API", func() {
 					}]`))
 			})
 
+			Context("when some of the pipelines are archived", func() {
+				BeforeEach(func() {
+					publicPipeline.ArchivedReturns(true)
+				})
+
+				Context("when filtering for archived pipelines", func() {
+					BeforeEach(func() {
+						queryParams = "?archived=true"
+					})
+
+					It("returns only the archived pipelines", func() {
+						var pipelines []atc.Pipeline
+						err := json.NewDecoder(response.Body).Decode(&pipelines)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(pipelines).To(HaveLen(1))
+						Expect(pipelines[0].Name).To(Equal("public-pipeline"))
+						Expect(pipelines[0].Archived).To(BeTrue())
+					})
+				})
+
+				Context("when filtering for pipelines that are not archived", func() {
+					BeforeEach(func() {
+						queryParams = "?archived=false"
+					})
+
+					It("returns only the pipelines that are not archived", func() {
+						var pipelines []atc.Pipeline
+						err := json.NewDecoder(response.Body).Decode(&pipelines)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(pipelines).To(HaveLen(1))
+						Expect(pipelines[0].Name).To(Equal("private-pipeline"))
+					})
+				})
+
+				Context("when not filtering", func() {
+					It("returns all of the pipelines", func() {
+						var pipelines []atc.Pipeline
+						err := json.NewDecoder(response.Body).Decode(&pipelines)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(pipelines).To(HaveLen(2))
+					})
+				})
+			})
+
+			Context("when a pipeline has instances", func() {
+				BeforeEach(func() {
+					privatePipelineInstance := new(dbfakes.FakePipeline)
+					privatePipelineInstance.IDReturns(4)
+					privatePipelineInstance.NameReturns("private-pipeline")
+					privatePipelineInstance.InstanceVarsReturns(atc.InstanceVars{"branch": "some-branch"})
+					privatePipelineInstance.TeamNameReturns("main")
+
+					fakeTeam.PipelinesReturns([]db.Pipeline{
+						privatePipeline,
+						publicPipeline,
+						privatePipelineInstance,
+					}, nil)
+				})
+
+				It("groups the instances under the pipeline's name", func() {
+					var pipelines []atc.Pipeline
+					err := json.NewDecoder(response.Body).Decode(&pipelines)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(pipelines).To(HaveLen(3))
+					Expect(pipelines[0].ID).To(Equal(3))
+					Expect(pipelines[1].ID).To(Equal(4))
+					Expect(pipelines[1].Name).To(Equal("private-pipeline"))
+					Expect(pipelines[1].InstanceVars).To(Equal(atc.InstanceVars{"branch": "some-branch"}))
+					Expect(pipelines[2].ID).To(Equal(1))
+				})
+			})
+
 			Context("when the call to get active pipelines fails", func() {
 				BeforeEach(func() {
 					fakeTeam.PipelinesReturns(nil, errors.New("disaster"))
@@ -310,6 +400,7 @@ var _ = Describe("Pipelines API", func() {
 						"name": "public-pipeline",
 						"paused": true,
 						"public": true,
+						"archived": false,
 						"team_name": "main",
 						"groups": [
 							{
@@ -339,6 +430,7 @@ var _ = Describe("Pipelines API", func() {
 						"name": "public-pipeline",
 						"paused": true,
 						"public": true,
+						"archived": false,
 						"team_name": "main",
 						"groups": [
 							{
@@ -424,6 +516,7 @@ var _ = Describe("Pipelines API", func() {
 						"name": "some-specific-pipeline",
 						"paused": false,
 						"public": true,
+						"archived": false,
 						"team_name": "a-team",
 						"groups": [
 							{
@@ -957,6 +1050,97 @@ var _ = Describe("Pipelines API", func() {
 						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
 					})
 				})
+
+				Context("when the pipeline is archived", func() {
+					BeforeEach(func() {
+						dbPipeline.ArchivedReturns(true)
+					})
+
+					It("returns 409", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusConflict))
+					})
+
+					It("does not unpause the pipeline", func() {
+						Expect(dbPipeline.UnpauseCallCount()).To(BeZero())
+					})
+				})
+			})
+
+			Context("when requester does not belong to the team", func() {
+				BeforeEach(func() {
+					jwtValidator.IsAuthenticatedReturns(true)
+					userContextReader.GetTeamReturns("another-team", true, true)
+				})
+
+				It("returns 403 Forbidden", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				jwtValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401 Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
+	Describe("PUT /api/v1/teams/:team_name/pipelines/:pipeline_name/archive", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+
+			request, err := http.NewRequest("PUT", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/archive", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated", func() {
+			Context("when requester belongs to the team", func() {
+				BeforeEach(func() {
+					jwtValidator.IsAuthenticatedReturns(true)
+					userContextReader.GetTeamReturns("a-team", true, true)
+					dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+				})
+
+				It("constructs team with provided team name", func() {
+					Expect(dbTeamFactory.FindTeamCallCount()).To(Equal(1))
+					Expect(dbTeamFactory.FindTeamArgsForCall(0)).To(Equal("a-team"))
+				})
+
+				It("injects the proper pipelineDB", func() {
+					pipelineName := fakeTeam.PipelineArgsForCall(0)
+					Expect(pipelineName).To(Equal("a-pipeline"))
+				})
+
+				Context("when archiving the pipeline succeeds", func() {
+					BeforeEach(func() {
+						fakeTeam.PipelineReturns(dbPipeline, true, nil)
+						dbPipeline.ArchiveReturns(nil)
+					})
+
+					It("returns 200", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+					})
+				})
+
+				Context("when archiving the pipeline fails", func() {
+					BeforeEach(func() {
+						fakeTeam.PipelineReturns(dbPipeline, true, nil)
+						dbPipeline.ArchiveReturns(errors.New("welp"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
 			})
 
 			Context("when requester does not belong to the team", func() {
@@ -1374,6 +1558,119 @@ var _ = Describe("Pipelines API", func() {
 		})
 	})
 
+	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/notifications", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+
+			request, err := http.NewRequest("GET", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/notifications", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				jwtValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("a-team", true, true)
+				dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+				fakeTeam.PipelineReturns(dbPipeline, true, nil)
+			})
+
+			Context("when getting the notifications succeeds", func() {
+				BeforeEach(func() {
+					dbPipeline.NotificationsReturns([]db.Notification{
+						{
+							ID: 1,
+							Config: atc.NotificationConfig{
+								Name:   "chat",
+								URL:    "https://chat.example.com/hook",
+								Secret: "some-secret",
+								Events: []atc.BuildStatus{atc.StatusFailed},
+								Jobs:   []string{"some-job"},
+							},
+							LastDelivery: &db.NotificationDelivery{
+								ID:              42,
+								BuildID:         7,
+								Event:           atc.StatusFailed,
+								Status:          db.NotificationDeliveryPending,
+								Attempts:        2,
+								LastAttemptTime: time.Unix(100, 0),
+								ResponseStatus:  503,
+								Error:           "unexpected response: 503 Service Unavailable",
+							},
+						},
+						{
+							ID: 2,
+							Config: atc.NotificationConfig{
+								Name: "audit",
+								URL:  "https://audit.example.com",
+							},
+						},
+					}, nil)
+				})
+
+				It("returns 200", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				It("returns application/json", func() {
+					Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+				})
+
+				It("returns the notifications with their last delivery, without their secrets", func() {
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`[
+						{
+							"name": "chat",
+							"url": "https://chat.example.com/hook",
+							"events": ["failed"],
+							"jobs": ["some-job"],
+							"last_delivery": {
+								"id": 42,
+								"build_id": 7,
+								"event": "failed",
+								"status": "pending",
+								"attempts": 2,
+								"last_attempt_time": 100,
+								"response_status": 503,
+								"error": "unexpected response: 503 Service Unavailable"
+							}
+						},
+						{
+							"name": "audit",
+							"url": "https://audit.example.com"
+						}
+					]`))
+				})
+			})
+
+			Context("when getting the notifications fails", func() {
+				BeforeEach(func() {
+					dbPipeline.NotificationsReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				jwtValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401 Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
 	Describe("PUT /api/v1/teams/:team_name/pipelines/:pipeline_name/rename", func() {
 		var response *http.Response
 
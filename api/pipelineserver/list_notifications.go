@@ -0,0 +1,36 @@
+package pipelineserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/present"
+	"github.com/concourse/atc/db"
+)
+
+func (s *Server) ListNotifications(pipelineDB db.Pipeline) http.Handler {
+	logger := s.logger.Session("list-notifications")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		notifications, err := pipelineDB.Notifications()
+		if err != nil {
+			logger.Error("failed-to-get-notifications", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		presentedNotifications := []atc.Notification{}
+		for _, notification := range notifications {
+			presentedNotifications = append(presentedNotifications, present.Notification(notification))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		err = json.NewEncoder(w).Encode(presentedNotifications)
+		if err != nil {
+			logger.Error("failed-to-encode-notifications", err)
+		}
+	})
+}
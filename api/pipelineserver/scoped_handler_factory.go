@@ -3,6 +3,7 @@ package pipelineserver
 import (
 	"net/http"
 
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/api/auth"
 	"github.com/concourse/atc/db"
 )
@@ -37,7 +38,13 @@ func (pdbh *ScopedHandlerFactory) HandlerFor(pipelineScopedHandler func(db.Pipel
 				return
 			}
 
-			pipeline, found, err = dbTeam.Pipeline(pipelineName)
+			instanceVars, err := atc.ParseInstanceVars(r.FormValue(atc.InstanceVarsQueryParam))
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			pipeline, found, err = auth.FindPipeline(dbTeam, pipelineName, instanceVars)
 			if err != nil {
 				w.WriteHeader(http.StatusInternalServerError)
 				return
@@ -5,7 +5,9 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/api/auth"
 	"github.com/concourse/atc/api/pipelineserver"
 	"github.com/concourse/atc/db"
@@ -25,7 +27,8 @@ var _ = Describe("Handler", func() {
 		fakeTeam      *dbfakes.FakeTeam
 		fakePipeline  *dbfakes.FakePipeline
 
-		handler http.Handler
+		handler     http.Handler
+		queryParams string
 	)
 
 	BeforeEach(func() {
@@ -35,6 +38,8 @@ var _ = Describe("Handler", func() {
 		fakeTeam = new(dbfakes.FakeTeam)
 		fakePipeline = new(dbfakes.FakePipeline)
 
+		queryParams = ""
+
 		handlerFactory := pipelineserver.NewScopedHandlerFactory(dbTeamFactory)
 		handler = handlerFactory.HandlerFor(delegate.GetHandler)
 	})
@@ -42,7 +47,7 @@ var _ = Describe("Handler", func() {
 	JustBeforeEach(func() {
 		server = httptest.NewServer(handler)
 
-		request, err := http.NewRequest("POST", server.URL+"?:team_name=some-team&:pipeline_name=some-pipeline", nil)
+		request, err := http.NewRequest("POST", server.URL+"?:team_name=some-team&:pipeline_name=some-pipeline"+queryParams, nil)
 		Expect(err).NotTo(HaveOccurred())
 
 		response, err = new(http.Client).Do(request)
@@ -127,6 +132,41 @@ var _ = Describe("Handler", func() {
 				})
 			})
 
+			Context("when an instance of the pipeline is requested", func() {
+				BeforeEach(func() {
+					queryParams = "&instance_vars=" + url.QueryEscape(`{"branch":"some-branch"}`)
+					fakeTeam.PipelineInstanceReturns(fakePipeline, true, nil)
+				})
+
+				It("looks up the instance identified by the vars", func() {
+					Expect(fakeTeam.PipelineCallCount()).To(BeZero())
+					Expect(fakeTeam.PipelineInstanceCallCount()).To(Equal(1))
+
+					pipelineName, instanceVars := fakeTeam.PipelineInstanceArgsForCall(0)
+					Expect(pipelineName).To(Equal("some-pipeline"))
+					Expect(instanceVars).To(Equal(atc.InstanceVars{"branch": "some-branch"}))
+				})
+
+				It("calls the scoped handler with the instance", func() {
+					Expect(delegate.IsCalled).To(BeTrue())
+					Expect(delegate.Pipeline).To(BeIdenticalTo(fakePipeline))
+				})
+
+				Context("when the instance vars are malformed", func() {
+					BeforeEach(func() {
+						queryParams = "&instance_vars=" + url.QueryEscape(`{"branch":`)
+					})
+
+					It("returns 400", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+
+					It("does not call the scoped handler", func() {
+						Expect(delegate.IsCalled).To(BeFalse())
+					})
+				})
+			})
+
 			Context("when the pipeline does not exist", func() {
 				BeforeEach(func() {
 					fakeTeam.PipelineReturns(nil, false, nil)
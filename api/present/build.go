@@ -26,6 +26,8 @@ func Build(build db.Build) atc.Build {
 		TeamName:     build.TeamName(),
 		Status:       string(build.Status()),
 		APIURL:       apiURL,
+		Metadata:     build.Metadata(),
+		Archived:     build.IsArchived(),
 	}
 
 	if !build.StartTime().IsZero() {
@@ -40,5 +42,14 @@ func Build(build db.Build) atc.Build {
 		atcBuild.ReapTime = build.ReapTime().Unix()
 	}
 
+	if build.RerunOf() != 0 {
+		atcBuild.RerunOf = &atc.RerunOfBuild{
+			ID:   build.RerunOf(),
+			Name: build.RerunOfName(),
+		}
+	}
+
+	atcBuild.InputOverrides = build.InputOverrides()
+
 	return atcBuild
 }
@@ -0,0 +1,21 @@
+package present
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+)
+
+func BuildLogMatch(match db.BuildLogMatch) atc.BuildLogMatch {
+	lines := make([]atc.BuildLogLine, len(match.Lines))
+	for i, line := range match.Lines {
+		lines[i] = atc.BuildLogLine{
+			Offset: line.Offset,
+			Text:   line.Text,
+		}
+	}
+
+	return atc.BuildLogMatch{
+		Build: Build(match.Build),
+		Lines: lines,
+	}
+}
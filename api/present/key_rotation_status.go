@@ -0,0 +1,18 @@
+package present
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+)
+
+func KeyRotationStatus(status db.KeyRotationStatus) atc.KeyRotationStatus {
+	return atc.KeyRotationStatus{
+		Table:       status.Table,
+		Column:      status.Column,
+		LastID:      status.LastID,
+		RowsRotated: status.RowsRotated,
+		RowsSkipped: status.RowsSkipped,
+		Finished:    status.Finished,
+		UpdatedAt:   status.UpdatedAt.Unix(),
+	}
+}
@@ -0,0 +1,35 @@
+package present
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+)
+
+func Notification(notification db.Notification) atc.Notification {
+	atcNotification := atc.Notification{
+		Name:   notification.Config.Name,
+		URL:    notification.Config.URL,
+		Events: notification.Config.Events,
+		Jobs:   notification.Config.Jobs,
+	}
+
+	if notification.LastDelivery != nil {
+		delivery := notification.LastDelivery
+
+		atcNotification.LastDelivery = &atc.NotificationDelivery{
+			ID:             delivery.ID,
+			BuildID:        delivery.BuildID,
+			Event:          delivery.Event,
+			Status:         string(delivery.Status),
+			Attempts:       delivery.Attempts,
+			ResponseStatus: delivery.ResponseStatus,
+			Error:          delivery.Error,
+		}
+
+		if !delivery.LastAttemptTime.IsZero() {
+			atcNotification.LastDelivery.LastAttemptTime = delivery.LastAttemptTime.Unix()
+		}
+	}
+
+	return atcNotification
+}
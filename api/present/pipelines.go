@@ -5,11 +5,24 @@ import (
 	"github.com/concourse/atc/db"
 )
 
+// Pipelines presents the pipelines in order, with the instances of a pipeline
+// grouped under its name where its first instance appears.
 func Pipelines(savedPipelines []db.Pipeline) []atc.Pipeline {
-	pipelines := make([]atc.Pipeline, len(savedPipelines))
+	instances := map[string][]atc.Pipeline{}
+	names := []string{}
 
-	for i := range savedPipelines {
-		pipelines[i] = Pipeline(savedPipelines[i])
+	for _, savedPipeline := range savedPipelines {
+		name := savedPipeline.TeamName() + "/" + savedPipeline.Name()
+		if _, found := instances[name]; !found {
+			names = append(names, name)
+		}
+
+		instances[name] = append(instances[name], Pipeline(savedPipeline))
+	}
+
+	pipelines := []atc.Pipeline{}
+	for _, name := range names {
+		pipelines = append(pipelines, instances[name]...)
 	}
 
 	return pipelines
@@ -29,6 +29,9 @@ func Resource(resource db.Resource, groups atc.GroupConfigs, showCheckError bool
 
 		Paused: resource.Paused(),
 
+		PinnedVersion: resource.PinnedVersion(),
+		PinComment:    resource.PinComment(),
+
 		FailingToCheck: resource.FailingToCheck(),
 		CheckError:     checkErrString,
 	}
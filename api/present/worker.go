@@ -26,6 +26,8 @@ func Worker(workerInfo db.Worker) atc.Worker {
 		HTTPSProxyURL:    workerInfo.HTTPSProxyURL(),
 		NoProxy:          workerInfo.NoProxy(),
 		ActiveContainers: workerInfo.ActiveContainers(),
+		ActiveVolumes:    workerInfo.ActiveVolumes(),
+		MaxContainers:    workerInfo.MaxContainers(),
 		ResourceTypes:    workerInfo.ResourceTypes(),
 		Platform:         workerInfo.Platform(),
 		Tags:             workerInfo.Tags(),
@@ -361,6 +361,38 @@ var _ = Describe("Resources API", func() {
 								"check_error": "sup"
 							}`))
 				})
+
+				Context("when the resource is pinned", func() {
+					BeforeEach(func() {
+						resource1 := new(dbfakes.FakeResource)
+						resource1.PipelineNameReturns("a-pipeline")
+						resource1.NameReturns("resource-1")
+						resource1.TypeReturns("type-1")
+						resource1.LastCheckedReturns(time.Unix(1513364881, 0))
+						resource1.PinnedVersionIDReturns(42)
+						resource1.PinnedVersionReturns(atc.Version{"version": "v1"})
+						resource1.PinCommentReturns("broken in v2")
+
+						fakePipeline.ResourceReturns(resource1, true, nil)
+					})
+
+					It("returns the resource json with the pinned version", func() {
+						body, err := ioutil.ReadAll(response.Body)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(body).To(MatchJSON(`
+							{
+								"name": "resource-1",
+								"pipeline_name": "a-pipeline",
+								"team_name": "a-team",
+								"type": "type-1",
+								"groups": ["group-1", "group-2"],
+								"last_checked": 1513364881,
+								"pinned_version": {"version": "v1"},
+								"pin_comment": "broken in v2"
+							}`))
+					})
+				})
 			})
 		})
 	})
@@ -0,0 +1,63 @@
+package versionserver
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	"github.com/tedsuo/rata"
+)
+
+func (s *Server) PinResourceVersion(pipeline db.Pipeline) http.Handler {
+	logger := s.logger.Session("pin-resource-version")
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resourceName := rata.Param(r, "resource_name")
+
+		versionedResourceID, err := strconv.Atoi(rata.Param(r, "resource_version_id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		// the request body, and with it the comment, is optional
+		var reqBody atc.PinRequestBody
+		err = json.NewDecoder(r.Body).Decode(&reqBody)
+		if err != nil && err != io.EOF {
+			logger.Info("malformed-request", lager.Data{"error": err.Error()})
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		resource, found, err := pipeline.Resource(resourceName)
+		if err != nil {
+			logger.Error("failed-to-get-resource", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			logger.Debug("resource-not-found", lager.Data{"resource": resourceName})
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		found, err = resource.PinVersion(versionedResourceID, reqBody.Comment)
+		if err != nil {
+			logger.Error("failed-to-pin-resource-version", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			logger.Debug("resource-version-not-found", lager.Data{"resource": resourceName, "version": versionedResourceID})
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	})
+}
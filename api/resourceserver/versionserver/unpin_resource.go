@@ -0,0 +1,38 @@
+package versionserver
+
+import (
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/db"
+	"github.com/tedsuo/rata"
+)
+
+func (s *Server) UnpinResource(pipeline db.Pipeline) http.Handler {
+	logger := s.logger.Session("unpin-resource")
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resourceName := rata.Param(r, "resource_name")
+
+		resource, found, err := pipeline.Resource(resourceName)
+		if err != nil {
+			logger.Error("failed-to-get-resource", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			logger.Debug("resource-not-found", lager.Data{"resource": resourceName})
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		err = resource.UnpinVersion()
+		if err != nil {
+			logger.Error("failed-to-unpin-resource", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	})
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/concourse/skymarshal/provider/providerfakes"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/dbfakes"
+	"github.com/concourse/atc/event"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 )
@@ -201,6 +202,36 @@ var _ = Describe("Teams API", func() {
 					})
 				})
 
+				Context("when the roles are invalid", func() {
+					Context("when a role is unknown", func() {
+						BeforeEach(func() {
+							atcTeam.Roles = atc.TeamRoles{
+								"bogus": {
+									fakeProviderName: {Users: []string{"some-user"}},
+								},
+							}
+						})
+
+						It("returns a 400 Bad Request", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+						})
+					})
+
+					Context("when a role refers to a provider the team does not configure", func() {
+						BeforeEach(func() {
+							atcTeam.Roles = atc.TeamRoles{
+								atc.MemberRole: {
+									"some-other-provider": {Users: []string{"some-user"}},
+								},
+							}
+						})
+
+						It("returns a 400 Bad Request", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+						})
+					})
+				})
+
 				Context("when the auth config cannot be finalized", func() {
 					BeforeEach(func() {
 						fakeAuthConfig.FinalizeReturns(errors.New("finalize error"))
@@ -244,6 +275,32 @@ var _ = Describe("Teams API", func() {
 								Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
 							})
 						})
+
+						Context("when roles are given", func() {
+							BeforeEach(func() {
+								atcTeam.Roles = atc.TeamRoles{
+									atc.ViewerRole: {
+										fakeProviderName: {Users: []string{"some-user"}},
+									},
+								}
+							})
+
+							It("updates the roles", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusOK))
+								Expect(fakeTeam.UpdateRolesCallCount()).To(Equal(1))
+								Expect(fakeTeam.UpdateRolesArgsForCall(0)).To(Equal(atcTeam.Roles))
+							})
+
+							Context("when updating the roles fails", func() {
+								BeforeEach(func() {
+									fakeTeam.UpdateRolesReturns(errors.New("nope"))
+								})
+
+								It("returns 500 Internal Server error", func() {
+									Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+								})
+							})
+						})
 					})
 				})
 			})
@@ -632,4 +689,161 @@ var _ = Describe("Teams API", func() {
 			})
 		})
 	})
+
+	Describe("GET /api/v1/teams/:team_name/events", func() {
+		var (
+			request  *http.Request
+			response *http.Response
+
+			fakeEventSource *dbfakes.FakeTeamEventSource
+		)
+
+		BeforeEach(func() {
+			var err error
+			request, err = http.NewRequest("GET", server.URL+"/api/v1/teams/some-team/events", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			fakeEventSource = new(dbfakes.FakeTeamEventSource)
+
+			events := []db.TeamEvent{
+				{
+					ID: 3,
+					Envelope: event.Envelope{
+						Event:   event.EventTypeJobStatus,
+						Version: "1.0",
+						Data:    fakeData(`{"job_name":"some-job","paused":true}`),
+					},
+				},
+				{
+					ID: 7,
+					Envelope: event.Envelope{
+						Event:   event.EventTypeBuildStatus,
+						Version: "1.0",
+						Data:    fakeData(`{"job_name":"some-job","status":"started"}`),
+					},
+				},
+			}
+
+			fakeEventSource.NextStub = func() (db.TeamEvent, error) {
+				if len(events) == 0 {
+					return db.TeamEvent{}, db.ErrTeamEventStreamClosed
+				}
+
+				ev := events[0]
+				events = events[1:]
+				return ev, nil
+			}
+
+			fakeTeam.EventsReturns(fakeEventSource, nil)
+			fakeTeam.PublicEventsReturns(fakeEventSource, nil)
+			dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized for the team", func() {
+			BeforeEach(func() {
+				jwtValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("some-team", false, true)
+			})
+
+			It("returns 200", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+			})
+
+			It("returns Content-Type 'text/event-stream'", func() {
+				Expect(response.Header.Get("Content-Type")).To(Equal("text/event-stream; charset=utf-8"))
+			})
+
+			It("streams the events of all of the team's pipelines from now on", func() {
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(string(body)).To(ContainSubstring("id: 3\nevent: event\ndata: {\"data\":{\"job_name\":\"some-job\",\"paused\":true},\"event\":\"job-status\",\"version\":\"1.0\"}\n"))
+				Expect(string(body)).To(ContainSubstring("id: 7\nevent: event\ndata: {\"data\":{\"job_name\":\"some-job\",\"status\":\"started\"},\"event\":\"build-status\",\"version\":\"1.0\"}\n"))
+
+				Expect(dbTeamFactory.FindTeamArgsForCall(0)).To(Equal("some-team"))
+				Expect(fakeTeam.EventsCallCount()).To(Equal(1))
+				Expect(fakeTeam.EventsArgsForCall(0)).To(Equal(0))
+				Expect(fakeTeam.PublicEventsCallCount()).To(Equal(0))
+			})
+
+			It("closes the event source", func() {
+				_, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(fakeEventSource.CloseCallCount).Should(BeNumerically(">=", 1))
+			})
+
+			Context("when resuming from an event", func() {
+				BeforeEach(func() {
+					request.Header.Set("Last-Event-ID", "2")
+				})
+
+				It("streams the events after it", func() {
+					Expect(fakeTeam.EventsCallCount()).To(Equal(1))
+					Expect(fakeTeam.EventsArgsForCall(0)).To(Equal(2))
+				})
+			})
+
+			Context("when the Last-Event-ID is not a number", func() {
+				BeforeEach(func() {
+					request.Header.Set("Last-Event-ID", "nope")
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+			})
+
+			Context("when getting the events fails", func() {
+				BeforeEach(func() {
+					fakeTeam.EventsReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not authorized for the team", func() {
+			BeforeEach(func() {
+				jwtValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 200", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+			})
+
+			It("only streams the events of the team's public pipelines", func() {
+				Expect(fakeTeam.PublicEventsCallCount()).To(Equal(1))
+				Expect(fakeTeam.EventsCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the team cannot be found", func() {
+			BeforeEach(func() {
+				dbTeamFactory.FindTeamReturns(nil, false, nil)
+			})
+
+			It("returns 404", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+			})
+		})
+
+		Context("when finding the team fails", func() {
+			BeforeEach(func() {
+				dbTeamFactory.FindTeamReturns(nil, false, errors.New("nope"))
+			})
+
+			It("returns 500", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+			})
+		})
+	})
 })
@@ -0,0 +1,114 @@
+package teamserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/api/auth"
+	"github.com/concourse/atc/db"
+	"github.com/vito/go-sse/sse"
+)
+
+// TeamEvents streams the changes to the team's pipelines as server-sent
+// events. Requesters that are not authorized for the team only see the
+// changes to its public pipelines.
+func (s *Server) TeamEvents(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("team-events")
+
+	teamName := r.FormValue(":team_name")
+
+	var from int
+	if r.Header.Get("Last-Event-ID") != "" {
+		startString := r.Header.Get("Last-Event-ID")
+		_, err := fmt.Sscanf(startString, "%d", &from)
+		if err != nil {
+			logger.Info("failed-to-parse-last-event-id", lager.Data{"last-event-id": startString})
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+	}
+
+	team, found, err := s.teamFactory.FindTeam(teamName)
+	if err != nil {
+		logger.Error("failed-to-get-team", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		logger.Info("team-not-found")
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	var events db.TeamEventSource
+
+	authTeam, authTeamFound := auth.GetTeam(r)
+	if authTeamFound && authTeam.IsAuthorized(teamName) {
+		events, err = team.Events(from)
+	} else {
+		events, err = team.PublicEvents(from)
+	}
+
+	if err != nil {
+		logger.Error("failed-to-get-team-events", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	defer db.Close(events)
+
+	// the stream only ends when the client goes away, so close it then rather
+	// than waiting for the next event to fail to write
+	done := make(chan struct{})
+	defer close(done)
+
+	go func() {
+		select {
+		case <-w.(http.CloseNotifier).CloseNotify():
+			db.Close(events)
+		case <-done:
+		}
+	}()
+
+	w.Header().Add("Content-Type", "text/event-stream; charset=utf-8")
+	w.Header().Add("Cache-Control", "no-cache, no-store, must-revalidate")
+	w.Header().Add("X-Accel-Buffering", "no")
+
+	w.WriteHeader(http.StatusOK)
+
+	flusher := w.(http.Flusher)
+	flusher.Flush()
+
+	for {
+		ev, err := events.Next()
+		if err != nil {
+			if err != db.ErrTeamEventStreamClosed {
+				logger.Error("failed-to-get-next-team-event", err)
+			}
+
+			return
+		}
+
+		payload, err := json.Marshal(ev.Envelope)
+		if err != nil {
+			logger.Error("failed-to-marshal-team-event", err)
+			return
+		}
+
+		err = sse.Event{
+			ID:   strconv.Itoa(ev.ID),
+			Name: "event",
+			Data: payload,
+		}.Write(w)
+		if err != nil {
+			logger.Info("failed-to-write-event", lager.Data{"error": err.Error()})
+			return
+		}
+
+		flusher.Flush()
+	}
+}
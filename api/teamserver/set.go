@@ -81,6 +81,22 @@ func (s *Server) SetTeam(w http.ResponseWriter, r *http.Request) {
 		atcTeam.Auth[providerName] = jsonConfig
 	}
 
+	for role, members := range atcTeam.Roles {
+		if !atc.IsValidRole(role) {
+			hLog.Error("unknown-role", errors.New("unknown role"), lager.Data{"role": role})
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		for providerName := range members {
+			if _, found := atcTeam.Auth[providerName]; !found {
+				hLog.Error("role-provider-not-configured", errors.New("provider not configured"), lager.Data{"role": role, "provider": providerName})
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+		}
+	}
+
 	team, found, err := s.teamFactory.FindTeam(teamName)
 	if err != nil {
 		hLog.Error("failed-to-lookup-team", err, lager.Data{"teamName": teamName})
@@ -97,6 +113,14 @@ func (s *Server) SetTeam(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
+		hLog.Debug("updating-roles")
+		err = team.UpdateRoles(atcTeam.Roles)
+		if err != nil {
+			hLog.Error("failed-to-update-team-roles", err, lager.Data{"teamName": teamName})
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
 		w.WriteHeader(http.StatusOK)
 	} else if authTeam.IsAdmin() {
 		hLog.Debug("creating team")
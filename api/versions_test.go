@@ -1,8 +1,10 @@
 package api_test
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"time"
@@ -349,6 +351,222 @@ var _ = Describe("Versions API", func() {
 		})
 	})
 
+	Describe("PUT /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/pin", func() {
+		var (
+			fakeResource *dbfakes.FakeResource
+			requestBody  io.Reader
+			response     *http.Response
+		)
+
+		BeforeEach(func() {
+			fakeResource = new(dbfakes.FakeResource)
+			requestBody = bytes.NewBufferString(`{"comment":"some-comment"}`)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			request, err := http.NewRequest("PUT", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/resources/resource-name/versions/42/pin", requestBody)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				jwtValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("a-team", true, true)
+			})
+
+			Context("when the resource exists", func() {
+				BeforeEach(func() {
+					fakePipeline.ResourceReturns(fakeResource, true, nil)
+				})
+
+				It("looks up the right resource", func() {
+					Expect(fakePipeline.ResourceArgsForCall(0)).To(Equal("resource-name"))
+				})
+
+				Context("when pinning the version succeeds", func() {
+					BeforeEach(func() {
+						fakeResource.PinVersionReturns(true, nil)
+					})
+
+					It("pins the right versioned resource with the comment", func() {
+						Expect(fakeResource.PinVersionCallCount()).To(Equal(1))
+
+						versionedResourceID, comment := fakeResource.PinVersionArgsForCall(0)
+						Expect(versionedResourceID).To(Equal(42))
+						Expect(comment).To(Equal("some-comment"))
+					})
+
+					It("returns 200", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+					})
+
+					Context("when no request body is given", func() {
+						BeforeEach(func() {
+							requestBody = nil
+						})
+
+						It("pins the version without a comment", func() {
+							_, comment := fakeResource.PinVersionArgsForCall(0)
+							Expect(comment).To(BeEmpty())
+						})
+
+						It("returns 200", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusOK))
+						})
+					})
+				})
+
+				Context("when the version does not belong to the resource", func() {
+					BeforeEach(func() {
+						fakeResource.PinVersionReturns(false, nil)
+					})
+
+					It("returns 404", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+					})
+				})
+
+				Context("when pinning the version fails", func() {
+					BeforeEach(func() {
+						fakeResource.PinVersionReturns(false, errors.New("welp"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+
+				Context("when the request body is malformed", func() {
+					BeforeEach(func() {
+						requestBody = bytes.NewBufferString(`{"comment":`)
+					})
+
+					It("does not pin the version", func() {
+						Expect(fakeResource.PinVersionCallCount()).To(BeZero())
+					})
+
+					It("returns 400", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+				})
+			})
+
+			Context("when the resource does not exist", func() {
+				BeforeEach(func() {
+					fakePipeline.ResourceReturns(nil, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when looking up the resource fails", func() {
+				BeforeEach(func() {
+					fakePipeline.ResourceReturns(nil, false, errors.New("welp"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				jwtValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
+	Describe("PUT /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/unpin", func() {
+		var (
+			fakeResource *dbfakes.FakeResource
+			response     *http.Response
+		)
+
+		BeforeEach(func() {
+			fakeResource = new(dbfakes.FakeResource)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			request, err := http.NewRequest("PUT", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/resources/resource-name/unpin", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				jwtValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("a-team", true, true)
+			})
+
+			Context("when the resource exists", func() {
+				BeforeEach(func() {
+					fakePipeline.ResourceReturns(fakeResource, true, nil)
+				})
+
+				Context("when unpinning the resource succeeds", func() {
+					BeforeEach(func() {
+						fakeResource.UnpinVersionReturns(nil)
+					})
+
+					It("unpins the resource", func() {
+						Expect(fakePipeline.ResourceArgsForCall(0)).To(Equal("resource-name"))
+						Expect(fakeResource.UnpinVersionCallCount()).To(Equal(1))
+					})
+
+					It("returns 200", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+					})
+				})
+
+				Context("when unpinning the resource fails", func() {
+					BeforeEach(func() {
+						fakeResource.UnpinVersionReturns(errors.New("welp"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+
+			Context("when the resource does not exist", func() {
+				BeforeEach(func() {
+					fakePipeline.ResourceReturns(nil, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				jwtValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
 	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/input_to", func() {
 		var response *http.Response
 		var stringVersionID string
@@ -33,11 +33,14 @@ import (
 	"github.com/concourse/atc/exec"
 	"github.com/concourse/atc/gc"
 	"github.com/concourse/atc/lockrunner"
+	"github.com/concourse/atc/logarchive"
 	"github.com/concourse/atc/metric"
+	"github.com/concourse/atc/notifications"
 	"github.com/concourse/atc/pipelines"
 	"github.com/concourse/atc/radar"
 	"github.com/concourse/atc/resource"
 	"github.com/concourse/atc/scheduler"
+	"github.com/concourse/atc/tracing"
 	"github.com/concourse/atc/worker"
 	"github.com/concourse/atc/worker/image"
 	"github.com/concourse/atc/wrappa"
@@ -69,6 +72,9 @@ import (
 	// dynamically registered metric emitters
 	_ "github.com/concourse/atc/metric/emitter"
 
+	// dynamically registered tracing exporters
+	_ "github.com/concourse/atc/tracing/exporter"
+
 	// dynamically registered credential managers
 	_ "github.com/concourse/atc/creds/credhub"
 	_ "github.com/concourse/atc/creds/kubernetes"
@@ -105,8 +111,13 @@ type ATCCommand struct {
 	CredentialManagement struct{} `group:"Credential Management"`
 	CredentialManagers   creds.Managers
 
-	EncryptionKey    CipherFlag `long:"encryption-key"     description:"A 16 or 32 length key used to encrypt sensitive information before storing it in the database."`
-	OldEncryptionKey CipherFlag `long:"old-encryption-key" description:"Encryption key previously used for encrypting sensitive information. If provided without a new key, data is encrypted. If provided with a new key, data is re-encrypted."`
+	EncryptionKey     CipherFlag   `long:"encryption-key"     description:"A 16 or 32 length key used to encrypt sensitive information before storing it in the database."`
+	OldEncryptionKeys []CipherFlag `long:"old-encryption-key" description:"Encryption key previously used for encrypting sensitive information. Can be specified multiple times. Data encrypted with these keys stays readable while it is re-encrypted with the new key, or decrypted without one, in the background."`
+
+	EncryptionKeyRotation struct {
+		Interval  time.Duration `long:"interval"   default:"1m"  description:"Interval on which to re-encrypt data still encrypted with an old key or not yet encrypted."`
+		BatchSize int           `long:"batch-size" default:"500" description:"Number of rows to re-encrypt in each transaction."`
+	} `group:"Encryption Key Rotation" namespace:"encryption-key-rotation"`
 
 	DebugBindIP   IPFlag `long:"debug-bind-ip"   default:"127.0.0.1" description:"IP address on which to listen for the pprof debugger endpoints."`
 	DebugBindPort uint16 `long:"debug-bind-port" default:"8079"      description:"Port on which to listen for the pprof debugger endpoints."`
@@ -117,9 +128,17 @@ type ATCCommand struct {
 	ResourceCheckingInterval          time.Duration `long:"resource-checking-interval" default:"1m" description:"Interval on which to check for new versions of resources."`
 	OldResourceGracePeriod            time.Duration `long:"old-resource-grace-period" default:"5m" description:"How long to cache the result of a get step after a newer version of the resource is found."`
 	ResourceCacheCleanupInterval      time.Duration `long:"resource-cache-cleanup-interval" default:"30s" description:"Interval on which to cleanup old caches of resources."`
-	ContainerPlacementStrategy        string        `long:"container-placement-strategy" default:"volume-locality" choice:"volume-locality" choice:"random" description:"Method by which a worker is selected during container placement."`
+	ContainerPlacementStrategy        []string      `long:"container-placement-strategy" default:"volume-locality" choice:"volume-locality" choice:"random" choice:"fewest-build-containers" choice:"fewest-volumes" choice:"weighted" description:"Method by which a worker is selected during container placement. Can be specified multiple times; each strategy then only chooses between the workers the ones before it consider equally good."`
+	ContainerPlacementLocalityWeight  float64       `long:"container-placement-locality-weight" default:"1" description:"How much the weighted placement strategy favors workers with the container's inputs."`
+	ContainerPlacementLoadWeight      float64       `long:"container-placement-load-weight" default:"1" description:"How much the weighted placement strategy avoids workers running many containers."`
+	MaxContainersPerWorker            int           `long:"max-containers-per-worker" default:"0" description:"Maximum number of containers to place on a worker which does not advertise its own limit. Steps wait for a worker with room when all are full. 0 means no limit."`
 	BaggageclaimResponseHeaderTimeout time.Duration `long:"baggageclaim-response-header-timeout" default:"1m" description:"How long to wait for Baggageclaim to send the response header."`
 
+	P2PVolumeStreaming         bool          `long:"enable-p2p-volume-streaming" description:"Have workers stream volumes to each other directly instead of through the ATC. Workers must be able to reach each other's registered Baggageclaim URLs, which for forwarded workers are addresses on the TSA. Volumes are streamed through the ATC to workers older than 2.1, or when streaming directly fails."`
+	VolumeStreamingTimeout     time.Duration `long:"volume-streaming-timeout" default:"1h" description:"How long a volume may take to stream from one worker to another, directly or compressed, before it is given up on."`
+	VolumeStreamingEncoding    string        `long:"volume-streaming-encoding" default:"raw" choice:"raw" choice:"gzip" choice:"zstd" description:"Compression to ask workers for when streaming volumes through the ATC. Workers which do not support it stream volumes uncompressed."`
+	DeduplicateStreamedVolumes bool          `long:"enable-streamed-volume-deduplication" description:"Keep an unmounted copy of each volume streamed to a worker, recorded with the hash of its content, and use it instead of streaming the same content to the worker again. The copy lives as long as the container it was streamed for. Content is matched by the hash of the stream as it was sent, so the same content streamed in another encoding, or compressed differently by another worker, is streamed again."`
+
 	CLIArtifactsDir DirFlag `long:"cli-artifacts-dir" description:"Directory containing downloadable CLI binaries."`
 
 	Developer struct {
@@ -151,6 +170,20 @@ type ATCCommand struct {
 		WorkerConcurrency int           `long:"worker-concurrency" default:"50" description:"Maximum number of delete operations to have in flight per worker."`
 	} `group:"Garbage Collection" namespace:"gc"`
 
+	AuditRetention time.Duration `long:"audit-retention" default:"720h" description:"How long to keep the audit events recorded for mutating API calls."`
+
+	TeamEventRetention int `long:"team-event-retention" default:"10000" description:"Number of each team's most recent pipeline events to keep for clients resuming the event stream."`
+
+	// applies to jobs which configure neither build_log_retention nor
+	// build_logs_to_retain
+	DefaultBuildLogRetention struct {
+		Builds                 int `long:"builds"                   description:"Number of most recent builds of a job to keep the logs of."`
+		Days                   int `long:"days"                     description:"Number of days to keep the logs of a job's builds for."`
+		MinimumSucceededBuilds int `long:"minimum-succeeded-builds" description:"Number of most recent succeeded builds of a job to keep the logs of, however old."`
+	} `group:"Default Build Log Retention" namespace:"default-build-log-retention"`
+
+	BuildLogArchive logarchive.Config `group:"Build Log Archive" namespace:"build-log-archive"`
+
 	BuildTrackerInterval time.Duration `long:"build-tracker-interval" default:"10s" description:"Interval on which to run build tracking."`
 
 	TelemetryOptIn bool `long:"telemetry-opt-in" hidden:"true" description:"Enable anonymous concourse version reporting."`
@@ -303,6 +336,7 @@ func (cmd *ATCCommand) WireDynamicFlags(commandFlags *flags.Command) {
 	cmd.CredentialManagers = managerConfigs
 
 	metric.WireEmitters(metricsGroup)
+	tracing.WireExporters(metricsGroup)
 
 }
 
@@ -367,14 +401,22 @@ func (cmd *ATCCommand) constructMembers(
 		break
 	}
 
+	var buildLogArchive logarchive.Archive
+	if cmd.BuildLogArchive.IsConfigured() {
+		buildLogArchive, err = cmd.BuildLogArchive.NewArchive()
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	var newKey *encryption.Key
 	if cmd.EncryptionKey.AEAD != nil {
 		newKey = encryption.NewKey(cmd.EncryptionKey.AEAD)
 	}
 
-	var oldKey *encryption.Key
-	if cmd.OldEncryptionKey.AEAD != nil {
-		oldKey = encryption.NewKey(cmd.OldEncryptionKey.AEAD)
+	var oldKeys []*encryption.Key
+	for _, oldKey := range cmd.OldEncryptionKeys {
+		oldKeys = append(oldKeys, encryption.NewKey(oldKey.AEAD))
 	}
 
 	lockConn, err := cmd.constructLockConn(retryingDriverName)
@@ -384,7 +426,7 @@ func (cmd *ATCCommand) constructMembers(
 
 	lockFactory := lock.NewLockFactory(lockConn)
 
-	dbConn, err := cmd.constructDBConn(retryingDriverName, logger, newKey, oldKey, maxConns, connectionName, lockFactory)
+	dbConn, err := cmd.constructDBConn(retryingDriverName, logger, newKey, oldKeys, maxConns, connectionName, lockFactory)
 	if err != nil {
 		return nil, err
 	}
@@ -405,6 +447,8 @@ func (cmd *ATCCommand) constructMembers(
 	dbResourceConfigCheckSessionFactory := db.NewResourceConfigCheckSessionFactory(dbConn, lockFactory)
 	dbWorkerBaseResourceTypeFactory := db.NewWorkerBaseResourceTypeFactory(dbConn)
 	dbWorkerTaskCacheFactory := db.NewWorkerTaskCacheFactory(dbConn)
+	dbAuditLog := db.NewAuditLog(dbConn)
+	dbKeyRotator := db.NewKeyRotator(logger.Session("key-rotator"), dbConn, newKey, oldKeys, cmd.EncryptionKeyRotation.BatchSize)
 	resourceFetcherFactory := resource.NewFetcherFactory(lockFactory, clock.NewClock(), dbResourceCacheFactory)
 
 	imageResourceFetcherFactory := image.NewImageResourceFetcherFactory(
@@ -427,16 +471,23 @@ func (cmd *ATCCommand) constructMembers(
 		dbWorkerFactory,
 		workerVersion,
 		cmd.BaggageclaimResponseHeaderTimeout,
+		worker.VolumeStreamingConfig{
+			P2P:         cmd.P2PVolumeStreaming,
+			Encoding:    cmd.VolumeStreamingEncoding,
+			Timeout:     cmd.VolumeStreamingTimeout,
+			Deduplicate: cmd.DeduplicateStreamedVolumes,
+		},
 	)
 
 	workerClient := cmd.constructWorkerPool(
 		logger,
 		workerProvider,
+		teamFactory,
 	)
 
 	resourceFetcher := resourceFetcherFactory.FetcherFor(workerClient)
 	resourceFactory := resource.NewResourceFactory(workerClient)
-	engine := cmd.constructEngine(workerClient, resourceFetcher, resourceFactory, dbResourceCacheFactory, variablesFactory)
+	engine := cmd.constructEngine(workerClient, resourceFetcher, resourceFactory, dbResourceCacheFactory, variablesFactory, teamFactory)
 
 	radarSchedulerFactory := pipelines.NewRadarSchedulerFactory(
 		resourceFactory,
@@ -479,6 +530,8 @@ func (cmd *ATCCommand) constructMembers(
 		dbVolumeFactory,
 		dbContainerRepository,
 		dbBuildFactory,
+		dbAuditLog,
+		dbKeyRotator,
 		signingKey,
 		engine,
 		workerClient,
@@ -487,6 +540,7 @@ func (cmd *ATCCommand) constructMembers(
 		radarSchedulerFactory,
 		radarScannerFactory,
 		variablesFactory,
+		buildLogArchive,
 	)
 
 	if err != nil {
@@ -660,18 +714,66 @@ func (cmd *ATCCommand) constructMembers(
 			cmd.GC.Interval,
 		)},
 
+		{"audit-collector", lockrunner.NewRunner(
+			logger.Session("audit-collector-runner"),
+			gc.NewAuditCollector(
+				logger.Session("audit-collector"),
+				dbAuditLog,
+				cmd.AuditRetention,
+				clock.NewClock(),
+			),
+			"audit-collector",
+			lockFactory,
+			clock.NewClock(),
+			cmd.GC.Interval,
+		)},
+
+		{"team-event-collector", lockrunner.NewRunner(
+			logger.Session("team-event-collector-runner"),
+			gc.NewTeamEventCollector(
+				logger.Session("team-event-collector"),
+				db.NewTeamEventLog(dbConn),
+				cmd.TeamEventRetention,
+			),
+			"team-event-collector",
+			lockFactory,
+			clock.NewClock(),
+			cmd.GC.Interval,
+		)},
+
 		{"build-reaper", lockrunner.NewRunner(
 			logger.Session("build-reaper-runner"),
 			gc.NewBuildReaper(
 				logger.Session("build-reaper"),
 				dbPipelineFactory,
 				500,
+				atc.BuildLogRetention{
+					Builds:                 cmd.DefaultBuildLogRetention.Builds,
+					Days:                   cmd.DefaultBuildLogRetention.Days,
+					MinimumSucceededBuilds: cmd.DefaultBuildLogRetention.MinimumSucceededBuilds,
+				},
+				buildLogArchive,
 			),
 			"build-reaper",
 			lockFactory,
 			clock.NewClock(),
 			30*time.Second,
 		)},
+
+		{"notification-deliverer", lockrunner.NewRunner(
+			logger.Session("notification-deliverer-runner"),
+			notifications.NewDeliverer(
+				logger.Session("notification-deliverer"),
+				db.NewNotificationOutbox(dbConn),
+				&http.Client{Timeout: 10 * time.Second},
+				variablesFactory,
+				clock.NewClock(),
+			),
+			"notification-deliverer",
+			lockFactory,
+			clock.NewClock(),
+			10*time.Second,
+		)},
 	}
 
 	if cmd.TelemetryOptIn {
@@ -688,6 +790,24 @@ func (cmd *ATCCommand) constructMembers(
 		members = cmd.appendStaticWorker(logger, dbWorkerFactory, members)
 	}
 
+	// some credential managers have to stay logged in for as long as the ATC
+	// runs
+	if runner, ok := variablesFactory.(ifrit.Runner); ok {
+		members = append(members, grouper.Member{"credential-manager", runner})
+	}
+
+	// without any keys there is nothing to encrypt or decrypt
+	if newKey != nil || len(oldKeys) > 0 {
+		members = append(members, grouper.Member{"key-rotator", lockrunner.NewRunner(
+			logger.Session("key-rotator-runner"),
+			dbKeyRotator,
+			"key-rotator",
+			lockFactory,
+			clock.NewClock(),
+			cmd.EncryptionKeyRotation.Interval,
+		)})
+	}
+
 	if httpsHandler != nil {
 		cert, err := tls.LoadX509KeyPair(string(cmd.TLSCert), string(cmd.TLSKey))
 		if err != nil {
@@ -742,7 +862,12 @@ func (cmd *ATCCommand) Runner(positionalArguments []string) (ifrit.Runner, error
 	}
 	go metric.PeriodicallyEmit(logger.Session("periodic-metrics"), 10*time.Second)
 
+	if err := tracing.Initialize(logger.Session("tracing")); err != nil {
+		return nil, err
+	}
+
 	apiMembers, err := cmd.constructMembers(positionalArguments, []string{
+		"credential-manager",
 		"debug",
 		"web-tls",
 		"web",
@@ -761,8 +886,13 @@ func (cmd *ATCCommand) Runner(positionalArguments []string) (ifrit.Runner, error
 		"pipelines",
 		"builds",
 		"collector",
+		"audit-collector",
+		"team-event-collector",
 		"build-reaper",
+		"notification-deliverer",
+		"key-rotator",
 		"static-worker",
+		"credential-manager",
 	},
 		32,
 		"backend",
@@ -776,7 +906,7 @@ func (cmd *ATCCommand) Runner(positionalArguments []string) (ifrit.Runner, error
 	members = append(members, apiMembers...)
 	members = append(members, serviceMembers...)
 
-	return onReady(grouper.NewParallel(os.Interrupt, members), func() {
+	runner := onReady(grouper.NewParallel(os.Interrupt, members), func() {
 		logData := lager.Data{
 			"http":  cmd.nonTLSBindAddr(),
 			"debug": cmd.debugBindAddr(),
@@ -787,6 +917,11 @@ func (cmd *ATCCommand) Runner(positionalArguments []string) (ifrit.Runner, error
 		}
 
 		logger.Info("listening", logData)
+	})
+
+	return ifrit.RunFunc(func(signals <-chan os.Signal, ready chan<- struct{}) error {
+		defer metric.Deinitialize(logger.Session("metrics"))
+		return runner.Run(signals, ready)
 	}), nil
 }
 
@@ -853,6 +988,13 @@ func (cmd *ATCCommand) validate() error {
 		tlsFlagCount++
 	}
 
+	if cmd.BuildLogArchive.IsConfigured() {
+		err := cmd.BuildLogArchive.Validate()
+		if err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+
 	if tlsFlagCount == 3 {
 		if cmd.ExternalURL.URL().Scheme != "https" {
 			errs = multierror.Append(
@@ -906,12 +1048,12 @@ func (cmd *ATCCommand) constructDBConn(
 	driverName string,
 	logger lager.Logger,
 	newKey *encryption.Key,
-	oldKey *encryption.Key,
+	oldKeys []*encryption.Key,
 	maxConn int,
 	connectionName string,
 	lockFactory lock.LockFactory,
 ) (db.Conn, error) {
-	dbConn, err := db.Open(logger.Session("db"), driverName, cmd.Postgres.ConnectionString(), newKey, oldKey, connectionName, lockFactory)
+	dbConn, err := db.Open(logger.Session("db"), driverName, cmd.Postgres.ConnectionString(), newKey, oldKeys, connectionName, lockFactory)
 	if err != nil {
 		return nil, fmt.Errorf("failed to migrate database: %s", err)
 	}
@@ -947,19 +1089,41 @@ func (cmd *ATCCommand) constructLockConn(driverName string) (*sql.DB, error) {
 func (cmd *ATCCommand) constructWorkerPool(
 	logger lager.Logger,
 	workerProvider worker.WorkerProvider,
+	teamFactory db.TeamFactory,
 ) worker.Client {
 
+	var strategies []worker.CandidatePlacementStrategy
+	for _, name := range cmd.ContainerPlacementStrategy {
+		switch name {
+		case "random":
+			strategies = append(strategies, worker.NewRandomPlacementStrategy())
+		case "fewest-build-containers":
+			strategies = append(strategies, worker.NewFewestBuildContainersPlacementStrategy())
+		case "fewest-volumes":
+			strategies = append(strategies, worker.NewFewestVolumesPlacementStrategy())
+		case "weighted":
+			strategies = append(strategies, worker.NewWeightedPlacementStrategy(
+				cmd.ContainerPlacementLocalityWeight,
+				cmd.ContainerPlacementLoadWeight,
+			))
+		default:
+			strategies = append(strategies, worker.NewVolumeLocalityPlacementStrategy())
+		}
+	}
+
 	var strategy worker.ContainerPlacementStrategy
-	switch cmd.ContainerPlacementStrategy {
-	case "random":
-		strategy = worker.NewRandomPlacementStrategy()
-	default:
-		strategy = worker.NewVolumeLocalityPlacementStrategy()
+	if len(strategies) == 1 {
+		strategy = strategies[0]
+	} else {
+		strategy = worker.NewChainedPlacementStrategy(strategies...)
 	}
 
 	return worker.NewPool(
 		workerProvider,
 		strategy,
+		clock.NewClock(),
+		cmd.MaxContainersPerWorker,
+		teamFactory,
 	)
 }
 
@@ -1052,6 +1216,7 @@ func (cmd *ATCCommand) constructEngine(
 	resourceFactory resource.ResourceFactory,
 	dbResourceCacheFactory db.ResourceCacheFactory,
 	variablesFactory creds.VariablesFactory,
+	teamFactory db.TeamFactory,
 ) engine.Engine {
 	gardenFactory := exec.NewGardenFactory(
 		workerClient,
@@ -1059,6 +1224,7 @@ func (cmd *ATCCommand) constructEngine(
 		resourceFactory,
 		dbResourceCacheFactory,
 		variablesFactory,
+		teamFactory,
 	)
 
 	execV2Engine := engine.NewExecEngine(
@@ -1110,6 +1276,8 @@ func (cmd *ATCCommand) constructAPIHandler(
 	dbVolumeFactory db.VolumeFactory,
 	dbContainerRepository db.ContainerRepository,
 	dbBuildFactory db.BuildFactory,
+	dbAuditLog db.AuditLog,
+	dbKeyRotator db.KeyRotator,
 	signingKey *rsa.PrivateKey,
 	engine engine.Engine,
 	workerClient worker.Client,
@@ -1118,6 +1286,7 @@ func (cmd *ATCCommand) constructAPIHandler(
 	radarSchedulerFactory pipelines.RadarSchedulerFactory,
 	radarScannerFactory radar.ScannerFactory,
 	variablesFactory creds.VariablesFactory,
+	buildLogArchive logarchive.Archive,
 ) (http.Handler, error) {
 
 	checkPipelineAccessHandlerFactory := auth.NewCheckPipelineAccessHandlerFactory(teamFactory)
@@ -1134,6 +1303,12 @@ func (cmd *ATCCommand) constructAPIHandler(
 			checkBuildReadAccessHandlerFactory,
 			checkBuildWriteAccessHandlerFactory,
 			checkWorkerTeamAccessHandlerFactory,
+			teamFactory,
+		),
+		wrappa.NewAPIAuditWrappa(
+			logger.Session("audit"),
+			dbAuditLog,
+			auth.JWTReader{PublicKey: &signingKey.PublicKey},
 		),
 		wrappa.NewConcourseVersionWrappa(Version),
 	}
@@ -1151,9 +1326,13 @@ func (cmd *ATCCommand) constructAPIHandler(
 		dbVolumeFactory,
 		dbContainerRepository,
 		dbBuildFactory,
+		dbAuditLog,
+		dbKeyRotator,
 
 		cmd.PeerURL.String(),
-		buildserver.NewEventHandler,
+		func(logger lager.Logger, build db.Build) http.Handler {
+			return buildserver.NewEventHandler(logger, build, buildLogArchive)
+		},
 		drain,
 
 		engine,
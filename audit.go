@@ -0,0 +1,11 @@
+package atc
+
+type AuditEvent struct {
+	ID       int               `json:"id"`
+	Time     int64             `json:"time"`
+	User     string            `json:"user,omitempty"`
+	TeamName string            `json:"team_name,omitempty"`
+	Route    string            `json:"route"`
+	Params   map[string]string `json:"params,omitempty"`
+	Status   int               `json:"status"`
+}
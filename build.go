@@ -1,5 +1,12 @@
 package atc
 
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
 type BuildStatus string
 
 const (
@@ -22,6 +29,19 @@ type Build struct {
 	StartTime    int64  `json:"start_time,omitempty"`
 	EndTime      int64  `json:"end_time,omitempty"`
 	ReapTime     int64  `json:"reap_time,omitempty"`
+	Archived     bool   `json:"archived,omitempty"`
+
+	Metadata map[string]string `json:"metadata,omitempty"`
+
+	RerunOf *RerunOfBuild `json:"rerun_of,omitempty"`
+
+	InputOverrides []BuildInputOverride `json:"input_overrides,omitempty"`
+}
+
+// RerunOfBuild identifies the build of the same job which a build re-runs.
+type RerunOfBuild struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
 }
 
 func (b Build) IsRunning() bool {
@@ -41,6 +61,21 @@ func (b Build) OneOff() bool {
 	return b.JobName == ""
 }
 
+// BuildInputOverride is a version chosen for an input of a manually triggered
+// build, in place of the version the scheduler would choose. The version must
+// still satisfy the input's passed constraints, unless IgnorePassed is set.
+type BuildInputOverride struct {
+	Name         string  `json:"name"`
+	Version      Version `json:"version"`
+	IgnorePassed bool    `json:"ignore_passed,omitempty"`
+}
+
+// CreateJobBuildRequest is the optional body of a request to manually trigger
+// a job. The inputs without an override use the latest versions as usual.
+type CreateJobBuildRequest struct {
+	Inputs []BuildInputOverride `json:"inputs,omitempty"`
+}
+
 type BuildPreparationStatus string
 
 const (
@@ -60,3 +95,45 @@ type BuildPreparation struct {
 	InputsSatisfied     BuildPreparationStatus            `json:"inputs_satisfied"`
 	MissingInputReasons MissingInputReasons               `json:"missing_input_reasons"`
 }
+
+// BuildQueryMetadata filters a list of builds down to the builds with the
+// given metadata. It is given once per key, as key:value.
+const BuildQueryMetadata = "metadata"
+
+// ParseBuildMetadataFilter parses the values of the BuildQueryMetadata query
+// parameter into the metadata the builds must have.
+func ParseBuildMetadataFilter(values []string) (map[string]string, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+
+	metadata := map[string]string{}
+	for _, value := range values {
+		segs := strings.SplitN(value, ":", 2)
+		if len(segs) != 2 || segs[0] == "" {
+			return nil, fmt.Errorf("malformed %s: %q is not key:value", BuildQueryMetadata, value)
+		}
+
+		metadata[segs[0]] = segs[1]
+	}
+
+	return metadata, nil
+}
+
+// BuildMetadataFilterQuery encodes the metadata as BuildQueryMetadata query
+// parameters, for linking to another page of the same list of builds.
+func BuildMetadataFilterQuery(metadata map[string]string) string {
+	keys := make([]string, 0, len(metadata))
+	for key := range metadata {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	query := ""
+	for _, key := range keys {
+		query += "&" + BuildQueryMetadata + "=" + url.QueryEscape(key+":"+metadata[key])
+	}
+
+	return query
+}
@@ -0,0 +1,37 @@
+package atc
+
+import "time"
+
+const (
+	// BuildLogSearchMaxWindow is the longest stretch of time whose builds a
+	// search may cover, as every log in it may have to be read.
+	BuildLogSearchMaxWindow = 7 * 24 * time.Hour
+
+	// BuildLogSearchMaxLimit is the most builds a page of results may have.
+	BuildLogSearchMaxLimit = 25
+)
+
+const (
+	BuildLogSearchQueryTeam     = "team"
+	BuildLogSearchQueryPipeline = "pipeline"
+	BuildLogSearchQueryJob      = "job"
+	BuildLogSearchQueryStatus   = "status"
+	BuildLogSearchQueryAfter    = "after"
+	BuildLogSearchQueryBefore   = "before"
+	BuildLogSearchQueryText     = "query"
+	BuildLogSearchQueryRegex    = "regex"
+)
+
+// BuildLogMatch is a build whose log output matched a search, along with the
+// lines that matched.
+type BuildLogMatch struct {
+	Build Build          `json:"build"`
+	Lines []BuildLogLine `json:"lines"`
+}
+
+// BuildLogLine is a line of a build's log output. Offset is the zero-based
+// number of the line within the build's output.
+type BuildLogLine struct {
+	Offset int    `json:"offset"`
+	Text   string `json:"text"`
+}
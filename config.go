@@ -5,6 +5,9 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+
+	"github.com/mitchellh/mapstructure"
+	yaml "gopkg.in/yaml.v2"
 )
 
 const ConfigVersionHeader = "X-Concourse-Config-Version"
@@ -24,6 +27,70 @@ type Config struct {
 	Resources     ResourceConfigs `yaml:"resources" json:"resources" mapstructure:"resources"`
 	ResourceTypes ResourceTypes   `yaml:"resource_types" json:"resource_types" mapstructure:"resource_types"`
 	Jobs          JobConfigs      `yaml:"jobs" json:"jobs" mapstructure:"jobs"`
+
+	Notifications NotificationConfigs `yaml:"notifications,omitempty" json:"notifications,omitempty" mapstructure:"notifications"`
+}
+
+// ExtraKeysError is returned when a pipeline config has keys which are not
+// part of a pipeline config, e.g. because they are misspelled.
+type ExtraKeysError struct {
+	Keys []string
+}
+
+func (err ExtraKeysError) Error() string {
+	return fmt.Sprintf("extra keys in the pipeline configuration: %s", strings.Join(err.Keys, ", "))
+}
+
+// NewConfig loads a pipeline config from its YAML (or JSON) representation. The
+// config is not validated.
+func NewConfig(configBytes []byte) (Config, error) {
+	var untypedInput interface{}
+
+	if err := yaml.Unmarshal(configBytes, &untypedInput); err != nil {
+		return Config{}, err
+	}
+
+	return DecodeConfig(untypedInput)
+}
+
+// DecodeConfig decodes a pipeline config which has been unmarshaled without a
+// type. Keys nested in the config which are not part of it are rejected with
+// an ExtraKeysError; the config is not otherwise validated.
+func DecodeConfig(untypedInput interface{}) (Config, error) {
+	var config Config
+	var metadata mapstructure.Metadata
+
+	msConfig := &mapstructure.DecoderConfig{
+		Metadata:         &metadata,
+		Result:           &config,
+		WeaklyTypedInput: true,
+		DecodeHook: mapstructure.ComposeDecodeHookFunc(
+			SanitizeDecodeHook,
+			VersionConfigDecodeHook,
+		),
+	}
+
+	decoder, err := mapstructure.NewDecoder(msConfig)
+	if err != nil {
+		return Config{}, err
+	}
+
+	if err := decoder.Decode(untypedInput); err != nil {
+		return Config{}, err
+	}
+
+	nestedUnused := []string{}
+	for _, unused := range metadata.Unused {
+		if strings.Contains(unused, ".") {
+			nestedUnused = append(nestedUnused, unused)
+		}
+	}
+
+	if len(nestedUnused) > 0 {
+		return Config{}, ExtraKeysError{Keys: nestedUnused}
+	}
+
+	return config, nil
 }
 
 type RawConfig string
@@ -237,11 +304,26 @@ type PlanConfig struct {
 	Task string `yaml:"task,omitempty" json:"task,omitempty" mapstructure:"task"`
 	// run task privileged
 	Privileged bool `yaml:"privileged,omitempty" json:"privileged,omitempty" mapstructure:"privileged"`
-	// task config path, e.g. foo/build.yml
+	// task config path, e.g. foo/build.yml; also the pipeline config path for
+	// set_pipeline and the file to read for load_var
 	TaskConfigPath string `yaml:"file,omitempty" json:"file,omitempty" mapstructure:"file"`
 	// inlined task config
 	TaskConfig *TaskConfig `yaml:"config,omitempty" json:"config,omitempty" mapstructure:"config"`
 
+	// corresponds to a SetPipeline plan
+	// name of the pipeline to configure, e.g. some-child-pipeline
+	SetPipeline string `yaml:"set_pipeline,omitempty" json:"set_pipeline,omitempty" mapstructure:"set_pipeline"`
+	// vars identifying the instance of the pipeline to configure, e.g. {branch: release-1.0}
+	InstanceVars InstanceVars `yaml:"instance_vars,omitempty" json:"instance_vars,omitempty" mapstructure:"instance_vars"`
+
+	// corresponds to a LoadVar plan
+	// name of the build-local var to load the file into, e.g. version
+	LoadVar string `yaml:"load_var,omitempty" json:"load_var,omitempty" mapstructure:"load_var"`
+	// how to parse the file: raw, trim, json or yaml; defaults based on the file extension
+	Format string `yaml:"format,omitempty" json:"format,omitempty" mapstructure:"format"`
+	// show the var's value in build logs rather than redacting it
+	Reveal bool `yaml:"reveal,omitempty" json:"reveal,omitempty" mapstructure:"reveal"`
+
 	// used by Get and Put for specifying params to the resource
 	Params Params `yaml:"params,omitempty" json:"params,omitempty" mapstructure:"params"`
 
@@ -276,6 +358,10 @@ type PlanConfig struct {
 	// used on any step to interrupt the step after a given duration
 	Timeout string `yaml:"timeout,omitempty" json:"timeout,omitempty" mapstructure:"timeout"`
 
+	// used on any step to record the key/value pairs in a file, e.g.
+	// release/metadata.json, as the build's metadata once the step succeeds
+	BuildMetadata string `yaml:"build_metadata,omitempty" json:"build_metadata,omitempty" mapstructure:"build_metadata"`
+
 	// not present in yaml
 	DependentGet string `yaml:"-" json:"-"`
 
@@ -283,6 +369,44 @@ type PlanConfig struct {
 	Attempts int `yaml:"attempts,omitempty" json:"attempts,omitempty" mapstructure:"attempts"`
 
 	Version *VersionConfig `yaml:"version,omitempty" json:"version,omitempty" mapstructure:"version"`
+
+	// used on any step to run it once for every combination of the given vars
+	Across *AcrossConfig `yaml:"across,omitempty" json:"across,omitempty" mapstructure:"across"`
+}
+
+// An AcrossConfig fans a step out over every combination of the values of its
+// vars. Each var is interpolated into the step as ((name)).
+type AcrossConfig struct {
+	Vars        []AcrossVarConfig `yaml:"vars" json:"vars" mapstructure:"vars"`
+	MaxInFlight int               `yaml:"max_in_flight,omitempty" json:"max_in_flight,omitempty" mapstructure:"max_in_flight"`
+	FailFast    bool              `yaml:"fail_fast,omitempty" json:"fail_fast,omitempty" mapstructure:"fail_fast"`
+}
+
+type AcrossVarConfig struct {
+	Name   string   `yaml:"name" json:"name" mapstructure:"name"`
+	Values []string `yaml:"values" json:"values" mapstructure:"values"`
+}
+
+// Combinations returns every combination of the vars' values, ordered such
+// that the last var varies fastest. Each combination has one value per var.
+func (config AcrossConfig) Combinations() [][]string {
+	combinations := [][]string{{}}
+
+	for _, v := range config.Vars {
+		next := [][]string{}
+
+		for _, combination := range combinations {
+			for _, value := range v.Values {
+				values := make([]string, len(combination), len(combination)+1)
+				copy(values, combination)
+				next = append(next, append(values, value))
+			}
+		}
+
+		combinations = next
+	}
+
+	return combinations
 }
 
 func (config PlanConfig) Name() string {
@@ -302,6 +426,14 @@ func (config PlanConfig) Name() string {
 		return config.Task
 	}
 
+	if config.SetPipeline != "" {
+		return config.SetPipeline
+	}
+
+	if config.LoadVar != "" {
+		return config.LoadVar
+	}
+
 	return ""
 }
 
@@ -0,0 +1,114 @@
+package atc
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+const (
+	ConfigChangeAdded   = "added"
+	ConfigChangeChanged = "changed"
+	ConfigChangeRemoved = "removed"
+)
+
+// A ConfigChange describes a group, resource, resource type, job or
+// notification that was added, changed or removed between two versions of a
+// pipeline config.
+type ConfigChange struct {
+	Kind   string `json:"kind"`
+	Name   string `json:"name"`
+	Action string `json:"action"`
+}
+
+type namedConfig struct {
+	name   string
+	config interface{}
+}
+
+// Diff returns the changes required to go from this config to the new config.
+func (c Config) Diff(newConfig Config) []ConfigChange {
+	changes := []ConfigChange{}
+
+	var oldGroups, newGroups []namedConfig
+	for _, group := range c.Groups {
+		oldGroups = append(oldGroups, namedConfig{group.Name, group})
+	}
+	for _, group := range newConfig.Groups {
+		newGroups = append(newGroups, namedConfig{group.Name, group})
+	}
+	changes = append(changes, diffNamedConfigs("group", oldGroups, newGroups)...)
+
+	var oldResources, newResources []namedConfig
+	for _, resource := range c.Resources {
+		oldResources = append(oldResources, namedConfig{resource.Name, resource})
+	}
+	for _, resource := range newConfig.Resources {
+		newResources = append(newResources, namedConfig{resource.Name, resource})
+	}
+	changes = append(changes, diffNamedConfigs("resource", oldResources, newResources)...)
+
+	var oldResourceTypes, newResourceTypes []namedConfig
+	for _, resourceType := range c.ResourceTypes {
+		oldResourceTypes = append(oldResourceTypes, namedConfig{resourceType.Name, resourceType})
+	}
+	for _, resourceType := range newConfig.ResourceTypes {
+		newResourceTypes = append(newResourceTypes, namedConfig{resourceType.Name, resourceType})
+	}
+	changes = append(changes, diffNamedConfigs("resource type", oldResourceTypes, newResourceTypes)...)
+
+	var oldJobs, newJobs []namedConfig
+	for _, job := range c.Jobs {
+		oldJobs = append(oldJobs, namedConfig{job.Name, job})
+	}
+	for _, job := range newConfig.Jobs {
+		newJobs = append(newJobs, namedConfig{job.Name, job})
+	}
+	changes = append(changes, diffNamedConfigs("job", oldJobs, newJobs)...)
+
+	var oldNotifications, newNotifications []namedConfig
+	for _, notification := range c.Notifications {
+		oldNotifications = append(oldNotifications, namedConfig{notification.Name, notification})
+	}
+	for _, notification := range newConfig.Notifications {
+		newNotifications = append(newNotifications, namedConfig{notification.Name, notification})
+	}
+	changes = append(changes, diffNamedConfigs("notification", oldNotifications, newNotifications)...)
+
+	return changes
+}
+
+func diffNamedConfigs(kind string, oldConfigs []namedConfig, newConfigs []namedConfig) []ConfigChange {
+	changes := []ConfigChange{}
+
+	oldByName := map[string]interface{}{}
+	for _, previous := range oldConfigs {
+		oldByName[previous.name] = previous.config
+	}
+
+	newNames := map[string]bool{}
+	for _, current := range newConfigs {
+		newNames[current.name] = true
+
+		previous, found := oldByName[current.name]
+		if !found {
+			changes = append(changes, ConfigChange{Kind: kind, Name: current.name, Action: ConfigChangeAdded})
+			continue
+		}
+
+		// compare the serialized form, as that is what gets saved
+		oldPayload, _ := json.Marshal(previous)
+		newPayload, _ := json.Marshal(current.config)
+
+		if !bytes.Equal(oldPayload, newPayload) {
+			changes = append(changes, ConfigChange{Kind: kind, Name: current.name, Action: ConfigChangeChanged})
+		}
+	}
+
+	for _, previous := range oldConfigs {
+		if !newNames[previous.name] {
+			changes = append(changes, ConfigChange{Kind: kind, Name: previous.name, Action: ConfigChangeRemoved})
+		}
+	}
+
+	return changes
+}
@@ -46,4 +46,101 @@ var _ = Describe("Config", func() {
 			})
 		})
 	})
+
+	Describe("NewConfig", func() {
+		It("loads the config from YAML", func() {
+			config, err := NewConfig([]byte(`
+resources:
+- name: some-resource
+  type: git
+  source: {uri: some-uri}
+jobs:
+- name: some-job
+  plan:
+  - get: some-resource
+    version: {ref: abc}
+`))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(config).To(Equal(Config{
+				Resources: ResourceConfigs{
+					{Name: "some-resource", Type: "git", Source: Source{"uri": "some-uri"}},
+				},
+				Jobs: JobConfigs{
+					{
+						Name: "some-job",
+						Plan: PlanSequence{
+							{Get: "some-resource", Version: &VersionConfig{Pinned: Version{"ref": "abc"}}},
+						},
+					},
+				},
+			}))
+		})
+
+		Context("when the config has extra keys", func() {
+			It("returns an error", func() {
+				_, err := NewConfig([]byte(`
+jobs:
+- name: some-job
+  bogus: key
+`))
+				Expect(err).To(MatchError(ContainSubstring("extra keys in the pipeline configuration: jobs[0].bogus")))
+			})
+		})
+	})
+
+	Describe("Diff", func() {
+		It("reports what was added, changed and removed", func() {
+			oldConfig := Config{
+				Resources: ResourceConfigs{
+					{Name: "some-resource", Type: "git"},
+					{Name: "some-removed-resource", Type: "git"},
+				},
+				Jobs: JobConfigs{
+					{Name: "some-job", Public: false},
+				},
+			}
+
+			newConfig := Config{
+				Resources: ResourceConfigs{
+					{Name: "some-resource", Type: "git"},
+				},
+				Jobs: JobConfigs{
+					{Name: "some-job", Public: true},
+					{Name: "some-new-job"},
+				},
+			}
+
+			Expect(oldConfig.Diff(newConfig)).To(Equal([]ConfigChange{
+				{Kind: "resource", Name: "some-removed-resource", Action: ConfigChangeRemoved},
+				{Kind: "job", Name: "some-job", Action: ConfigChangeChanged},
+				{Kind: "job", Name: "some-new-job", Action: ConfigChangeAdded},
+			}))
+		})
+
+		It("reports changes to notifications", func() {
+			oldConfig := Config{
+				Notifications: NotificationConfigs{
+					{Name: "some-notification", URL: "https://example.com/old"},
+				},
+			}
+
+			newConfig := Config{
+				Notifications: NotificationConfigs{
+					{Name: "some-notification", URL: "https://example.com/new"},
+				},
+			}
+
+			Expect(oldConfig.Diff(newConfig)).To(Equal([]ConfigChange{
+				{Kind: "notification", Name: "some-notification", Action: ConfigChangeChanged},
+			}))
+		})
+
+		It("reports nothing for identical configs", func() {
+			config := Config{
+				Jobs: JobConfigs{{Name: "some-job"}},
+			}
+
+			Expect(config.Diff(config)).To(BeEmpty())
+		})
+	})
 })
@@ -0,0 +1,111 @@
+package vault
+
+import (
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/lager"
+
+	"github.com/concourse/atc/metric"
+	vaultapi "github.com/hashicorp/vault/api"
+)
+
+// A SecretReader reads a secret from Vault. A nil secret means that nothing
+// was found at the path.
+type SecretReader interface {
+	Read(path string) (*vaultapi.Secret, error)
+}
+
+type CacheConfig struct {
+	Enabled  bool          `long:"cache"     description:"Cache returned secrets in memory for their lease duration."`
+	MaxLease time.Duration `long:"max-lease" description:"If the cache is enabled and this is set, cache secrets for at most this long, regardless of their lease duration."`
+
+	NotFoundLease time.Duration `long:"not-found-lease" default:"10s" description:"If the cache is enabled, how long to remember that nothing was found at a path, as every pipeline-level lookup of a team-level secret misses."`
+}
+
+type cachedSecret struct {
+	secret    *vaultapi.Secret
+	expiresAt time.Time
+}
+
+// Cache is a SecretReader which keeps the secrets read through it until their
+// lease runs out, so that builds looking up the same credentials do not each
+// go to Vault.
+//
+// Secrets without a lease are not cached, as there is no telling how long they
+// are good for. Paths where nothing was found are cached for the not found
+// lease, so that a secret added there is picked up soon after.
+type Cache struct {
+	logger        lager.Logger
+	reader        SecretReader
+	maxLease      time.Duration
+	notFoundLease time.Duration
+	clock         clock.Clock
+
+	secretsL sync.RWMutex
+	secrets  map[string]cachedSecret
+}
+
+func NewCache(logger lager.Logger, reader SecretReader, config CacheConfig, clock clock.Clock) *Cache {
+	return &Cache{
+		logger:        logger,
+		reader:        reader,
+		maxLease:      config.MaxLease,
+		notFoundLease: config.NotFoundLease,
+		clock:         clock,
+
+		secrets: map[string]cachedSecret{},
+	}
+}
+
+func (cache *Cache) Read(path string) (*vaultapi.Secret, error) {
+	cache.secretsL.RLock()
+	cached, found := cache.secrets[path]
+	cache.secretsL.RUnlock()
+
+	if found && cache.clock.Now().Before(cached.expiresAt) {
+		metric.VaultSecretCacheHit{}.Emit(cache.logger)
+		return cached.secret, nil
+	}
+
+	metric.VaultSecretCacheMiss{}.Emit(cache.logger)
+
+	secret, err := cache.reader.Read(path)
+	if err != nil {
+		return nil, err
+	}
+
+	cache.secretsL.Lock()
+	defer cache.secretsL.Unlock()
+
+	lease := cache.lease(secret)
+	if lease == 0 {
+		delete(cache.secrets, path)
+		return secret, nil
+	}
+
+	cache.secrets[path] = cachedSecret{
+		secret:    secret,
+		expiresAt: cache.clock.Now().Add(lease),
+	}
+
+	return secret, nil
+}
+
+func (cache *Cache) lease(secret *vaultapi.Secret) time.Duration {
+	if secret == nil {
+		return cache.notFoundLease
+	}
+
+	if secret.LeaseDuration <= 0 {
+		return 0
+	}
+
+	lease := time.Duration(secret.LeaseDuration) * time.Second
+	if cache.maxLease > 0 && lease > cache.maxLease {
+		lease = cache.maxLease
+	}
+
+	return lease
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/url"
 
+	"code.cloudfoundry.org/clock"
 	"code.cloudfoundry.org/lager"
 
 	"github.com/cloudfoundry/bosh-cli/director/template"
@@ -27,6 +28,8 @@ type VaultManager struct {
 	}
 
 	Auth AuthConfig
+
+	Cache CacheConfig
 }
 
 type AuthConfig struct {
@@ -83,5 +86,5 @@ func (manager VaultManager) NewVariablesFactory(logger lager.Logger) (creds.Vari
 		return nil, err
 	}
 
-	return NewVaultFactory(logger, client, manager.Auth, manager.PathPrefix), nil
+	return NewVaultFactory(logger, client, manager.Auth, manager.Cache, manager.PathPrefix, clock.NewClock()), nil
 }
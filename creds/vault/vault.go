@@ -8,7 +8,7 @@ import (
 )
 
 type Vault struct {
-	VaultClient *vaultapi.Logical
+	VaultClient SecretReader
 
 	PathPrefix   string
 	TeamName     string
@@ -1,10 +1,12 @@
 package vault
 
 import (
+	"os"
 	"path"
 	"sync"
 	"time"
 
+	"code.cloudfoundry.org/clock"
 	"code.cloudfoundry.org/lager"
 
 	"github.com/concourse/atc/creds"
@@ -12,7 +14,13 @@ import (
 )
 
 type vaultFactory struct {
+	logger lager.Logger
+
 	vaultClient *vaultapi.Client
+	auth        AuthConfig
+	clock       clock.Clock
+
+	reader SecretReader
 
 	prefix string
 
@@ -22,9 +30,16 @@ type vaultFactory struct {
 	loggedIn chan struct{}
 }
 
-func NewVaultFactory(logger lager.Logger, client *vaultapi.Client, auth AuthConfig, prefix string) *vaultFactory {
+// NewVaultFactory returns a factory for variables read from Vault. The
+// factory must be run to log in and keep the token renewed; until it has
+// logged in, making variables blocks.
+func NewVaultFactory(logger lager.Logger, client *vaultapi.Client, auth AuthConfig, cache CacheConfig, prefix string, clock clock.Clock) *vaultFactory {
 	factory := &vaultFactory{
+		logger: logger,
+
 		vaultClient: client,
+		auth:        auth,
+		clock:       clock,
 
 		prefix: prefix,
 
@@ -32,16 +47,28 @@ func NewVaultFactory(logger lager.Logger, client *vaultapi.Client, auth AuthConf
 		loggedIn: make(chan struct{}),
 	}
 
-	go factory.authLoop(logger, auth)
+	factory.reader = factory
+	if cache.Enabled {
+		factory.reader = NewCache(logger.Session("cache"), factory, cache, clock)
+	}
 
 	return factory
 }
 
+// Run logs in and keeps the token renewed until signalled.
+func (factory *vaultFactory) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	close(ready)
+
+	factory.authLoop(factory.logger, factory.auth, signals)
+
+	return nil
+}
+
 func (factory *vaultFactory) NewVariables(teamName string, pipelineName string) creds.Variables {
 	<-factory.loggedIn
 
 	return &Vault{
-		VaultClient: factory.clientWith(factory.currentToken()).Logical(),
+		VaultClient: factory.reader,
 
 		PathPrefix:   factory.prefix,
 		TeamName:     teamName,
@@ -49,6 +76,12 @@ func (factory *vaultFactory) NewVariables(teamName string, pipelineName string)
 	}
 }
 
+// Read reads the secret with the current token, so that variables keep working
+// when the token is replaced by logging in again.
+func (factory *vaultFactory) Read(path string) (*vaultapi.Secret, error) {
+	return factory.clientWith(factory.currentToken()).Logical().Read(path)
+}
+
 func (factory *vaultFactory) currentToken() string {
 	factory.tokenL.RLock()
 	token := factory.token
@@ -56,34 +89,83 @@ func (factory *vaultFactory) currentToken() string {
 	return token
 }
 
-func (factory *vaultFactory) authLoop(logger lager.Logger, config AuthConfig) {
+func (factory *vaultFactory) setToken(token string) {
+	factory.tokenL.Lock()
+	if factory.token == "" {
+		close(factory.loggedIn)
+	}
+	factory.token = token
+	factory.tokenL.Unlock()
+}
+
+// authLoop logs in and then renews the token in the background, halfway
+// through each lease. When logged in through an auth backend, it logs in again
+// once the token can no longer be renewed, either because renewing it failed
+// or because it has reached its max TTL. The previous token is used until
+// then. It returns once signalled.
+func (factory *vaultFactory) authLoop(logger lager.Logger, config AuthConfig, signals <-chan os.Signal) {
+	var token string
+	var lease time.Duration
+
 	for {
-		currentToken := factory.currentToken()
+		if token == "" {
+			newToken, newLease, err := factory.login(logger.Session("login"), config)
+			if err != nil {
+				if !factory.wait(time.Second, signals) {
+					return
+				}
+
+				continue
+			}
 
-		var token string
-		var delay time.Duration
-		if currentToken == "" {
-			token, delay = factory.login(logger.Session("login"), config)
+			token, lease = newToken, newLease
+			factory.setToken(token)
 		} else {
-			token, delay = factory.renew(logger.Session("renew"), currentToken)
-		}
+			renewedLease, err := factory.renew(logger.Session("renew"), token)
+			if err != nil {
+				if config.ClientToken == "" {
+					token = ""
+				}
+
+				if !factory.wait(time.Second, signals) {
+					return
+				}
+
+				continue
+			}
 
-		if token != "" {
-			factory.tokenL.Lock()
-			factory.token = token
-			if currentToken == "" {
-				close(factory.loggedIn)
+			// a renewal which does not extend the lease as far as the last one
+			// means the token is about to reach its max TTL
+			if renewedLease < lease && config.ClientToken == "" {
+				token = ""
 			}
-			factory.tokenL.Unlock()
+
+			lease = renewedLease
+		}
+
+		if !factory.wait(lease/2, signals) {
+			return
 		}
+	}
+}
 
-		time.Sleep(delay)
+// wait returns true once the duration has passed, or false if signalled
+// first.
+func (factory *vaultFactory) wait(duration time.Duration, signals <-chan os.Signal) bool {
+	timer := factory.clock.NewTimer(duration)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C():
+		return true
+	case <-signals:
+		return false
 	}
 }
 
-func (factory *vaultFactory) login(logger lager.Logger, config AuthConfig) (string, time.Duration) {
+func (factory *vaultFactory) login(logger lager.Logger, config AuthConfig) (string, time.Duration, error) {
 	if config.ClientToken != "" {
-		return config.ClientToken, 0
+		return config.ClientToken, 0, nil
 	}
 
 	backend := config.Backend
@@ -96,7 +178,7 @@ func (factory *vaultFactory) login(logger lager.Logger, config AuthConfig) (stri
 	secret, err := factory.vaultClient.Logical().Write(path.Join("auth", backend, "login"), params)
 	if err != nil {
 		logger.Error("failed", err)
-		return "", time.Second
+		return "", 0, err
 	}
 
 	logger.Info("succeeded", lager.Data{
@@ -105,14 +187,14 @@ func (factory *vaultFactory) login(logger lager.Logger, config AuthConfig) (stri
 		"policies":       secret.Auth.Policies,
 	})
 
-	return secret.Auth.ClientToken, (time.Duration(secret.Auth.LeaseDuration) * time.Second) / 2
+	return secret.Auth.ClientToken, time.Duration(secret.Auth.LeaseDuration) * time.Second, nil
 }
 
-func (factory *vaultFactory) renew(logger lager.Logger, token string) (string, time.Duration) {
+func (factory *vaultFactory) renew(logger lager.Logger, token string) (time.Duration, error) {
 	secret, err := factory.clientWith(token).Auth().Token().RenewSelf(0)
 	if err != nil {
 		logger.Error("failed", err)
-		return "", time.Second
+		return 0, err
 	}
 
 	logger.Info("succeeded", lager.Data{
@@ -121,7 +203,7 @@ func (factory *vaultFactory) renew(logger lager.Logger, token string) (string, t
 		"policies":       secret.Auth.Policies,
 	})
 
-	return secret.Auth.ClientToken, (time.Duration(secret.Auth.LeaseDuration) * time.Second) / 2
+	return time.Duration(secret.Auth.LeaseDuration) * time.Second, nil
 }
 
 func (factory *vaultFactory) clientWith(token string) *vaultapi.Client {
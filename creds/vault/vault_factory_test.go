@@ -0,0 +1,349 @@
+package vault_test
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/cloudfoundry/bosh-cli/director/template"
+	"github.com/concourse/atc/creds"
+	"github.com/concourse/atc/creds/vault"
+	vaultapi "github.com/hashicorp/vault/api"
+	"github.com/tedsuo/ifrit"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+// fakeVault serves the parts of the Vault API used to log in, renew tokens and
+// read secrets, counting the requests made to it.
+type fakeVault struct {
+	server *ghttp.Server
+
+	lock sync.Mutex
+
+	loginLease    int
+	renewalLease  int
+	renewalStatus int
+	secretLease   int
+
+	logins      int
+	renewals    int
+	reads       int
+	misses      int
+	readTokens  []string
+	renewTokens []string
+}
+
+func newFakeVault() *fakeVault {
+	fake := &fakeVault{
+		server: ghttp.NewServer(),
+
+		loginLease:    3600,
+		renewalLease:  3600,
+		renewalStatus: http.StatusOK,
+		secretLease:   3600,
+	}
+
+	fake.server.RouteToHandler("PUT", "/v1/auth/approle/login", fake.login)
+	fake.server.RouteToHandler("PUT", "/v1/auth/token/renew-self", fake.renew)
+	fake.server.RouteToHandler("GET", "/v1/concourse/main/some-pipeline/some-var", fake.miss)
+	fake.server.RouteToHandler("GET", "/v1/concourse/main/some-var", fake.read)
+
+	return fake
+}
+
+func (fake *fakeVault) login(w http.ResponseWriter, r *http.Request) {
+	fake.lock.Lock()
+	defer fake.lock.Unlock()
+
+	fake.logins++
+
+	fmt.Fprintf(w, `{"auth":{"client_token":"token-%d","lease_duration":%d}}`, fake.logins, fake.loginLease)
+}
+
+func (fake *fakeVault) renew(w http.ResponseWriter, r *http.Request) {
+	fake.lock.Lock()
+	defer fake.lock.Unlock()
+
+	fake.renewals++
+	fake.renewTokens = append(fake.renewTokens, r.Header.Get("X-Vault-Token"))
+
+	if fake.renewalStatus != http.StatusOK {
+		w.WriteHeader(fake.renewalStatus)
+		fmt.Fprint(w, `{"errors":["permission denied"]}`)
+		return
+	}
+
+	fmt.Fprintf(w, `{"auth":{"client_token":"%s","lease_duration":%d}}`, r.Header.Get("X-Vault-Token"), fake.renewalLease)
+}
+
+func (fake *fakeVault) read(w http.ResponseWriter, r *http.Request) {
+	fake.lock.Lock()
+	defer fake.lock.Unlock()
+
+	fake.reads++
+	fake.readTokens = append(fake.readTokens, r.Header.Get("X-Vault-Token"))
+
+	fmt.Fprintf(w, `{"lease_duration":%d,"data":{"value":"some-secret"}}`, fake.secretLease)
+}
+
+func (fake *fakeVault) miss(w http.ResponseWriter, r *http.Request) {
+	fake.lock.Lock()
+	defer fake.lock.Unlock()
+
+	fake.misses++
+
+	w.WriteHeader(http.StatusNotFound)
+	fmt.Fprint(w, `{"errors":[]}`)
+}
+
+func (fake *fakeVault) Logins() int {
+	fake.lock.Lock()
+	defer fake.lock.Unlock()
+	return fake.logins
+}
+
+func (fake *fakeVault) Renewals() int {
+	fake.lock.Lock()
+	defer fake.lock.Unlock()
+	return fake.renewals
+}
+
+func (fake *fakeVault) Reads() int {
+	fake.lock.Lock()
+	defer fake.lock.Unlock()
+	return fake.reads
+}
+
+func (fake *fakeVault) Misses() int {
+	fake.lock.Lock()
+	defer fake.lock.Unlock()
+	return fake.misses
+}
+
+func (fake *fakeVault) ReadTokens() []string {
+	fake.lock.Lock()
+	defer fake.lock.Unlock()
+	return append([]string{}, fake.readTokens...)
+}
+
+func (fake *fakeVault) RenewTokens() []string {
+	fake.lock.Lock()
+	defer fake.lock.Unlock()
+	return append([]string{}, fake.renewTokens...)
+}
+
+var _ = Describe("VaultFactory", func() {
+	var (
+		fakeVaultServer *fakeVault
+
+		auth  vault.AuthConfig
+		cache vault.CacheConfig
+
+		fakeClock *fakeclock.FakeClock
+
+		factory creds.VariablesFactory
+		process ifrit.Process
+	)
+
+	BeforeEach(func() {
+		fakeVaultServer = newFakeVault()
+		fakeClock = fakeclock.NewFakeClock(time.Unix(123456789, 0))
+
+		auth = vault.AuthConfig{
+			Backend: "approle",
+			Params: []template.VarKV{
+				{Name: "role_id", Value: "some-role"},
+				{Name: "secret_id", Value: "some-secret-id"},
+			},
+		}
+
+		cache = vault.CacheConfig{}
+	})
+
+	AfterEach(func() {
+		process.Signal(os.Interrupt)
+		Eventually(process.Wait()).Should(Receive(BeNil()))
+
+		fakeVaultServer.server.Close()
+	})
+
+	JustBeforeEach(func() {
+		client, err := vaultapi.NewClient(vaultapi.DefaultConfig())
+		Expect(err).NotTo(HaveOccurred())
+
+		err = client.SetAddress(fakeVaultServer.server.URL())
+		Expect(err).NotTo(HaveOccurred())
+
+		vaultFactory := vault.NewVaultFactory(lagertest.NewTestLogger("test"), client, auth, cache, "/concourse", fakeClock)
+		factory = vaultFactory
+
+		process = ifrit.Invoke(vaultFactory)
+	})
+
+	// advance moves the clock on once the factory is waiting to renew the
+	// token or to try again
+	advance := func(duration time.Duration) {
+		Eventually(fakeClock.WatcherCount).Should(Equal(1))
+		fakeClock.Increment(duration)
+	}
+
+	get := func() interface{} {
+		value, found, err := factory.NewVariables("main", "some-pipeline").Get(template.VariableDefinition{Name: "some-var"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeTrue())
+		return value
+	}
+
+	It("reads secrets with the token it logged in with", func() {
+		Expect(get()).To(Equal("some-secret"))
+
+		Expect(fakeVaultServer.Logins()).To(Equal(1))
+		Expect(fakeVaultServer.ReadTokens()).To(Equal([]string{"token-1"}))
+	})
+
+	It("reads secrets from Vault on every lookup", func() {
+		Expect(get()).To(Equal("some-secret"))
+		Expect(get()).To(Equal("some-secret"))
+
+		Expect(fakeVaultServer.Reads()).To(Equal(2))
+	})
+
+	It("renews the token halfway through its lease", func() {
+		Eventually(fakeVaultServer.Logins).Should(Equal(1))
+
+		advance(30*time.Minute - time.Second)
+		Consistently(fakeVaultServer.Renewals).Should(BeZero())
+
+		fakeClock.Increment(time.Second)
+		Eventually(fakeVaultServer.Renewals).Should(Equal(1))
+
+		advance(30 * time.Minute)
+		Eventually(fakeVaultServer.Renewals).Should(Equal(2))
+
+		Expect(fakeVaultServer.RenewTokens()).To(Equal([]string{"token-1", "token-1"}))
+		Expect(fakeVaultServer.Logins()).To(Equal(1))
+	})
+
+	Context("when renewing the token fails", func() {
+		BeforeEach(func() {
+			fakeVaultServer.renewalStatus = http.StatusForbidden
+		})
+
+		It("logs in again and reads secrets with the new token", func() {
+			advance(30 * time.Minute)
+			Eventually(fakeVaultServer.Renewals).Should(Equal(1))
+
+			advance(time.Second)
+			Eventually(fakeVaultServer.Logins).Should(Equal(2))
+
+			Expect(get()).To(Equal("some-secret"))
+			Expect(fakeVaultServer.ReadTokens()).To(Equal([]string{"token-2"}))
+		})
+	})
+
+	Context("when renewing the token no longer extends its lease", func() {
+		BeforeEach(func() {
+			fakeVaultServer.loginLease = 2
+			fakeVaultServer.renewalLease = 1
+		})
+
+		It("logs in again before the token expires", func() {
+			advance(time.Second)
+			Eventually(fakeVaultServer.Renewals).Should(Equal(1))
+
+			advance(500 * time.Millisecond)
+			Eventually(fakeVaultServer.Logins).Should(Equal(2))
+		})
+	})
+
+	Context("when logged in with a client token which can not be renewed", func() {
+		BeforeEach(func() {
+			auth = vault.AuthConfig{ClientToken: "some-client-token"}
+			fakeVaultServer.renewalStatus = http.StatusForbidden
+		})
+
+		It("keeps trying to renew the token rather than logging in", func() {
+			// the client token has no lease, so it is renewed straight away
+			Eventually(func() int {
+				fakeClock.Increment(0)
+				return fakeVaultServer.Renewals()
+			}).Should(Equal(1))
+
+			advance(time.Second)
+			Eventually(fakeVaultServer.Renewals).Should(Equal(2))
+
+			Expect(fakeVaultServer.Logins()).To(BeZero())
+
+			Expect(get()).To(Equal("some-secret"))
+			Expect(fakeVaultServer.ReadTokens()).To(Equal([]string{"some-client-token"}))
+		})
+	})
+
+	Context("when the cache is enabled", func() {
+		BeforeEach(func() {
+			cache.Enabled = true
+			cache.NotFoundLease = 10 * time.Second
+		})
+
+		It("reads each secret from Vault once for its lease", func() {
+			Expect(get()).To(Equal("some-secret"))
+			Expect(get()).To(Equal("some-secret"))
+
+			Expect(fakeVaultServer.Reads()).To(Equal(1))
+
+			fakeClock.Increment(time.Hour)
+
+			Expect(get()).To(Equal("some-secret"))
+			Expect(fakeVaultServer.Reads()).To(Equal(2))
+		})
+
+		It("remembers that nothing was found at a path for the not found lease", func() {
+			Expect(get()).To(Equal("some-secret"))
+			Expect(get()).To(Equal("some-secret"))
+
+			Expect(fakeVaultServer.Misses()).To(Equal(1))
+
+			fakeClock.Increment(10 * time.Second)
+
+			Expect(get()).To(Equal("some-secret"))
+			Expect(fakeVaultServer.Misses()).To(Equal(2))
+		})
+
+		Context("when a max lease is configured", func() {
+			BeforeEach(func() {
+				cache.MaxLease = 100 * time.Millisecond
+			})
+
+			It("reads the secret again once the max lease is up", func() {
+				Expect(get()).To(Equal("some-secret"))
+				Expect(get()).To(Equal("some-secret"))
+				Expect(fakeVaultServer.Reads()).To(Equal(1))
+
+				fakeClock.Increment(100 * time.Millisecond)
+
+				Expect(get()).To(Equal("some-secret"))
+				Expect(fakeVaultServer.Reads()).To(Equal(2))
+			})
+		})
+
+		Context("when the secret has no lease", func() {
+			BeforeEach(func() {
+				fakeVaultServer.secretLease = 0
+			})
+
+			It("does not cache it", func() {
+				Expect(get()).To(Equal("some-secret"))
+				Expect(get()).To(Equal("some-secret"))
+
+				Expect(fakeVaultServer.Reads()).To(Equal(2))
+			})
+		})
+	})
+})
@@ -800,6 +800,94 @@ var _ = DescribeTable("Input resolving",
 		},
 	}),
 
+	Entry("resolves the version a resource is pinned to", Example{
+		DB: DB{
+			Resources: []DBRow{
+				{Resource: "resource-x", Version: "rxv1", CheckOrder: 1},
+				{Resource: "resource-x", Version: "rxv2", CheckOrder: 2},
+				{Resource: "resource-x", Version: "rxv3", CheckOrder: 3},
+			},
+			PinnedVersions: map[string]string{"resource-x": "rxv2"},
+		},
+
+		Inputs: Inputs{
+			{Name: "resource-x", Resource: "resource-x"},
+		},
+
+		Result: Result{
+			OK: true,
+			Values: map[string]string{
+				"resource-x": "rxv2",
+			},
+		},
+	}),
+
+	Entry("resolves the version a resource is pinned to over every version", Example{
+		DB: DB{
+			Resources: []DBRow{
+				{Resource: "resource-x", Version: "rxv1", CheckOrder: 1},
+				{Resource: "resource-x", Version: "rxv2", CheckOrder: 2},
+				{Resource: "resource-x", Version: "rxv3", CheckOrder: 3},
+			},
+			PinnedVersions: map[string]string{"resource-x": "rxv1"},
+		},
+
+		Inputs: Inputs{
+			{Name: "resource-x", Resource: "resource-x", Version: Version{Every: true}},
+		},
+
+		Result: Result{
+			OK: true,
+			Values: map[string]string{
+				"resource-x": "rxv1",
+			},
+		},
+	}),
+
+	Entry("resolves the version a resource is pinned to over a pinned version config", Example{
+		DB: DB{
+			Resources: []DBRow{
+				{Resource: "resource-x", Version: "rxv1", CheckOrder: 1},
+				{Resource: "resource-x", Version: "rxv2", CheckOrder: 2},
+				{Resource: "resource-x", Version: "rxv3", CheckOrder: 3},
+			},
+			PinnedVersions: map[string]string{"resource-x": "rxv3"},
+		},
+
+		Inputs: Inputs{
+			{Name: "resource-x", Resource: "resource-x", Version: Version{Pinned: "rxv1"}},
+		},
+
+		Result: Result{
+			OK: true,
+			Values: map[string]string{
+				"resource-x": "rxv3",
+			},
+		},
+	}),
+
+	Entry("resolves the version a resource is pinned to once it has passed the constraints", Example{
+		DB: DB{
+			BuildOutputs: []DBRow{
+				{Job: "simple-a", BuildID: 1, Resource: "resource-x", Version: "rxv1", CheckOrder: 1},
+				{Job: "simple-a", BuildID: 2, Resource: "resource-x", Version: "rxv2", CheckOrder: 2},
+				{Job: "simple-a", BuildID: 3, Resource: "resource-x", Version: "rxv3", CheckOrder: 3},
+			},
+			PinnedVersions: map[string]string{"resource-x": "rxv2"},
+		},
+
+		Inputs: Inputs{
+			{Name: "resource-x", Resource: "resource-x", Passed: []string{"simple-a"}},
+		},
+
+		Result: Result{
+			OK: true,
+			Values: map[string]string{
+				"resource-x": "rxv2",
+			},
+		},
+	}),
+
 	Entry("check orders take precedence over version ID", Example{
 		DB: DB{
 			Resources: []DBRow{
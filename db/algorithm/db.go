@@ -6,6 +6,9 @@ type VersionsDB struct {
 	BuildInputs      []BuildInput
 	JobIDs           map[string]int
 	ResourceIDs      map[string]int
+
+	// resource ID -> version ID the resource is pinned to via the API
+	PinnedVersionIDs map[int]int
 }
 
 type ResourceVersion struct {
@@ -38,6 +41,13 @@ func (db VersionsDB) IsVersionFirstOccurrence(versionID int, jobID int, inputNam
 	return true
 }
 
+// PinnedVersionOfResource returns the version the resource is pinned to, if
+// any.
+func (db VersionsDB) PinnedVersionOfResource(resourceID int) (int, bool) {
+	versionID, found := db.PinnedVersionIDs[resourceID]
+	return versionID, found
+}
+
 func (db VersionsDB) AllVersionsOfResource(resourceID int) VersionCandidates {
 	candidates := VersionCandidates{}
 	for _, output := range db.ResourceVersions {
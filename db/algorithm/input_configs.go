@@ -19,15 +19,24 @@ func (configs InputConfigs) Resolve(db *VersionsDB) (InputMapping, bool) {
 	for _, inputConfig := range configs {
 		versionCandidates := VersionCandidates{}
 
+		// a resource pinned via the API overrides the version config of every
+		// job using it
+		pinnedVersionID := inputConfig.PinnedVersionID
+		useEveryVersion := inputConfig.UseEveryVersion
+		if versionID, found := db.PinnedVersionOfResource(inputConfig.ResourceID); found {
+			pinnedVersionID = versionID
+			useEveryVersion = false
+		}
+
 		if len(inputConfig.Passed) == 0 {
-			if inputConfig.UseEveryVersion {
+			if useEveryVersion {
 				versionCandidates = db.AllVersionsOfResource(inputConfig.ResourceID)
 			} else {
 				var versionCandidate VersionCandidate
 				var found bool
 
-				if inputConfig.PinnedVersionID != 0 {
-					versionCandidate, found = db.FindVersionOfResource(inputConfig.ResourceID, inputConfig.PinnedVersionID)
+				if pinnedVersionID != 0 {
+					versionCandidate, found = db.FindVersionOfResource(inputConfig.ResourceID, pinnedVersionID)
 				} else {
 					versionCandidate, found = db.LatestVersionOfResource(inputConfig.ResourceID)
 				}
@@ -62,8 +71,8 @@ func (configs InputConfigs) Resolve(db *VersionsDB) (InputMapping, bool) {
 		inputCandidates = append(inputCandidates, InputVersionCandidates{
 			Input:                 inputConfig.Name,
 			Passed:                inputConfig.Passed,
-			UseEveryVersion:       inputConfig.UseEveryVersion,
-			PinnedVersionID:       inputConfig.PinnedVersionID,
+			UseEveryVersion:       useEveryVersion,
+			PinnedVersionID:       pinnedVersionID,
 			VersionCandidates:     versionCandidates,
 			ExistingBuildResolver: existingBuildResolver,
 		})
@@ -14,6 +14,9 @@ type DB struct {
 	BuildInputs  []DBRow
 	BuildOutputs []DBRow
 	Resources    []DBRow
+
+	// resource name -> version the resource is pinned to
+	PinnedVersions map[string]string
 }
 
 type DBRow struct {
@@ -138,6 +141,14 @@ func (example Example) Run() {
 				JobID:           jobIDs.ID(row.Job),
 			})
 		}
+
+		for resource, version := range example.DB.PinnedVersions {
+			if db.PinnedVersionIDs == nil {
+				db.PinnedVersionIDs = map[int]int{}
+			}
+
+			db.PinnedVersionIDs[resourceIDs.ID(resource)] = versionIDs.ID(version)
+		}
 	}
 
 	inputConfigs := make(algorithm.InputConfigs, len(example.Inputs))
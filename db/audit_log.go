@@ -0,0 +1,154 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+//go:generate counterfeiter . AuditLog
+
+type AuditLog interface {
+	Record(AuditEvent) error
+	Events(Page) ([]AuditEvent, Pagination, error)
+	DeleteEventsBefore(time.Time) error
+}
+
+type AuditEvent struct {
+	ID       int
+	Time     time.Time
+	User     string
+	TeamName string
+	Route    string
+	Params   map[string]string
+	Status   int
+}
+
+type auditLog struct {
+	conn Conn
+}
+
+func NewAuditLog(conn Conn) AuditLog {
+	return &auditLog{
+		conn: conn,
+	}
+}
+
+func (l *auditLog) Record(event AuditEvent) error {
+	params, err := json.Marshal(event.Params)
+	if err != nil {
+		return err
+	}
+
+	_, err = psql.Insert("audit_events").
+		Columns(`"user"`, "team_name", "route", "params", "status").
+		Values(event.User, event.TeamName, event.Route, params, event.Status).
+		RunWith(l.conn).
+		Exec()
+
+	return err
+}
+
+func (l *auditLog) Events(page Page) ([]AuditEvent, Pagination, error) {
+	query := psql.Select("id", `"time"`, `"user"`, "team_name", "route", "params", "status").
+		From("audit_events")
+
+	var reverse bool
+	if page.Since == 0 && page.Until == 0 {
+		query = query.OrderBy("id DESC").Limit(uint64(page.Limit))
+	} else if page.Until != 0 {
+		query = query.Where(sq.Gt{"id": page.Until}).OrderBy("id ASC").Limit(uint64(page.Limit))
+		reverse = true
+	} else {
+		query = query.Where(sq.Lt{"id": page.Since}).OrderBy("id DESC").Limit(uint64(page.Limit))
+	}
+
+	rows, err := query.RunWith(l.conn).Query()
+	if err != nil {
+		return nil, Pagination{}, err
+	}
+
+	defer Close(rows)
+
+	events := []AuditEvent{}
+
+	for rows.Next() {
+		var (
+			event          AuditEvent
+			user, teamName sql.NullString
+			params         []byte
+		)
+
+		err = rows.Scan(&event.ID, &event.Time, &user, &teamName, &event.Route, &params, &event.Status)
+		if err != nil {
+			return nil, Pagination{}, err
+		}
+
+		event.User = user.String
+		event.TeamName = teamName.String
+
+		if params != nil {
+			err = json.Unmarshal(params, &event.Params)
+			if err != nil {
+				return nil, Pagination{}, err
+			}
+		}
+
+		events = append(events, event)
+	}
+
+	if reverse {
+		for i, j := 0, len(events)-1; i < j; i, j = i+1, j-1 {
+			events[i], events[j] = events[j], events[i]
+		}
+	}
+
+	if len(events) == 0 {
+		return events, Pagination{}, nil
+	}
+
+	var minID int
+	var maxID int
+	err = psql.Select("COALESCE(MAX(id), 0)", "COALESCE(MIN(id), 0)").
+		From("audit_events").
+		RunWith(l.conn).
+		QueryRow().
+		Scan(&maxID, &minID)
+	if err != nil {
+		return nil, Pagination{}, err
+	}
+
+	first := events[0]
+	last := events[len(events)-1]
+
+	var pagination Pagination
+
+	if first.ID < maxID {
+		pagination.Previous = &Page{
+			Until: first.ID,
+			Limit: page.Limit,
+		}
+	}
+
+	if last.ID > minID {
+		pagination.Next = &Page{
+			Since: last.ID,
+			Limit: page.Limit,
+		}
+	}
+
+	return events, pagination, nil
+}
+
+// DeleteEventsBefore removes the events recorded before the given time, so
+// that the audit log only keeps events for as long as it is configured to.
+func (l *auditLog) DeleteEventsBefore(before time.Time) error {
+	_, err := psql.Delete("audit_events").
+		Where(sq.Lt{`"time"`: before}).
+		RunWith(l.conn).
+		Exec()
+
+	return err
+}
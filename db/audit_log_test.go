@@ -0,0 +1,110 @@
+package db_test
+
+import (
+	"time"
+
+	"github.com/concourse/atc/db"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AuditLog", func() {
+	var auditLog db.AuditLog
+
+	BeforeEach(func() {
+		auditLog = db.NewAuditLog(dbConn)
+	})
+
+	Describe("Record", func() {
+		It("saves the event", func() {
+			err := auditLog.Record(db.AuditEvent{
+				User:     "some-user",
+				TeamName: "some-team",
+				Route:    "PausePipeline",
+				Params:   map[string]string{"team_name": "some-team", "pipeline_name": "some-pipeline"},
+				Status:   200,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			events, _, err := auditLog.Events(db.Page{Limit: 10})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(events).To(HaveLen(1))
+
+			Expect(events[0].ID).NotTo(BeZero())
+			Expect(events[0].Time).To(BeTemporally("~", time.Now(), time.Minute))
+			Expect(events[0].User).To(Equal("some-user"))
+			Expect(events[0].TeamName).To(Equal("some-team"))
+			Expect(events[0].Route).To(Equal("PausePipeline"))
+			Expect(events[0].Params).To(Equal(map[string]string{"team_name": "some-team", "pipeline_name": "some-pipeline"}))
+			Expect(events[0].Status).To(Equal(200))
+		})
+	})
+
+	Describe("Events", func() {
+		var ids []int
+
+		BeforeEach(func() {
+			ids = nil
+
+			for _, route := range []string{"PauseJob", "UnpauseJob", "PauseJob"} {
+				err := auditLog.Record(db.AuditEvent{Route: route, Status: 200})
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			events, _, err := auditLog.Events(db.Page{Limit: 10})
+			Expect(err).NotTo(HaveOccurred())
+
+			for _, event := range events {
+				ids = append(ids, event.ID)
+			}
+		})
+
+		It("returns the most recent events first", func() {
+			Expect(ids).To(HaveLen(3))
+			Expect(ids[0]).To(BeNumerically(">", ids[1]))
+			Expect(ids[1]).To(BeNumerically(">", ids[2]))
+		})
+
+		It("paginates the events", func() {
+			events, pagination, err := auditLog.Events(db.Page{Limit: 2})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(events).To(HaveLen(2))
+			Expect(events[0].ID).To(Equal(ids[0]))
+			Expect(events[1].ID).To(Equal(ids[1]))
+			Expect(pagination.Previous).To(BeNil())
+			Expect(pagination.Next).To(Equal(&db.Page{Since: ids[1], Limit: 2}))
+
+			events, pagination, err = auditLog.Events(*pagination.Next)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(events).To(HaveLen(1))
+			Expect(events[0].ID).To(Equal(ids[2]))
+			Expect(pagination.Previous).To(Equal(&db.Page{Until: ids[2], Limit: 2}))
+			Expect(pagination.Next).To(BeNil())
+		})
+	})
+
+	Describe("DeleteEventsBefore", func() {
+		BeforeEach(func() {
+			err := auditLog.Record(db.AuditEvent{Route: "PauseJob", Status: 200})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("deletes events recorded before the given time", func() {
+			err := auditLog.DeleteEventsBefore(time.Now().Add(time.Minute))
+			Expect(err).NotTo(HaveOccurred())
+
+			events, _, err := auditLog.Events(db.Page{Limit: 10})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(events).To(BeEmpty())
+		})
+
+		It("keeps events recorded after the given time", func() {
+			err := auditLog.DeleteEventsBefore(time.Now().Add(-time.Hour))
+			Expect(err).NotTo(HaveOccurred())
+
+			events, _, err := auditLog.Events(db.Page{Limit: 10})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(events).To(HaveLen(1))
+		})
+	})
+})
@@ -28,11 +28,12 @@ const (
 	BuildStatusErrored   BuildStatus = "errored"
 )
 
-var buildsQuery = psql.Select("b.id, b.name, b.job_id, b.team_id, b.status, b.manually_triggered, b.scheduled, b.engine, b.engine_metadata, b.public_plan, b.start_time, b.end_time, b.reap_time, j.name, b.pipeline_id, p.name, t.name, b.nonce").
+var buildsQuery = psql.Select("b.id, b.name, b.job_id, b.team_id, b.status, b.manually_triggered, b.scheduled, b.engine, b.engine_metadata, b.public_plan, b.start_time, b.end_time, b.reap_time, j.name, b.pipeline_id, p.name, t.name, b.nonce, b.metadata, b.rerun_of, rb.name, b.input_overrides, b.archived").
 	From("builds b").
 	JoinClause("LEFT OUTER JOIN jobs j ON b.job_id = j.id").
 	JoinClause("LEFT OUTER JOIN pipelines p ON b.pipeline_id = p.id").
-	JoinClause("LEFT OUTER JOIN teams t ON b.team_id = t.id")
+	JoinClause("LEFT OUTER JOIN teams t ON b.team_id = t.id").
+	JoinClause("LEFT OUTER JOIN builds rb ON b.rerun_of = rb.id")
 
 //go:generate counterfeiter . Build
 
@@ -52,8 +53,13 @@ type Build interface {
 	StartTime() time.Time
 	EndTime() time.Time
 	ReapTime() time.Time
+	IsArchived() bool
 	IsManuallyTriggered() bool
 	IsScheduled() bool
+	Metadata() map[string]string
+	RerunOf() int
+	RerunOfName() string
+	InputOverrides() []atc.BuildInputOverride
 
 	IsRunning() bool
 
@@ -68,6 +74,7 @@ type Build interface {
 	Finish(BuildStatus) error
 
 	SetInterceptible(bool) error
+	SaveMetadata(map[string]string) error
 
 	Events(uint) (EventSource, error)
 	SaveEvent(event atc.Event) error
@@ -75,6 +82,7 @@ type Build interface {
 	SaveInput(input BuildInput) error
 	SaveOutput(vr VersionedResource, explicit bool) error
 	UseInputs(inputs []BuildInput) error
+	Inputs() ([]BuildInput, error)
 
 	Resources() ([]BuildInput, []BuildOutput, error)
 	GetVersionedResources() (SavedVersionedResources, error)
@@ -84,6 +92,7 @@ type Build interface {
 
 	Delete() (bool, error)
 	MarkAsAborted() error
+	MarkAsArchived() error
 	AbortNotifier() (Notifier, error)
 	Schedule() (bool, error)
 }
@@ -107,10 +116,17 @@ type build struct {
 	engine         string
 	engineMetadata string
 	publicPlan     *json.RawMessage
+	metadata       map[string]string
+
+	rerunOf     int
+	rerunOfName string
+
+	inputOverrides []atc.BuildInputOverride
 
 	startTime time.Time
 	endTime   time.Time
 	reapTime  time.Time
+	archived  bool
 
 	conn        Conn
 	lockFactory lock.LockFactory
@@ -135,6 +151,13 @@ func (b *build) EndTime() time.Time           { return b.endTime }
 func (b *build) ReapTime() time.Time          { return b.reapTime }
 func (b *build) Status() BuildStatus          { return b.status }
 func (b *build) IsScheduled() bool            { return b.scheduled }
+func (b *build) Metadata() map[string]string  { return b.metadata }
+func (b *build) RerunOf() int                 { return b.rerunOf }
+func (b *build) RerunOfName() string          { return b.rerunOfName }
+
+func (b *build) InputOverrides() []atc.BuildInputOverride { return b.inputOverrides }
+
+func (b *build) IsArchived() bool { return b.archived }
 
 func (b *build) IsRunning() bool {
 	switch b.status {
@@ -179,6 +202,32 @@ func (b *build) Interceptible() (bool, error) {
 	return interceptible, nil
 }
 
+// SaveMetadata merges the given keys into the build's metadata, replacing the
+// values of any keys it already has.
+func (b *build) SaveMetadata(metadata map[string]string) error {
+	payload, err := json.Marshal(metadata)
+	if err != nil {
+		return err
+	}
+
+	var savedPayload []byte
+	err = psql.Update("builds").
+		Set("metadata", sq.Expr("COALESCE(metadata, '{}'::jsonb) || ?::jsonb", string(payload))).
+		Where(sq.Eq{"id": b.id}).
+		Suffix("RETURNING metadata").
+		RunWith(b.conn).
+		QueryRow().
+		Scan(&savedPayload)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrBuildDisappeared
+		}
+		return err
+	}
+
+	return json.Unmarshal(savedPayload, &b.metadata)
+}
+
 func (b *build) SetInterceptible(i bool) error {
 	rows, err := psql.Update("builds").
 		Set("interceptible", i).
@@ -249,6 +298,16 @@ func (b *build) Start(engine, metadata string, plan atc.Plan) (bool, error) {
 		return false, err
 	}
 
+	err = b.enqueueNotifications(tx, atc.StatusStarted, startTime, time.Time{})
+	if err != nil {
+		return false, err
+	}
+
+	err = b.saveTeamEvent(tx, atc.StatusStarted, startTime)
+	if err != nil {
+		return false, err
+	}
+
 	err = tx.Commit()
 	if err != nil {
 		return false, err
@@ -311,6 +370,16 @@ func (b *build) Finish(status BuildStatus) error {
 		return err
 	}
 
+	err = b.enqueueNotifications(tx, atc.BuildStatus(status), b.startTime, endTime)
+	if err != nil {
+		return err
+	}
+
+	err = b.saveTeamEvent(tx, atc.BuildStatus(status), endTime)
+	if err != nil {
+		return err
+	}
+
 	_, err = tx.Exec(fmt.Sprintf(`
 		DROP SEQUENCE %s
 	`, buildEventSeq(b.id)))
@@ -354,6 +423,35 @@ func (b *build) Finish(status BuildStatus) error {
 	return err
 }
 
+func (b *build) enqueueNotifications(tx Tx, status atc.BuildStatus, startTime time.Time, endTime time.Time) error {
+	if b.pipelineID == 0 {
+		return nil
+	}
+
+	atcBuild, err := notificationBuild(b, status, startTime, endTime)
+	if err != nil {
+		return err
+	}
+
+	return enqueueNotifications(tx, b.conn.EncryptionStrategy(), b.pipelineID, atcBuild)
+}
+
+func (b *build) saveTeamEvent(tx Tx, status atc.BuildStatus, at time.Time) error {
+	if b.pipelineID == 0 {
+		return nil
+	}
+
+	return saveTeamEvent(tx, b.teamID, b.pipelineID, event.BuildStatus{
+		Time:         at.Unix(),
+		PipelineID:   b.pipelineID,
+		PipelineName: b.pipelineName,
+		JobName:      b.jobName,
+		BuildID:      b.id,
+		BuildName:    b.name,
+		Status:       status,
+	})
+}
+
 func (b *build) Delete() (bool, error) {
 	rows, err := psql.Delete("builds").
 		Where(sq.Eq{
@@ -396,6 +494,23 @@ func (b *build) MarkAsAborted() error {
 	return b.conn.Bus().Notify(buildAbortChannel(b.id))
 }
 
+// MarkAsArchived records that the build's events have been archived, so that
+// they can still be read once they are reaped.
+func (b *build) MarkAsArchived() error {
+	_, err := psql.Update("builds").
+		Set("archived", true).
+		Where(sq.Eq{"id": b.id}).
+		RunWith(b.conn).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	b.archived = true
+
+	return nil
+}
+
 // AbortNotifier returns a Notifier that can be watched for when the build
 // is marked as aborted. Once the build is marked as aborted it will send a
 // notification to finish the build to ATC that is tracking this build.
@@ -538,17 +653,7 @@ func (b *build) Preparation() (BuildPreparation, bool, error) {
 		maxInFlightReachedStatus = BuildPreparationStatusBlocking
 	}
 
-	tf := NewTeamFactory(b.conn, b.lockFactory)
-	t, found, err := tf.FindTeam(b.teamName)
-	if err != nil {
-		return BuildPreparation{}, false, err
-	}
-
-	if !found {
-		return BuildPreparation{}, false, nil
-	}
-
-	pipeline, found, err := t.Pipeline(b.pipelineName)
+	pipeline, found, err := b.Pipeline()
 	if err != nil {
 		return BuildPreparation{}, false, err
 	}
@@ -778,6 +883,49 @@ func (b *build) UseInputs(inputs []BuildInput) error {
 	return tx.Commit()
 }
 
+// Inputs returns the versions the build uses for its inputs, as saved by
+// UseInputs or SaveInput.
+func (b *build) Inputs() ([]BuildInput, error) {
+	rows, err := psql.Select("i.name", "r.name", "v.type", "v.version", "v.metadata").
+		From("build_inputs i").
+		Join("versioned_resources v ON v.id = i.versioned_resource_id").
+		Join("resources r ON r.id = v.resource_id").
+		Where(sq.Eq{"i.build_id": b.id}).
+		OrderBy("i.name ASC").
+		RunWith(b.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	inputs := []BuildInput{}
+
+	for rows.Next() {
+		var input BuildInput
+		var version, metadata string
+		err = rows.Scan(&input.Name, &input.Resource, &input.Type, &version, &metadata)
+		if err != nil {
+			return nil, err
+		}
+
+		err = json.Unmarshal([]byte(version), &input.Version)
+		if err != nil {
+			return nil, err
+		}
+
+		err = json.Unmarshal([]byte(metadata), &input.Metadata)
+		if err != nil {
+			return nil, err
+		}
+
+		inputs = append(inputs, input)
+	}
+
+	return inputs, nil
+}
+
 func (b *build) Resources() ([]BuildInput, []BuildOutput, error) {
 	inputs := []BuildInput{}
 	outputs := []BuildOutput{}
@@ -966,11 +1114,15 @@ func scanBuild(b *build, row scannable, encryptionStrategy encryption.Strategy)
 		engine, engineMetadata, jobName, pipelineName, publicPlan sql.NullString
 		startTime, endTime, reapTime                              pq.NullTime
 		nonce                                                     sql.NullString
+		metadata                                                  []byte
+		rerunOf                                                   sql.NullInt64
+		rerunOfName                                               sql.NullString
+		inputOverrides                                            []byte
 
 		status string
 	)
 
-	err := row.Scan(&b.id, &b.name, &jobID, &b.teamID, &status, &b.isManuallyTriggered, &b.scheduled, &engine, &engineMetadata, &publicPlan, &startTime, &endTime, &reapTime, &jobName, &pipelineID, &pipelineName, &b.teamName, &nonce)
+	err := row.Scan(&b.id, &b.name, &jobID, &b.teamID, &status, &b.isManuallyTriggered, &b.scheduled, &engine, &engineMetadata, &publicPlan, &startTime, &endTime, &reapTime, &jobName, &pipelineID, &pipelineName, &b.teamName, &nonce, &metadata, &rerunOf, &rerunOfName, &inputOverrides, &b.archived)
 	if err != nil {
 		return err
 	}
@@ -984,6 +1136,8 @@ func scanBuild(b *build, row scannable, encryptionStrategy encryption.Strategy)
 	b.startTime = startTime.Time
 	b.endTime = endTime.Time
 	b.reapTime = reapTime.Time
+	b.rerunOf = int(rerunOf.Int64)
+	b.rerunOfName = rerunOfName.String
 
 	var (
 		noncense                *string
@@ -1007,6 +1161,22 @@ func scanBuild(b *build, row scannable, encryptionStrategy encryption.Strategy)
 		}
 	}
 
+	b.metadata = nil
+	if metadata != nil {
+		err = json.Unmarshal(metadata, &b.metadata)
+		if err != nil {
+			return err
+		}
+	}
+
+	b.inputOverrides = nil
+	if inputOverrides != nil {
+		err = json.Unmarshal(inputOverrides, &b.inputOverrides)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -2,6 +2,7 @@ package db
 
 import (
 	"database/sql"
+	"encoding/json"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/concourse/atc/db/lock"
@@ -12,6 +13,7 @@ import (
 type BuildFactory interface {
 	Build(int) (Build, bool, error)
 	PublicBuilds(Page) ([]Build, Pagination, error)
+	SearchBuildLogs(BuildLogSearch, Page) ([]BuildLogMatch, Pagination, error)
 	GetAllStartedBuilds() ([]Build, error)
 
 	// TODO: move to BuildLifecycle, new interface (see WorkerLifecycle)
@@ -56,6 +58,11 @@ func (f *buildFactory) PublicBuilds(page Page) ([]Build, Pagination, error) {
 	return getBuildsWithPagination(buildsQuery.Where(sq.Eq{"p.public": true}), page, f.conn, f.lockFactory)
 }
 
+// SearchBuildLogs searches the logs of the builds of every team.
+func (f *buildFactory) SearchBuildLogs(search BuildLogSearch, page Page) ([]BuildLogMatch, Pagination, error) {
+	return searchBuildLogs(buildsQuery, search, page, f.conn, f.lockFactory)
+}
+
 func (f *buildFactory) MarkNonInterceptibleBuilds() error {
 	latestBuildsPrefix := `WITH
 		latest_builds AS (
@@ -109,9 +116,20 @@ func (f *buildFactory) GetAllStartedBuilds() ([]Build, error) {
 }
 
 func getBuildsWithPagination(buildsQuery sq.SelectBuilder, page Page, conn Conn, lockFactory lock.LockFactory) ([]Build, Pagination, error) {
+	return queryBuildsWithPagination(buildsQuery, page, conn, conn, lockFactory)
+}
+
+// queryBuildsWithPagination runs the queries with the runner, e.g. a
+// transaction, while the builds returned use the connection.
+func queryBuildsWithPagination(buildsQuery sq.SelectBuilder, page Page, runner sq.BaseRunner, conn Conn, lockFactory lock.LockFactory) ([]Build, Pagination, error) {
 	var rows *sql.Rows
 	var err error
 
+	buildsQuery, err = filterBuildsByMetadata(buildsQuery, page.Metadata)
+	if err != nil {
+		return nil, Pagination{}, err
+	}
+
 	var reverse bool
 	if page.Since == 0 && page.Until == 0 {
 		buildsQuery = buildsQuery.OrderBy("b.id DESC").Limit(uint64(page.Limit))
@@ -122,7 +140,7 @@ func getBuildsWithPagination(buildsQuery sq.SelectBuilder, page Page, conn Conn,
 		buildsQuery = buildsQuery.Where(sq.Lt{"b.id": page.Since}).OrderBy("b.id DESC").Limit(uint64(page.Limit))
 	}
 
-	rows, err = buildsQuery.RunWith(conn).Query()
+	rows, err = buildsQuery.RunWith(runner).Query()
 	if err != nil {
 		return nil, Pagination{}, err
 	}
@@ -155,7 +173,7 @@ func getBuildsWithPagination(buildsQuery sq.SelectBuilder, page Page, conn Conn,
 	var maxID int
 	err = psql.Select("COALESCE(MAX(id), 0)", "COALESCE(MIN(id), 0)").
 		From("builds").
-		RunWith(conn).
+		RunWith(runner).
 		QueryRow().
 		Scan(&maxID, &minID)
 	if err != nil {
@@ -169,17 +187,32 @@ func getBuildsWithPagination(buildsQuery sq.SelectBuilder, page Page, conn Conn,
 
 	if first.ID() < maxID {
 		pagination.Previous = &Page{
-			Until: first.ID(),
-			Limit: page.Limit,
+			Until:    first.ID(),
+			Limit:    page.Limit,
+			Metadata: page.Metadata,
 		}
 	}
 
 	if last.ID() > minID {
 		pagination.Next = &Page{
-			Since: last.ID(),
-			Limit: page.Limit,
+			Since:    last.ID(),
+			Limit:    page.Limit,
+			Metadata: page.Metadata,
 		}
 	}
 
 	return builds, pagination, nil
 }
+
+func filterBuildsByMetadata(buildsQuery sq.SelectBuilder, metadata map[string]string) (sq.SelectBuilder, error) {
+	if len(metadata) == 0 {
+		return buildsQuery, nil
+	}
+
+	payload, err := json.Marshal(metadata)
+	if err != nil {
+		return buildsQuery, err
+	}
+
+	return buildsQuery.Where(sq.Expr("b.metadata @> ?::jsonb", string(payload))), nil
+}
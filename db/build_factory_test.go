@@ -4,6 +4,7 @@ import (
 	"github.com/concourse/atc/db"
 
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/event"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
@@ -227,6 +228,67 @@ var _ = Describe("BuildFactory", func() {
 		})
 	})
 
+	Describe("SearchBuildLogs", func() {
+		var (
+			oneOffBuild     db.Build
+			otherTeamBuild  db.Build
+			privateJobBuild db.Build
+		)
+
+		BeforeEach(func() {
+			var err error
+			oneOffBuild, err = team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			err = oneOffBuild.SaveEvent(event.Log{Payload: "some-error happened\n"})
+			Expect(err).NotTo(HaveOccurred())
+
+			otherTeam, err := teamFactory.CreateTeam(atc.Team{Name: "some-other-team"})
+			Expect(err).NotTo(HaveOccurred())
+
+			otherTeamBuild, err = otherTeam.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			err = otherTeamBuild.SaveEvent(event.Log{Payload: "some-error happened\n"})
+			Expect(err).NotTo(HaveOccurred())
+
+			config := atc.Config{Jobs: atc.JobConfigs{{Name: "some-job"}}}
+			privatePipeline, _, err := otherTeam.SavePipeline("private-pipeline", config, db.ConfigVersion(1), db.PipelineUnpaused)
+			Expect(err).NotTo(HaveOccurred())
+
+			privateJob, found, err := privatePipeline.Job("some-job")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			privateJobBuild, err = privateJob.CreateBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			err = privateJobBuild.SaveEvent(event.Log{Payload: "some-error happened\n"})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("searches the builds of every team", func() {
+			matches, _, err := buildFactory.SearchBuildLogs(db.BuildLogSearch{Text: "some-error"}, db.Page{Limit: 10})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(matches).To(HaveLen(3))
+			Expect(matches[0].Build.ID()).To(Equal(privateJobBuild.ID()))
+			Expect(matches[1].Build.ID()).To(Equal(otherTeamBuild.ID()))
+			Expect(matches[2].Build.ID()).To(Equal(oneOffBuild.ID()))
+		})
+
+		It("filters by team", func() {
+			matches, _, err := buildFactory.SearchBuildLogs(db.BuildLogSearch{
+				Text:     "some-error",
+				TeamName: "some-team",
+			}, db.Page{Limit: 10})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(matches).To(HaveLen(1))
+			Expect(matches[0].Build.ID()).To(Equal(oneOffBuild.ID()))
+		})
+	})
+
 	Describe("GetAllStartedBuilds", func() {
 		var build1DB db.Build
 		var build2DB db.Build
@@ -0,0 +1,235 @@
+package db
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/atc/db/lock"
+	"github.com/concourse/atc/event"
+)
+
+// BuildLogSearch selects the builds whose logs are searched and what they are
+// searched for. A line matches if it contains Text and, if it is set, matches
+// Regexp.
+//
+// The database only looks for Text, which must be set; the lines of the
+// builds it finds are matched against Regexp once they are read.
+type BuildLogSearch struct {
+	TeamName      string
+	PipelineName  string
+	JobName       string
+	Statuses      []BuildStatus
+	StartedAfter  time.Time
+	StartedBefore time.Time
+
+	Text   string
+	Regexp *regexp.Regexp
+}
+
+// buildLogSearchTimeout bounds each query of a search, as a search may read
+// the logs of many builds.
+const buildLogSearchTimeout = 30 * time.Second
+
+type BuildLogMatch struct {
+	Build Build
+	Lines []BuildLogLine
+}
+
+type BuildLogLine struct {
+	Offset int
+	Text   string
+}
+
+func (search BuildLogSearch) filter(query sq.SelectBuilder) sq.SelectBuilder {
+	if search.TeamName != "" {
+		query = query.Where(sq.Eq{"t.name": search.TeamName})
+	}
+
+	if search.PipelineName != "" {
+		query = query.Where(sq.Eq{"p.name": search.PipelineName})
+	}
+
+	if search.JobName != "" {
+		query = query.Where(sq.Eq{"j.name": search.JobName})
+	}
+
+	if len(search.Statuses) > 0 {
+		statuses := make([]string, len(search.Statuses))
+		for i, status := range search.Statuses {
+			statuses[i] = string(status)
+		}
+
+		query = query.Where(sq.Eq{"b.status": statuses})
+	}
+
+	if !search.StartedAfter.IsZero() {
+		query = query.Where(sq.GtOrEq{"b.start_time": search.StartedAfter})
+	}
+
+	if !search.StartedBefore.IsZero() {
+		query = query.Where(sq.LtOrEq{"b.start_time": search.StartedBefore})
+	}
+
+	// narrow down the builds in the database by looking for the text
+	// literally; the lines are matched again when the logs are assembled to
+	// determine their offsets
+	return query.Where(sq.Expr(`EXISTS (
+		SELECT 1
+		FROM build_events e
+		WHERE e.build_id = b.id
+		AND e.type = ?
+		GROUP BY e.build_id
+		HAVING strpos(string_agg(e.payload::json->>'payload', '' ORDER BY e.event_id), ?) > 0
+	)`, string(event.EventTypeLog), search.Text))
+}
+
+func (search BuildLogSearch) matches(line string) bool {
+	if search.Text != "" && !strings.Contains(line, search.Text) {
+		return false
+	}
+
+	if search.Regexp != nil && !search.Regexp.MatchString(line) {
+		return false
+	}
+
+	return true
+}
+
+func searchBuildLogs(buildsQuery sq.SelectBuilder, search BuildLogSearch, page Page, conn Conn, lockFactory lock.LockFactory) ([]BuildLogMatch, Pagination, error) {
+	tx, err := conn.Begin()
+	if err != nil {
+		return nil, Pagination{}, err
+	}
+
+	defer Rollback(tx)
+
+	_, err = tx.Exec(fmt.Sprintf("SET LOCAL statement_timeout = %d", buildLogSearchTimeout/time.Millisecond))
+	if err != nil {
+		return nil, Pagination{}, err
+	}
+
+	builds, pagination, err := queryBuildsWithPagination(search.filter(buildsQuery), page, tx, conn, lockFactory)
+	if err != nil {
+		return nil, Pagination{}, err
+	}
+
+	matches := []BuildLogMatch{}
+	for _, build := range builds {
+		lines, err := matchingLogLines(tx, build.ID(), search)
+		if err != nil {
+			return nil, Pagination{}, err
+		}
+
+		// the text may only be found across lines, or the lines may not
+		// match the regexp
+		if len(lines) == 0 {
+			continue
+		}
+
+		matches = append(matches, BuildLogMatch{
+			Build: build,
+			Lines: lines,
+		})
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, Pagination{}, err
+	}
+
+	return matches, pagination, nil
+}
+
+func matchingLogLines(tx Tx, buildID int, search BuildLogSearch) ([]BuildLogLine, error) {
+	rows, err := psql.Select("e.payload").
+		From("build_events e").
+		Where(sq.Eq{
+			"e.build_id": buildID,
+			"e.type":     string(event.EventTypeLog),
+		}).
+		OrderBy("e.event_id ASC").
+		RunWith(tx).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	var (
+		lines   []BuildLogLine
+		offset  int
+		partial string
+	)
+
+	match := func(line string) {
+		if search.matches(line) {
+			lines = append(lines, BuildLogLine{Offset: offset, Text: line})
+		}
+
+		offset++
+	}
+
+	for rows.Next() {
+		var payload string
+		err = rows.Scan(&payload)
+		if err != nil {
+			return nil, err
+		}
+
+		var log event.Log
+		err = json.Unmarshal([]byte(payload), &log)
+		if err != nil {
+			return nil, err
+		}
+
+		// log events are chunks of output, so a line may span several events
+		chunks := strings.Split(partial+log.Payload, "\n")
+		for _, line := range chunks[:len(chunks)-1] {
+			match(line)
+		}
+
+		partial = chunks[len(chunks)-1]
+	}
+
+	if partial != "" {
+		match(partial)
+	}
+
+	return lines, nil
+}
+
+// publicJobIDs returns the IDs of the jobs whose builds are visible to
+// everyone, i.e. public jobs of public pipelines. Whether a job is public is
+// part of its config, which may be encrypted, so it is checked here rather
+// than in the query.
+func publicJobIDs(conn Conn, lockFactory lock.LockFactory) ([]int, error) {
+	rows, err := jobsQuery.
+		Where(sq.Eq{
+			"p.public": true,
+			"j.active": true,
+		}).
+		RunWith(conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	jobs, err := scanJobs(conn, lockFactory, rows)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := []int{}
+	for _, job := range jobs {
+		if job.Config().Public {
+			ids = append(ids, job.ID())
+		}
+	}
+
+	return ids, nil
+}
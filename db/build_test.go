@@ -174,6 +174,60 @@ var _ = Describe("Build", func() {
 		})
 	})
 
+	Describe("SaveMetadata", func() {
+		var build db.Build
+
+		BeforeEach(func() {
+			var err error
+			build, err = team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("starts out without metadata", func() {
+			Expect(build.Metadata()).To(BeNil())
+		})
+
+		It("saves the metadata", func() {
+			err := build.SaveMetadata(map[string]string{"sha": "abc123"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(build.Metadata()).To(Equal(map[string]string{"sha": "abc123"}))
+
+			found, err := build.Reload()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(build.Metadata()).To(Equal(map[string]string{"sha": "abc123"}))
+		})
+
+		It("merges with the metadata saved before", func() {
+			err := build.SaveMetadata(map[string]string{"sha": "abc123", "ticket": "REL-41"})
+			Expect(err).NotTo(HaveOccurred())
+
+			err = build.SaveMetadata(map[string]string{"ticket": "REL-42", "url": "https://example.com"})
+			Expect(err).NotTo(HaveOccurred())
+
+			found, err := build.Reload()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(build.Metadata()).To(Equal(map[string]string{
+				"sha":    "abc123",
+				"ticket": "REL-42",
+				"url":    "https://example.com",
+			}))
+		})
+
+		Context("when the build has been deleted", func() {
+			BeforeEach(func() {
+				_, err := dbConn.Exec("DELETE FROM builds WHERE id = $1", build.ID())
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns ErrBuildDisappeared", func() {
+				err := build.SaveMetadata(map[string]string{"sha": "abc123"})
+				Expect(err).To(Equal(db.ErrBuildDisappeared))
+			})
+		})
+	})
+
 	Describe("Events", func() {
 		It("saves and emits status events", func() {
 			build, err := team.CreateOneOffBuild()
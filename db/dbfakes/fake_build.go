@@ -422,6 +422,82 @@ type FakeBuild struct {
 		result1 bool
 		result2 error
 	}
+	MetadataStub        func() map[string]string
+	metadataMutex       sync.RWMutex
+	metadataArgsForCall []struct{}
+	metadataReturns     struct {
+		result1 map[string]string
+	}
+	metadataReturnsOnCall map[int]struct {
+		result1 map[string]string
+	}
+	SaveMetadataStub        func(arg1 map[string]string) error
+	saveMetadataMutex       sync.RWMutex
+	saveMetadataArgsForCall []struct {
+		arg1 map[string]string
+	}
+	saveMetadataReturns struct {
+		result1 error
+	}
+	saveMetadataReturnsOnCall map[int]struct {
+		result1 error
+	}
+	RerunOfStub        func() int
+	rerunOfMutex       sync.RWMutex
+	rerunOfArgsForCall []struct{}
+	rerunOfReturns     struct {
+		result1 int
+	}
+	rerunOfReturnsOnCall map[int]struct {
+		result1 int
+	}
+	RerunOfNameStub        func() string
+	rerunOfNameMutex       sync.RWMutex
+	rerunOfNameArgsForCall []struct{}
+	rerunOfNameReturns     struct {
+		result1 string
+	}
+	rerunOfNameReturnsOnCall map[int]struct {
+		result1 string
+	}
+	InputsStub        func() ([]db.BuildInput, error)
+	inputsMutex       sync.RWMutex
+	inputsArgsForCall []struct{}
+	inputsReturns     struct {
+		result1 []db.BuildInput
+		result2 error
+	}
+	inputsReturnsOnCall map[int]struct {
+		result1 []db.BuildInput
+		result2 error
+	}
+	InputOverridesStub        func() []atc.BuildInputOverride
+	inputOverridesMutex       sync.RWMutex
+	inputOverridesArgsForCall []struct{}
+	inputOverridesReturns     struct {
+		result1 []atc.BuildInputOverride
+	}
+	inputOverridesReturnsOnCall map[int]struct {
+		result1 []atc.BuildInputOverride
+	}
+	IsArchivedStub        func() bool
+	isArchivedMutex       sync.RWMutex
+	isArchivedArgsForCall []struct{}
+	isArchivedReturns     struct {
+		result1 bool
+	}
+	isArchivedReturnsOnCall map[int]struct {
+		result1 bool
+	}
+	MarkAsArchivedStub        func() error
+	markAsArchivedMutex       sync.RWMutex
+	markAsArchivedArgsForCall []struct{}
+	markAsArchivedReturns     struct {
+		result1 error
+	}
+	markAsArchivedReturnsOnCall map[int]struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -2131,6 +2207,337 @@ func (fake *FakeBuild) ScheduleReturnsOnCall(i int, result1 bool, result2 error)
 	}{result1, result2}
 }
 
+func (fake *FakeBuild) Metadata() map[string]string {
+	fake.metadataMutex.Lock()
+	ret, specificReturn := fake.metadataReturnsOnCall[len(fake.metadataArgsForCall)]
+	fake.metadataArgsForCall = append(fake.metadataArgsForCall, struct{}{})
+	fake.recordInvocation("Metadata", []interface{}{})
+	fake.metadataMutex.Unlock()
+	if fake.MetadataStub != nil {
+		return fake.MetadataStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.metadataReturns.result1
+}
+
+func (fake *FakeBuild) MetadataCallCount() int {
+	fake.metadataMutex.RLock()
+	defer fake.metadataMutex.RUnlock()
+	return len(fake.metadataArgsForCall)
+}
+
+func (fake *FakeBuild) MetadataReturns(result1 map[string]string) {
+	fake.MetadataStub = nil
+	fake.metadataReturns = struct {
+		result1 map[string]string
+	}{result1}
+}
+
+func (fake *FakeBuild) MetadataReturnsOnCall(i int, result1 map[string]string) {
+	fake.MetadataStub = nil
+	if fake.metadataReturnsOnCall == nil {
+		fake.metadataReturnsOnCall = make(map[int]struct {
+			result1 map[string]string
+		})
+	}
+	fake.metadataReturnsOnCall[i] = struct {
+		result1 map[string]string
+	}{result1}
+}
+
+func (fake *FakeBuild) SaveMetadata(arg1 map[string]string) error {
+	fake.saveMetadataMutex.Lock()
+	ret, specificReturn := fake.saveMetadataReturnsOnCall[len(fake.saveMetadataArgsForCall)]
+	fake.saveMetadataArgsForCall = append(fake.saveMetadataArgsForCall, struct {
+		arg1 map[string]string
+	}{arg1})
+	fake.recordInvocation("SaveMetadata", []interface{}{arg1})
+	fake.saveMetadataMutex.Unlock()
+	if fake.SaveMetadataStub != nil {
+		return fake.SaveMetadataStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.saveMetadataReturns.result1
+}
+
+func (fake *FakeBuild) SaveMetadataCallCount() int {
+	fake.saveMetadataMutex.RLock()
+	defer fake.saveMetadataMutex.RUnlock()
+	return len(fake.saveMetadataArgsForCall)
+}
+
+func (fake *FakeBuild) SaveMetadataArgsForCall(i int) map[string]string {
+	fake.saveMetadataMutex.RLock()
+	defer fake.saveMetadataMutex.RUnlock()
+	return fake.saveMetadataArgsForCall[i].arg1
+}
+
+func (fake *FakeBuild) SaveMetadataReturns(result1 error) {
+	fake.SaveMetadataStub = nil
+	fake.saveMetadataReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) SaveMetadataReturnsOnCall(i int, result1 error) {
+	fake.SaveMetadataStub = nil
+	if fake.saveMetadataReturnsOnCall == nil {
+		fake.saveMetadataReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.saveMetadataReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) RerunOf() int {
+	fake.rerunOfMutex.Lock()
+	ret, specificReturn := fake.rerunOfReturnsOnCall[len(fake.rerunOfArgsForCall)]
+	fake.rerunOfArgsForCall = append(fake.rerunOfArgsForCall, struct{}{})
+	fake.recordInvocation("RerunOf", []interface{}{})
+	fake.rerunOfMutex.Unlock()
+	if fake.RerunOfStub != nil {
+		return fake.RerunOfStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.rerunOfReturns.result1
+}
+
+func (fake *FakeBuild) RerunOfCallCount() int {
+	fake.rerunOfMutex.RLock()
+	defer fake.rerunOfMutex.RUnlock()
+	return len(fake.rerunOfArgsForCall)
+}
+
+func (fake *FakeBuild) RerunOfReturns(result1 int) {
+	fake.RerunOfStub = nil
+	fake.rerunOfReturns = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeBuild) RerunOfReturnsOnCall(i int, result1 int) {
+	fake.RerunOfStub = nil
+	if fake.rerunOfReturnsOnCall == nil {
+		fake.rerunOfReturnsOnCall = make(map[int]struct {
+			result1 int
+		})
+	}
+	fake.rerunOfReturnsOnCall[i] = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeBuild) RerunOfName() string {
+	fake.rerunOfNameMutex.Lock()
+	ret, specificReturn := fake.rerunOfNameReturnsOnCall[len(fake.rerunOfNameArgsForCall)]
+	fake.rerunOfNameArgsForCall = append(fake.rerunOfNameArgsForCall, struct{}{})
+	fake.recordInvocation("RerunOfName", []interface{}{})
+	fake.rerunOfNameMutex.Unlock()
+	if fake.RerunOfNameStub != nil {
+		return fake.RerunOfNameStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.rerunOfNameReturns.result1
+}
+
+func (fake *FakeBuild) RerunOfNameCallCount() int {
+	fake.rerunOfNameMutex.RLock()
+	defer fake.rerunOfNameMutex.RUnlock()
+	return len(fake.rerunOfNameArgsForCall)
+}
+
+func (fake *FakeBuild) RerunOfNameReturns(result1 string) {
+	fake.RerunOfNameStub = nil
+	fake.rerunOfNameReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeBuild) RerunOfNameReturnsOnCall(i int, result1 string) {
+	fake.RerunOfNameStub = nil
+	if fake.rerunOfNameReturnsOnCall == nil {
+		fake.rerunOfNameReturnsOnCall = make(map[int]struct {
+			result1 string
+		})
+	}
+	fake.rerunOfNameReturnsOnCall[i] = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeBuild) Inputs() ([]db.BuildInput, error) {
+	fake.inputsMutex.Lock()
+	ret, specificReturn := fake.inputsReturnsOnCall[len(fake.inputsArgsForCall)]
+	fake.inputsArgsForCall = append(fake.inputsArgsForCall, struct{}{})
+	fake.recordInvocation("Inputs", []interface{}{})
+	fake.inputsMutex.Unlock()
+	if fake.InputsStub != nil {
+		return fake.InputsStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.inputsReturns.result1, fake.inputsReturns.result2
+}
+
+func (fake *FakeBuild) InputsCallCount() int {
+	fake.inputsMutex.RLock()
+	defer fake.inputsMutex.RUnlock()
+	return len(fake.inputsArgsForCall)
+}
+
+func (fake *FakeBuild) InputsReturns(result1 []db.BuildInput, result2 error) {
+	fake.InputsStub = nil
+	fake.inputsReturns = struct {
+		result1 []db.BuildInput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) InputsReturnsOnCall(i int, result1 []db.BuildInput, result2 error) {
+	fake.InputsStub = nil
+	if fake.inputsReturnsOnCall == nil {
+		fake.inputsReturnsOnCall = make(map[int]struct {
+			result1 []db.BuildInput
+			result2 error
+		})
+	}
+	fake.inputsReturnsOnCall[i] = struct {
+		result1 []db.BuildInput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) InputOverrides() []atc.BuildInputOverride {
+	fake.inputOverridesMutex.Lock()
+	ret, specificReturn := fake.inputOverridesReturnsOnCall[len(fake.inputOverridesArgsForCall)]
+	fake.inputOverridesArgsForCall = append(fake.inputOverridesArgsForCall, struct{}{})
+	fake.recordInvocation("InputOverrides", []interface{}{})
+	fake.inputOverridesMutex.Unlock()
+	if fake.InputOverridesStub != nil {
+		return fake.InputOverridesStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.inputOverridesReturns.result1
+}
+
+func (fake *FakeBuild) InputOverridesCallCount() int {
+	fake.inputOverridesMutex.RLock()
+	defer fake.inputOverridesMutex.RUnlock()
+	return len(fake.inputOverridesArgsForCall)
+}
+
+func (fake *FakeBuild) InputOverridesReturns(result1 []atc.BuildInputOverride) {
+	fake.InputOverridesStub = nil
+	fake.inputOverridesReturns = struct {
+		result1 []atc.BuildInputOverride
+	}{result1}
+}
+
+func (fake *FakeBuild) InputOverridesReturnsOnCall(i int, result1 []atc.BuildInputOverride) {
+	fake.InputOverridesStub = nil
+	if fake.inputOverridesReturnsOnCall == nil {
+		fake.inputOverridesReturnsOnCall = make(map[int]struct {
+			result1 []atc.BuildInputOverride
+		})
+	}
+	fake.inputOverridesReturnsOnCall[i] = struct {
+		result1 []atc.BuildInputOverride
+	}{result1}
+}
+
+func (fake *FakeBuild) IsArchived() bool {
+	fake.isArchivedMutex.Lock()
+	ret, specificReturn := fake.isArchivedReturnsOnCall[len(fake.isArchivedArgsForCall)]
+	fake.isArchivedArgsForCall = append(fake.isArchivedArgsForCall, struct{}{})
+	fake.recordInvocation("IsArchived", []interface{}{})
+	fake.isArchivedMutex.Unlock()
+	if fake.IsArchivedStub != nil {
+		return fake.IsArchivedStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.isArchivedReturns.result1
+}
+
+func (fake *FakeBuild) IsArchivedCallCount() int {
+	fake.isArchivedMutex.RLock()
+	defer fake.isArchivedMutex.RUnlock()
+	return len(fake.isArchivedArgsForCall)
+}
+
+func (fake *FakeBuild) IsArchivedReturns(result1 bool) {
+	fake.IsArchivedStub = nil
+	fake.isArchivedReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeBuild) IsArchivedReturnsOnCall(i int, result1 bool) {
+	fake.IsArchivedStub = nil
+	if fake.isArchivedReturnsOnCall == nil {
+		fake.isArchivedReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.isArchivedReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeBuild) MarkAsArchived() error {
+	fake.markAsArchivedMutex.Lock()
+	ret, specificReturn := fake.markAsArchivedReturnsOnCall[len(fake.markAsArchivedArgsForCall)]
+	fake.markAsArchivedArgsForCall = append(fake.markAsArchivedArgsForCall, struct{}{})
+	fake.recordInvocation("MarkAsArchived", []interface{}{})
+	fake.markAsArchivedMutex.Unlock()
+	if fake.MarkAsArchivedStub != nil {
+		return fake.MarkAsArchivedStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.markAsArchivedReturns.result1
+}
+
+func (fake *FakeBuild) MarkAsArchivedCallCount() int {
+	fake.markAsArchivedMutex.RLock()
+	defer fake.markAsArchivedMutex.RUnlock()
+	return len(fake.markAsArchivedArgsForCall)
+}
+
+func (fake *FakeBuild) MarkAsArchivedReturns(result1 error) {
+	fake.MarkAsArchivedStub = nil
+	fake.markAsArchivedReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) MarkAsArchivedReturnsOnCall(i int, result1 error) {
+	fake.MarkAsArchivedStub = nil
+	if fake.markAsArchivedReturnsOnCall == nil {
+		fake.markAsArchivedReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.markAsArchivedReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -2212,6 +2619,22 @@ func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	defer fake.abortNotifierMutex.RUnlock()
 	fake.scheduleMutex.RLock()
 	defer fake.scheduleMutex.RUnlock()
+	fake.metadataMutex.RLock()
+	defer fake.metadataMutex.RUnlock()
+	fake.saveMetadataMutex.RLock()
+	defer fake.saveMetadataMutex.RUnlock()
+	fake.rerunOfMutex.RLock()
+	defer fake.rerunOfMutex.RUnlock()
+	fake.rerunOfNameMutex.RLock()
+	defer fake.rerunOfNameMutex.RUnlock()
+	fake.inputsMutex.RLock()
+	defer fake.inputsMutex.RUnlock()
+	fake.inputOverridesMutex.RLock()
+	defer fake.inputOverridesMutex.RUnlock()
+	fake.isArchivedMutex.RLock()
+	defer fake.isArchivedMutex.RUnlock()
+	fake.markAsArchivedMutex.RLock()
+	defer fake.markAsArchivedMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -58,6 +58,22 @@ type FakeBuildFactory struct {
 	markNonInterceptibleBuildsReturnsOnCall map[int]struct {
 		result1 error
 	}
+	SearchBuildLogsStub        func(arg1 db.BuildLogSearch, arg2 db.Page) ([]db.BuildLogMatch, db.Pagination, error)
+	searchBuildLogsMutex       sync.RWMutex
+	searchBuildLogsArgsForCall []struct {
+		arg1 db.BuildLogSearch
+		arg2 db.Page
+	}
+	searchBuildLogsReturns struct {
+		result1 []db.BuildLogMatch
+		result2 db.Pagination
+		result3 error
+	}
+	searchBuildLogsReturnsOnCall map[int]struct {
+		result1 []db.BuildLogMatch
+		result2 db.Pagination
+		result3 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -253,6 +269,61 @@ func (fake *FakeBuildFactory) MarkNonInterceptibleBuildsReturnsOnCall(i int, res
 	}{result1}
 }
 
+func (fake *FakeBuildFactory) SearchBuildLogs(arg1 db.BuildLogSearch, arg2 db.Page) ([]db.BuildLogMatch, db.Pagination, error) {
+	fake.searchBuildLogsMutex.Lock()
+	ret, specificReturn := fake.searchBuildLogsReturnsOnCall[len(fake.searchBuildLogsArgsForCall)]
+	fake.searchBuildLogsArgsForCall = append(fake.searchBuildLogsArgsForCall, struct {
+		arg1 db.BuildLogSearch
+		arg2 db.Page
+	}{arg1, arg2})
+	fake.recordInvocation("SearchBuildLogs", []interface{}{arg1, arg2})
+	fake.searchBuildLogsMutex.Unlock()
+	if fake.SearchBuildLogsStub != nil {
+		return fake.SearchBuildLogsStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fake.searchBuildLogsReturns.result1, fake.searchBuildLogsReturns.result2, fake.searchBuildLogsReturns.result3
+}
+
+func (fake *FakeBuildFactory) SearchBuildLogsCallCount() int {
+	fake.searchBuildLogsMutex.RLock()
+	defer fake.searchBuildLogsMutex.RUnlock()
+	return len(fake.searchBuildLogsArgsForCall)
+}
+
+func (fake *FakeBuildFactory) SearchBuildLogsArgsForCall(i int) (db.BuildLogSearch, db.Page) {
+	fake.searchBuildLogsMutex.RLock()
+	defer fake.searchBuildLogsMutex.RUnlock()
+	return fake.searchBuildLogsArgsForCall[i].arg1, fake.searchBuildLogsArgsForCall[i].arg2
+}
+
+func (fake *FakeBuildFactory) SearchBuildLogsReturns(result1 []db.BuildLogMatch, result2 db.Pagination, result3 error) {
+	fake.SearchBuildLogsStub = nil
+	fake.searchBuildLogsReturns = struct {
+		result1 []db.BuildLogMatch
+		result2 db.Pagination
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeBuildFactory) SearchBuildLogsReturnsOnCall(i int, result1 []db.BuildLogMatch, result2 db.Pagination, result3 error) {
+	fake.SearchBuildLogsStub = nil
+	if fake.searchBuildLogsReturnsOnCall == nil {
+		fake.searchBuildLogsReturnsOnCall = make(map[int]struct {
+			result1 []db.BuildLogMatch
+			result2 db.Pagination
+			result3 error
+		})
+	}
+	fake.searchBuildLogsReturnsOnCall[i] = struct {
+		result1 []db.BuildLogMatch
+		result2 db.Pagination
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeBuildFactory) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -264,6 +335,8 @@ func (fake *FakeBuildFactory) Invocations() map[string][][]interface{} {
 	defer fake.getAllStartedBuildsMutex.RUnlock()
 	fake.markNonInterceptibleBuildsMutex.RLock()
 	defer fake.markNonInterceptibleBuildsMutex.RUnlock()
+	fake.searchBuildLogsMutex.RLock()
+	defer fake.searchBuildLogsMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -299,6 +299,74 @@ type FakeJob struct {
 		result2 bool
 		result3 error
 	}
+	RerunBuildStub        func(build db.Build) (db.Build, error)
+	rerunBuildMutex       sync.RWMutex
+	rerunBuildArgsForCall []struct {
+		build db.Build
+	}
+	rerunBuildReturns struct {
+		result1 db.Build
+		result2 error
+	}
+	rerunBuildReturnsOnCall map[int]struct {
+		result1 db.Build
+		result2 error
+	}
+	CreateBuildWithInputsStub        func(inputMapping algorithm.InputMapping, overrides []atc.BuildInputOverride) (db.Build, error)
+	createBuildWithInputsMutex       sync.RWMutex
+	createBuildWithInputsArgsForCall []struct {
+		inputMapping algorithm.InputMapping
+		overrides    []atc.BuildInputOverride
+	}
+	createBuildWithInputsReturns struct {
+		result1 db.Build
+		result2 error
+	}
+	createBuildWithInputsReturnsOnCall map[int]struct {
+		result1 db.Build
+		result2 error
+	}
+	UnreapedBuildsStub        func(afterID int, limit int) ([]db.Build, error)
+	unreapedBuildsMutex       sync.RWMutex
+	unreapedBuildsArgsForCall []struct {
+		afterID int
+		limit   int
+	}
+	unreapedBuildsReturns struct {
+		result1 []db.Build
+		result2 error
+	}
+	unreapedBuildsReturnsOnCall map[int]struct {
+		result1 []db.Build
+		result2 error
+	}
+	LatestSucceededBuildIDsStub        func(limit int) ([]int, error)
+	latestSucceededBuildIDsMutex       sync.RWMutex
+	latestSucceededBuildIDsArgsForCall []struct {
+		limit int
+	}
+	latestSucceededBuildIDsReturns struct {
+		result1 []int
+		result2 error
+	}
+	latestSucceededBuildIDsReturnsOnCall map[int]struct {
+		result1 []int
+		result2 error
+	}
+	BuildIDsUsedByJobsStub        func(buildIDs []int, jobIDs []int) ([]int, error)
+	buildIDsUsedByJobsMutex       sync.RWMutex
+	buildIDsUsedByJobsArgsForCall []struct {
+		buildIDs []int
+		jobIDs   []int
+	}
+	buildIDsUsedByJobsReturns struct {
+		result1 []int
+		result2 error
+	}
+	buildIDsUsedByJobsReturnsOnCall map[int]struct {
+		result1 []int
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -1502,6 +1570,279 @@ func (fake *FakeJob) GetNextPendingBuildBySerialGroupReturnsOnCall(i int, result
 	}{result1, result2, result3}
 }
 
+func (fake *FakeJob) RerunBuild(build db.Build) (db.Build, error) {
+	fake.rerunBuildMutex.Lock()
+	ret, specificReturn := fake.rerunBuildReturnsOnCall[len(fake.rerunBuildArgsForCall)]
+	fake.rerunBuildArgsForCall = append(fake.rerunBuildArgsForCall, struct {
+		build db.Build
+	}{build})
+	fake.recordInvocation("RerunBuild", []interface{}{build})
+	fake.rerunBuildMutex.Unlock()
+	if fake.RerunBuildStub != nil {
+		return fake.RerunBuildStub(build)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.rerunBuildReturns.result1, fake.rerunBuildReturns.result2
+}
+
+func (fake *FakeJob) RerunBuildCallCount() int {
+	fake.rerunBuildMutex.RLock()
+	defer fake.rerunBuildMutex.RUnlock()
+	return len(fake.rerunBuildArgsForCall)
+}
+
+func (fake *FakeJob) RerunBuildArgsForCall(i int) db.Build {
+	fake.rerunBuildMutex.RLock()
+	defer fake.rerunBuildMutex.RUnlock()
+	return fake.rerunBuildArgsForCall[i].build
+}
+
+func (fake *FakeJob) RerunBuildReturns(result1 db.Build, result2 error) {
+	fake.RerunBuildStub = nil
+	fake.rerunBuildReturns = struct {
+		result1 db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeJob) RerunBuildReturnsOnCall(i int, result1 db.Build, result2 error) {
+	fake.RerunBuildStub = nil
+	if fake.rerunBuildReturnsOnCall == nil {
+		fake.rerunBuildReturnsOnCall = make(map[int]struct {
+			result1 db.Build
+			result2 error
+		})
+	}
+	fake.rerunBuildReturnsOnCall[i] = struct {
+		result1 db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeJob) CreateBuildWithInputs(inputMapping algorithm.InputMapping, overrides []atc.BuildInputOverride) (db.Build, error) {
+	var overridesCopy []atc.BuildInputOverride
+	if overrides != nil {
+		overridesCopy = make([]atc.BuildInputOverride, len(overrides))
+		copy(overridesCopy, overrides)
+	}
+	fake.createBuildWithInputsMutex.Lock()
+	ret, specificReturn := fake.createBuildWithInputsReturnsOnCall[len(fake.createBuildWithInputsArgsForCall)]
+	fake.createBuildWithInputsArgsForCall = append(fake.createBuildWithInputsArgsForCall, struct {
+		inputMapping algorithm.InputMapping
+		overrides    []atc.BuildInputOverride
+	}{inputMapping, overridesCopy})
+	fake.recordInvocation("CreateBuildWithInputs", []interface{}{inputMapping, overridesCopy})
+	fake.createBuildWithInputsMutex.Unlock()
+	if fake.CreateBuildWithInputsStub != nil {
+		return fake.CreateBuildWithInputsStub(inputMapping, overrides)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.createBuildWithInputsReturns.result1, fake.createBuildWithInputsReturns.result2
+}
+
+func (fake *FakeJob) CreateBuildWithInputsCallCount() int {
+	fake.createBuildWithInputsMutex.RLock()
+	defer fake.createBuildWithInputsMutex.RUnlock()
+	return len(fake.createBuildWithInputsArgsForCall)
+}
+
+func (fake *FakeJob) CreateBuildWithInputsArgsForCall(i int) (algorithm.InputMapping, []atc.BuildInputOverride) {
+	fake.createBuildWithInputsMutex.RLock()
+	defer fake.createBuildWithInputsMutex.RUnlock()
+	return fake.createBuildWithInputsArgsForCall[i].inputMapping, fake.createBuildWithInputsArgsForCall[i].overrides
+}
+
+func (fake *FakeJob) CreateBuildWithInputsReturns(result1 db.Build, result2 error) {
+	fake.CreateBuildWithInputsStub = nil
+	fake.createBuildWithInputsReturns = struct {
+		result1 db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeJob) CreateBuildWithInputsReturnsOnCall(i int, result1 db.Build, result2 error) {
+	fake.CreateBuildWithInputsStub = nil
+	if fake.createBuildWithInputsReturnsOnCall == nil {
+		fake.createBuildWithInputsReturnsOnCall = make(map[int]struct {
+			result1 db.Build
+			result2 error
+		})
+	}
+	fake.createBuildWithInputsReturnsOnCall[i] = struct {
+		result1 db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeJob) UnreapedBuilds(afterID int, limit int) ([]db.Build, error) {
+	fake.unreapedBuildsMutex.Lock()
+	ret, specificReturn := fake.unreapedBuildsReturnsOnCall[len(fake.unreapedBuildsArgsForCall)]
+	fake.unreapedBuildsArgsForCall = append(fake.unreapedBuildsArgsForCall, struct {
+		afterID int
+		limit   int
+	}{afterID, limit})
+	fake.recordInvocation("UnreapedBuilds", []interface{}{afterID, limit})
+	fake.unreapedBuildsMutex.Unlock()
+	if fake.UnreapedBuildsStub != nil {
+		return fake.UnreapedBuildsStub(afterID, limit)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.unreapedBuildsReturns.result1, fake.unreapedBuildsReturns.result2
+}
+
+func (fake *FakeJob) UnreapedBuildsCallCount() int {
+	fake.unreapedBuildsMutex.RLock()
+	defer fake.unreapedBuildsMutex.RUnlock()
+	return len(fake.unreapedBuildsArgsForCall)
+}
+
+func (fake *FakeJob) UnreapedBuildsArgsForCall(i int) (int, int) {
+	fake.unreapedBuildsMutex.RLock()
+	defer fake.unreapedBuildsMutex.RUnlock()
+	return fake.unreapedBuildsArgsForCall[i].afterID, fake.unreapedBuildsArgsForCall[i].limit
+}
+
+func (fake *FakeJob) UnreapedBuildsReturns(result1 []db.Build, result2 error) {
+	fake.UnreapedBuildsStub = nil
+	fake.unreapedBuildsReturns = struct {
+		result1 []db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeJob) UnreapedBuildsReturnsOnCall(i int, result1 []db.Build, result2 error) {
+	fake.UnreapedBuildsStub = nil
+	if fake.unreapedBuildsReturnsOnCall == nil {
+		fake.unreapedBuildsReturnsOnCall = make(map[int]struct {
+			result1 []db.Build
+			result2 error
+		})
+	}
+	fake.unreapedBuildsReturnsOnCall[i] = struct {
+		result1 []db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeJob) LatestSucceededBuildIDs(limit int) ([]int, error) {
+	fake.latestSucceededBuildIDsMutex.Lock()
+	ret, specificReturn := fake.latestSucceededBuildIDsReturnsOnCall[len(fake.latestSucceededBuildIDsArgsForCall)]
+	fake.latestSucceededBuildIDsArgsForCall = append(fake.latestSucceededBuildIDsArgsForCall, struct {
+		limit int
+	}{limit})
+	fake.recordInvocation("LatestSucceededBuildIDs", []interface{}{limit})
+	fake.latestSucceededBuildIDsMutex.Unlock()
+	if fake.LatestSucceededBuildIDsStub != nil {
+		return fake.LatestSucceededBuildIDsStub(limit)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.latestSucceededBuildIDsReturns.result1, fake.latestSucceededBuildIDsReturns.result2
+}
+
+func (fake *FakeJob) LatestSucceededBuildIDsCallCount() int {
+	fake.latestSucceededBuildIDsMutex.RLock()
+	defer fake.latestSucceededBuildIDsMutex.RUnlock()
+	return len(fake.latestSucceededBuildIDsArgsForCall)
+}
+
+func (fake *FakeJob) LatestSucceededBuildIDsArgsForCall(i int) int {
+	fake.latestSucceededBuildIDsMutex.RLock()
+	defer fake.latestSucceededBuildIDsMutex.RUnlock()
+	return fake.latestSucceededBuildIDsArgsForCall[i].limit
+}
+
+func (fake *FakeJob) LatestSucceededBuildIDsReturns(result1 []int, result2 error) {
+	fake.LatestSucceededBuildIDsStub = nil
+	fake.latestSucceededBuildIDsReturns = struct {
+		result1 []int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeJob) LatestSucceededBuildIDsReturnsOnCall(i int, result1 []int, result2 error) {
+	fake.LatestSucceededBuildIDsStub = nil
+	if fake.latestSucceededBuildIDsReturnsOnCall == nil {
+		fake.latestSucceededBuildIDsReturnsOnCall = make(map[int]struct {
+			result1 []int
+			result2 error
+		})
+	}
+	fake.latestSucceededBuildIDsReturnsOnCall[i] = struct {
+		result1 []int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeJob) BuildIDsUsedByJobs(buildIDs []int, jobIDs []int) ([]int, error) {
+	var buildIDsCopy []int
+	if buildIDs != nil {
+		buildIDsCopy = make([]int, len(buildIDs))
+		copy(buildIDsCopy, buildIDs)
+	}
+	var jobIDsCopy []int
+	if jobIDs != nil {
+		jobIDsCopy = make([]int, len(jobIDs))
+		copy(jobIDsCopy, jobIDs)
+	}
+	fake.buildIDsUsedByJobsMutex.Lock()
+	ret, specificReturn := fake.buildIDsUsedByJobsReturnsOnCall[len(fake.buildIDsUsedByJobsArgsForCall)]
+	fake.buildIDsUsedByJobsArgsForCall = append(fake.buildIDsUsedByJobsArgsForCall, struct {
+		buildIDs []int
+		jobIDs   []int
+	}{buildIDsCopy, jobIDsCopy})
+	fake.recordInvocation("BuildIDsUsedByJobs", []interface{}{buildIDsCopy, jobIDsCopy})
+	fake.buildIDsUsedByJobsMutex.Unlock()
+	if fake.BuildIDsUsedByJobsStub != nil {
+		return fake.BuildIDsUsedByJobsStub(buildIDs, jobIDs)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.buildIDsUsedByJobsReturns.result1, fake.buildIDsUsedByJobsReturns.result2
+}
+
+func (fake *FakeJob) BuildIDsUsedByJobsCallCount() int {
+	fake.buildIDsUsedByJobsMutex.RLock()
+	defer fake.buildIDsUsedByJobsMutex.RUnlock()
+	return len(fake.buildIDsUsedByJobsArgsForCall)
+}
+
+func (fake *FakeJob) BuildIDsUsedByJobsArgsForCall(i int) ([]int, []int) {
+	fake.buildIDsUsedByJobsMutex.RLock()
+	defer fake.buildIDsUsedByJobsMutex.RUnlock()
+	return fake.buildIDsUsedByJobsArgsForCall[i].buildIDs, fake.buildIDsUsedByJobsArgsForCall[i].jobIDs
+}
+
+func (fake *FakeJob) BuildIDsUsedByJobsReturns(result1 []int, result2 error) {
+	fake.BuildIDsUsedByJobsStub = nil
+	fake.buildIDsUsedByJobsReturns = struct {
+		result1 []int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeJob) BuildIDsUsedByJobsReturnsOnCall(i int, result1 []int, result2 error) {
+	fake.BuildIDsUsedByJobsStub = nil
+	if fake.buildIDsUsedByJobsReturnsOnCall == nil {
+		fake.buildIDsUsedByJobsReturnsOnCall = make(map[int]struct {
+			result1 []int
+			result2 error
+		})
+	}
+	fake.buildIDsUsedByJobsReturnsOnCall[i] = struct {
+		result1 []int
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeJob) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -1559,6 +1900,16 @@ func (fake *FakeJob) Invocations() map[string][][]interface{} {
 	defer fake.getRunningBuildsBySerialGroupMutex.RUnlock()
 	fake.getNextPendingBuildBySerialGroupMutex.RLock()
 	defer fake.getNextPendingBuildBySerialGroupMutex.RUnlock()
+	fake.rerunBuildMutex.RLock()
+	defer fake.rerunBuildMutex.RUnlock()
+	fake.createBuildWithInputsMutex.RLock()
+	defer fake.createBuildWithInputsMutex.RUnlock()
+	fake.unreapedBuildsMutex.RLock()
+	defer fake.unreapedBuildsMutex.RUnlock()
+	fake.latestSucceededBuildIDsMutex.RLock()
+	defer fake.latestSucceededBuildIDsMutex.RUnlock()
+	fake.buildIDsUsedByJobsMutex.RLock()
+	defer fake.buildIDsUsedByJobsMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
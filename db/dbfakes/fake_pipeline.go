@@ -514,6 +514,44 @@ type FakePipeline struct {
 		result1 db.Build
 		result2 error
 	}
+	ArchivedStub        func() bool
+	archivedMutex       sync.RWMutex
+	archivedArgsForCall []struct{}
+	archivedReturns     struct {
+		result1 bool
+	}
+	archivedReturnsOnCall map[int]struct {
+		result1 bool
+	}
+	ArchiveStub        func() error
+	archiveMutex       sync.RWMutex
+	archiveArgsForCall []struct{}
+	archiveReturns     struct {
+		result1 error
+	}
+	archiveReturnsOnCall map[int]struct {
+		result1 error
+	}
+	InstanceVarsStub        func() atc.InstanceVars
+	instanceVarsMutex       sync.RWMutex
+	instanceVarsArgsForCall []struct{}
+	instanceVarsReturns     struct {
+		result1 atc.InstanceVars
+	}
+	instanceVarsReturnsOnCall map[int]struct {
+		result1 atc.InstanceVars
+	}
+	NotificationsStub        func() ([]db.Notification, error)
+	notificationsMutex       sync.RWMutex
+	notificationsArgsForCall []struct{}
+	notificationsReturns     struct {
+		result1 []db.Notification
+		result2 error
+	}
+	notificationsReturnsOnCall map[int]struct {
+		result1 []db.Notification
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -2491,6 +2529,169 @@ func (fake *FakePipeline) CreateOneOffBuildReturnsOnCall(i int, result1 db.Build
 	}{result1, result2}
 }
 
+func (fake *FakePipeline) Archived() bool {
+	fake.archivedMutex.Lock()
+	ret, specificReturn := fake.archivedReturnsOnCall[len(fake.archivedArgsForCall)]
+	fake.archivedArgsForCall = append(fake.archivedArgsForCall, struct{}{})
+	fake.recordInvocation("Archived", []interface{}{})
+	fake.archivedMutex.Unlock()
+	if fake.ArchivedStub != nil {
+		return fake.ArchivedStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.archivedReturns.result1
+}
+
+func (fake *FakePipeline) ArchivedCallCount() int {
+	fake.archivedMutex.RLock()
+	defer fake.archivedMutex.RUnlock()
+	return len(fake.archivedArgsForCall)
+}
+
+func (fake *FakePipeline) ArchivedReturns(result1 bool) {
+	fake.ArchivedStub = nil
+	fake.archivedReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakePipeline) ArchivedReturnsOnCall(i int, result1 bool) {
+	fake.ArchivedStub = nil
+	if fake.archivedReturnsOnCall == nil {
+		fake.archivedReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.archivedReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakePipeline) Archive() error {
+	fake.archiveMutex.Lock()
+	ret, specificReturn := fake.archiveReturnsOnCall[len(fake.archiveArgsForCall)]
+	fake.archiveArgsForCall = append(fake.archiveArgsForCall, struct{}{})
+	fake.recordInvocation("Archive", []interface{}{})
+	fake.archiveMutex.Unlock()
+	if fake.ArchiveStub != nil {
+		return fake.ArchiveStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.archiveReturns.result1
+}
+
+func (fake *FakePipeline) ArchiveCallCount() int {
+	fake.archiveMutex.RLock()
+	defer fake.archiveMutex.RUnlock()
+	return len(fake.archiveArgsForCall)
+}
+
+func (fake *FakePipeline) ArchiveReturns(result1 error) {
+	fake.ArchiveStub = nil
+	fake.archiveReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePipeline) ArchiveReturnsOnCall(i int, result1 error) {
+	fake.ArchiveStub = nil
+	if fake.archiveReturnsOnCall == nil {
+		fake.archiveReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.archiveReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePipeline) InstanceVars() atc.InstanceVars {
+	fake.instanceVarsMutex.Lock()
+	ret, specificReturn := fake.instanceVarsReturnsOnCall[len(fake.instanceVarsArgsForCall)]
+	fake.instanceVarsArgsForCall = append(fake.instanceVarsArgsForCall, struct{}{})
+	fake.recordInvocation("InstanceVars", []interface{}{})
+	fake.instanceVarsMutex.Unlock()
+	if fake.InstanceVarsStub != nil {
+		return fake.InstanceVarsStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.instanceVarsReturns.result1
+}
+
+func (fake *FakePipeline) InstanceVarsCallCount() int {
+	fake.instanceVarsMutex.RLock()
+	defer fake.instanceVarsMutex.RUnlock()
+	return len(fake.instanceVarsArgsForCall)
+}
+
+func (fake *FakePipeline) InstanceVarsReturns(result1 atc.InstanceVars) {
+	fake.InstanceVarsStub = nil
+	fake.instanceVarsReturns = struct {
+		result1 atc.InstanceVars
+	}{result1}
+}
+
+func (fake *FakePipeline) InstanceVarsReturnsOnCall(i int, result1 atc.InstanceVars) {
+	fake.InstanceVarsStub = nil
+	if fake.instanceVarsReturnsOnCall == nil {
+		fake.instanceVarsReturnsOnCall = make(map[int]struct {
+			result1 atc.InstanceVars
+		})
+	}
+	fake.instanceVarsReturnsOnCall[i] = struct {
+		result1 atc.InstanceVars
+	}{result1}
+}
+
+func (fake *FakePipeline) Notifications() ([]db.Notification, error) {
+	fake.notificationsMutex.Lock()
+	ret, specificReturn := fake.notificationsReturnsOnCall[len(fake.notificationsArgsForCall)]
+	fake.notificationsArgsForCall = append(fake.notificationsArgsForCall, struct{}{})
+	fake.recordInvocation("Notifications", []interface{}{})
+	fake.notificationsMutex.Unlock()
+	if fake.NotificationsStub != nil {
+		return fake.NotificationsStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.notificationsReturns.result1, fake.notificationsReturns.result2
+}
+
+func (fake *FakePipeline) NotificationsCallCount() int {
+	fake.notificationsMutex.RLock()
+	defer fake.notificationsMutex.RUnlock()
+	return len(fake.notificationsArgsForCall)
+}
+
+func (fake *FakePipeline) NotificationsReturns(result1 []db.Notification, result2 error) {
+	fake.NotificationsStub = nil
+	fake.notificationsReturns = struct {
+		result1 []db.Notification
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipeline) NotificationsReturnsOnCall(i int, result1 []db.Notification, result2 error) {
+	fake.NotificationsStub = nil
+	if fake.notificationsReturnsOnCall == nil {
+		fake.notificationsReturnsOnCall = make(map[int]struct {
+			result1 []db.Notification
+			result2 error
+		})
+	}
+	fake.notificationsReturnsOnCall[i] = struct {
+		result1 []db.Notification
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakePipeline) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -2578,6 +2779,14 @@ func (fake *FakePipeline) Invocations() map[string][][]interface{} {
 	defer fake.renameMutex.RUnlock()
 	fake.createOneOffBuildMutex.RLock()
 	defer fake.createOneOffBuildMutex.RUnlock()
+	fake.archivedMutex.RLock()
+	defer fake.archivedMutex.RUnlock()
+	fake.archiveMutex.RLock()
+	defer fake.archiveMutex.RUnlock()
+	fake.instanceVarsMutex.RLock()
+	defer fake.instanceVarsMutex.RUnlock()
+	fake.notificationsMutex.RLock()
+	defer fake.notificationsMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
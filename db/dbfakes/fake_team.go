@@ -225,22 +225,6 @@ type FakeTeam struct {
 		result1 []db.Container
 		result2 error
 	}
-	FindCheckContainersStub        func(lager.Logger, string, string, creds.VariablesFactory) ([]db.Container, error)
-	findCheckContainersMutex       sync.RWMutex
-	findCheckContainersArgsForCall []struct {
-		arg1 lager.Logger
-		arg2 string
-		arg3 string
-		arg4 creds.VariablesFactory
-	}
-	findCheckContainersReturns struct {
-		result1 []db.Container
-		result2 error
-	}
-	findCheckContainersReturnsOnCall map[int]struct {
-		result1 []db.Container
-		result2 error
-	}
 	FindCreatedContainerByHandleStub        func(string) (db.CreatedContainer, bool, error)
 	findCreatedContainerByHandleMutex       sync.RWMutex
 	findCreatedContainerByHandleArgsForCall []struct {
@@ -353,6 +337,138 @@ type FakeTeam struct {
 		result1 db.Pipe
 		result2 error
 	}
+	SearchBuildLogsStub        func(db.BuildLogSearch, db.Page) ([]db.BuildLogMatch, db.Pagination, error)
+	searchBuildLogsMutex       sync.RWMutex
+	searchBuildLogsArgsForCall []struct {
+		arg1 db.BuildLogSearch
+		arg2 db.Page
+	}
+	searchBuildLogsReturns struct {
+		result1 []db.BuildLogMatch
+		result2 db.Pagination
+		result3 error
+	}
+	searchBuildLogsReturnsOnCall map[int]struct {
+		result1 []db.BuildLogMatch
+		result2 db.Pagination
+		result3 error
+	}
+	RolesStub        func() atc.TeamRoles
+	rolesMutex       sync.RWMutex
+	rolesArgsForCall []struct{}
+	rolesReturns     struct {
+		result1 atc.TeamRoles
+	}
+	rolesReturnsOnCall map[int]struct {
+		result1 atc.TeamRoles
+	}
+	UpdateRolesStub        func(roles atc.TeamRoles) error
+	updateRolesMutex       sync.RWMutex
+	updateRolesArgsForCall []struct {
+		roles atc.TeamRoles
+	}
+	updateRolesReturns struct {
+		result1 error
+	}
+	updateRolesReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SavePipelineInstanceStub        func(pipelineName string, instanceVars atc.InstanceVars, config atc.Config, from db.ConfigVersion, pausedState db.PipelinePausedState) (db.Pipeline, bool, error)
+	savePipelineInstanceMutex       sync.RWMutex
+	savePipelineInstanceArgsForCall []struct {
+		pipelineName string
+		instanceVars atc.InstanceVars
+		config       atc.Config
+		from         db.ConfigVersion
+		pausedState  db.PipelinePausedState
+	}
+	savePipelineInstanceReturns struct {
+		result1 db.Pipeline
+		result2 bool
+		result3 error
+	}
+	savePipelineInstanceReturnsOnCall map[int]struct {
+		result1 db.Pipeline
+		result2 bool
+		result3 error
+	}
+	PipelineInstanceStub        func(pipelineName string, instanceVars atc.InstanceVars) (db.Pipeline, bool, error)
+	pipelineInstanceMutex       sync.RWMutex
+	pipelineInstanceArgsForCall []struct {
+		pipelineName string
+		instanceVars atc.InstanceVars
+	}
+	pipelineInstanceReturns struct {
+		result1 db.Pipeline
+		result2 bool
+		result3 error
+	}
+	pipelineInstanceReturnsOnCall map[int]struct {
+		result1 db.Pipeline
+		result2 bool
+		result3 error
+	}
+	EventsStub        func(from int) (db.TeamEventSource, error)
+	eventsMutex       sync.RWMutex
+	eventsArgsForCall []struct {
+		from int
+	}
+	eventsReturns struct {
+		result1 db.TeamEventSource
+		result2 error
+	}
+	eventsReturnsOnCall map[int]struct {
+		result1 db.TeamEventSource
+		result2 error
+	}
+	PublicEventsStub        func(from int) (db.TeamEventSource, error)
+	publicEventsMutex       sync.RWMutex
+	publicEventsArgsForCall []struct {
+		from int
+	}
+	publicEventsReturns struct {
+		result1 db.TeamEventSource
+		result2 error
+	}
+	publicEventsReturnsOnCall map[int]struct {
+		result1 db.TeamEventSource
+		result2 error
+	}
+	ReserveContainerStub        func(workerName string, owner db.ContainerOwner, meta db.ContainerMetadata, maxContainers int) (db.CreatingContainer, bool, error)
+	reserveContainerMutex       sync.RWMutex
+	reserveContainerArgsForCall []struct {
+		workerName    string
+		owner         db.ContainerOwner
+		meta          db.ContainerMetadata
+		maxContainers int
+	}
+	reserveContainerReturns struct {
+		result1 db.CreatingContainer
+		result2 bool
+		result3 error
+	}
+	reserveContainerReturnsOnCall map[int]struct {
+		result1 db.CreatingContainer
+		result2 bool
+		result3 error
+	}
+	FindCheckContainersStub        func(arg1 lager.Logger, arg2 string, arg3 atc.InstanceVars, arg4 string, arg5 creds.VariablesFactory) ([]db.Container, error)
+	findCheckContainersMutex       sync.RWMutex
+	findCheckContainersArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 string
+		arg3 atc.InstanceVars
+		arg4 string
+		arg5 creds.VariablesFactory
+	}
+	findCheckContainersReturns struct {
+		result1 []db.Container
+		result2 error
+	}
+	findCheckContainersReturnsOnCall map[int]struct {
+		result1 []db.Container
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -1195,60 +1311,6 @@ func (fake *FakeTeam) FindContainersByMetadataReturnsOnCall(i int, result1 []db.
 	}{result1, result2}
 }
 
-func (fake *FakeTeam) FindCheckContainers(arg1 lager.Logger, arg2 string, arg3 string, arg4 creds.VariablesFactory) ([]db.Container, error) {
-	fake.findCheckContainersMutex.Lock()
-	ret, specificReturn := fake.findCheckContainersReturnsOnCall[len(fake.findCheckContainersArgsForCall)]
-	fake.findCheckContainersArgsForCall = append(fake.findCheckContainersArgsForCall, struct {
-		arg1 lager.Logger
-		arg2 string
-		arg3 string
-		arg4 creds.VariablesFactory
-	}{arg1, arg2, arg3, arg4})
-	fake.recordInvocation("FindCheckContainers", []interface{}{arg1, arg2, arg3, arg4})
-	fake.findCheckContainersMutex.Unlock()
-	if fake.FindCheckContainersStub != nil {
-		return fake.FindCheckContainersStub(arg1, arg2, arg3, arg4)
-	}
-	if specificReturn {
-		return ret.result1, ret.result2
-	}
-	return fake.findCheckContainersReturns.result1, fake.findCheckContainersReturns.result2
-}
-
-func (fake *FakeTeam) FindCheckContainersCallCount() int {
-	fake.findCheckContainersMutex.RLock()
-	defer fake.findCheckContainersMutex.RUnlock()
-	return len(fake.findCheckContainersArgsForCall)
-}
-
-func (fake *FakeTeam) FindCheckContainersArgsForCall(i int) (lager.Logger, string, string, creds.VariablesFactory) {
-	fake.findCheckContainersMutex.RLock()
-	defer fake.findCheckContainersMutex.RUnlock()
-	return fake.findCheckContainersArgsForCall[i].arg1, fake.findCheckContainersArgsForCall[i].arg2, fake.findCheckContainersArgsForCall[i].arg3, fake.findCheckContainersArgsForCall[i].arg4
-}
-
-func (fake *FakeTeam) FindCheckContainersReturns(result1 []db.Container, result2 error) {
-	fake.FindCheckContainersStub = nil
-	fake.findCheckContainersReturns = struct {
-		result1 []db.Container
-		result2 error
-	}{result1, result2}
-}
-
-func (fake *FakeTeam) FindCheckContainersReturnsOnCall(i int, result1 []db.Container, result2 error) {
-	fake.FindCheckContainersStub = nil
-	if fake.findCheckContainersReturnsOnCall == nil {
-		fake.findCheckContainersReturnsOnCall = make(map[int]struct {
-			result1 []db.Container
-			result2 error
-		})
-	}
-	fake.findCheckContainersReturnsOnCall[i] = struct {
-		result1 []db.Container
-		result2 error
-	}{result1, result2}
-}
-
 func (fake *FakeTeam) FindCreatedContainerByHandle(arg1 string) (db.CreatedContainer, bool, error) {
 	fake.findCreatedContainerByHandleMutex.Lock()
 	ret, specificReturn := fake.findCreatedContainerByHandleReturnsOnCall[len(fake.findCreatedContainerByHandleArgsForCall)]
@@ -1667,6 +1729,476 @@ func (fake *FakeTeam) GetPipeReturnsOnCall(i int, result1 db.Pipe, result2 error
 	}{result1, result2}
 }
 
+func (fake *FakeTeam) SearchBuildLogs(arg1 db.BuildLogSearch, arg2 db.Page) ([]db.BuildLogMatch, db.Pagination, error) {
+	fake.searchBuildLogsMutex.Lock()
+	ret, specificReturn := fake.searchBuildLogsReturnsOnCall[len(fake.searchBuildLogsArgsForCall)]
+	fake.searchBuildLogsArgsForCall = append(fake.searchBuildLogsArgsForCall, struct {
+		arg1 db.BuildLogSearch
+		arg2 db.Page
+	}{arg1, arg2})
+	fake.recordInvocation("SearchBuildLogs", []interface{}{arg1, arg2})
+	fake.searchBuildLogsMutex.Unlock()
+	if fake.SearchBuildLogsStub != nil {
+		return fake.SearchBuildLogsStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fake.searchBuildLogsReturns.result1, fake.searchBuildLogsReturns.result2, fake.searchBuildLogsReturns.result3
+}
+
+func (fake *FakeTeam) SearchBuildLogsCallCount() int {
+	fake.searchBuildLogsMutex.RLock()
+	defer fake.searchBuildLogsMutex.RUnlock()
+	return len(fake.searchBuildLogsArgsForCall)
+}
+
+func (fake *FakeTeam) SearchBuildLogsArgsForCall(i int) (db.BuildLogSearch, db.Page) {
+	fake.searchBuildLogsMutex.RLock()
+	defer fake.searchBuildLogsMutex.RUnlock()
+	return fake.searchBuildLogsArgsForCall[i].arg1, fake.searchBuildLogsArgsForCall[i].arg2
+}
+
+func (fake *FakeTeam) SearchBuildLogsReturns(result1 []db.BuildLogMatch, result2 db.Pagination, result3 error) {
+	fake.SearchBuildLogsStub = nil
+	fake.searchBuildLogsReturns = struct {
+		result1 []db.BuildLogMatch
+		result2 db.Pagination
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) SearchBuildLogsReturnsOnCall(i int, result1 []db.BuildLogMatch, result2 db.Pagination, result3 error) {
+	fake.SearchBuildLogsStub = nil
+	if fake.searchBuildLogsReturnsOnCall == nil {
+		fake.searchBuildLogsReturnsOnCall = make(map[int]struct {
+			result1 []db.BuildLogMatch
+			result2 db.Pagination
+			result3 error
+		})
+	}
+	fake.searchBuildLogsReturnsOnCall[i] = struct {
+		result1 []db.BuildLogMatch
+		result2 db.Pagination
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) Roles() atc.TeamRoles {
+	fake.rolesMutex.Lock()
+	ret, specificReturn := fake.rolesReturnsOnCall[len(fake.rolesArgsForCall)]
+	fake.rolesArgsForCall = append(fake.rolesArgsForCall, struct{}{})
+	fake.recordInvocation("Roles", []interface{}{})
+	fake.rolesMutex.Unlock()
+	if fake.RolesStub != nil {
+		return fake.RolesStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.rolesReturns.result1
+}
+
+func (fake *FakeTeam) RolesCallCount() int {
+	fake.rolesMutex.RLock()
+	defer fake.rolesMutex.RUnlock()
+	return len(fake.rolesArgsForCall)
+}
+
+func (fake *FakeTeam) RolesReturns(result1 atc.TeamRoles) {
+	fake.RolesStub = nil
+	fake.rolesReturns = struct {
+		result1 atc.TeamRoles
+	}{result1}
+}
+
+func (fake *FakeTeam) RolesReturnsOnCall(i int, result1 atc.TeamRoles) {
+	fake.RolesStub = nil
+	if fake.rolesReturnsOnCall == nil {
+		fake.rolesReturnsOnCall = make(map[int]struct {
+			result1 atc.TeamRoles
+		})
+	}
+	fake.rolesReturnsOnCall[i] = struct {
+		result1 atc.TeamRoles
+	}{result1}
+}
+
+func (fake *FakeTeam) UpdateRoles(roles atc.TeamRoles) error {
+	fake.updateRolesMutex.Lock()
+	ret, specificReturn := fake.updateRolesReturnsOnCall[len(fake.updateRolesArgsForCall)]
+	fake.updateRolesArgsForCall = append(fake.updateRolesArgsForCall, struct {
+		roles atc.TeamRoles
+	}{roles})
+	fake.recordInvocation("UpdateRoles", []interface{}{roles})
+	fake.updateRolesMutex.Unlock()
+	if fake.UpdateRolesStub != nil {
+		return fake.UpdateRolesStub(roles)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.updateRolesReturns.result1
+}
+
+func (fake *FakeTeam) UpdateRolesCallCount() int {
+	fake.updateRolesMutex.RLock()
+	defer fake.updateRolesMutex.RUnlock()
+	return len(fake.updateRolesArgsForCall)
+}
+
+func (fake *FakeTeam) UpdateRolesArgsForCall(i int) atc.TeamRoles {
+	fake.updateRolesMutex.RLock()
+	defer fake.updateRolesMutex.RUnlock()
+	return fake.updateRolesArgsForCall[i].roles
+}
+
+func (fake *FakeTeam) UpdateRolesReturns(result1 error) {
+	fake.UpdateRolesStub = nil
+	fake.updateRolesReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) UpdateRolesReturnsOnCall(i int, result1 error) {
+	fake.UpdateRolesStub = nil
+	if fake.updateRolesReturnsOnCall == nil {
+		fake.updateRolesReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateRolesReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) SavePipelineInstance(pipelineName string, instanceVars atc.InstanceVars, config atc.Config, from db.ConfigVersion, pausedState db.PipelinePausedState) (db.Pipeline, bool, error) {
+	fake.savePipelineInstanceMutex.Lock()
+	ret, specificReturn := fake.savePipelineInstanceReturnsOnCall[len(fake.savePipelineInstanceArgsForCall)]
+	fake.savePipelineInstanceArgsForCall = append(fake.savePipelineInstanceArgsForCall, struct {
+		pipelineName string
+		instanceVars atc.InstanceVars
+		config       atc.Config
+		from         db.ConfigVersion
+		pausedState  db.PipelinePausedState
+	}{pipelineName, instanceVars, config, from, pausedState})
+	fake.recordInvocation("SavePipelineInstance", []interface{}{pipelineName, instanceVars, config, from, pausedState})
+	fake.savePipelineInstanceMutex.Unlock()
+	if fake.SavePipelineInstanceStub != nil {
+		return fake.SavePipelineInstanceStub(pipelineName, instanceVars, config, from, pausedState)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fake.savePipelineInstanceReturns.result1, fake.savePipelineInstanceReturns.result2, fake.savePipelineInstanceReturns.result3
+}
+
+func (fake *FakeTeam) SavePipelineInstanceCallCount() int {
+	fake.savePipelineInstanceMutex.RLock()
+	defer fake.savePipelineInstanceMutex.RUnlock()
+	return len(fake.savePipelineInstanceArgsForCall)
+}
+
+func (fake *FakeTeam) SavePipelineInstanceArgsForCall(i int) (string, atc.InstanceVars, atc.Config, db.ConfigVersion, db.PipelinePausedState) {
+	fake.savePipelineInstanceMutex.RLock()
+	defer fake.savePipelineInstanceMutex.RUnlock()
+	return fake.savePipelineInstanceArgsForCall[i].pipelineName, fake.savePipelineInstanceArgsForCall[i].instanceVars, fake.savePipelineInstanceArgsForCall[i].config, fake.savePipelineInstanceArgsForCall[i].from, fake.savePipelineInstanceArgsForCall[i].pausedState
+}
+
+func (fake *FakeTeam) SavePipelineInstanceReturns(result1 db.Pipeline, result2 bool, result3 error) {
+	fake.SavePipelineInstanceStub = nil
+	fake.savePipelineInstanceReturns = struct {
+		result1 db.Pipeline
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) SavePipelineInstanceReturnsOnCall(i int, result1 db.Pipeline, result2 bool, result3 error) {
+	fake.SavePipelineInstanceStub = nil
+	if fake.savePipelineInstanceReturnsOnCall == nil {
+		fake.savePipelineInstanceReturnsOnCall = make(map[int]struct {
+			result1 db.Pipeline
+			result2 bool
+			result3 error
+		})
+	}
+	fake.savePipelineInstanceReturnsOnCall[i] = struct {
+		result1 db.Pipeline
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) PipelineInstance(pipelineName string, instanceVars atc.InstanceVars) (db.Pipeline, bool, error) {
+	fake.pipelineInstanceMutex.Lock()
+	ret, specificReturn := fake.pipelineInstanceReturnsOnCall[len(fake.pipelineInstanceArgsForCall)]
+	fake.pipelineInstanceArgsForCall = append(fake.pipelineInstanceArgsForCall, struct {
+		pipelineName string
+		instanceVars atc.InstanceVars
+	}{pipelineName, instanceVars})
+	fake.recordInvocation("PipelineInstance", []interface{}{pipelineName, instanceVars})
+	fake.pipelineInstanceMutex.Unlock()
+	if fake.PipelineInstanceStub != nil {
+		return fake.PipelineInstanceStub(pipelineName, instanceVars)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fake.pipelineInstanceReturns.result1, fake.pipelineInstanceReturns.result2, fake.pipelineInstanceReturns.result3
+}
+
+func (fake *FakeTeam) PipelineInstanceCallCount() int {
+	fake.pipelineInstanceMutex.RLock()
+	defer fake.pipelineInstanceMutex.RUnlock()
+	return len(fake.pipelineInstanceArgsForCall)
+}
+
+func (fake *FakeTeam) PipelineInstanceArgsForCall(i int) (string, atc.InstanceVars) {
+	fake.pipelineInstanceMutex.RLock()
+	defer fake.pipelineInstanceMutex.RUnlock()
+	return fake.pipelineInstanceArgsForCall[i].pipelineName, fake.pipelineInstanceArgsForCall[i].instanceVars
+}
+
+func (fake *FakeTeam) PipelineInstanceReturns(result1 db.Pipeline, result2 bool, result3 error) {
+	fake.PipelineInstanceStub = nil
+	fake.pipelineInstanceReturns = struct {
+		result1 db.Pipeline
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) PipelineInstanceReturnsOnCall(i int, result1 db.Pipeline, result2 bool, result3 error) {
+	fake.PipelineInstanceStub = nil
+	if fake.pipelineInstanceReturnsOnCall == nil {
+		fake.pipelineInstanceReturnsOnCall = make(map[int]struct {
+			result1 db.Pipeline
+			result2 bool
+			result3 error
+		})
+	}
+	fake.pipelineInstanceReturnsOnCall[i] = struct {
+		result1 db.Pipeline
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) Events(from int) (db.TeamEventSource, error) {
+	fake.eventsMutex.Lock()
+	ret, specificReturn := fake.eventsReturnsOnCall[len(fake.eventsArgsForCall)]
+	fake.eventsArgsForCall = append(fake.eventsArgsForCall, struct {
+		from int
+	}{from})
+	fake.recordInvocation("Events", []interface{}{from})
+	fake.eventsMutex.Unlock()
+	if fake.EventsStub != nil {
+		return fake.EventsStub(from)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.eventsReturns.result1, fake.eventsReturns.result2
+}
+
+func (fake *FakeTeam) EventsCallCount() int {
+	fake.eventsMutex.RLock()
+	defer fake.eventsMutex.RUnlock()
+	return len(fake.eventsArgsForCall)
+}
+
+func (fake *FakeTeam) EventsArgsForCall(i int) int {
+	fake.eventsMutex.RLock()
+	defer fake.eventsMutex.RUnlock()
+	return fake.eventsArgsForCall[i].from
+}
+
+func (fake *FakeTeam) EventsReturns(result1 db.TeamEventSource, result2 error) {
+	fake.EventsStub = nil
+	fake.eventsReturns = struct {
+		result1 db.TeamEventSource
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) EventsReturnsOnCall(i int, result1 db.TeamEventSource, result2 error) {
+	fake.EventsStub = nil
+	if fake.eventsReturnsOnCall == nil {
+		fake.eventsReturnsOnCall = make(map[int]struct {
+			result1 db.TeamEventSource
+			result2 error
+		})
+	}
+	fake.eventsReturnsOnCall[i] = struct {
+		result1 db.TeamEventSource
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) PublicEvents(from int) (db.TeamEventSource, error) {
+	fake.publicEventsMutex.Lock()
+	ret, specificReturn := fake.publicEventsReturnsOnCall[len(fake.publicEventsArgsForCall)]
+	fake.publicEventsArgsForCall = append(fake.publicEventsArgsForCall, struct {
+		from int
+	}{from})
+	fake.recordInvocation("PublicEvents", []interface{}{from})
+	fake.publicEventsMutex.Unlock()
+	if fake.PublicEventsStub != nil {
+		return fake.PublicEventsStub(from)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.publicEventsReturns.result1, fake.publicEventsReturns.result2
+}
+
+func (fake *FakeTeam) PublicEventsCallCount() int {
+	fake.publicEventsMutex.RLock()
+	defer fake.publicEventsMutex.RUnlock()
+	return len(fake.publicEventsArgsForCall)
+}
+
+func (fake *FakeTeam) PublicEventsArgsForCall(i int) int {
+	fake.publicEventsMutex.RLock()
+	defer fake.publicEventsMutex.RUnlock()
+	return fake.publicEventsArgsForCall[i].from
+}
+
+func (fake *FakeTeam) PublicEventsReturns(result1 db.TeamEventSource, result2 error) {
+	fake.PublicEventsStub = nil
+	fake.publicEventsReturns = struct {
+		result1 db.TeamEventSource
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) PublicEventsReturnsOnCall(i int, result1 db.TeamEventSource, result2 error) {
+	fake.PublicEventsStub = nil
+	if fake.publicEventsReturnsOnCall == nil {
+		fake.publicEventsReturnsOnCall = make(map[int]struct {
+			result1 db.TeamEventSource
+			result2 error
+		})
+	}
+	fake.publicEventsReturnsOnCall[i] = struct {
+		result1 db.TeamEventSource
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) ReserveContainer(workerName string, owner db.ContainerOwner, meta db.ContainerMetadata, maxContainers int) (db.CreatingContainer, bool, error) {
+	fake.reserveContainerMutex.Lock()
+	ret, specificReturn := fake.reserveContainerReturnsOnCall[len(fake.reserveContainerArgsForCall)]
+	fake.reserveContainerArgsForCall = append(fake.reserveContainerArgsForCall, struct {
+		workerName    string
+		owner         db.ContainerOwner
+		meta          db.ContainerMetadata
+		maxContainers int
+	}{workerName, owner, meta, maxContainers})
+	fake.recordInvocation("ReserveContainer", []interface{}{workerName, owner, meta, maxContainers})
+	fake.reserveContainerMutex.Unlock()
+	if fake.ReserveContainerStub != nil {
+		return fake.ReserveContainerStub(workerName, owner, meta, maxContainers)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fake.reserveContainerReturns.result1, fake.reserveContainerReturns.result2, fake.reserveContainerReturns.result3
+}
+
+func (fake *FakeTeam) ReserveContainerCallCount() int {
+	fake.reserveContainerMutex.RLock()
+	defer fake.reserveContainerMutex.RUnlock()
+	return len(fake.reserveContainerArgsForCall)
+}
+
+func (fake *FakeTeam) ReserveContainerArgsForCall(i int) (string, db.ContainerOwner, db.ContainerMetadata, int) {
+	fake.reserveContainerMutex.RLock()
+	defer fake.reserveContainerMutex.RUnlock()
+	return fake.reserveContainerArgsForCall[i].workerName, fake.reserveContainerArgsForCall[i].owner, fake.reserveContainerArgsForCall[i].meta, fake.reserveContainerArgsForCall[i].maxContainers
+}
+
+func (fake *FakeTeam) ReserveContainerReturns(result1 db.CreatingContainer, result2 bool, result3 error) {
+	fake.ReserveContainerStub = nil
+	fake.reserveContainerReturns = struct {
+		result1 db.CreatingContainer
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) ReserveContainerReturnsOnCall(i int, result1 db.CreatingContainer, result2 bool, result3 error) {
+	fake.ReserveContainerStub = nil
+	if fake.reserveContainerReturnsOnCall == nil {
+		fake.reserveContainerReturnsOnCall = make(map[int]struct {
+			result1 db.CreatingContainer
+			result2 bool
+			result3 error
+		})
+	}
+	fake.reserveContainerReturnsOnCall[i] = struct {
+		result1 db.CreatingContainer
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) FindCheckContainers(arg1 lager.Logger, arg2 string, arg3 atc.InstanceVars, arg4 string, arg5 creds.VariablesFactory) ([]db.Container, error) {
+	fake.findCheckContainersMutex.Lock()
+	ret, specificReturn := fake.findCheckContainersReturnsOnCall[len(fake.findCheckContainersArgsForCall)]
+	fake.findCheckContainersArgsForCall = append(fake.findCheckContainersArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 string
+		arg3 atc.InstanceVars
+		arg4 string
+		arg5 creds.VariablesFactory
+	}{arg1, arg2, arg3, arg4, arg5})
+	fake.recordInvocation("FindCheckContainers", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.findCheckContainersMutex.Unlock()
+	if fake.FindCheckContainersStub != nil {
+		return fake.FindCheckContainersStub(arg1, arg2, arg3, arg4, arg5)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.findCheckContainersReturns.result1, fake.findCheckContainersReturns.result2
+}
+
+func (fake *FakeTeam) FindCheckContainersCallCount() int {
+	fake.findCheckContainersMutex.RLock()
+	defer fake.findCheckContainersMutex.RUnlock()
+	return len(fake.findCheckContainersArgsForCall)
+}
+
+func (fake *FakeTeam) FindCheckContainersArgsForCall(i int) (lager.Logger, string, atc.InstanceVars, string, creds.VariablesFactory) {
+	fake.findCheckContainersMutex.RLock()
+	defer fake.findCheckContainersMutex.RUnlock()
+	return fake.findCheckContainersArgsForCall[i].arg1, fake.findCheckContainersArgsForCall[i].arg2, fake.findCheckContainersArgsForCall[i].arg3, fake.findCheckContainersArgsForCall[i].arg4, fake.findCheckContainersArgsForCall[i].arg5
+}
+
+func (fake *FakeTeam) FindCheckContainersReturns(result1 []db.Container, result2 error) {
+	fake.FindCheckContainersStub = nil
+	fake.findCheckContainersReturns = struct {
+		result1 []db.Container
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) FindCheckContainersReturnsOnCall(i int, result1 []db.Container, result2 error) {
+	fake.FindCheckContainersStub = nil
+	if fake.findCheckContainersReturnsOnCall == nil {
+		fake.findCheckContainersReturnsOnCall = make(map[int]struct {
+			result1 []db.Container
+			result2 error
+		})
+	}
+	fake.findCheckContainersReturnsOnCall[i] = struct {
+		result1 []db.Container
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -1706,8 +2238,6 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.findContainerByHandleMutex.RUnlock()
 	fake.findContainersByMetadataMutex.RLock()
 	defer fake.findContainersByMetadataMutex.RUnlock()
-	fake.findCheckContainersMutex.RLock()
-	defer fake.findCheckContainersMutex.RUnlock()
 	fake.findCreatedContainerByHandleMutex.RLock()
 	defer fake.findCreatedContainerByHandleMutex.RUnlock()
 	fake.findWorkerForContainerMutex.RLock()
@@ -1724,6 +2254,24 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.createPipeMutex.RUnlock()
 	fake.getPipeMutex.RLock()
 	defer fake.getPipeMutex.RUnlock()
+	fake.searchBuildLogsMutex.RLock()
+	defer fake.searchBuildLogsMutex.RUnlock()
+	fake.rolesMutex.RLock()
+	defer fake.rolesMutex.RUnlock()
+	fake.updateRolesMutex.RLock()
+	defer fake.updateRolesMutex.RUnlock()
+	fake.savePipelineInstanceMutex.RLock()
+	defer fake.savePipelineInstanceMutex.RUnlock()
+	fake.pipelineInstanceMutex.RLock()
+	defer fake.pipelineInstanceMutex.RUnlock()
+	fake.eventsMutex.RLock()
+	defer fake.eventsMutex.RUnlock()
+	fake.publicEventsMutex.RLock()
+	defer fake.publicEventsMutex.RUnlock()
+	fake.reserveContainerMutex.RLock()
+	defer fake.reserveContainerMutex.RUnlock()
+	fake.findCheckContainersMutex.RLock()
+	defer fake.findCheckContainersMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
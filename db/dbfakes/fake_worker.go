@@ -223,6 +223,24 @@ type FakeWorker struct {
 	deleteReturnsOnCall map[int]struct {
 		result1 error
 	}
+	ActiveVolumesStub        func() int
+	activeVolumesMutex       sync.RWMutex
+	activeVolumesArgsForCall []struct{}
+	activeVolumesReturns     struct {
+		result1 int
+	}
+	activeVolumesReturnsOnCall map[int]struct {
+		result1 int
+	}
+	MaxContainersStub        func() int
+	maxContainersMutex       sync.RWMutex
+	maxContainersArgsForCall []struct{}
+	maxContainersReturns     struct {
+		result1 int
+	}
+	maxContainersReturnsOnCall map[int]struct {
+		result1 int
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -1156,6 +1174,86 @@ func (fake *FakeWorker) DeleteReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeWorker) ActiveVolumes() int {
+	fake.activeVolumesMutex.Lock()
+	ret, specificReturn := fake.activeVolumesReturnsOnCall[len(fake.activeVolumesArgsForCall)]
+	fake.activeVolumesArgsForCall = append(fake.activeVolumesArgsForCall, struct{}{})
+	fake.recordInvocation("ActiveVolumes", []interface{}{})
+	fake.activeVolumesMutex.Unlock()
+	if fake.ActiveVolumesStub != nil {
+		return fake.ActiveVolumesStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.activeVolumesReturns.result1
+}
+
+func (fake *FakeWorker) ActiveVolumesCallCount() int {
+	fake.activeVolumesMutex.RLock()
+	defer fake.activeVolumesMutex.RUnlock()
+	return len(fake.activeVolumesArgsForCall)
+}
+
+func (fake *FakeWorker) ActiveVolumesReturns(result1 int) {
+	fake.ActiveVolumesStub = nil
+	fake.activeVolumesReturns = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeWorker) ActiveVolumesReturnsOnCall(i int, result1 int) {
+	fake.ActiveVolumesStub = nil
+	if fake.activeVolumesReturnsOnCall == nil {
+		fake.activeVolumesReturnsOnCall = make(map[int]struct {
+			result1 int
+		})
+	}
+	fake.activeVolumesReturnsOnCall[i] = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeWorker) MaxContainers() int {
+	fake.maxContainersMutex.Lock()
+	ret, specificReturn := fake.maxContainersReturnsOnCall[len(fake.maxContainersArgsForCall)]
+	fake.maxContainersArgsForCall = append(fake.maxContainersArgsForCall, struct{}{})
+	fake.recordInvocation("MaxContainers", []interface{}{})
+	fake.maxContainersMutex.Unlock()
+	if fake.MaxContainersStub != nil {
+		return fake.MaxContainersStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.maxContainersReturns.result1
+}
+
+func (fake *FakeWorker) MaxContainersCallCount() int {
+	fake.maxContainersMutex.RLock()
+	defer fake.maxContainersMutex.RUnlock()
+	return len(fake.maxContainersArgsForCall)
+}
+
+func (fake *FakeWorker) MaxContainersReturns(result1 int) {
+	fake.MaxContainersStub = nil
+	fake.maxContainersReturns = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeWorker) MaxContainersReturnsOnCall(i int, result1 int) {
+	fake.MaxContainersStub = nil
+	if fake.maxContainersReturnsOnCall == nil {
+		fake.maxContainersReturnsOnCall = make(map[int]struct {
+			result1 int
+		})
+	}
+	fake.maxContainersReturnsOnCall[i] = struct {
+		result1 int
+	}{result1}
+}
+
 func (fake *FakeWorker) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -1205,6 +1303,10 @@ func (fake *FakeWorker) Invocations() map[string][][]interface{} {
 	defer fake.pruneMutex.RUnlock()
 	fake.deleteMutex.RLock()
 	defer fake.deleteMutex.RUnlock()
+	fake.activeVolumesMutex.RLock()
+	defer fake.activeVolumesMutex.RUnlock()
+	fake.maxContainersMutex.RLock()
+	defer fake.maxContainersMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
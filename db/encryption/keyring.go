@@ -0,0 +1,46 @@
+package encryption
+
+// A Keyring encrypts with its current key and decrypts with whichever of its
+// keys can, so that data encrypted with old keys stays readable while it is
+// re-encrypted with the current one.
+type Keyring struct {
+	current Strategy
+	old     []Strategy
+}
+
+func NewKeyring(current Strategy, old ...Strategy) *Keyring {
+	return &Keyring{
+		current: current,
+		old:     old,
+	}
+}
+
+func (k *Keyring) Current() Strategy {
+	return k.current
+}
+
+func (k *Keyring) Old() []Strategy {
+	return k.old
+}
+
+func (k *Keyring) Encrypt(plaintext []byte) (string, *string, error) {
+	return k.current.Encrypt(plaintext)
+}
+
+// Decrypt tries the current key first. If none of the keys can decrypt the
+// text, the current key's error is returned.
+func (k *Keyring) Decrypt(text string, nonce *string) ([]byte, error) {
+	plaintext, err := k.current.Decrypt(text, nonce)
+	if err == nil {
+		return plaintext, nil
+	}
+
+	for _, key := range k.old {
+		plaintext, oldErr := key.Decrypt(text, nonce)
+		if oldErr == nil {
+			return plaintext, nil
+		}
+	}
+
+	return nil, err
+}
@@ -0,0 +1,74 @@
+package encryption_test
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+
+	"github.com/concourse/atc/db/encryption"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Keyring", func() {
+	var (
+		currentKey *encryption.Key
+		oldKey     *encryption.Key
+		keyring    *encryption.Keyring
+	)
+
+	newKey := func(k string) *encryption.Key {
+		block, err := aes.NewCipher([]byte(k))
+		Expect(err).ToNot(HaveOccurred())
+
+		aesgcm, err := cipher.NewGCM(block)
+		Expect(err).ToNot(HaveOccurred())
+
+		return encryption.NewKey(aesgcm)
+	}
+
+	BeforeEach(func() {
+		currentKey = newKey("AES256Key-32Characters1234567890")
+		oldKey = newKey("AES256Key-32Characters9564567123")
+
+		keyring = encryption.NewKeyring(currentKey, oldKey, encryption.NewNoEncryption())
+	})
+
+	It("encrypts with the current key", func() {
+		encryptedText, nonce, err := keyring.Encrypt([]byte("exampleplaintext"))
+		Expect(err).ToNot(HaveOccurred())
+
+		decryptedText, err := currentKey.Decrypt(encryptedText, nonce)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(decryptedText).To(Equal([]byte("exampleplaintext")))
+	})
+
+	It("decrypts text encrypted with an old key", func() {
+		encryptedText, nonce, err := oldKey.Encrypt([]byte("exampleplaintext"))
+		Expect(err).ToNot(HaveOccurred())
+
+		decryptedText, err := keyring.Decrypt(encryptedText, nonce)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(decryptedText).To(Equal([]byte("exampleplaintext")))
+	})
+
+	It("decrypts plaintext when it has no encryption as an old key", func() {
+		decryptedText, err := keyring.Decrypt("exampleplaintext", nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(decryptedText).To(Equal([]byte("exampleplaintext")))
+	})
+
+	Context("when none of the keys can decrypt the text", func() {
+		It("returns the current key's error", func() {
+			unknownKey := newKey("AES256Key-32Characters0000000000")
+
+			encryptedText, nonce, err := unknownKey.Encrypt([]byte("exampleplaintext"))
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = keyring.Decrypt(encryptedText, nonce)
+			Expect(err).To(HaveOccurred())
+
+			_, currentErr := currentKey.Decrypt(encryptedText, nonce)
+			Expect(err).To(Equal(currentErr))
+		})
+	})
+})
@@ -4,11 +4,13 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db/algorithm"
 	"github.com/concourse/atc/db/lock"
+	"github.com/concourse/atc/event"
 )
 
 //go:generate counterfeiter . Job
@@ -30,10 +32,15 @@ type Job interface {
 	Unpause() error
 
 	CreateBuild() (Build, error)
+	RerunBuild(build Build) (Build, error)
+	CreateBuildWithInputs(inputMapping algorithm.InputMapping, overrides []atc.BuildInputOverride) (Build, error)
 	Builds(page Page) ([]Build, Pagination, error)
 	Build(name string) (Build, bool, error)
 	FinishedAndNextBuild() (Build, Build, error)
 	UpdateFirstLoggedBuildID(newFirstLoggedBuildID int) error
+	UnreapedBuilds(afterID int, limit int) ([]Build, error)
+	LatestSucceededBuildIDs(limit int) ([]int, error)
+	BuildIDsUsedByJobs(buildIDs []int, jobIDs []int) ([]int, error)
 	EnsurePendingBuildExists() error
 	GetPendingBuilds() ([]Build, error)
 
@@ -178,8 +185,109 @@ func (j *job) UpdateFirstLoggedBuildID(newFirstLoggedBuildID int) error {
 	return nil
 }
 
+// UnreapedBuilds returns the builds of the job whose logs have not been
+// reaped, oldest first, starting after the given build ID.
+func (j *job) UnreapedBuilds(afterID int, limit int) ([]Build, error) {
+	rows, err := buildsQuery.
+		Where(sq.Eq{
+			"b.job_id":    j.id,
+			"b.reap_time": nil,
+		}).
+		Where(sq.Gt{"b.id": afterID}).
+		OrderBy("b.id ASC").
+		Limit(uint64(limit)).
+		RunWith(j.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	builds := []Build{}
+
+	for rows.Next() {
+		build := &build{conn: j.conn, lockFactory: j.lockFactory}
+		err = scanBuild(build, rows, j.conn.EncryptionStrategy())
+		if err != nil {
+			return nil, err
+		}
+
+		builds = append(builds, build)
+	}
+
+	return builds, nil
+}
+
+// LatestSucceededBuildIDs returns the IDs of the job's most recent succeeded
+// builds, newest first.
+func (j *job) LatestSucceededBuildIDs(limit int) ([]int, error) {
+	rows, err := psql.Select("id").
+		From("builds").
+		Where(sq.Eq{
+			"job_id": j.id,
+			"status": BuildStatusSucceeded,
+		}).
+		OrderBy("id DESC").
+		Limit(uint64(limit)).
+		RunWith(j.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	return scanBuildIDs(rows)
+}
+
+// BuildIDsUsedByJobs returns those of the given builds of the job which have
+// an output that was used as an input by a build of one of the given jobs,
+// as long as that build still has its logs.
+func (j *job) BuildIDsUsedByJobs(buildIDs []int, jobIDs []int) ([]int, error) {
+	if len(buildIDs) == 0 || len(jobIDs) == 0 {
+		return []int{}, nil
+	}
+
+	rows, err := psql.Select("DISTINCT o.build_id").
+		From("build_outputs o").
+		Join("build_inputs i ON i.versioned_resource_id = o.versioned_resource_id").
+		Join("builds b ON b.id = i.build_id").
+		Where(sq.Eq{
+			"o.build_id":  buildIDs,
+			"b.job_id":    jobIDs,
+			"b.reap_time": nil,
+		}).
+		RunWith(j.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	return scanBuildIDs(rows)
+}
+
+func scanBuildIDs(rows *sql.Rows) ([]int, error) {
+	defer Close(rows)
+
+	ids := []int{}
+
+	for rows.Next() {
+		var id int
+		err := rows.Scan(&id)
+		if err != nil {
+			return nil, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
 func (j *job) Builds(page Page) ([]Build, Pagination, error) {
-	query := buildsQuery.Where(sq.Eq{"j.id": j.id})
+	query, err := filterBuildsByMetadata(buildsQuery.Where(sq.Eq{"j.id": j.id}), page.Metadata)
+	if err != nil {
+		return nil, Pagination{}, err
+	}
 
 	limit := uint64(page.Limit)
 
@@ -242,15 +350,17 @@ func (j *job) Builds(page Page) ([]Build, Pagination, error) {
 
 	if firstBuild.ID() < maxID {
 		pagination.Previous = &Page{
-			Until: firstBuild.ID(),
-			Limit: page.Limit,
+			Until:    firstBuild.ID(),
+			Limit:    page.Limit,
+			Metadata: page.Metadata,
 		}
 	}
 
 	if lastBuild.ID() > minID {
 		pagination.Next = &Page{
-			Since: lastBuild.ID(),
-			Limit: page.Limit,
+			Since:    lastBuild.ID(),
+			Limit:    page.Limit,
+			Metadata: page.Metadata,
 		}
 	}
 
@@ -578,6 +688,138 @@ func (j *job) CreateBuild() (Build, error) {
 	return build, nil
 }
 
+// CreateBuildWithInputs creates a manually triggered pending build which uses
+// the versions in the input mapping rather than the next inputs of the job. The
+// overrides the versions were chosen with are recorded on the build.
+func (j *job) CreateBuildWithInputs(inputMapping algorithm.InputMapping, overrides []atc.BuildInputOverride) (Build, error) {
+	overridesPayload, err := json.Marshal(overrides)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := j.conn.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Rollback(tx)
+
+	buildName, err := j.getNewBuildName(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	build := &build{conn: j.conn, lockFactory: j.lockFactory}
+	err = createBuild(tx, build, map[string]interface{}{
+		"name":               buildName,
+		"job_id":             j.id,
+		"pipeline_id":        j.pipelineID,
+		"team_id":            j.teamID,
+		"status":             BuildStatusPending,
+		"manually_triggered": true,
+		"input_overrides":    string(overridesPayload),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for inputName, inputVersion := range inputMapping {
+		_, err = psql.Insert("build_inputs").
+			Columns("build_id", "versioned_resource_id", "name").
+			Values(build.ID(), inputVersion.VersionID, inputName).
+			RunWith(tx).
+			Exec()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = j.conn.Exec(`REFRESH MATERIALIZED VIEW CONCURRENTLY next_builds_per_job`)
+	if err != nil {
+		return nil, err
+	}
+
+	return build, nil
+}
+
+// RerunBuild creates a pending build which uses the same input versions as the
+// given build. The new build is named after the build it re-runs, e.g. 42.1
+// for the first re-run of build 42. Re-running a re-run re-runs the original
+// build.
+func (j *job) RerunBuild(rerunBuild Build) (Build, error) {
+	originalID := rerunBuild.ID()
+	if rerunBuild.RerunOf() != 0 {
+		originalID = rerunBuild.RerunOf()
+	}
+
+	tx, err := j.conn.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Rollback(tx)
+
+	var originalName string
+	var rerunNumber int
+	err = psql.Update("builds").
+		Set("rerun_number", sq.Expr("rerun_number + 1")).
+		Where(sq.Eq{
+			"id":     originalID,
+			"job_id": j.id,
+		}).
+		Suffix("RETURNING name, rerun_number").
+		RunWith(tx).
+		QueryRow().
+		Scan(&originalName, &rerunNumber)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrBuildDisappeared
+		}
+		return nil, err
+	}
+
+	build := &build{conn: j.conn, lockFactory: j.lockFactory}
+	err = createBuild(tx, build, map[string]interface{}{
+		"name":               fmt.Sprintf("%s.%d", originalName, rerunNumber),
+		"job_id":             j.id,
+		"pipeline_id":        j.pipelineID,
+		"team_id":            j.teamID,
+		"status":             BuildStatusPending,
+		"manually_triggered": true,
+		"rerun_of":           originalID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO build_inputs (build_id, versioned_resource_id, name)
+		SELECT $1, versioned_resource_id, name
+		FROM build_inputs
+		WHERE build_id = $2
+	`, build.ID(), originalID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = j.conn.Exec(`REFRESH MATERIALIZED VIEW CONCURRENTLY next_builds_per_job`)
+	if err != nil {
+		return nil, err
+	}
+
+	return build, nil
+}
+
 func (j *job) updateSerialGroups(serialGroups []string) error {
 	tx, err := j.conn.Begin()
 	if err != nil {
@@ -610,10 +852,17 @@ func (j *job) updateSerialGroups(serialGroups []string) error {
 }
 
 func (j *job) updatePausedJob(pause bool) error {
+	tx, err := j.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer Rollback(tx)
+
 	result, err := psql.Update("jobs").
 		Set("paused", pause).
 		Where(sq.Eq{"id": j.id}).
-		RunWith(j.conn).
+		RunWith(tx).
 		Exec()
 	if err != nil {
 		return err
@@ -628,7 +877,18 @@ func (j *job) updatePausedJob(pause bool) error {
 		return nonOneRowAffectedError{rowsAffected}
 	}
 
-	return nil
+	err = saveTeamEvent(tx, j.teamID, j.pipelineID, event.JobStatus{
+		Time:         time.Now().Unix(),
+		PipelineID:   j.pipelineID,
+		PipelineName: j.pipelineName,
+		JobName:      j.name,
+		Paused:       pause,
+	})
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
 }
 
 func (j *job) getBuildInputs(table string) ([]BuildInput, error) {
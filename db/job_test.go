@@ -244,6 +244,113 @@ var _ = Describe("Job", func() {
 		})
 	})
 
+	Describe("finding builds to reap", func() {
+		var builds [4]db.Build
+
+		BeforeEach(func() {
+			for i := range builds {
+				var err error
+				builds[i], err = job.CreateBuild()
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			err := builds[0].Finish(db.BuildStatusSucceeded)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = builds[1].Finish(db.BuildStatusFailed)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = builds[2].Finish(db.BuildStatusSucceeded)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Describe("UnreapedBuilds", func() {
+			BeforeEach(func() {
+				err := pipeline.DeleteBuildEventsByBuildIDs([]int{builds[1].ID()})
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns the builds after the given build which still have their logs, oldest first", func() {
+				unreapedBuilds, err := job.UnreapedBuilds(builds[0].ID(), 10)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(unreapedBuilds).To(HaveLen(2))
+				Expect(unreapedBuilds[0].ID()).To(Equal(builds[2].ID()))
+				Expect(unreapedBuilds[1].ID()).To(Equal(builds[3].ID()))
+
+				unreapedBuilds, err = job.UnreapedBuilds(0, 1)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(unreapedBuilds).To(HaveLen(1))
+				Expect(unreapedBuilds[0].ID()).To(Equal(builds[0].ID()))
+			})
+		})
+
+		Describe("LatestSucceededBuildIDs", func() {
+			It("returns the most recent succeeded builds, newest first", func() {
+				ids, err := job.LatestSucceededBuildIDs(10)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ids).To(Equal([]int{builds[2].ID(), builds[0].ID()}))
+
+				ids, err = job.LatestSucceededBuildIDs(1)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ids).To(Equal([]int{builds[2].ID()}))
+			})
+		})
+
+		Describe("BuildIDsUsedByJobs", func() {
+			var otherJob db.Job
+			var otherBuild db.Build
+
+			BeforeEach(func() {
+				var found bool
+				var err error
+				otherJob, found, err = pipeline.Job("some-other-job")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				vr := db.VersionedResource{
+					Resource: "some-resource",
+					Type:     "some-type",
+					Version:  db.ResourceVersion{"ver": "1"},
+				}
+
+				err = builds[0].SaveOutput(vr, true)
+				Expect(err).NotTo(HaveOccurred())
+
+				otherBuild, err = otherJob.CreateBuild()
+				Expect(err).NotTo(HaveOccurred())
+
+				err = otherBuild.SaveInput(db.BuildInput{
+					Name:              "some-input",
+					VersionedResource: vr,
+				})
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns the builds with an output used as an input by the jobs", func() {
+				ids, err := job.BuildIDsUsedByJobs([]int{builds[0].ID(), builds[1].ID()}, []int{otherJob.ID()})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ids).To(Equal([]int{builds[0].ID()}))
+
+				ids, err = job.BuildIDsUsedByJobs([]int{builds[0].ID(), builds[1].ID()}, []int{job.ID()})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ids).To(BeEmpty())
+			})
+
+			Context("when the build which used the output has been reaped", func() {
+				BeforeEach(func() {
+					err := pipeline.DeleteBuildEventsByBuildIDs([]int{otherBuild.ID()})
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("does not return the build", func() {
+					ids, err := job.BuildIDsUsedByJobs([]int{builds[0].ID()}, []int{otherJob.ID()})
+					Expect(err).NotTo(HaveOccurred())
+					Expect(ids).To(BeEmpty())
+				})
+			})
+		})
+	})
+
 	Context("Builds", func() {
 		var (
 			builds       [10]db.Build
@@ -331,6 +438,37 @@ var _ = Describe("Job", func() {
 				Expect(pagination.Next).To(Equal(&db.Page{Since: builds[8].ID(), Limit: 2}))
 			})
 		})
+
+		Context("with a metadata filter", func() {
+			BeforeEach(func() {
+				err := builds[3].SaveMetadata(map[string]string{"release": "1.2.3"})
+				Expect(err).NotTo(HaveOccurred())
+
+				err = builds[5].SaveMetadata(map[string]string{"release": "1.2.3", "sha": "abc123"})
+				Expect(err).NotTo(HaveOccurred())
+
+				err = builds[7].SaveMetadata(map[string]string{"release": "1.2.4"})
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns only the builds with all of the given metadata", func() {
+				buildsPage, _, err := someJob.Builds(db.Page{Limit: 10, Metadata: map[string]string{"release": "1.2.3"}})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(buildsPage).To(Equal([]db.Build{builds[5], builds[3]}))
+
+				buildsPage, _, err = someJob.Builds(db.Page{Limit: 10, Metadata: map[string]string{"release": "1.2.3", "sha": "abc123"}})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(buildsPage).To(Equal([]db.Build{builds[5]}))
+			})
+
+			It("keeps the filter for the previous/next pages", func() {
+				metadata := map[string]string{"release": "1.2.3"}
+
+				_, pagination, err := someJob.Builds(db.Page{Limit: 1, Metadata: metadata})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(pagination.Next).To(Equal(&db.Page{Since: builds[5].ID(), Limit: 1, Metadata: metadata}))
+			})
+		})
 	})
 
 	Describe("Build", func() {
@@ -969,6 +1107,178 @@ var _ = Describe("Job", func() {
 		})
 	})
 
+	Describe("CreateBuildWithInputs", func() {
+		var (
+			savedVersion db.SavedVersionedResource
+			overrides    []atc.BuildInputOverride
+			build        db.Build
+		)
+
+		BeforeEach(func() {
+			err := pipeline.SaveResourceVersions(
+				atc.ResourceConfig{Name: "some-resource", Type: "some-type"},
+				[]atc.Version{{"version": "v1"}, {"version": "v2"}},
+			)
+			Expect(err).NotTo(HaveOccurred())
+
+			var found bool
+			savedVersion, found, err = pipeline.GetVersionedResourceByVersion(atc.Version{"version": "v1"}, "some-resource")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			overrides = []atc.BuildInputOverride{
+				{Name: "some-input", Version: atc.Version{"version": "v1"}, IgnorePassed: true},
+			}
+
+			build, err = job.CreateBuildWithInputs(algorithm.InputMapping{
+				"some-input": algorithm.InputVersion{VersionID: savedVersion.ID, FirstOccurrence: true},
+			}, overrides)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("creates a manually triggered pending build", func() {
+			Expect(build.Name()).To(Equal("1"))
+			Expect(build.Status()).To(Equal(db.BuildStatusPending))
+			Expect(build.IsManuallyTriggered()).To(BeTrue())
+		})
+
+		It("records the overrides", func() {
+			found, err := build.Reload()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(build.InputOverrides()).To(Equal(overrides))
+		})
+
+		It("uses the versions of the input mapping", func() {
+			inputs, err := build.Inputs()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(inputs).To(HaveLen(1))
+			Expect(inputs[0].Name).To(Equal("some-input"))
+			Expect(inputs[0].Resource).To(Equal("some-resource"))
+			Expect(inputs[0].Version).To(Equal(db.ResourceVersion{"version": "v1"}))
+		})
+
+		It("is one of the pending builds of the job", func() {
+			pendingBuilds, err := job.GetPendingBuilds()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pendingBuilds).To(HaveLen(1))
+			Expect(pendingBuilds[0].ID()).To(Equal(build.ID()))
+			Expect(pendingBuilds[0].InputOverrides()).To(Equal(overrides))
+		})
+	})
+
+	Describe("RerunBuild", func() {
+		var (
+			originalBuild db.Build
+			input         db.BuildInput
+		)
+
+		BeforeEach(func() {
+			var err error
+			originalBuild, err = job.CreateBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			input = db.BuildInput{
+				Name: "some-input",
+				VersionedResource: db.VersionedResource{
+					Resource: "some-other-resource",
+					Type:     "some-type",
+					Version:  db.ResourceVersion{"ver": "2"},
+					Metadata: []db.ResourceMetadataField{{Name: "meta", Value: "value"}},
+				},
+			}
+
+			err = originalBuild.SaveInput(input)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("creates a pending build named after the original build", func() {
+			rerunBuild, err := job.RerunBuild(originalBuild)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(rerunBuild.Name()).To(Equal(originalBuild.Name() + ".1"))
+			Expect(rerunBuild.Status()).To(Equal(db.BuildStatusPending))
+			Expect(rerunBuild.JobName()).To(Equal("some-job"))
+			Expect(rerunBuild.IsManuallyTriggered()).To(BeTrue())
+		})
+
+		It("records which build it re-runs", func() {
+			rerunBuild, err := job.RerunBuild(originalBuild)
+			Expect(err).NotTo(HaveOccurred())
+
+			found, err := rerunBuild.Reload()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			Expect(rerunBuild.RerunOf()).To(Equal(originalBuild.ID()))
+			Expect(rerunBuild.RerunOfName()).To(Equal(originalBuild.Name()))
+		})
+
+		It("uses the inputs of the original build", func() {
+			rerunBuild, err := job.RerunBuild(originalBuild)
+			Expect(err).NotTo(HaveOccurred())
+
+			inputs, err := rerunBuild.Inputs()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(inputs).To(Equal([]db.BuildInput{input}))
+		})
+
+		It("is one of the pending builds of the job", func() {
+			rerunBuild, err := job.RerunBuild(originalBuild)
+			Expect(err).NotTo(HaveOccurred())
+
+			pendingBuilds, err := job.GetPendingBuilds()
+			Expect(err).NotTo(HaveOccurred())
+
+			var pendingIDs []int
+			for _, build := range pendingBuilds {
+				pendingIDs = append(pendingIDs, build.ID())
+			}
+
+			Expect(pendingIDs).To(ContainElement(rerunBuild.ID()))
+		})
+
+		Context("when the build has been re-run before", func() {
+			var firstRerun db.Build
+
+			BeforeEach(func() {
+				var err error
+				firstRerun, err = job.RerunBuild(originalBuild)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("numbers the next re-run after the previous one", func() {
+				rerunBuild, err := job.RerunBuild(originalBuild)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(rerunBuild.Name()).To(Equal(originalBuild.Name() + ".2"))
+			})
+
+			Context("when re-running the re-run", func() {
+				It("re-runs the original build", func() {
+					rerunBuild, err := job.RerunBuild(firstRerun)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(rerunBuild.Name()).To(Equal(originalBuild.Name() + ".2"))
+
+					found, err := rerunBuild.Reload()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeTrue())
+					Expect(rerunBuild.RerunOf()).To(Equal(originalBuild.ID()))
+				})
+			})
+		})
+
+		Context("when the build is of another job", func() {
+			It("returns ErrBuildDisappeared", func() {
+				otherJob, found, err := pipeline.Job("some-other-job")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				_, err = otherJob.RerunBuild(originalBuild)
+				Expect(err).To(Equal(db.ErrBuildDisappeared))
+			})
+		})
+	})
+
 	Describe("a build is created for a job", func() {
 		var (
 			build1DB      db.Build
@@ -0,0 +1,362 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"sort"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/atc/db/encryption"
+)
+
+var ErrEncryptedWithUnknownKey = errors.New("row encrypted with neither old nor new key")
+
+var encryptedColumns = map[string]string{
+	"teams":          "auth",
+	"resources":      "config",
+	"jobs":           "config",
+	"resource_types": "config",
+	"notifications":  "config",
+	"builds":         "engine_metadata",
+}
+
+// keyCheck is encrypted with the key a rotation re-encrypts with, so that a
+// rotation's progress is only resumed with the same key.
+const keyCheck = "concourse-encryption-key-check"
+
+//go:generate counterfeiter . KeyRotator
+
+// A KeyRotator re-encrypts the encrypted columns with the new key, or decrypts
+// them without one, in batches. Its progress through each table is kept in
+// the database, so that a rotation resumes where it left off. Rows encrypted
+// with none of the keys are skipped and counted, rather than holding up the
+// rest of the table.
+type KeyRotator interface {
+	Run() error
+	Status() ([]KeyRotationStatus, error)
+}
+
+type KeyRotationStatus struct {
+	Table       string
+	Column      string
+	LastID      int
+	RowsRotated int
+	RowsSkipped int
+	Finished    bool
+	UpdatedAt   time.Time
+}
+
+type keyRotator struct {
+	logger    lager.Logger
+	conn      Conn
+	keyring   *encryption.Keyring
+	batchSize int
+}
+
+func NewKeyRotator(
+	logger lager.Logger,
+	conn Conn,
+	newKey *encryption.Key,
+	oldKeys []*encryption.Key,
+	batchSize int,
+) KeyRotator {
+	return &keyRotator{
+		logger:    logger,
+		conn:      conn,
+		keyring:   newKeyring(newKey, oldKeys),
+		batchSize: batchSize,
+	}
+}
+
+// newKeyring encrypts with the new key, if there is one, and decrypts with
+// any of the keys. Plaintext written before a key was configured can always be
+// read.
+func newKeyring(newKey *encryption.Key, oldKeys []*encryption.Key) *encryption.Keyring {
+	var current encryption.Strategy = encryption.NewNoEncryption()
+	if newKey != nil {
+		current = newKey
+	}
+
+	old := []encryption.Strategy{}
+	for _, key := range oldKeys {
+		old = append(old, key)
+	}
+
+	if newKey != nil {
+		old = append(old, encryption.NewNoEncryption())
+	}
+
+	return encryption.NewKeyring(current, old...)
+}
+
+func (rotator *keyRotator) Run() error {
+	rotator.logger.Debug("start")
+	defer rotator.logger.Debug("done")
+
+	tables := []string{}
+	for table := range encryptedColumns {
+		tables = append(tables, table)
+	}
+
+	sort.Strings(tables)
+
+	for _, table := range tables {
+		tLog := rotator.logger.Session("table", lager.Data{
+			"table": table,
+		})
+
+		for {
+			finished, err := rotator.rotateBatch(tLog, table, encryptedColumns[table])
+			if err != nil {
+				return err
+			}
+
+			if finished {
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+func (rotator *keyRotator) Status() ([]KeyRotationStatus, error) {
+	rows, err := psql.Select("table_name", "last_id", "rows_rotated", "rows_skipped", "finished", "updated_at").
+		From("encryption_key_rotations").
+		OrderBy("table_name ASC").
+		RunWith(rotator.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	statuses := []KeyRotationStatus{}
+
+	for rows.Next() {
+		var status KeyRotationStatus
+		err := rows.Scan(&status.Table, &status.LastID, &status.RowsRotated, &status.RowsSkipped, &status.Finished, &status.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		status.Column = encryptedColumns[status.Table]
+
+		statuses = append(statuses, status)
+	}
+
+	return statuses, nil
+}
+
+type encryptedRow struct {
+	id    int
+	val   sql.NullString
+	nonce sql.NullString
+}
+
+// rotateBatch rotates the rows after the last one which was rotated, and
+// returns whether there are none left in the table.
+func (rotator *keyRotator) rotateBatch(logger lager.Logger, table string, col string) (bool, error) {
+	tx, err := rotator.conn.Begin()
+	if err != nil {
+		return false, err
+	}
+
+	defer Rollback(tx)
+
+	lastID, finished, err := rotator.progress(tx, table)
+	if err != nil {
+		logger.Error("failed-to-get-progress", err)
+		return false, err
+	}
+
+	if finished {
+		return true, nil
+	}
+
+	rows, err := tx.Query(`
+		SELECT id, nonce, `+col+`
+		FROM `+table+`
+		WHERE id > $1
+		ORDER BY id ASC
+		LIMIT $2
+	`, lastID, rotator.batchSize)
+	if err != nil {
+		return false, err
+	}
+
+	batch := []encryptedRow{}
+	for rows.Next() {
+		var row encryptedRow
+		err := rows.Scan(&row.id, &row.nonce, &row.val)
+		if err != nil {
+			_ = rows.Close()
+			logger.Error("failed-to-scan", err)
+			return false, err
+		}
+
+		batch = append(batch, row)
+	}
+
+	err = rows.Close()
+	if err != nil {
+		return false, err
+	}
+
+	rotatedRows := 0
+	skippedRows := 0
+	for _, row := range batch {
+		lastID = row.id
+
+		rotated, err := rotator.rotateRow(logger, tx, table, col, row)
+		if err == ErrEncryptedWithUnknownKey {
+			skippedRows++
+			continue
+		}
+
+		if err != nil {
+			return false, err
+		}
+
+		if rotated {
+			rotatedRows++
+		}
+	}
+
+	finished = len(batch) < rotator.batchSize
+
+	_, err = tx.Exec(`
+		UPDATE encryption_key_rotations
+		SET last_id = $2, rows_rotated = rows_rotated + $3, rows_skipped = rows_skipped + $4, finished = $5, updated_at = now()
+		WHERE table_name = $1
+	`, table, lastID, rotatedRows, skippedRows, finished)
+	if err != nil {
+		return false, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return false, err
+	}
+
+	if rotatedRows > 0 || skippedRows > 0 {
+		logger.Info("rotated-batch", lager.Data{
+			"rows":    rotatedRows,
+			"skipped": skippedRows,
+			"last-id": lastID,
+		})
+	}
+
+	return finished, nil
+}
+
+// rotateRow re-encrypts the row with the current key, unless it already is.
+func (rotator *keyRotator) rotateRow(logger lager.Logger, tx Tx, table string, col string, row encryptedRow) (bool, error) {
+	if !row.val.Valid {
+		return false, nil
+	}
+
+	rLog := logger.Session("row", lager.Data{
+		"id": row.id,
+	})
+
+	var nonce *string
+	if row.nonce.Valid {
+		nonce = &row.nonce.String
+	}
+
+	current := rotator.keyring.Current()
+
+	_, err := current.Decrypt(row.val.String, nonce)
+	if err == nil {
+		return false, nil
+	}
+
+	// the row is left as it is, so that it can still be read once the key it
+	// was encrypted with is found
+	decrypted, err := rotator.keyring.Decrypt(row.val.String, nonce)
+	if err != nil {
+		rLog.Error("skipping-row-encrypted-with-unknown-key", err)
+		return false, ErrEncryptedWithUnknownKey
+	}
+
+	encrypted, newNonce, err := current.Encrypt(decrypted)
+	if err != nil {
+		rLog.Error("failed-to-encrypt", err)
+		return false, err
+	}
+
+	// the nonce changes with every write, so a row which has been updated
+	// since it was read is left alone; it was written with the current key
+	_, err = tx.Exec(`
+		UPDATE `+table+`
+		SET `+col+` = $1, nonce = $2
+		WHERE id = $3
+		AND nonce IS NOT DISTINCT FROM $4
+	`, encrypted, newNonce, row.id, row.nonce)
+	if err != nil {
+		rLog.Error("failed-to-update", err)
+		return false, err
+	}
+
+	return true, nil
+}
+
+// progress returns the last row rotated in the table, and whether the table
+// is done. It starts the table over if its progress was made with another key.
+func (rotator *keyRotator) progress(tx Tx, table string) (int, bool, error) {
+	var (
+		lastID     int
+		finished   bool
+		checkText  string
+		checkNonce sql.NullString
+	)
+
+	err := psql.Select("last_id", "finished", "key_check", "key_check_nonce").
+		From("encryption_key_rotations").
+		Where(sq.Eq{"table_name": table}).
+		RunWith(tx).
+		QueryRow().
+		Scan(&lastID, &finished, &checkText, &checkNonce)
+	if err != nil && err != sql.ErrNoRows {
+		return 0, false, err
+	}
+
+	if err == nil {
+		var nonce *string
+		if checkNonce.Valid {
+			nonce = &checkNonce.String
+		}
+
+		check, err := rotator.keyring.Current().Decrypt(checkText, nonce)
+		if err == nil && string(check) == keyCheck {
+			return lastID, finished, nil
+		}
+	}
+
+	encryptedCheck, nonce, err := rotator.keyring.Current().Encrypt([]byte(keyCheck))
+	if err != nil {
+		return 0, false, err
+	}
+
+	_, err = tx.Exec(`
+		DELETE FROM encryption_key_rotations
+		WHERE table_name = $1
+	`, table)
+	if err != nil {
+		return 0, false, err
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO encryption_key_rotations (table_name, key_check, key_check_nonce)
+		VALUES ($1, $2, $3)
+	`, table, encryptedCheck, nonce)
+	if err != nil {
+		return 0, false, err
+	}
+
+	return 0, false, nil
+}
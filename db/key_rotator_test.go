@@ -0,0 +1,187 @@
+package db_test
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"database/sql"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/encryption"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("KeyRotator", func() {
+	var (
+		oldKey *encryption.Key
+		newKey *encryption.Key
+	)
+
+	newEncryptionKey := func(k string) *encryption.Key {
+		block, err := aes.NewCipher([]byte(k))
+		Expect(err).NotTo(HaveOccurred())
+
+		aesgcm, err := cipher.NewGCM(block)
+		Expect(err).NotTo(HaveOccurred())
+
+		return encryption.NewKey(aesgcm)
+	}
+
+	rotate := func(newKey *encryption.Key, oldKeys ...*encryption.Key) error {
+		return db.NewKeyRotator(lagertest.NewTestLogger("test"), dbConn, newKey, oldKeys, 1).Run()
+	}
+
+	status := func(table string) db.KeyRotationStatus {
+		statuses, err := db.NewKeyRotator(lagertest.NewTestLogger("test"), dbConn, nil, nil, 1).Status()
+		Expect(err).NotTo(HaveOccurred())
+
+		for _, status := range statuses {
+			if status.Table == table {
+				return status
+			}
+		}
+
+		Fail("no status for table " + table)
+		return db.KeyRotationStatus{}
+	}
+
+	expectEncryptedWith := func(key encryption.Strategy) {
+		for table, col := range map[string]string{"teams": "auth", "jobs": "config", "resources": "config"} {
+			rows, err := dbConn.Query(`SELECT ` + col + `, nonce FROM ` + table + ` WHERE ` + col + ` IS NOT NULL`)
+			Expect(err).NotTo(HaveOccurred())
+
+			found := 0
+			for rows.Next() {
+				var val string
+				var nonce sql.NullString
+				Expect(rows.Scan(&val, &nonce)).To(Succeed())
+
+				var n *string
+				if nonce.Valid {
+					n = &nonce.String
+				}
+
+				_, err := key.Decrypt(val, n)
+				Expect(err).NotTo(HaveOccurred(), "row in "+table+" not encrypted with the key")
+
+				found++
+			}
+
+			Expect(rows.Close()).To(Succeed())
+			Expect(found).NotTo(BeZero(), "no rows in "+table)
+		}
+	}
+
+	BeforeEach(func() {
+		oldKey = newEncryptionKey("AES256Key-32Characters1234567890")
+		newKey = newEncryptionKey("AES256Key-32Characters9564567123")
+	})
+
+	Context("when the data is plaintext", func() {
+		It("encrypts it with the new key", func() {
+			Expect(rotate(oldKey)).To(Succeed())
+
+			expectEncryptedWith(oldKey)
+		})
+
+		It("records its progress through each table", func() {
+			Expect(rotate(oldKey)).To(Succeed())
+
+			teamsStatus := status("teams")
+			Expect(teamsStatus.Column).To(Equal("auth"))
+			Expect(teamsStatus.RowsRotated).To(Equal(1))
+			Expect(teamsStatus.LastID).To(Equal(defaultTeam.ID()))
+			Expect(teamsStatus.Finished).To(BeTrue())
+		})
+	})
+
+	Context("when the data is encrypted with an old key", func() {
+		BeforeEach(func() {
+			Expect(rotate(oldKey)).To(Succeed())
+		})
+
+		It("re-encrypts it with the new key", func() {
+			Expect(rotate(newKey, oldKey)).To(Succeed())
+
+			expectEncryptedWith(newKey)
+		})
+
+		It("starts the rotation over for the new key", func() {
+			Expect(rotate(newKey, oldKey)).To(Succeed())
+
+			Expect(status("teams").RowsRotated).To(Equal(1))
+			Expect(status("teams").Finished).To(BeTrue())
+		})
+
+		It("decrypts it to plaintext without a new key", func() {
+			Expect(rotate(nil, oldKey)).To(Succeed())
+
+			expectEncryptedWith(encryption.NewNoEncryption())
+		})
+
+		It("does not rotate a finished table again", func() {
+			Expect(rotate(newKey, oldKey)).To(Succeed())
+			Expect(rotate(newKey, oldKey)).To(Succeed())
+
+			Expect(status("teams").RowsRotated).To(Equal(1))
+		})
+
+		Context("when the old key is not given", func() {
+			It("skips the rows it cannot decrypt", func() {
+				Expect(rotate(newKey)).To(Succeed())
+
+				Expect(status("teams").RowsRotated).To(BeZero())
+				Expect(status("teams").RowsSkipped).To(Equal(1))
+				Expect(status("teams").Finished).To(BeTrue())
+			})
+		})
+	})
+
+	Context("when a row is encrypted with an unknown key", func() {
+		var otherTeam db.Team
+
+		BeforeEach(func() {
+			var err error
+			otherTeam, err = teamFactory.CreateTeam(atc.Team{Name: "other-team"})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(rotate(oldKey)).To(Succeed())
+
+			unknownKey := newEncryptionKey("AES256Key-32Characters0000000000")
+
+			encrypted, nonce, err := unknownKey.Encrypt([]byte(`{}`))
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = dbConn.Exec(`UPDATE teams SET auth = $1, nonce = $2 WHERE id = $3`, encrypted, nonce, defaultTeam.ID())
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("skips and counts the row, and rotates the rest of the table", func() {
+			Expect(rotate(newKey, oldKey)).To(Succeed())
+
+			teamsStatus := status("teams")
+			Expect(teamsStatus.RowsRotated).To(Equal(1))
+			Expect(teamsStatus.RowsSkipped).To(Equal(1))
+			Expect(teamsStatus.LastID).To(Equal(otherTeam.ID()))
+			Expect(teamsStatus.Finished).To(BeTrue())
+
+			var val string
+			var nonce sql.NullString
+			err := dbConn.QueryRow(`SELECT auth, nonce FROM teams WHERE id = $1`, otherTeam.ID()).Scan(&val, &nonce)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = newKey.Decrypt(val, &nonce.String)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("rotates the other tables", func() {
+			Expect(rotate(newKey, oldKey)).To(Succeed())
+
+			Expect(status("jobs").RowsSkipped).To(BeZero())
+			Expect(status("jobs").Finished).To(BeTrue())
+			Expect(status("resources").Finished).To(BeTrue())
+		})
+	})
+})
@@ -12,6 +12,36 @@
 // db/migration/migrations/1517330648_add_worker_resource_certs.up.sql
 // db/migration/migrations/1517585875_add_name_index_to_builds.down.sql
 // db/migration/migrations/1517585875_add_name_index_to_builds.up.sql
+// db/migration/migrations/1518016341_add_pinned_version_to_resources.down.sql
+// db/migration/migrations/1518016341_add_pinned_version_to_resources.up.sql
+// db/migration/migrations/1518203154_add_roles_to_teams.down.sql
+// db/migration/migrations/1518203154_add_roles_to_teams.up.sql
+// db/migration/migrations/1518467021_create_audit_events.down.sql
+// db/migration/migrations/1518467021_create_audit_events.up.sql
+// db/migration/migrations/1518551232_add_archived_to_pipelines.down.sql
+// db/migration/migrations/1518551232_add_archived_to_pipelines.up.sql
+// db/migration/migrations/1518637448_add_instance_vars_to_pipelines.down.sql
+// db/migration/migrations/1518637448_add_instance_vars_to_pipelines.up.sql
+// db/migration/migrations/1518723901_add_active_volumes_to_workers.down.sql
+// db/migration/migrations/1518723901_add_active_volumes_to_workers.up.sql
+// db/migration/migrations/1518810457_add_max_containers_to_workers.down.sql
+// db/migration/migrations/1518810457_add_max_containers_to_workers.up.sql
+// db/migration/migrations/1518896215_create_notifications.down.sql
+// db/migration/migrations/1518896215_create_notifications.up.sql
+// db/migration/migrations/1518982615_create_team_events.down.sql
+// db/migration/migrations/1518982615_create_team_events.up.sql
+// db/migration/migrations/1519069015_add_metadata_to_builds.down.sql
+// db/migration/migrations/1519069015_add_metadata_to_builds.up.sql
+// db/migration/migrations/1519155415_add_rerun_of_to_builds.down.sql
+// db/migration/migrations/1519155415_add_rerun_of_to_builds.up.sql
+// db/migration/migrations/1519241815_add_input_overrides_to_builds.down.sql
+// db/migration/migrations/1519241815_add_input_overrides_to_builds.up.sql
+// db/migration/migrations/1519328215_add_archived_to_builds.down.sql
+// db/migration/migrations/1519328215_add_archived_to_builds.up.sql
+// db/migration/migrations/1519414615_create_encryption_key_rotations.down.sql
+// db/migration/migrations/1519414615_create_encryption_key_rotations.up.sql
+// db/migration/migrations/1519501015_add_rows_skipped_to_encryption_key_rotations.down.sql
+// db/migration/migrations/1519501015_add_rows_skipped_to_encryption_key_rotations.up.sql
 // DO NOT EDIT!
 
 package migration
@@ -319,6 +349,606 @@ func _1517585875_add_name_index_to_buildsUpSql() (*asset, error) {
 	return a, nil
 }
 
+var __1518016341_add_pinned_version_to_resourcesDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x28\x4a\x2d\xce\x2f\x2d\x4a\x4e\x2d\x06\x8a\x2b\x28\xb8\x04\xf9\x07\x28\x38\xfb\xfb\x84\xfa\xfa\x29\x14\x64\xe6\xe5\xa5\xa6\xc4\x97\xa5\x16\x15\x67\xe6\xe7\xc5\x67\xa6\xe8\x60\x53\x12\x9f\x9c\x9f\x9b\x9b\x9a\x57\x62\xcd\xe5\xec\xef\xeb\xeb\x19\x62\xcd\x05\x00\xd1\x3d\x1f\x48\x67\x00\x00\x00")
+
+func _1518016341_add_pinned_version_to_resourcesDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1518016341_add_pinned_version_to_resourcesDownSql,
+		"1518016341_add_pinned_version_to_resources.down.sql",
+	)
+}
+
+func _1518016341_add_pinned_version_to_resourcesDownSql() (*asset, error) {
+	bytes, err := _1518016341_add_pinned_version_to_resourcesDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1518016341_add_pinned_version_to_resources.down.sql", size: 103, mode: os.FileMode(420), modTime: time.Unix(1518016341, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1518016341_add_pinned_version_to_resourcesUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x6d\x8d\xbd\x0a\xc2\x30\x18\x45\xf7\x3c\xc5\x1d\x15\x7c\x83\x4c\x6d\xfa\x29\x85\xfc\x40\x9b\xce\x1d\x9a\x0f\xc9\xd0\x54\x92\x28\x3e\xbe\x19\xc4\xc9\xf5\x9c\xc3\xbd\x3d\xdd\x46\x2b\x05\xd0\x69\x4f\x13\x7c\xd7\x6b\x42\xe6\x72\x3c\xf3\xc6\xa5\xf1\x66\x86\x01\xca\xe9\xc5\x58\x3c\x62\x4a\x1c\xd6\x17\xe7\x12\x8f\xb4\xc6\x80\x98\x2a\xdf\x39\x63\xa2\x2b\x4d\x64\x15\xcd\xf8\xda\xd6\xfd\x76\x70\x8a\xe1\x0c\x67\x31\x90\x26\x4f\x98\xc9\xc3\x2e\x5a\x5f\xfe\x1c\xac\xdb\xb1\xef\x9c\x2a\x2a\xbf\xab\x14\xca\x19\x33\x7a\x29\x3e\x3b\x5f\x13\x24\xa9\x00\x00\x00")
+
+func _1518016341_add_pinned_version_to_resourcesUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1518016341_add_pinned_version_to_resourcesUpSql,
+		"1518016341_add_pinned_version_to_resources.up.sql",
+	)
+}
+
+func _1518016341_add_pinned_version_to_resourcesUpSql() (*asset, error) {
+	bytes, err := _1518016341_add_pinned_version_to_resourcesUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1518016341_add_pinned_version_to_resources.up.sql", size: 169, mode: os.FileMode(420), modTime: time.Unix(1518016341, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1518203154_add_roles_to_teamsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x28\x49\x4d\xcc\x2d\x56\x70\x09\xf2\x0f\x50\x70\xf6\xf7\x09\xf5\xf5\x53\x28\xca\xcf\x49\x2d\xb6\xe6\x72\xf6\xf7\xf5\xf5\x0c\xb1\xe6\x02\x00\x47\x1e\x76\xc3\x36\x00\x00\x00")
+
+func _1518203154_add_roles_to_teamsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1518203154_add_roles_to_teamsDownSql,
+		"1518203154_add_roles_to_teams.down.sql",
+	)
+}
+
+func _1518203154_add_roles_to_teamsDownSql() (*asset, error) {
+	bytes, err := _1518203154_add_roles_to_teamsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1518203154_add_roles_to_teams.down.sql", size: 54, mode: os.FileMode(420), modTime: time.Unix(1518203154, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1518203154_add_roles_to_teamsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x28\x49\x4d\xcc\x2d\x56\x70\x74\x71\x51\x70\xf6\xf7\x09\xf5\xf5\x53\x28\xca\xcf\x49\x2d\x56\xc8\x2a\xce\xcf\xb3\xe6\x72\xf6\xf7\xf5\xf5\x0c\xb1\xe6\x02\x00\xe3\x53\x5e\x1a\x3a\x00\x00\x00")
+
+func _1518203154_add_roles_to_teamsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1518203154_add_roles_to_teamsUpSql,
+		"1518203154_add_roles_to_teams.up.sql",
+	)
+}
+
+func _1518203154_add_roles_to_teamsUpSql() (*asset, error) {
+	bytes, err := _1518203154_add_roles_to_teamsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1518203154_add_roles_to_teams.up.sql", size: 58, mode: os.FileMode(420), modTime: time.Unix(1518203154, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1518467021_create_audit_eventsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\x09\xf2\x0f\x50\x08\x71\x74\xf2\x71\x55\x50\x4a\x2c\x4d\xc9\x2c\x89\x4f\x2d\x4b\xcd\x2b\x29\x56\xb2\xe6\x72\xf6\xf7\xf5\xf5\x0c\xb1\xe6\x02\x00\x79\xac\xb4\xc6\x2c\x00\x00\x00")
+
+func _1518467021_create_audit_eventsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1518467021_create_audit_eventsDownSql,
+		"1518467021_create_audit_events.down.sql",
+	)
+}
+
+func _1518467021_create_audit_eventsDownSql() (*asset, error) {
+	bytes, err := _1518467021_create_audit_eventsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1518467021_create_audit_events.down.sql", size: 44, mode: os.FileMode(420), modTime: time.Unix(1518467021, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1518467021_create_audit_eventsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x65\x90\x41\x6a\xc3\x30\x10\x45\xf7\x39\xc5\x47\x2b\x1b\x7a\x83\xac\x9c\x44\x0d\x22\xb6\x5c\x1c\x19\x9a\x95\x51\xc9\x90\xa8\xd4\x72\x90\xc7\x4d\xe8\xe9\x2b\x9b\x36\x75\x89\x16\x02\xbd\xd1\x7f\xcc\xcc\x4a\x6e\x95\x5e\x2e\x80\x75\x25\x33\x23\x61\xb2\x55\x2e\x21\xec\x70\x74\xdc\xd0\x27\x79\xee\x05\x92\x58\x1f\x8f\x70\x47\x81\x9e\x82\xb3\x1f\x4f\xbf\x88\x5d\x4b\x02\xe3\xdd\xb3\x6d\x2f\xb8\x3a\x3e\x4f\x4f\x7c\x75\x9e\xb0\x91\xcf\x59\x9d\x1b\xf8\xee\x9a\xa4\xd0\xa5\x81\xae\xf3\xfc\x9e\x1e\xa2\x2d\xa6\xe9\xc6\x7f\x42\xb2\x6d\xe3\xed\x64\x9d\xf3\xd0\x0d\xfc\xc3\x1e\x3d\x17\x1b\x6c\x1b\x3b\x7d\xef\x3b\x7f\x87\xb1\x21\x1e\x22\x74\x9e\xe9\x44\xe1\x21\xf5\x52\xa9\x22\xab\x0e\xd8\xc9\x03\x92\x71\xb6\x34\x16\xd2\xd9\x32\x94\xde\xc8\x57\xcc\x77\xd1\x4c\x93\x95\xfa\x1f\x44\xbd\x57\x7a\x8b\x37\x0e\x44\x48\xc6\x1f\x51\xb2\x2e\x8b\x42\x99\xe5\xe2\x1b\xb5\x7d\x88\xbc\x61\x01\x00\x00")
+
+func _1518467021_create_audit_eventsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1518467021_create_audit_eventsUpSql,
+		"1518467021_create_audit_events.up.sql",
+	)
+}
+
+func _1518467021_create_audit_eventsUpSql() (*asset, error) {
+	bytes, err := _1518467021_create_audit_eventsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1518467021_create_audit_events.up.sql", size: 353, mode: os.FileMode(420), modTime: time.Unix(1518467021, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1518551232_add_archived_to_pipelinesDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x28\xc8\x2c\x48\xcd\xc9\xcc\x4b\x2d\x56\x70\x09\xf2\x0f\x50\x70\xf6\xf7\x09\xf5\xf5\x53\x48\x2c\x4a\xce\xc8\x2c\x4b\x4d\xb1\xe6\x72\xf6\xf7\xf5\xf5\x0c\xb1\xe6\x02\x00\x22\x6f\x92\x74\x3d\x00\x00\x00")
+
+func _1518551232_add_archived_to_pipelinesDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1518551232_add_archived_to_pipelinesDownSql,
+		"1518551232_add_archived_to_pipelines.down.sql",
+	)
+}
+
+func _1518551232_add_archived_to_pipelinesDownSql() (*asset, error) {
+	bytes, err := _1518551232_add_archived_to_pipelinesDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1518551232_add_archived_to_pipelines.down.sql", size: 61, mode: os.FileMode(420), modTime: time.Unix(1518551232, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1518551232_add_archived_to_pipelinesUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x05\xc1\x5b\x0a\x80\x20\x10\x05\xd0\x7f\x57\x71\xf7\xe1\x97\xaf\x22\x18\x15\x62\x5c\x80\xd5\x44\x82\x54\x14\xb4\xfe\xce\xb1\x61\x9c\x92\x56\x80\x21\x0e\x33\xd8\x58\x0a\xb8\xdb\x2d\xbd\x9d\xf2\xc2\x78\x0f\x97\xa9\xc4\x84\xfa\xac\x47\xfb\x64\xc3\x72\x5d\x5d\xea\x89\x94\x19\xa9\x10\xc1\x87\xc1\x14\x62\xec\xb5\xbf\xa2\x95\xcb\x31\x4e\xac\xd5\x0f\xcf\x2e\xd6\x40\x5b\x00\x00\x00")
+
+func _1518551232_add_archived_to_pipelinesUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1518551232_add_archived_to_pipelinesUpSql,
+		"1518551232_add_archived_to_pipelines.up.sql",
+	)
+}
+
+func _1518551232_add_archived_to_pipelinesUpSql() (*asset, error) {
+	bytes, err := _1518551232_add_archived_to_pipelinesUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1518551232_add_archived_to_pipelines.up.sql", size: 91, mode: os.FileMode(420), modTime: time.Unix(1518551232, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1518637448_add_instance_vars_to_pipelinesDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x75\x8d\xbd\x0a\xc2\x30\x14\x46\xf7\x3c\xc5\x1d\x15\xfa\x06\x99\xd2\xe6\xaa\x81\xfc\x68\x9a\xa0\x5b\x08\x9a\x21\x60\x43\xb1\xc5\xe7\xb7\x2d\x85\xd2\xc1\xf5\x1c\xce\xf7\xd5\x78\x16\x9a\x12\x00\x8e\x12\x1d\xc2\xc9\x1a\x05\x7d\xee\xd3\x3b\x97\x34\xc0\xfd\x82\x16\x21\x97\x61\x8c\xe5\x99\xc2\x37\x7e\x06\x10\x2d\x68\xe3\x40\x7b\x29\x29\x99\x4b\x6b\xae\x20\x34\xc7\xc7\x16\x86\x12\xbb\x14\xc6\x14\xbb\x90\x5f\x61\xd7\x2f\x0d\x93\x0e\x2d\x38\x56\x4b\xdc\xa2\x89\xaf\x6b\x8d\x91\x5e\xe9\xfd\x6f\xb5\x68\xc6\xf9\x64\x75\xeb\x2c\x13\xda\xfd\x39\x04\xaf\xc5\xcd\x23\x1c\x66\x58\xc1\x4a\x8f\x94\x34\x46\x29\xe1\x28\xf9\x01\x73\x1d\x69\xb9\xf6\x00\x00\x00")
+
+func _1518637448_add_instance_vars_to_pipelinesDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1518637448_add_instance_vars_to_pipelinesDownSql,
+		"1518637448_add_instance_vars_to_pipelines.down.sql",
+	)
+}
+
+func _1518637448_add_instance_vars_to_pipelinesDownSql() (*asset, error) {
+	bytes, err := _1518637448_add_instance_vars_to_pipelinesDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1518637448_add_instance_vars_to_pipelines.down.sql", size: 246, mode: os.FileMode(420), modTime: time.Unix(1518637448, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1518637448_add_instance_vars_to_pipelinesUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x75\xce\xb1\x0e\x82\x30\x10\x06\xe0\xbd\x4f\x71\x1b\x90\xf0\x04\x30\x95\xf6\x62\x9a\x40\xab\xa5\x24\x6e\x0d\x6a\x07\x8c\x54\x62\x89\x8b\xf1\xdd\xad\xc4\x84\x30\xb8\xde\x7d\xff\xfd\x57\xe1\x4e\xc8\x92\x00\xd0\xda\xa0\x06\x43\xab\x1a\x61\x1a\x26\x77\x1b\xbc\x0b\x71\x1e\x37\x9c\x03\x53\x75\xd7\x48\x18\x7c\x98\x7b\x7f\x76\xf6\xd9\x3f\x02\x5c\xc3\xdd\x9f\xf2\xc5\x70\xad\xf6\x11\xc9\xd6\x68\x2a\xa4\x59\x2f\x58\xdf\x8f\xce\xce\xae\x1f\xed\x70\x29\x49\xc4\x4c\x23\x35\x08\x9d\x14\x87\x0e\x41\x48\x8e\xc7\x3f\xdc\x6e\xeb\x94\x5c\x1d\xa4\x5f\x98\xc3\x4f\xe6\xb1\x9b\xd6\xd8\x32\x4c\x37\x99\x1c\x92\xd7\x3b\x29\x8a\xe5\xd3\x2c\x2b\x09\x53\x4d\x23\x4c\x49\x3e\x2b\xb0\x89\x5a\xf7\x00\x00\x00")
+
+func _1518637448_add_instance_vars_to_pipelinesUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1518637448_add_instance_vars_to_pipelinesUpSql,
+		"1518637448_add_instance_vars_to_pipelines.up.sql",
+	)
+}
+
+func _1518637448_add_instance_vars_to_pipelinesUpSql() (*asset, error) {
+	bytes, err := _1518637448_add_instance_vars_to_pipelinesUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1518637448_add_instance_vars_to_pipelines.up.sql", size: 247, mode: os.FileMode(420), modTime: time.Unix(1518637448, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1518723901_add_active_volumes_to_workersDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x28\xcf\x2f\xca\x4e\x2d\x2a\x56\x70\x09\xf2\x0f\x50\x70\xf6\xf7\x09\xf5\xf5\x53\x48\x4c\x2e\xc9\x2c\x4b\x8d\x2f\xcb\xcf\x29\xcd\x4d\x2d\xb6\xe6\x72\xf6\xf7\xf5\xf5\x0c\xb1\xe6\x02\x00\x52\x6c\xcb\x36\x41\x00\x00\x00")
+
+func _1518723901_add_active_volumes_to_workersDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1518723901_add_active_volumes_to_workersDownSql,
+		"1518723901_add_active_volumes_to_workers.down.sql",
+	)
+}
+
+func _1518723901_add_active_volumes_to_workersDownSql() (*asset, error) {
+	bytes, err := _1518723901_add_active_volumes_to_workersDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1518723901_add_active_volumes_to_workers.down.sql", size: 65, mode: os.FileMode(420), modTime: time.Unix(1518723901, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1518723901_add_active_volumes_to_workersUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x28\xcf\x2f\xca\x4e\x2d\x2a\x56\x70\x74\x71\x51\x70\xf6\xf7\x09\xf5\xf5\x53\x48\x4c\x2e\xc9\x2c\x4b\x8d\x2f\xcb\xcf\x29\xcd\x4d\x2d\x56\xc8\xcc\x2b\x49\x4d\x4f\x2d\x52\x70\x71\x75\x73\x0c\xf5\x09\x51\x30\xb0\xe6\x72\xf6\xf7\xf5\xf5\x0c\xb1\xe6\x02\x00\xfa\x3d\xa0\x38\x52\x00\x00\x00")
+
+func _1518723901_add_active_volumes_to_workersUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1518723901_add_active_volumes_to_workersUpSql,
+		"1518723901_add_active_volumes_to_workers.up.sql",
+	)
+}
+
+func _1518723901_add_active_volumes_to_workersUpSql() (*asset, error) {
+	bytes, err := _1518723901_add_active_volumes_to_workersUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1518723901_add_active_volumes_to_workers.up.sql", size: 82, mode: os.FileMode(420), modTime: time.Unix(1518723901, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1518810457_add_max_containers_to_workersDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x28\xcf\x2f\xca\x4e\x2d\x2a\x56\x70\x09\xf2\x0f\x50\x70\xf6\xf7\x09\xf5\xf5\x53\xc8\x4d\xac\x88\x4f\xce\xcf\x2b\x49\xcc\xcc\x03\x4a\x59\x73\x39\xfb\xfb\xfa\x7a\x86\x58\x73\x01\x00\x57\xa0\x7b\x49\x41\x00\x00\x00")
+
+func _1518810457_add_max_containers_to_workersDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1518810457_add_max_containers_to_workersDownSql,
+		"1518810457_add_max_containers_to_workers.down.sql",
+	)
+}
+
+func _1518810457_add_max_containers_to_workersDownSql() (*asset, error) {
+	bytes, err := _1518810457_add_max_containers_to_workersDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1518810457_add_max_containers_to_workers.down.sql", size: 65, mode: os.FileMode(420), modTime: time.Unix(1518810457, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1518810457_add_max_containers_to_workersUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x15\xc9\x41\x0a\x80\x20\x10\x05\xd0\xbd\xa7\xf8\x47\x68\xef\xca\xd2\x42\x18\x15\x62\x5c\x87\x84\x44\x44\x06\x26\xd4\xf1\xa3\xb7\x7d\xbd\x99\xac\x97\x02\x50\xc4\x66\x06\xab\x9e\x0c\x9e\xab\x1e\xb9\xde\x50\x5a\x63\x08\x14\x9d\xc7\x99\xde\x65\xbd\x4a\x4b\x7b\xf9\x67\x2f\x2d\x6f\xb9\xc2\x07\x86\x8f\x44\xd0\x66\x54\x91\x18\x9d\x14\x43\x70\xce\xb2\x14\x1f\x10\xc0\xc5\x7f\x5b\x00\x00\x00")
+
+func _1518810457_add_max_containers_to_workersUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1518810457_add_max_containers_to_workersUpSql,
+		"1518810457_add_max_containers_to_workers.up.sql",
+	)
+}
+
+func _1518810457_add_max_containers_to_workersUpSql() (*asset, error) {
+	bytes, err := _1518810457_add_max_containers_to_workersUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1518810457_add_max_containers_to_workers.up.sql", size: 91, mode: os.FileMode(420), modTime: time.Unix(1518810457, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1518896215_create_notificationsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\x09\xf2\x0f\x50\x08\x71\x74\xf2\x71\x55\xc8\xcb\x2f\xc9\x4c\xcb\x4c\x4e\x2c\xc9\xcc\xcf\x8b\x4f\x49\xcd\xc9\x2c\x4b\x2d\xca\x4c\x2d\xc6\xa3\x0a\x28\xe7\xec\xef\xeb\xeb\x19\x62\xcd\x05\x00\x34\x78\xde\x66\x51\x00\x00\x00")
+
+func _1518896215_create_notificationsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1518896215_create_notificationsDownSql,
+		"1518896215_create_notifications.down.sql",
+	)
+}
+
+func _1518896215_create_notificationsDownSql() (*asset, error) {
+	bytes, err := _1518896215_create_notificationsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1518896215_create_notifications.down.sql", size: 81, mode: os.FileMode(420), modTime: time.Unix(1518896215, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1518896215_create_notificationsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x95\x52\x4d\x6f\x82\x40\x10\xbd\xfb\x2b\x26\x5c\x94\xc4\x43\xef\xa4\x07\xc4\xd5\x92\x22\xb6\x08\x69\x3d\x91\x55\x46\xbb\x09\xee\x92\x65\xd5\xb6\xbf\xbe\xab\xe5\xa3\x4a\x29\x96\xc3\x26\xcc\xc7\x7b\x33\xf3\xde\x88\x4c\x5d\xdf\xea\x01\x38\x01\xb1\x43\x02\xa1\x3d\xf2\x08\x18\x5c\x28\xb6\x61\x6b\xaa\x98\xe0\xb9\x01\x03\x5d\x70\xfa\x0c\x96\x18\x90\xa3\x64\x34\x1d\x96\xa1\x8c\x65\x98\x32\x8e\xf1\x29\xc7\xb8\xc2\x2d\x4a\xf0\xe7\x21\xf8\x91\xe7\x41\x40\x26\x24\x20\xbe\x43\x16\x50\x16\xe6\x30\x60\x89\x09\x73\x1f\xc6\xc4\x23\x9a\xd3\xb1\x17\x8e\x3d\x26\x15\x22\xa7\x3b\x34\x40\xe1\xbb\xaa\x70\xaa\xdc\x5a\xf0\x0d\xdb\xb6\x65\xb9\xe0\xeb\xa2\xb5\x8a\xd1\xb5\x62\x07\x1d\x5c\x09\x91\x22\xe5\x9a\x74\x62\x47\x5e\x08\x4a\xee\xb1\x81\xf0\x14\xb8\x33\x3b\x58\xc2\x23\x59\xc2\xe0\xb4\xad\x59\x66\x22\xdf\x7d\x8e\x88\x0e\xfe\xdc\x77\x58\x0c\x6b\xea\x22\xd3\xea\xfd\x79\xc6\x38\xd1\x6d\x07\x7d\x3b\xec\x38\xe8\x45\x53\xd7\x51\x2f\x84\xea\x3a\xec\x6a\xcf\xd2\xa4\x13\xf2\x5c\xd5\x89\x85\x07\xe4\xaa\x4d\x87\x8c\x7e\xa4\x82\x26\x6d\xe9\x5c\x51\xb5\xcf\x8b\x6c\xa9\x47\x3f\x43\x9e\x30\xbe\xed\x37\xeb\xa9\x52\xb8\xcb\x54\x5e\xcf\x5d\x36\xdd\xfd\xe2\x01\x0d\x1a\x17\x1d\xb1\x62\x67\x2b\xe9\x57\x73\xee\x32\x38\x32\xf5\x76\xfe\x85\x4f\xc1\xb1\x82\xe1\xe2\x38\x30\x9b\x50\x29\xcd\x6f\x85\xaa\x7a\x24\xe6\x99\x96\x02\xe3\x72\xc9\x62\xe4\xfa\x70\x52\x0a\x79\xe9\xd1\xa6\xeb\xbe\xfd\x54\xd9\xc9\xf5\xc7\xe4\x15\x5a\xdc\x14\x5f\x19\xe6\x24\x5a\x4b\x29\x44\x0b\xd7\x9f\xc2\x4a\x49\x44\xcd\x74\xed\xb4\xdb\x29\x0b\xad\xfe\x41\xd5\x50\xc5\x84\x97\x07\x6d\xb9\xda\x0d\xf7\xb5\x05\xac\x9e\x33\x9f\xcd\xdc\xd0\xea\x7d\x01\xfc\x98\x90\xcf\xa0\x04\x00\x00")
+
+func _1518896215_create_notificationsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1518896215_create_notificationsUpSql,
+		"1518896215_create_notifications.up.sql",
+	)
+}
+
+func _1518896215_create_notificationsUpSql() (*asset, error) {
+	bytes, err := _1518896215_create_notificationsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1518896215_create_notifications.up.sql", size: 1184, mode: os.FileMode(420), modTime: time.Unix(1518896215, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1518982615_create_team_eventsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\x09\xf2\x0f\x50\x08\x71\x74\xf2\x71\x55\x50\x2a\x49\x4d\xcc\x8d\x4f\x2d\x4b\xcd\x2b\x29\x56\xb2\xe6\x72\xf6\xf7\xf5\xf5\x0c\xb1\xe6\x02\x00\x0a\xa5\x7c\x97\x2b\x00\x00\x00")
+
+func _1518982615_create_team_eventsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1518982615_create_team_eventsDownSql,
+		"1518982615_create_team_events.down.sql",
+	)
+}
+
+func _1518982615_create_team_eventsDownSql() (*asset, error) {
+	bytes, err := _1518982615_create_team_eventsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1518982615_create_team_events.down.sql", size: 43, mode: os.FileMode(420), modTime: time.Unix(1518982615, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1518982615_create_team_eventsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x85\x90\xc1\x8a\x83\x30\x10\x86\xef\x7d\x8a\x9f\x9c\x14\xfa\x06\x3d\xa5\x71\xb6\x84\xd5\xb8\x44\x0b\xdb\x53\xb1\x38\x94\x80\x6b\x45\x43\xd9\xbe\xfd\x26\x65\x15\x2f\xa5\x39\xce\x37\x7c\x13\xbe\x3d\x1d\xb4\xd9\x6d\x00\x65\x49\xd6\x84\x5a\xee\x73\x82\xf0\xdc\xfc\x9c\xf9\xce\xbd\x9f\x04\x92\x80\xe3\x13\xae\x15\xb8\xb8\xeb\xc4\xa3\x6b\xba\xed\x3c\x7d\xee\x46\xe4\x7a\xcf\x57\x1e\x61\xca\x1a\xe6\x98\xe7\xb0\xf4\x41\x96\x8c\xa2\x0a\x71\x69\x42\xe2\xda\x14\xa5\x41\x46\x39\x85\x63\x4a\x56\x4a\x66\xb4\x98\x06\x37\x70\xe7\x7a\x7e\x6b\x9b\x17\xdf\x1a\xfd\x63\x60\x11\x8e\xff\xfa\xc5\xb3\xb0\x3b\x8f\x93\xbb\xf5\xaf\xf0\xd0\x3c\xba\x5b\xd3\xbe\xc0\x5f\x56\x17\xd2\x9e\xf0\x49\x27\x24\x31\x4c\x1a\x40\xba\x0a\xa9\x4d\x46\xdf\x58\x75\x3c\xff\x77\x8a\xbf\x5d\x8d\x71\xac\xb4\x39\xe0\xe2\x47\xe6\x60\x9a\x63\x6e\x9f\xb5\x83\x50\x95\x45\xa1\xeb\xdd\xe6\x0f\xce\xd1\xcd\x6e\xa9\x01\x00\x00")
+
+func _1518982615_create_team_eventsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1518982615_create_team_eventsUpSql,
+		"1518982615_create_team_events.up.sql",
+	)
+}
+
+func _1518982615_create_team_eventsUpSql() (*asset, error) {
+	bytes, err := _1518982615_create_team_eventsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1518982615_create_team_events.up.sql", size: 425, mode: os.FileMode(420), modTime: time.Unix(1518982615, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1519069015_add_metadata_to_buildsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\x09\xf2\x0f\x50\xf0\xf4\x73\x71\x8d\x50\x48\x2a\xcd\xcc\x49\x29\x8e\xcf\x4d\x2d\x49\x4c\x49\x2c\x49\x04\xc9\x3a\xfa\x84\xb8\x06\x29\x84\x38\x3a\xf9\xb8\x42\xa5\x21\x1a\x9c\xfd\x7d\x42\x7d\xfd\x14\x10\x4a\x9d\xfd\x7d\x7d\x3d\x43\xac\xb9\x00\xdd\xbd\x93\xef\x58\x00\x00\x00")
+
+func _1519069015_add_metadata_to_buildsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1519069015_add_metadata_to_buildsDownSql,
+		"1519069015_add_metadata_to_builds.down.sql",
+	)
+}
+
+func _1519069015_add_metadata_to_buildsDownSql() (*asset, error) {
+	bytes, err := _1519069015_add_metadata_to_buildsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1519069015_add_metadata_to_builds.down.sql", size: 88, mode: os.FileMode(420), modTime: time.Unix(1519069015, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1519069015_add_metadata_to_buildsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x3d\x8c\xcb\x0a\x84\x20\x14\x86\xf7\x3e\xc5\xbf\x6c\x9e\xc1\x95\xe9\x21\x04\x3d\x42\x19\xcc\x6e\x30\x8a\x28\xca\x16\xd5\xfb\x47\x30\xb5\xfe\x2e\x25\x55\x96\xa5\x00\x94\x8b\x54\x23\xaa\xd2\x11\xba\x73\x5a\xfa\x1d\xca\x18\xe8\xe0\x5a\xcf\x58\x87\x23\xf5\xe9\x48\x98\xf7\x2d\x77\xb7\xaf\x6b\x52\x91\x60\xd9\xd0\xf7\x1f\xfc\x5e\x2b\xf0\xf3\x68\x1b\xcb\x15\xc6\x29\xa3\x78\xe8\x47\x0a\x1d\xbc\xb7\x51\x8a\x0b\x1d\x9b\xb1\x2f\x7e\x00\x00\x00")
+
+func _1519069015_add_metadata_to_buildsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1519069015_add_metadata_to_buildsUpSql,
+		"1519069015_add_metadata_to_builds.up.sql",
+	)
+}
+
+func _1519069015_add_metadata_to_buildsUpSql() (*asset, error) {
+	bytes, err := _1519069015_add_metadata_to_buildsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1519069015_add_metadata_to_builds.up.sql", size: 126, mode: os.FileMode(420), modTime: time.Unix(1519069015, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1519155415_add_rerun_of_to_buildsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\x09\xf2\x0f\x50\xf0\xf4\x73\x71\x8d\x50\x48\x2a\xcd\xcc\x49\x29\x8e\x2f\x4a\x2d\x2a\xcd\x8b\xcf\x4f\xb3\xe6\x02\x4a\x3b\xfa\x84\xb8\x06\x29\x84\x38\x3a\xf9\xb8\x42\xe5\x81\x82\x50\x5d\xce\xfe\x3e\xa1\xbe\x7e\x0a\x10\xf5\x79\xa5\xb9\x49\xa9\x45\x3a\x38\x64\x41\xa6\x39\xfb\xfb\xfa\x7a\x86\x58\x73\x01\x00\xb9\xdb\x7c\xb8\x7b\x00\x00\x00")
+
+func _1519155415_add_rerun_of_to_buildsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1519155415_add_rerun_of_to_buildsDownSql,
+		"1519155415_add_rerun_of_to_builds.down.sql",
+	)
+}
+
+func _1519155415_add_rerun_of_to_buildsDownSql() (*asset, error) {
+	bytes, err := _1519155415_add_rerun_of_to_buildsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1519155415_add_rerun_of_to_builds.down.sql", size: 123, mode: os.FileMode(420), modTime: time.Unix(1519155415, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1519155415_add_rerun_of_to_buildsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x6d\x8e\x31\x0b\x83\x30\x10\x46\xf7\xfc\x8a\x6f\xac\xd0\xa1\x7b\xa6\x98\x9c\x45\xb8\x24\x10\x23\x74\x13\x44\x5b\x84\xd6\x82\xad\xff\xbf\x07\xd5\x4e\x5d\x1f\xf7\xde\x77\x25\x9d\xeb\xa0\x15\x60\x38\x53\x42\x36\x25\x13\xfa\x75\xba\x0f\x2f\x81\x82\x9d\x83\x8d\xdc\xfa\x80\x65\x5c\xd6\xb9\x7b\x5e\x31\xcd\xef\xf1\x36\x2e\x48\x54\x51\xa2\x60\xa9\xd9\x0c\x1c\xa6\xa1\x40\x0c\x70\xc4\x94\x09\x0d\x65\x84\x96\xf9\xf8\x3f\x35\xaf\x8f\x5e\x32\x7b\x2e\xc4\xef\xb5\xd8\x95\x69\x39\xe3\xa4\x95\x88\x36\x91\x91\x56\x1d\x1c\x5d\xb6\x9d\xee\xf7\x8a\x6c\xed\xd3\x3b\x2b\xb4\xb2\xd1\xfb\x3a\x6b\xf5\x01\xec\x63\x4c\x7e\xdd\x00\x00\x00")
+
+func _1519155415_add_rerun_of_to_buildsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1519155415_add_rerun_of_to_buildsUpSql,
+		"1519155415_add_rerun_of_to_builds.up.sql",
+	)
+}
+
+func _1519155415_add_rerun_of_to_buildsUpSql() (*asset, error) {
+	bytes, err := _1519155415_add_rerun_of_to_buildsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1519155415_add_rerun_of_to_builds.up.sql", size: 221, mode: os.FileMode(420), modTime: time.Unix(1519155415, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1519241815_add_input_overrides_to_buildsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x48\x2a\xcd\xcc\x49\x29\x06\x0a\x2a\x28\xb8\x04\xf9\x07\x28\x38\xfb\xfb\x84\xfa\xfa\x29\x64\xe6\x15\x94\x96\xc4\xe7\x97\xa5\x16\x15\x65\xa6\xa4\x16\x5b\x73\x39\xfb\xfb\xfa\x7a\x86\x58\x73\x01\x00\x5f\x54\x1c\x26\x45\x00\x00\x00")
+
+func _1519241815_add_input_overrides_to_buildsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1519241815_add_input_overrides_to_buildsDownSql,
+		"1519241815_add_input_overrides_to_builds.down.sql",
+	)
+}
+
+func _1519241815_add_input_overrides_to_buildsDownSql() (*asset, error) {
+	bytes, err := _1519241815_add_input_overrides_to_buildsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1519241815_add_input_overrides_to_builds.down.sql", size: 69, mode: os.FileMode(420), modTime: time.Unix(1519241815, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1519241815_add_input_overrides_to_buildsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x48\x2a\xcd\xcc\x49\x29\x06\x0a\x02\x85\x5d\x5c\x14\x9c\xfd\x7d\x42\x7d\xfd\x14\x32\xf3\x0a\x4a\x4b\xe2\xf3\xcb\x52\x8b\x8a\x32\x53\x52\x8b\x15\xb2\x8a\xf3\xf3\x92\xac\xb9\x9c\xfd\x7d\x7d\x3d\x43\xac\xb9\x00\xe0\x36\x05\xd7\x4a\x00\x00\x00")
+
+func _1519241815_add_input_overrides_to_buildsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1519241815_add_input_overrides_to_buildsUpSql,
+		"1519241815_add_input_overrides_to_builds.up.sql",
+	)
+}
+
+func _1519241815_add_input_overrides_to_buildsUpSql() (*asset, error) {
+	bytes, err := _1519241815_add_input_overrides_to_buildsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1519241815_add_input_overrides_to_builds.up.sql", size: 74, mode: os.FileMode(420), modTime: time.Unix(1519241815, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1519328215_add_archived_to_buildsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x48\x2a\xcd\xcc\x49\x29\x06\x0a\x2a\x28\xb8\x04\xf9\x07\x28\x38\xfb\xfb\x84\xfa\xfa\x29\x24\x16\x25\x67\x64\x96\xa5\xa6\x58\x73\x39\xfb\xfb\xfa\x7a\x86\x58\x73\x01\x00\x31\x1d\x10\x7c\x3e\x00\x00\x00")
+
+func _1519328215_add_archived_to_buildsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1519328215_add_archived_to_buildsDownSql,
+		"1519328215_add_archived_to_builds.down.sql",
+	)
+}
+
+func _1519328215_add_archived_to_buildsDownSql() (*asset, error) {
+	bytes, err := _1519328215_add_archived_to_buildsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1519328215_add_archived_to_builds.down.sql", size: 62, mode: os.FileMode(420), modTime: time.Unix(1519328215, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1519328215_add_archived_to_buildsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x0d\xc8\x4b\x0a\x80\x20\x14\x05\xd0\xb9\xab\xb8\xfb\x70\xe4\xaf\x10\x9e\x0a\xf1\x5c\x80\xa5\x51\x20\x05\x45\xad\xbf\xce\xf0\x68\x37\xfa\x28\x05\xa0\x88\xdd\x04\x56\x9a\x1c\xe6\x67\xef\xf5\xfe\xf3\x6f\x6b\x61\x12\xe5\x10\x51\xae\x65\xdb\xdf\x56\x31\x9f\x67\x6f\xe5\x40\x4c\x8c\x98\x89\x60\xdd\xa0\x32\x31\xd6\xd2\xef\x26\x85\x49\x21\x78\x96\xe2\x03\x34\x88\x1a\x8f\x5c\x00\x00\x00")
+
+func _1519328215_add_archived_to_buildsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1519328215_add_archived_to_buildsUpSql,
+		"1519328215_add_archived_to_builds.up.sql",
+	)
+}
+
+func _1519328215_add_archived_to_buildsUpSql() (*asset, error) {
+	bytes, err := _1519328215_add_archived_to_buildsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1519328215_add_archived_to_builds.up.sql", size: 92, mode: os.FileMode(420), modTime: time.Unix(1519328215, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1519414615_create_encryption_key_rotationsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\x09\xf2\x0f\x50\x08\x71\x74\xf2\x71\x55\x48\xcd\x4b\x2e\xaa\x2c\x28\xc9\xcc\xcf\x8b\xcf\x4e\xad\x8c\x2f\xca\x2f\x49\x04\x71\x8a\xad\xb9\x9c\xfd\x7d\x7d\x3d\x43\xac\xb9\x00\x2a\x4b\x87\x77\x36\x00\x00\x00")
+
+func _1519414615_create_encryption_key_rotationsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1519414615_create_encryption_key_rotationsDownSql,
+		"1519414615_create_encryption_key_rotations.down.sql",
+	)
+}
+
+func _1519414615_create_encryption_key_rotationsDownSql() (*asset, error) {
+	bytes, err := _1519414615_create_encryption_key_rotationsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1519414615_create_encryption_key_rotations.down.sql", size: 54, mode: os.FileMode(420), modTime: time.Unix(1519414615, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1519414615_create_encryption_key_rotationsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x85\xcf\xb1\x6e\x83\x40\x0c\x06\xe0\x9d\xa7\xf8\xc7\x44\xea\xd0\x9d\x89\xa4\xd7\x0a\x15\x48\x85\x2e\x43\xa6\xd3\x85\x38\xe1\x14\xf0\x21\xce\x15\x4d\x9f\xbe\x90\x53\x97\x66\xe8\x68\xf9\xb3\xfd\x7b\xa3\xde\xf2\x2a\x4d\x80\x6d\xad\x32\xad\xa0\xb3\x4d\xa1\x40\xdc\x8c\xb7\x41\x9c\x67\x73\xa5\x9b\x19\xbd\xd8\xa5\x08\x58\xcd\x12\x10\x7b\xec\xc8\xb0\xed\x09\x42\x5f\x82\x8f\x3a\x2f\xb3\xfa\x80\x77\x75\x78\xba\x83\xce\x06\x31\xee\x04\xc7\x42\x17\x1a\x51\xed\x34\xaa\x7d\x51\xe0\x45\xbd\x66\xfb\x42\xe3\x39\xba\xd1\x4f\x21\x6e\xa7\xff\xf1\xd9\xb1\x0b\xed\x0c\x8f\xde\x77\x64\xf9\x11\x9e\x6d\x17\x28\xe2\x25\x76\xd3\x52\x73\x8d\x09\x7f\xe9\x9f\xa6\x61\xcf\x4d\x7c\x22\x76\x3e\x87\xd3\x92\xc5\x58\x81\xb8\x9e\x82\xd8\x7e\xc0\xe4\xa4\xbd\x97\xf8\xf6\x4c\x8f\x67\xd9\x4f\xab\xf5\x3c\xbe\x4e\x93\xed\xae\x2c\x73\x9d\x26\x3f\xae\xa7\x57\x77\x57\x01\x00\x00")
+
+func _1519414615_create_encryption_key_rotationsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1519414615_create_encryption_key_rotationsUpSql,
+		"1519414615_create_encryption_key_rotations.up.sql",
+	)
+}
+
+func _1519414615_create_encryption_key_rotationsUpSql() (*asset, error) {
+	bytes, err := _1519414615_create_encryption_key_rotationsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1519414615_create_encryption_key_rotations.up.sql", size: 343, mode: os.FileMode(420), modTime: time.Unix(1519414615, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1519501015_add_rows_skipped_to_encryption_key_rotationsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x48\xcd\x4b\x2e\xaa\x2c\x28\xc9\xcc\xcf\x8b\xcf\x4e\xad\x8c\x2f\xca\x2f\x49\x04\x71\x8a\x15\x5c\x82\xfc\x03\x14\x9c\xfd\x7d\x42\x7d\xfd\x14\x8a\xf2\xcb\x8b\xe3\x8b\xb3\x33\x0b\x0a\x52\x53\xac\xb9\x9c\xfd\x7d\x7d\x3d\x43\xac\xb9\x00\x29\x96\xc3\x6e\x50\x00\x00\x00")
+
+func _1519501015_add_rows_skipped_to_encryption_key_rotationsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1519501015_add_rows_skipped_to_encryption_key_rotationsDownSql,
+		"1519501015_add_rows_skipped_to_encryption_key_rotations.down.sql",
+	)
+}
+
+func _1519501015_add_rows_skipped_to_encryption_key_rotationsDownSql() (*asset, error) {
+	bytes, err := _1519501015_add_rows_skipped_to_encryption_key_rotationsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1519501015_add_rows_skipped_to_encryption_key_rotations.down.sql", size: 80, mode: os.FileMode(420), modTime: time.Unix(1519501015, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1519501015_add_rows_skipped_to_encryption_key_rotationsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x15\xc7\x41\x0a\xc3\x20\x10\x05\xd0\xbd\xa7\xf8\x47\xe8\xde\x95\x89\xb6\x04\x46\x85\xa2\x6b\x09\xed\x50\x24\xa0\xa2\x42\xc8\xed\x4b\xde\xee\x2d\xe6\xb5\x39\x29\x00\x45\xc1\xbc\x11\xd4\x42\x06\x5c\x3e\xfd\x6a\x33\xd7\x92\x0e\xbe\x52\xaf\x73\xbf\x33\xa0\xb4\xc6\xea\x29\x5a\x87\x5e\xcf\x91\xc6\x91\x5b\xe3\x2f\x72\x99\xfc\xe3\x0e\xe7\x03\x5c\x24\x82\x36\x4f\x15\x29\xe0\x21\xc5\xea\xad\xdd\x82\x14\x7f\xb7\x6f\x7d\x5b\x6a\x00\x00\x00")
+
+func _1519501015_add_rows_skipped_to_encryption_key_rotationsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1519501015_add_rows_skipped_to_encryption_key_rotationsUpSql,
+		"1519501015_add_rows_skipped_to_encryption_key_rotations.up.sql",
+	)
+}
+
+func _1519501015_add_rows_skipped_to_encryption_key_rotationsUpSql() (*asset, error) {
+	bytes, err := _1519501015_add_rows_skipped_to_encryption_key_rotationsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1519501015_add_rows_skipped_to_encryption_key_rotations.up.sql", size: 106, mode: os.FileMode(420), modTime: time.Unix(1519501015, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 // Asset loads and returns the asset for the given name.
 // It returns an error if the asset could not be found or
 // could not be loaded.
@@ -383,6 +1013,36 @@ var _bindata = map[string]func() (*asset, error){
 	"1517330648_add_worker_resource_certs.up.sql": _1517330648_add_worker_resource_certsUpSql,
 	"1517585875_add_name_index_to_builds.down.sql": _1517585875_add_name_index_to_buildsDownSql,
 	"1517585875_add_name_index_to_builds.up.sql": _1517585875_add_name_index_to_buildsUpSql,
+	"1518016341_add_pinned_version_to_resources.down.sql": _1518016341_add_pinned_version_to_resourcesDownSql,
+	"1518016341_add_pinned_version_to_resources.up.sql": _1518016341_add_pinned_version_to_resourcesUpSql,
+	"1518203154_add_roles_to_teams.down.sql": _1518203154_add_roles_to_teamsDownSql,
+	"1518203154_add_roles_to_teams.up.sql": _1518203154_add_roles_to_teamsUpSql,
+	"1518467021_create_audit_events.down.sql": _1518467021_create_audit_eventsDownSql,
+	"1518467021_create_audit_events.up.sql": _1518467021_create_audit_eventsUpSql,
+	"1518551232_add_archived_to_pipelines.down.sql": _1518551232_add_archived_to_pipelinesDownSql,
+	"1518551232_add_archived_to_pipelines.up.sql": _1518551232_add_archived_to_pipelinesUpSql,
+	"1518637448_add_instance_vars_to_pipelines.down.sql": _1518637448_add_instance_vars_to_pipelinesDownSql,
+	"1518637448_add_instance_vars_to_pipelines.up.sql": _1518637448_add_instance_vars_to_pipelinesUpSql,
+	"1518723901_add_active_volumes_to_workers.down.sql": _1518723901_add_active_volumes_to_workersDownSql,
+	"1518723901_add_active_volumes_to_workers.up.sql": _1518723901_add_active_volumes_to_workersUpSql,
+	"1518810457_add_max_containers_to_workers.down.sql": _1518810457_add_max_containers_to_workersDownSql,
+	"1518810457_add_max_containers_to_workers.up.sql": _1518810457_add_max_containers_to_workersUpSql,
+	"1518896215_create_notifications.down.sql": _1518896215_create_notificationsDownSql,
+	"1518896215_create_notifications.up.sql": _1518896215_create_notificationsUpSql,
+	"1518982615_create_team_events.down.sql": _1518982615_create_team_eventsDownSql,
+	"1518982615_create_team_events.up.sql": _1518982615_create_team_eventsUpSql,
+	"1519069015_add_metadata_to_builds.down.sql": _1519069015_add_metadata_to_buildsDownSql,
+	"1519069015_add_metadata_to_builds.up.sql": _1519069015_add_metadata_to_buildsUpSql,
+	"1519155415_add_rerun_of_to_builds.down.sql": _1519155415_add_rerun_of_to_buildsDownSql,
+	"1519155415_add_rerun_of_to_builds.up.sql": _1519155415_add_rerun_of_to_buildsUpSql,
+	"1519241815_add_input_overrides_to_builds.down.sql": _1519241815_add_input_overrides_to_buildsDownSql,
+	"1519241815_add_input_overrides_to_builds.up.sql": _1519241815_add_input_overrides_to_buildsUpSql,
+	"1519328215_add_archived_to_builds.down.sql": _1519328215_add_archived_to_buildsDownSql,
+	"1519328215_add_archived_to_builds.up.sql": _1519328215_add_archived_to_buildsUpSql,
+	"1519414615_create_encryption_key_rotations.down.sql": _1519414615_create_encryption_key_rotationsDownSql,
+	"1519414615_create_encryption_key_rotations.up.sql": _1519414615_create_encryption_key_rotationsUpSql,
+	"1519501015_add_rows_skipped_to_encryption_key_rotations.down.sql": _1519501015_add_rows_skipped_to_encryption_key_rotationsDownSql,
+	"1519501015_add_rows_skipped_to_encryption_key_rotations.up.sql": _1519501015_add_rows_skipped_to_encryption_key_rotationsUpSql,
 }
 
 // AssetDir returns the file names below a certain
@@ -437,6 +1097,36 @@ var _bintree = &bintree{nil, map[string]*bintree{
 	"1517330648_add_worker_resource_certs.up.sql": &bintree{_1517330648_add_worker_resource_certsUpSql, map[string]*bintree{}},
 	"1517585875_add_name_index_to_builds.down.sql": &bintree{_1517585875_add_name_index_to_buildsDownSql, map[string]*bintree{}},
 	"1517585875_add_name_index_to_builds.up.sql": &bintree{_1517585875_add_name_index_to_buildsUpSql, map[string]*bintree{}},
+	"1518016341_add_pinned_version_to_resources.down.sql": &bintree{_1518016341_add_pinned_version_to_resourcesDownSql, map[string]*bintree{}},
+	"1518016341_add_pinned_version_to_resources.up.sql": &bintree{_1518016341_add_pinned_version_to_resourcesUpSql, map[string]*bintree{}},
+	"1518203154_add_roles_to_teams.down.sql": &bintree{_1518203154_add_roles_to_teamsDownSql, map[string]*bintree{}},
+	"1518203154_add_roles_to_teams.up.sql": &bintree{_1518203154_add_roles_to_teamsUpSql, map[string]*bintree{}},
+	"1518467021_create_audit_events.down.sql": &bintree{_1518467021_create_audit_eventsDownSql, map[string]*bintree{}},
+	"1518467021_create_audit_events.up.sql": &bintree{_1518467021_create_audit_eventsUpSql, map[string]*bintree{}},
+	"1518551232_add_archived_to_pipelines.down.sql": &bintree{_1518551232_add_archived_to_pipelinesDownSql, map[string]*bintree{}},
+	"1518551232_add_archived_to_pipelines.up.sql": &bintree{_1518551232_add_archived_to_pipelinesUpSql, map[string]*bintree{}},
+	"1518637448_add_instance_vars_to_pipelines.down.sql": &bintree{_1518637448_add_instance_vars_to_pipelinesDownSql, map[string]*bintree{}},
+	"1518637448_add_instance_vars_to_pipelines.up.sql": &bintree{_1518637448_add_instance_vars_to_pipelinesUpSql, map[string]*bintree{}},
+	"1518723901_add_active_volumes_to_workers.down.sql": &bintree{_1518723901_add_active_volumes_to_workersDownSql, map[string]*bintree{}},
+	"1518723901_add_active_volumes_to_workers.up.sql": &bintree{_1518723901_add_active_volumes_to_workersUpSql, map[string]*bintree{}},
+	"1518810457_add_max_containers_to_workers.down.sql": &bintree{_1518810457_add_max_containers_to_workersDownSql, map[string]*bintree{}},
+	"1518810457_add_max_containers_to_workers.up.sql": &bintree{_1518810457_add_max_containers_to_workersUpSql, map[string]*bintree{}},
+	"1518896215_create_notifications.down.sql": &bintree{_1518896215_create_notificationsDownSql, map[string]*bintree{}},
+	"1518896215_create_notifications.up.sql": &bintree{_1518896215_create_notificationsUpSql, map[string]*bintree{}},
+	"1518982615_create_team_events.down.sql": &bintree{_1518982615_create_team_eventsDownSql, map[string]*bintree{}},
+	"1518982615_create_team_events.up.sql": &bintree{_1518982615_create_team_eventsUpSql, map[string]*bintree{}},
+	"1519069015_add_metadata_to_builds.down.sql": &bintree{_1519069015_add_metadata_to_buildsDownSql, map[string]*bintree{}},
+	"1519069015_add_metadata_to_builds.up.sql": &bintree{_1519069015_add_metadata_to_buildsUpSql, map[string]*bintree{}},
+	"1519155415_add_rerun_of_to_builds.down.sql": &bintree{_1519155415_add_rerun_of_to_buildsDownSql, map[string]*bintree{}},
+	"1519155415_add_rerun_of_to_builds.up.sql": &bintree{_1519155415_add_rerun_of_to_buildsUpSql, map[string]*bintree{}},
+	"1519241815_add_input_overrides_to_builds.down.sql": &bintree{_1519241815_add_input_overrides_to_buildsDownSql, map[string]*bintree{}},
+	"1519241815_add_input_overrides_to_builds.up.sql": &bintree{_1519241815_add_input_overrides_to_buildsUpSql, map[string]*bintree{}},
+	"1519328215_add_archived_to_builds.down.sql": &bintree{_1519328215_add_archived_to_buildsDownSql, map[string]*bintree{}},
+	"1519328215_add_archived_to_builds.up.sql": &bintree{_1519328215_add_archived_to_buildsUpSql, map[string]*bintree{}},
+	"1519414615_create_encryption_key_rotations.down.sql": &bintree{_1519414615_create_encryption_key_rotationsDownSql, map[string]*bintree{}},
+	"1519414615_create_encryption_key_rotations.up.sql": &bintree{_1519414615_create_encryption_key_rotationsUpSql, map[string]*bintree{}},
+	"1519501015_add_rows_skipped_to_encryption_key_rotations.down.sql": &bintree{_1519501015_add_rows_skipped_to_encryption_key_rotationsDownSql, map[string]*bintree{}},
+	"1519501015_add_rows_skipped_to_encryption_key_rotations.up.sql": &bintree{_1519501015_add_rows_skipped_to_encryption_key_rotationsUpSql, map[string]*bintree{}},
 }}
 
 // RestoreAsset restores an asset under the given directory
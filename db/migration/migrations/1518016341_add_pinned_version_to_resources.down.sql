@@ -0,0 +1,5 @@
+BEGIN;
+  ALTER TABLE resources
+    DROP COLUMN pinned_version_id,
+    DROP COLUMN pin_comment;
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+  ALTER TABLE resources
+    ADD COLUMN pinned_version_id integer REFERENCES versioned_resources (id) ON DELETE SET NULL,
+    ADD COLUMN pin_comment text;
+COMMIT;
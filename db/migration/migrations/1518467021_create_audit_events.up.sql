@@ -0,0 +1,13 @@
+BEGIN;
+  CREATE TABLE "audit_events" (
+      "id" serial,
+      "time" timestamp with time zone DEFAULT now() NOT NULL,
+      "user" text,
+      "team_name" text,
+      "route" text NOT NULL,
+      "params" json,
+      "status" integer NOT NULL,
+      PRIMARY KEY ("id")
+  );
+  CREATE INDEX audit_events_time ON audit_events USING btree ("time");
+COMMIT;
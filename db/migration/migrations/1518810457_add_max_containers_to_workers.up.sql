@@ -0,0 +1,3 @@
+BEGIN;
+  ALTER TABLE workers ADD COLUMN max_containers integer NOT NULL DEFAULT 0;
+COMMIT;
@@ -0,0 +1,29 @@
+BEGIN;
+  CREATE TABLE "notifications" (
+      "id" serial,
+      "pipeline_id" integer NOT NULL REFERENCES pipelines (id) ON DELETE CASCADE,
+      "name" text NOT NULL,
+      "config" text NOT NULL,
+      "nonce" text,
+      "active" boolean DEFAULT true NOT NULL,
+      PRIMARY KEY ("id"),
+      UNIQUE ("pipeline_id", "name")
+  );
+
+  CREATE TABLE "notification_deliveries" (
+      "id" serial,
+      "notification_id" integer NOT NULL REFERENCES notifications (id) ON DELETE CASCADE,
+      "build_id" integer NOT NULL REFERENCES builds (id) ON DELETE CASCADE,
+      "event" text NOT NULL,
+      "payload" text NOT NULL,
+      "status" text DEFAULT 'pending' NOT NULL,
+      "attempts" integer DEFAULT 0 NOT NULL,
+      "next_attempt_time" timestamp with time zone DEFAULT now() NOT NULL,
+      "last_attempt_time" timestamp with time zone,
+      "response_status" integer,
+      "error" text,
+      PRIMARY KEY ("id")
+  );
+  CREATE INDEX notification_deliveries_notification_id ON notification_deliveries USING btree ("notification_id");
+  CREATE INDEX notification_deliveries_pending ON notification_deliveries USING btree ("next_attempt_time") WHERE "status" = 'pending';
+COMMIT;
@@ -0,0 +1,12 @@
+BEGIN;
+  CREATE TABLE "team_events" (
+      "id" bigserial,
+      "team_id" integer NOT NULL REFERENCES teams (id) ON DELETE CASCADE,
+      "pipeline_id" integer NOT NULL REFERENCES pipelines (id) ON DELETE CASCADE,
+      "type" text NOT NULL,
+      "version" text NOT NULL,
+      "payload" text NOT NULL,
+      PRIMARY KEY ("id")
+  );
+  CREATE INDEX team_events_team_id ON team_events USING btree ("team_id", "id");
+COMMIT;
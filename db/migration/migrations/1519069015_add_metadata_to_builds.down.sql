@@ -0,0 +1,4 @@
+BEGIN;
+  DROP INDEX builds_metadata;
+  ALTER TABLE builds DROP COLUMN metadata;
+COMMIT;
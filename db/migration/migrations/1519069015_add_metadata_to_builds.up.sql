@@ -0,0 +1,4 @@
+BEGIN;
+  ALTER TABLE builds ADD COLUMN metadata jsonb;
+  CREATE INDEX builds_metadata ON builds USING gin (metadata);
+COMMIT;
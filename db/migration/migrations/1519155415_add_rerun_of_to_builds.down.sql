@@ -0,0 +1,7 @@
+BEGIN;
+  DROP INDEX builds_rerun_of;
+
+  ALTER TABLE builds
+    DROP COLUMN rerun_number,
+    DROP COLUMN rerun_of;
+COMMIT;
@@ -0,0 +1,7 @@
+BEGIN;
+  ALTER TABLE builds
+    ADD COLUMN rerun_of integer REFERENCES builds (id) ON DELETE SET NULL,
+    ADD COLUMN rerun_number integer NOT NULL DEFAULT 0;
+
+  CREATE INDEX builds_rerun_of ON builds (rerun_of);
+COMMIT;
@@ -0,0 +1,4 @@
+BEGIN;
+  ALTER TABLE builds
+    ADD COLUMN input_overrides jsonb;
+COMMIT;
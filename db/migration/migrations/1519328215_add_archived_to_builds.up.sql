@@ -0,0 +1,4 @@
+BEGIN;
+  ALTER TABLE builds
+    ADD COLUMN archived boolean NOT NULL DEFAULT false;
+COMMIT;
@@ -0,0 +1,11 @@
+BEGIN;
+  CREATE TABLE encryption_key_rotations (
+    table_name text PRIMARY KEY,
+    last_id integer NOT NULL DEFAULT 0,
+    rows_rotated integer NOT NULL DEFAULT 0,
+    finished boolean NOT NULL DEFAULT false,
+    key_check text NOT NULL,
+    key_check_nonce text,
+    updated_at timestamp with time zone NOT NULL DEFAULT now()
+  );
+COMMIT;
@@ -0,0 +1,3 @@
+BEGIN;
+  ALTER TABLE encryption_key_rotations DROP COLUMN rows_skipped;
+COMMIT;
@@ -0,0 +1,3 @@
+BEGIN;
+  ALTER TABLE encryption_key_rotations ADD COLUMN rows_skipped integer NOT NULL DEFAULT 0;
+COMMIT;
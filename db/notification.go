@@ -0,0 +1,316 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db/encryption"
+	"github.com/lib/pq"
+	"github.com/tedsuo/rata"
+)
+
+type NotificationDeliveryStatus string
+
+const (
+	NotificationDeliveryPending   NotificationDeliveryStatus = "pending"
+	NotificationDeliveryDelivered NotificationDeliveryStatus = "delivered"
+	NotificationDeliveryFailed    NotificationDeliveryStatus = "failed"
+)
+
+type Notification struct {
+	ID           int
+	Config       atc.NotificationConfig
+	LastDelivery *NotificationDelivery
+}
+
+type NotificationDelivery struct {
+	ID              int
+	NotificationID  int
+	BuildID         int
+	Event           atc.BuildStatus
+	Payload         string
+	Status          NotificationDeliveryStatus
+	Attempts        int
+	LastAttemptTime time.Time
+	ResponseStatus  int
+	Error           string
+
+	// Config, TeamName and PipelineName are only set for the deliveries
+	// returned by DueDeliveries.
+	Config       atc.NotificationConfig
+	TeamName     string
+	PipelineName string
+}
+
+//go:generate counterfeiter . NotificationOutbox
+
+// NotificationOutbox holds the notifications to deliver for the state changes
+// of builds. They are added in the same transaction as the state change, so
+// that none are lost if the ATC stops before delivering them.
+type NotificationOutbox interface {
+	DueDeliveries(limit int) ([]NotificationDelivery, error)
+	Delivered(delivery NotificationDelivery, responseStatus int) error
+	Retry(delivery NotificationDelivery, responseStatus int, cause error, nextAttempt time.Time) error
+	GiveUp(delivery NotificationDelivery, responseStatus int, cause error) error
+
+	// DeleteFinished deletes the delivered and failed deliveries last
+	// attempted before the given time, other than the last delivery of each
+	// notification.
+	DeleteFinished(before time.Time) error
+}
+
+type notificationOutbox struct {
+	conn Conn
+}
+
+func NewNotificationOutbox(conn Conn) NotificationOutbox {
+	return &notificationOutbox{
+		conn: conn,
+	}
+}
+
+func (o *notificationOutbox) DueDeliveries(limit int) ([]NotificationDelivery, error) {
+	rows, err := psql.Select("d.id", "d.notification_id", "d.build_id", "d.event", "d.payload", "d.status", "d.attempts", "d.last_attempt_time", "d.response_status", "d.error", "n.config", "n.nonce", "t.name", "p.name").
+		From("notification_deliveries d").
+		Join("notifications n ON n.id = d.notification_id").
+		Join("pipelines p ON p.id = n.pipeline_id").
+		Join("teams t ON t.id = p.team_id").
+		Where(sq.Eq{
+			"d.status": NotificationDeliveryPending,
+			"n.active": true,
+		}).
+		Where(sq.Expr("d.next_attempt_time <= now()")).
+		OrderBy("d.id ASC").
+		Limit(uint64(limit)).
+		RunWith(o.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	deliveries := []NotificationDelivery{}
+
+	for rows.Next() {
+		var (
+			delivery   NotificationDelivery
+			configBlob string
+			nonce      sql.NullString
+		)
+
+		err = scanNotificationDelivery(&delivery, rows, &configBlob, &nonce, &delivery.TeamName, &delivery.PipelineName)
+		if err != nil {
+			return nil, err
+		}
+
+		delivery.Config, err = decryptNotificationConfig(o.conn.EncryptionStrategy(), configBlob, nonce)
+		if err != nil {
+			return nil, err
+		}
+
+		deliveries = append(deliveries, delivery)
+	}
+
+	return deliveries, nil
+}
+
+func (o *notificationOutbox) Delivered(delivery NotificationDelivery, responseStatus int) error {
+	return o.update(delivery, sq.Eq{
+		"status":          NotificationDeliveryDelivered,
+		"response_status": responseStatus,
+		"error":           nil,
+	})
+}
+
+func (o *notificationOutbox) Retry(delivery NotificationDelivery, responseStatus int, cause error, nextAttempt time.Time) error {
+	return o.update(delivery, sq.Eq{
+		"response_status":   nullableStatus(responseStatus),
+		"error":             cause.Error(),
+		"next_attempt_time": nextAttempt,
+	})
+}
+
+func (o *notificationOutbox) GiveUp(delivery NotificationDelivery, responseStatus int, cause error) error {
+	return o.update(delivery, sq.Eq{
+		"status":          NotificationDeliveryFailed,
+		"response_status": nullableStatus(responseStatus),
+		"error":           cause.Error(),
+	})
+}
+
+func (o *notificationOutbox) DeleteFinished(before time.Time) error {
+	_, err := psql.Delete("notification_deliveries").
+		Where(sq.NotEq{"status": NotificationDeliveryPending}).
+		Where(sq.Lt{"last_attempt_time": before}).
+		Where(sq.Expr("id NOT IN (SELECT max(id) FROM notification_deliveries GROUP BY notification_id)")).
+		RunWith(o.conn).
+		Exec()
+
+	return err
+}
+
+func (o *notificationOutbox) update(delivery NotificationDelivery, set sq.Eq) error {
+	_, err := psql.Update("notification_deliveries").
+		SetMap(set).
+		Set("attempts", sq.Expr("attempts + 1")).
+		Set("last_attempt_time", sq.Expr("now()")).
+		Where(sq.Eq{"id": delivery.ID}).
+		RunWith(o.conn).
+		Exec()
+
+	return err
+}
+
+func nullableStatus(responseStatus int) interface{} {
+	if responseStatus == 0 {
+		return nil
+	}
+
+	return responseStatus
+}
+
+func scanNotificationDelivery(delivery *NotificationDelivery, row scannable, extra ...interface{}) error {
+	var (
+		lastAttemptTime pq.NullTime
+		responseStatus  sql.NullInt64
+		deliveryErr     sql.NullString
+	)
+
+	dest := []interface{}{&delivery.ID, &delivery.NotificationID, &delivery.BuildID, &delivery.Event, &delivery.Payload, &delivery.Status, &delivery.Attempts, &lastAttemptTime, &responseStatus, &deliveryErr}
+
+	err := row.Scan(append(dest, extra...)...)
+	if err != nil {
+		return err
+	}
+
+	delivery.LastAttemptTime = lastAttemptTime.Time
+	delivery.ResponseStatus = int(responseStatus.Int64)
+	delivery.Error = deliveryErr.String
+
+	return nil
+}
+
+func decryptNotificationConfig(es encryption.Strategy, configBlob string, nonce sql.NullString) (atc.NotificationConfig, error) {
+	var noncense *string
+	if nonce.Valid {
+		noncense = &nonce.String
+	}
+
+	decryptedConfig, err := es.Decrypt(configBlob, noncense)
+	if err != nil {
+		return atc.NotificationConfig{}, err
+	}
+
+	var config atc.NotificationConfig
+	err = json.Unmarshal(decryptedConfig, &config)
+	if err != nil {
+		return atc.NotificationConfig{}, err
+	}
+
+	return config, nil
+}
+
+// enqueueNotifications adds a delivery to the outbox for each of the
+// pipeline's notifications which matches the build's new status.
+func enqueueNotifications(tx Tx, es encryption.Strategy, pipelineID int, build atc.Build) error {
+	rows, err := psql.Select("id", "config", "nonce").
+		From("notifications").
+		Where(sq.Eq{
+			"pipeline_id": pipelineID,
+			"active":      true,
+		}).
+		RunWith(tx).
+		Query()
+	if err != nil {
+		return err
+	}
+
+	notifications := []Notification{}
+
+	for rows.Next() {
+		var (
+			notification Notification
+			configBlob   string
+			nonce        sql.NullString
+		)
+
+		err = rows.Scan(&notification.ID, &configBlob, &nonce)
+		if err != nil {
+			Close(rows)
+			return err
+		}
+
+		notification.Config, err = decryptNotificationConfig(es, configBlob, nonce)
+		if err != nil {
+			Close(rows)
+			return err
+		}
+
+		notifications = append(notifications, notification)
+	}
+
+	Close(rows)
+
+	event := atc.BuildStatus(build.Status)
+
+	for _, notification := range notifications {
+		if !notification.Config.Matches(build.JobName, event) {
+			continue
+		}
+
+		payload, err := json.Marshal(atc.NotificationPayload{
+			Notification: notification.Config.Name,
+			Event:        event,
+			Build:        build,
+		})
+		if err != nil {
+			return err
+		}
+
+		_, err = psql.Insert("notification_deliveries").
+			Columns("notification_id", "build_id", "event", "payload").
+			Values(notification.ID, build.ID, event, string(payload)).
+			RunWith(tx).
+			Exec()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func notificationBuild(b *build, status atc.BuildStatus, startTime time.Time, endTime time.Time) (atc.Build, error) {
+	apiURL, err := atc.Routes.CreatePathForRoute(atc.GetBuild, rata.Params{
+		"build_id":  strconv.Itoa(b.id),
+		"team_name": b.teamName,
+	})
+	if err != nil {
+		return atc.Build{}, err
+	}
+
+	atcBuild := atc.Build{
+		ID:           b.id,
+		Name:         b.name,
+		JobName:      b.jobName,
+		PipelineName: b.pipelineName,
+		TeamName:     b.teamName,
+		Status:       string(status),
+		APIURL:       apiURL,
+	}
+
+	if !startTime.IsZero() {
+		atcBuild.StartTime = startTime.Unix()
+	}
+
+	if !endTime.IsZero() {
+		atcBuild.EndTime = endTime.Unix()
+	}
+
+	return atcBuild, nil
+}
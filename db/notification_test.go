@@ -0,0 +1,294 @@
+package db_test
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("NotificationOutbox", func() {
+	var (
+		outbox   db.NotificationOutbox
+		pipeline db.Pipeline
+		config   atc.Config
+		build    db.Build
+	)
+
+	BeforeEach(func() {
+		outbox = db.NewNotificationOutbox(dbConn)
+
+		config = atc.Config{
+			Jobs: atc.JobConfigs{
+				{Name: "some-job"},
+				{Name: "some-other-job"},
+			},
+			Notifications: atc.NotificationConfigs{
+				{
+					Name:   "chat",
+					URL:    "https://chat.example.com/hook",
+					Secret: "some-secret",
+					Events: []atc.BuildStatus{atc.StatusFailed},
+				},
+				{
+					Name: "audit",
+					URL:  "https://audit.example.com",
+					Jobs: []string{"some-job"},
+				},
+			},
+		}
+
+		var err error
+		pipeline, _, err = defaultTeam.SavePipeline("notifying-pipeline", config, db.ConfigVersion(0), db.PipelineUnpaused)
+		Expect(err).NotTo(HaveOccurred())
+
+		job, found, err := pipeline.Job("some-job")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeTrue())
+
+		build, err = job.CreateBuild()
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Context("when a build of the pipeline starts", func() {
+		BeforeEach(func() {
+			started, err := build.Start("engine", `{"meta":"data"}`, atc.Plan{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(started).To(BeTrue())
+		})
+
+		It("enqueues a delivery for the matching notifications", func() {
+			deliveries, err := outbox.DueDeliveries(10)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deliveries).To(HaveLen(1))
+
+			Expect(deliveries[0].BuildID).To(Equal(build.ID()))
+			Expect(deliveries[0].Event).To(Equal(atc.StatusStarted))
+			Expect(deliveries[0].Status).To(Equal(db.NotificationDeliveryPending))
+			Expect(deliveries[0].Config).To(Equal(config.Notifications[1]))
+			Expect(deliveries[0].TeamName).To(Equal(defaultTeam.Name()))
+			Expect(deliveries[0].PipelineName).To(Equal("notifying-pipeline"))
+
+			var payload atc.NotificationPayload
+			err = json.Unmarshal([]byte(deliveries[0].Payload), &payload)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(payload.Notification).To(Equal("audit"))
+			Expect(payload.Event).To(Equal(atc.StatusStarted))
+			Expect(payload.Build.ID).To(Equal(build.ID()))
+			Expect(payload.Build.JobName).To(Equal("some-job"))
+			Expect(payload.Build.PipelineName).To(Equal("notifying-pipeline"))
+			Expect(payload.Build.Status).To(Equal("started"))
+		})
+	})
+
+	Context("when a build of the pipeline finishes", func() {
+		BeforeEach(func() {
+			err := build.Finish(db.BuildStatusFailed)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("enqueues a delivery for each of the matching notifications", func() {
+			deliveries, err := outbox.DueDeliveries(10)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deliveries).To(HaveLen(2))
+
+			Expect(deliveries[0].Event).To(Equal(atc.StatusFailed))
+			Expect(deliveries[1].Event).To(Equal(atc.StatusFailed))
+
+			Expect([]string{deliveries[0].Config.Name, deliveries[1].Config.Name}).To(ConsistOf("chat", "audit"))
+		})
+
+		Context("when a delivery is delivered", func() {
+			BeforeEach(func() {
+				deliveries, err := outbox.DueDeliveries(10)
+				Expect(err).NotTo(HaveOccurred())
+
+				for _, delivery := range deliveries {
+					err = outbox.Delivered(delivery, 200)
+					Expect(err).NotTo(HaveOccurred())
+				}
+			})
+
+			It("is no longer due", func() {
+				deliveries, err := outbox.DueDeliveries(10)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(deliveries).To(BeEmpty())
+			})
+
+			It("is shown as the last delivery of the notification", func() {
+				notifications, err := pipeline.Notifications()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(notifications).To(HaveLen(2))
+
+				for _, notification := range notifications {
+					Expect(notification.LastDelivery).NotTo(BeNil())
+					Expect(notification.LastDelivery.Status).To(Equal(db.NotificationDeliveryDelivered))
+					Expect(notification.LastDelivery.Attempts).To(Equal(1))
+					Expect(notification.LastDelivery.ResponseStatus).To(Equal(200))
+					Expect(notification.LastDelivery.LastAttemptTime).To(BeTemporally("~", time.Now(), time.Minute))
+				}
+			})
+
+			Context("when another build finishes and is delivered", func() {
+				var otherBuild db.Build
+
+				BeforeEach(func() {
+					job, found, err := pipeline.Job("some-job")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeTrue())
+
+					otherBuild, err = job.CreateBuild()
+					Expect(err).NotTo(HaveOccurred())
+
+					err = otherBuild.Finish(db.BuildStatusFailed)
+					Expect(err).NotTo(HaveOccurred())
+
+					deliveries, err := outbox.DueDeliveries(10)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(deliveries).To(HaveLen(2))
+
+					for _, delivery := range deliveries {
+						err = outbox.Delivered(delivery, 200)
+						Expect(err).NotTo(HaveOccurred())
+					}
+				})
+
+				countDeliveries := func() int {
+					var count int
+					err := dbConn.QueryRow("SELECT COUNT(*) FROM notification_deliveries").Scan(&count)
+					Expect(err).NotTo(HaveOccurred())
+					return count
+				}
+
+				Context("when deleting the deliveries finished before now", func() {
+					BeforeEach(func() {
+						err := outbox.DeleteFinished(time.Now().Add(time.Hour))
+						Expect(err).NotTo(HaveOccurred())
+					})
+
+					It("keeps only the last delivery of each notification", func() {
+						Expect(countDeliveries()).To(Equal(2))
+
+						notifications, err := pipeline.Notifications()
+						Expect(err).NotTo(HaveOccurred())
+
+						for _, notification := range notifications {
+							Expect(notification.LastDelivery.BuildID).To(Equal(otherBuild.ID()))
+						}
+					})
+				})
+
+				Context("when deleting the deliveries finished before they were attempted", func() {
+					BeforeEach(func() {
+						err := outbox.DeleteFinished(time.Now().Add(-time.Hour))
+						Expect(err).NotTo(HaveOccurred())
+					})
+
+					It("keeps them", func() {
+						Expect(countDeliveries()).To(Equal(4))
+					})
+				})
+			})
+		})
+
+		Context("when a delivery is to be retried later", func() {
+			BeforeEach(func() {
+				deliveries, err := outbox.DueDeliveries(10)
+				Expect(err).NotTo(HaveOccurred())
+
+				for _, delivery := range deliveries {
+					err = outbox.Retry(delivery, 503, errors.New("unavailable"), time.Now().Add(time.Hour))
+					Expect(err).NotTo(HaveOccurred())
+				}
+			})
+
+			It("is not due until its next attempt", func() {
+				deliveries, err := outbox.DueDeliveries(10)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(deliveries).To(BeEmpty())
+			})
+
+			It("records the failed attempt", func() {
+				notifications, err := pipeline.Notifications()
+				Expect(err).NotTo(HaveOccurred())
+
+				for _, notification := range notifications {
+					Expect(notification.LastDelivery.Status).To(Equal(db.NotificationDeliveryPending))
+					Expect(notification.LastDelivery.Attempts).To(Equal(1))
+					Expect(notification.LastDelivery.ResponseStatus).To(Equal(503))
+					Expect(notification.LastDelivery.Error).To(Equal("unavailable"))
+				}
+			})
+		})
+
+		Context("when a delivery is given up on", func() {
+			BeforeEach(func() {
+				deliveries, err := outbox.DueDeliveries(10)
+				Expect(err).NotTo(HaveOccurred())
+
+				for _, delivery := range deliveries {
+					err = outbox.GiveUp(delivery, 0, errors.New("connection refused"))
+					Expect(err).NotTo(HaveOccurred())
+				}
+			})
+
+			It("is marked as failed", func() {
+				deliveries, err := outbox.DueDeliveries(10)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(deliveries).To(BeEmpty())
+
+				notifications, err := pipeline.Notifications()
+				Expect(err).NotTo(HaveOccurred())
+
+				for _, notification := range notifications {
+					Expect(notification.LastDelivery.Status).To(Equal(db.NotificationDeliveryFailed))
+					Expect(notification.LastDelivery.ResponseStatus).To(BeZero())
+					Expect(notification.LastDelivery.Error).To(Equal("connection refused"))
+				}
+			})
+		})
+
+		Context("when the notifications are removed from the pipeline", func() {
+			BeforeEach(func() {
+				config.Notifications = nil
+
+				var err error
+				pipeline, _, err = defaultTeam.SavePipeline("notifying-pipeline", config, pipeline.ConfigVersion(), db.PipelineUnpaused)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("no longer delivers them", func() {
+				deliveries, err := outbox.DueDeliveries(10)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(deliveries).To(BeEmpty())
+			})
+
+			It("no longer lists them", func() {
+				notifications, err := pipeline.Notifications()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(notifications).To(BeEmpty())
+			})
+		})
+	})
+
+	Context("when a one-off build finishes", func() {
+		BeforeEach(func() {
+			oneOff, err := defaultTeam.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			err = oneOff.Finish(db.BuildStatusFailed)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("enqueues nothing", func() {
+			deliveries, err := outbox.DueDeliveries(10)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deliveries).To(BeEmpty())
+		})
+	})
+})
@@ -8,6 +8,10 @@ type Page struct {
 	To   int // inclusive
 
 	Limit int
+
+	// Metadata filters the builds down to those with all of the given keys
+	// set to the given values.
+	Metadata map[string]string
 }
 
 type Pagination struct {
@@ -14,6 +14,8 @@ import (
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db/algorithm"
 	"github.com/concourse/atc/db/lock"
+	"github.com/concourse/atc/event"
+	"github.com/lib/pq"
 )
 
 type ErrResourceNotFound struct {
@@ -34,12 +36,14 @@ type Cause struct {
 type Pipeline interface {
 	ID() int
 	Name() string
+	InstanceVars() atc.InstanceVars
 	TeamID() int
 	TeamName() string
 	Groups() atc.GroupConfigs
 	ConfigVersion() ConfigVersion
 	Public() bool
 	Paused() bool
+	Archived() bool
 	ScopedName(string) string
 
 	CheckPaused() (bool, error)
@@ -90,6 +94,8 @@ type Pipeline interface {
 	ResourceTypes() (ResourceTypes, error)
 	ResourceType(name string) (ResourceType, bool, error)
 
+	Notifications() ([]Notification, error)
+
 	Job(name string) (Job, bool, error)
 	Jobs() (Jobs, error)
 	Dashboard(include string) (Dashboard, atc.GroupConfigs, error)
@@ -100,6 +106,8 @@ type Pipeline interface {
 	Pause() error
 	Unpause() error
 
+	Archive() error
+
 	Destroy() error
 	Rename(string) error
 
@@ -109,12 +117,14 @@ type Pipeline interface {
 type pipeline struct {
 	id            int
 	name          string
+	instanceVars  atc.InstanceVars
 	teamID        int
 	teamName      string
 	groups        atc.GroupConfigs
 	configVersion ConfigVersion
 	paused        bool
 	public        bool
+	archived      bool
 
 	cachedAt   time.Time
 	versionsDB *algorithm.VersionsDB
@@ -131,12 +141,14 @@ type PipelinePausedState string
 var pipelinesQuery = psql.Select(`
 		p.id,
 		p.name,
+		p.instance_vars,
 		p.groups,
 		p.version,
 		p.team_id,
 		t.name,
 		p.paused,
-		p.public
+		p.public,
+		p.archived
 	`).
 	From("pipelines p").
 	LeftJoin("teams t ON p.team_id = t.id")
@@ -170,14 +182,16 @@ func newPipeline(conn Conn, lockFactory lock.LockFactory) *pipeline {
 	}
 }
 
-func (p *pipeline) ID() int                      { return p.id }
-func (p *pipeline) Name() string                 { return p.name }
-func (p *pipeline) TeamID() int                  { return p.teamID }
-func (p *pipeline) TeamName() string             { return p.teamName }
-func (p *pipeline) Groups() atc.GroupConfigs     { return p.groups }
-func (p *pipeline) ConfigVersion() ConfigVersion { return p.configVersion }
-func (p *pipeline) Public() bool                 { return p.public }
-func (p *pipeline) Paused() bool                 { return p.paused }
+func (p *pipeline) ID() int                        { return p.id }
+func (p *pipeline) Name() string                   { return p.name }
+func (p *pipeline) InstanceVars() atc.InstanceVars { return p.instanceVars }
+func (p *pipeline) TeamID() int                    { return p.teamID }
+func (p *pipeline) TeamName() string               { return p.teamName }
+func (p *pipeline) Groups() atc.GroupConfigs       { return p.groups }
+func (p *pipeline) ConfigVersion() ConfigVersion   { return p.configVersion }
+func (p *pipeline) Public() bool                   { return p.public }
+func (p *pipeline) Paused() bool                   { return p.paused }
+func (p *pipeline) Archived() bool                 { return p.archived }
 
 func (p *pipeline) ScopedName(n string) string {
 	return p.name + ":" + n
@@ -312,24 +326,64 @@ func (p *pipeline) CreateJobBuild(jobName string) (Build, error) {
 	return build, nil
 }
 
+// SetResourceCheckError saves the error of the resource's last check, or
+// clears it if the check succeeded. Only changes to the error are sent to the
+// team's event stream, as resources are checked every minute. Nothing is
+// written if the resource was loaded with the same error.
 func (p *pipeline) SetResourceCheckError(resource Resource, cause error) error {
-	var err error
+	if sameCheckError(resource.CheckError(), cause) {
+		return nil
+	}
 
-	if cause == nil {
-		_, err = psql.Update("resources").
-			Set("check_error", nil).
-			Where(sq.Eq{"id": resource.ID()}).
-			RunWith(p.conn).
-			Exec()
-	} else {
-		_, err = psql.Update("resources").
-			Set("check_error", cause.Error()).
-			Where(sq.Eq{"id": resource.ID()}).
-			RunWith(p.conn).
-			Exec()
+	var checkError sql.NullString
+	if cause != nil {
+		checkError = sql.NullString{String: cause.Error(), Valid: true}
 	}
 
-	return err
+	tx, err := p.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer Rollback(tx)
+
+	result, err := psql.Update("resources").
+		Set("check_error", checkError).
+		Where(sq.Eq{"id": resource.ID()}).
+		Where(sq.Expr("check_error IS DISTINCT FROM ?", checkError)).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected > 0 {
+		err = saveTeamEvent(tx, p.teamID, p.id, event.ResourceCheckError{
+			Time:         time.Now().Unix(),
+			PipelineID:   p.id,
+			PipelineName: p.name,
+			ResourceName: resource.Name(),
+			CheckError:   checkError.String,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func sameCheckError(a error, b error) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+
+	return a.Error() == b.Error()
 }
 
 func (p *pipeline) GetAllPendingBuilds() (map[string][]Build, error) {
@@ -813,6 +867,76 @@ func (p *pipeline) ResourceTypes() (ResourceTypes, error) {
 	return resourceTypes, nil
 }
 
+// Notifications returns the pipeline's notifications along with their most
+// recent delivery, if any.
+func (p *pipeline) Notifications() ([]Notification, error) {
+	rows, err := p.conn.Query(`
+		SELECT n.id, n.config, n.nonce,
+			d.id, d.build_id, d.event, d.status, d.attempts, d.last_attempt_time, d.response_status, d.error
+		FROM notifications n
+		LEFT JOIN LATERAL (
+			SELECT *
+			FROM notification_deliveries
+			WHERE notification_id = n.id
+			ORDER BY id DESC
+			LIMIT 1
+		) d ON true
+		WHERE n.pipeline_id = $1
+		AND n.active
+		ORDER BY n.id ASC
+	`, p.id)
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	notifications := []Notification{}
+
+	for rows.Next() {
+		var (
+			notification Notification
+			configBlob   string
+			nonce        sql.NullString
+
+			deliveryID, buildID, attempts, responseStatus sql.NullInt64
+			event, status, deliveryErr                    sql.NullString
+			lastAttemptTime                               pq.NullTime
+		)
+
+		err = rows.Scan(
+			&notification.ID, &configBlob, &nonce,
+			&deliveryID, &buildID, &event, &status, &attempts, &lastAttemptTime, &responseStatus, &deliveryErr,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		notification.Config, err = decryptNotificationConfig(p.conn.EncryptionStrategy(), configBlob, nonce)
+		if err != nil {
+			return nil, err
+		}
+
+		if deliveryID.Valid {
+			notification.LastDelivery = &NotificationDelivery{
+				ID:              int(deliveryID.Int64),
+				NotificationID:  notification.ID,
+				BuildID:         int(buildID.Int64),
+				Event:           atc.BuildStatus(event.String),
+				Status:          NotificationDeliveryStatus(status.String),
+				Attempts:        int(attempts.Int64),
+				LastAttemptTime: lastAttemptTime.Time,
+				ResponseStatus:  int(responseStatus.Int64),
+				Error:           deliveryErr.String,
+			}
+		}
+
+		notifications = append(notifications, notification)
+	}
+
+	return notifications, nil
+}
+
 func (p *pipeline) ResourceType(name string) (ResourceType, bool, error) {
 	row := resourceTypesQuery.Where(sq.Eq{
 		"pipeline_id": p.id,
@@ -933,27 +1057,138 @@ func (p *pipeline) Dashboard(include string) (Dashboard, atc.GroupConfigs, error
 }
 
 func (p *pipeline) Pause() error {
-	_, err := psql.Update("pipelines").
-		Set("paused", true).
-		Where(sq.Eq{
-			"id": p.id,
-		}).
-		RunWith(p.conn).
-		Exec()
-
-	return err
+	return p.updatePaused(sq.Eq{"paused": true})
 }
 
 func (p *pipeline) Unpause() error {
-	_, err := psql.Update("pipelines").
-		Set("paused", false).
+	return p.updatePaused(sq.Eq{"paused": false})
+}
+
+// Archive pauses the pipeline, clears its config and marks it as archived. Its
+// builds are kept, but its jobs and resources are deactivated, so it is no
+// longer checked or scheduled and its check containers and caches are garbage
+// collected. Saving the pipeline's config again un-archives it.
+func (p *pipeline) Archive() error {
+	tx, err := p.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer Rollback(tx)
+
+	err = clearPipelineConfig(tx, p.id)
+	if err != nil {
+		return err
+	}
+
+	err = p.setPaused(tx, sq.Eq{
+		"paused":   true,
+		"archived": true,
+		"version":  sq.Expr("nextval('config_version_seq')"),
+	})
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// clearPipelineConfig deactivates the pipeline's jobs, resources, resource
+// types and notifications, so that only those saved again afterwards are
+// part of its config.
+func clearPipelineConfig(tx Tx, pipelineID int) error {
+	_, err := tx.Exec(`
+      DELETE FROM jobs_serial_groups
+      WHERE job_id in (
+        SELECT j.id
+        FROM jobs j
+        WHERE j.pipeline_id = $1
+      )
+		`, pipelineID)
+	if err != nil {
+		return err
+	}
+
+	for _, table := range []string{"jobs", "resources", "resource_types", "notifications"} {
+		_, err = psql.Update(table).
+			Set("active", false).
+			Where(sq.Eq{"pipeline_id": pipelineID}).
+			RunWith(tx).
+			Exec()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (p *pipeline) updatePaused(set sq.Eq) error {
+	tx, err := p.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer Rollback(tx)
+
+	err = p.setPaused(tx, set)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// setPaused updates the pipeline, sending the change to the team's event
+// stream if it paused or unpaused the pipeline.
+func (p *pipeline) setPaused(tx Tx, set sq.Eq) error {
+	var wasPaused bool
+	err := psql.Select("paused").
+		From("pipelines").
+		Where(sq.Eq{"id": p.id}).
+		Suffix("FOR UPDATE").
+		RunWith(tx).
+		QueryRow().
+		Scan(&wasPaused)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil
+		}
+		return err
+	}
+
+	var paused bool
+	err = psql.Update("pipelines").
+		SetMap(set).
 		Where(sq.Eq{
 			"id": p.id,
 		}).
-		RunWith(p.conn).
-		Exec()
+		Suffix("RETURNING paused").
+		RunWith(tx).
+		QueryRow().
+		Scan(&paused)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil
+		}
+		return err
+	}
 
-	return err
+	if paused == wasPaused {
+		return nil
+	}
+
+	err = saveTeamEvent(tx, p.teamID, p.id, event.PipelineStatus{
+		Time:         time.Now().Unix(),
+		PipelineID:   p.id,
+		PipelineName: p.name,
+		Paused:       paused,
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
 }
 
 func (p *pipeline) Hide() error {
@@ -1033,6 +1268,7 @@ func (p *pipeline) LoadVersionsDB() (*algorithm.VersionsDB, error) {
 		ResourceVersions: []algorithm.ResourceVersion{},
 		JobIDs:           map[string]int{},
 		ResourceIDs:      map[string]int{},
+		PinnedVersionIDs: map[int]int{},
 	}
 
 	rows, err := psql.Select("v.id, v.check_order, r.id, o.build_id, b.job_id").
@@ -1163,6 +1399,28 @@ func (p *pipeline) LoadVersionsDB() (*algorithm.VersionsDB, error) {
 		db.ResourceIDs[name] = id
 	}
 
+	rows, err = psql.Select("r.id, r.pinned_version_id").
+		From("resources r").
+		Where(sq.Eq{"r.pipeline_id": p.id}).
+		Where(sq.NotEq{"r.pinned_version_id": nil}).
+		RunWith(p.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	for rows.Next() {
+		var resourceID, versionID int
+		err = rows.Scan(&resourceID, &versionID)
+		if err != nil {
+			return nil, err
+		}
+
+		db.PinnedVersionIDs[resourceID] = versionID
+	}
+
 	p.versionsDB = db
 	p.cachedAt = latestModifiedTime
 
@@ -1539,10 +1797,13 @@ func (p *pipeline) getLatestModifiedTime() (time.Time, error) {
 	return maxModifiedTime, err
 }
 
+// getBuildsFrom loads the builds in the given materialized view. The views only
+// have the columns builds had when they were created, so the builds themselves
+// are read from the builds table.
 func (p *pipeline) getBuildsFrom(view string) (map[string]Build, error) {
 	rows, err := buildsQuery.
-		From(view + " b").
 		Where(sq.Eq{"b.pipeline_id": p.id}).
+		Where("b.id IN (SELECT id FROM " + view + ")").
 		RunWith(p.conn).Query()
 	if err != nil {
 		return nil, err
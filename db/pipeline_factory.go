@@ -24,9 +24,13 @@ func NewPipelineFactory(conn Conn, lockFactory lock.LockFactory) PipelineFactory
 	}
 }
 
+// PublicPipelines returns the public pipelines that are not archived.
 func (f *pipelineFactory) PublicPipelines() ([]Pipeline, error) {
 	rows, err := pipelinesQuery.
-		Where(sq.Eq{"p.public": true}).
+		Where(sq.Eq{
+			"p.public":   true,
+			"p.archived": false,
+		}).
 		OrderBy("t.name, ordering").
 		RunWith(f.conn).
 		Query()
@@ -42,8 +46,11 @@ func (f *pipelineFactory) PublicPipelines() ([]Pipeline, error) {
 	return pipelines, nil
 }
 
+// AllPipelines returns every pipeline that is not archived. Archived pipelines
+// are not checked, scheduled or reaped.
 func (f *pipelineFactory) AllPipelines() ([]Pipeline, error) {
 	rows, err := pipelinesQuery.
+		Where(sq.Eq{"p.archived": false}).
 		OrderBy("ordering").
 		RunWith(f.conn).
 		Query()
@@ -77,6 +77,17 @@ var _ = Describe("Pipeline Factory", func() {
 				Expect(publicPipelines[0].Name()).To(Equal(pipeline3.Name()))
 			})
 		})
+
+		Context("when a pipeline is archived", func() {
+			BeforeEach(func() {
+				Expect(pipeline1.Archive()).To(Succeed())
+			})
+
+			It("does not return the archived pipeline", func() {
+				Expect(len(publicPipelines)).To(Equal(1))
+				Expect(publicPipelines[0].Name()).To(Equal(pipeline3.Name()))
+			})
+		})
 	})
 
 	Describe("AllPipelines", func() {
@@ -128,5 +139,19 @@ var _ = Describe("Pipeline Factory", func() {
 			Expect(pipelines[1].Name()).To(Equal(pipeline2.Name()))
 			Expect(pipelines[2].Name()).To(Equal(pipeline3.Name()))
 		})
+
+		Context("when a pipeline is archived", func() {
+			BeforeEach(func() {
+				Expect(pipeline2.Archive()).To(Succeed())
+			})
+
+			It("does not return the archived pipeline, so it is not checked or scheduled", func() {
+				pipelines, err := pipelineFactory.AllPipelines()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(len(pipelines)).To(Equal(2))
+				Expect(pipelines[0].Name()).To(Equal(pipeline1.Name()))
+				Expect(pipelines[1].Name()).To(Equal(pipeline3.Name()))
+			})
+		})
 	})
 })
@@ -186,6 +186,89 @@ var _ = Describe("Pipeline", func() {
 		})
 	})
 
+	Describe("Archive", func() {
+		var configVersion db.ConfigVersion
+
+		JustBeforeEach(func() {
+			configVersion = pipeline.ConfigVersion()
+
+			Expect(pipeline.Archive()).To(Succeed())
+
+			found, err := pipeline.Reload()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+		})
+
+		It("archives the pipeline", func() {
+			Expect(pipeline.Archived()).To(BeTrue())
+		})
+
+		It("pauses the pipeline", func() {
+			Expect(pipeline.Paused()).To(BeTrue())
+		})
+
+		It("clears the pipeline's config", func() {
+			jobs, err := pipeline.Jobs()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(jobs).To(BeEmpty())
+
+			resources, err := pipeline.Resources()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resources).To(BeEmpty())
+
+			resourceTypes, err := pipeline.ResourceTypes()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resourceTypes).To(BeEmpty())
+
+			Expect(pipeline.ConfigVersion()).ToNot(Equal(configVersion))
+		})
+
+		It("leaves the pipeline out of its dashboard", func() {
+			dashboard, _, err := pipeline.Dashboard("")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(dashboard).To(BeEmpty())
+		})
+
+		Context("when the pipeline has builds", func() {
+			var build db.Build
+
+			BeforeEach(func() {
+				var err error
+				build, err = job.CreateBuild()
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("keeps the builds", func() {
+				builds, _, err := job.Builds(db.Page{Limit: 10})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(builds).To(HaveLen(1))
+				Expect(builds[0].ID()).To(Equal(build.ID()))
+			})
+		})
+
+		Context("when the pipeline's config is saved again", func() {
+			JustBeforeEach(func() {
+				var err error
+				pipeline, _, err = team.SavePipeline("fake-pipeline", pipelineConfig, pipeline.ConfigVersion(), db.PipelineNoChange)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("un-archives the pipeline", func() {
+				Expect(pipeline.Archived()).To(BeFalse())
+			})
+
+			It("restores the pipeline's config", func() {
+				jobs, err := pipeline.Jobs()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(jobs).To(HaveLen(len(pipelineConfig.Jobs)))
+			})
+
+			It("leaves the pipeline paused", func() {
+				Expect(pipeline.Paused()).To(BeTrue())
+			})
+		})
+	})
+
 	Describe("Rename", func() {
 		JustBeforeEach(func() {
 			Expect(pipeline.Rename("oopsies")).To(Succeed())
@@ -27,18 +27,25 @@ type Resource interface {
 	Paused() bool
 	WebhookToken() string
 	FailingToCheck() bool
+	PinnedVersionID() int
+	PinnedVersion() atc.Version
+	PinComment() string
 
 	SetResourceConfig(int) error
 
 	Pause() error
 	Unpause() error
 
+	PinVersion(versionedResourceID int, comment string) (bool, error)
+	UnpinVersion() error
+
 	Reload() (bool, error)
 }
 
-var resourcesQuery = psql.Select("r.id, r.name, r.config, r.check_error, r.paused, r.last_checked, r.pipeline_id, p.name, r.nonce").
+var resourcesQuery = psql.Select("r.id, r.name, r.config, r.check_error, r.paused, r.last_checked, r.pipeline_id, p.name, r.nonce, r.pinned_version_id, pv.version, r.pin_comment").
 	From("resources r").
 	Join("pipelines p ON p.id = r.pipeline_id").
+	LeftJoin("versioned_resources pv ON pv.id = r.pinned_version_id").
 	Where(sq.Eq{"r.active": true})
 
 type resource struct {
@@ -55,6 +62,10 @@ type resource struct {
 	paused       bool
 	webhookToken string
 
+	pinnedVersionID int
+	pinnedVersion   atc.Version
+	pinComment      string
+
 	conn Conn
 }
 
@@ -95,18 +106,21 @@ func (resources Resources) Configs() atc.ResourceConfigs {
 	return configs
 }
 
-func (r *resource) ID() int                { return r.id }
-func (r *resource) Name() string           { return r.name }
-func (r *resource) PipelineID() int        { return r.pipelineID }
-func (r *resource) PipelineName() string   { return r.pipelineName }
-func (r *resource) Type() string           { return r.type_ }
-func (r *resource) Source() atc.Source     { return r.source }
-func (r *resource) CheckEvery() string     { return r.checkEvery }
-func (r *resource) LastChecked() time.Time { return r.lastChecked }
-func (r *resource) Tags() atc.Tags         { return r.tags }
-func (r *resource) CheckError() error      { return r.checkError }
-func (r *resource) Paused() bool           { return r.paused }
-func (r *resource) WebhookToken() string   { return r.webhookToken }
+func (r *resource) ID() int                    { return r.id }
+func (r *resource) Name() string               { return r.name }
+func (r *resource) PipelineID() int            { return r.pipelineID }
+func (r *resource) PipelineName() string       { return r.pipelineName }
+func (r *resource) Type() string               { return r.type_ }
+func (r *resource) Source() atc.Source         { return r.source }
+func (r *resource) CheckEvery() string         { return r.checkEvery }
+func (r *resource) LastChecked() time.Time     { return r.lastChecked }
+func (r *resource) Tags() atc.Tags             { return r.tags }
+func (r *resource) CheckError() error          { return r.checkError }
+func (r *resource) Paused() bool               { return r.paused }
+func (r *resource) WebhookToken() string       { return r.webhookToken }
+func (r *resource) PinnedVersionID() int       { return r.pinnedVersionID }
+func (r *resource) PinnedVersion() atc.Version { return r.pinnedVersion }
+func (r *resource) PinComment() string         { return r.pinComment }
 func (r *resource) FailingToCheck() bool {
 	return r.checkError != nil
 }
@@ -151,6 +165,93 @@ func (r *resource) Unpause() error {
 	return err
 }
 
+// PinVersion pins the resource to one of its versions, so that every job
+// using the resource gets that version as an input. It returns false if the
+// versioned resource does not belong to the resource.
+func (r *resource) PinVersion(versionedResourceID int, comment string) (bool, error) {
+	tx, err := r.conn.Begin()
+	if err != nil {
+		return false, err
+	}
+
+	defer Rollback(tx)
+
+	err = r.touchPinnedVersion(tx)
+	if err != nil {
+		return false, err
+	}
+
+	result, err := psql.Update("resources").
+		Set("pinned_version_id", versionedResourceID).
+		Set("pin_comment", comment).
+		Where(sq.Eq{"id": r.id}).
+		Where(sq.Expr("EXISTS (SELECT 1 FROM versioned_resources WHERE id = ? AND resource_id = ?)", versionedResourceID, r.id)).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	if rowsAffected != 1 {
+		return false, nil
+	}
+
+	err = r.touchPinnedVersion(tx)
+	if err != nil {
+		return false, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (r *resource) UnpinVersion() error {
+	tx, err := r.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer Rollback(tx)
+
+	err = r.touchPinnedVersion(tx)
+	if err != nil {
+		return err
+	}
+
+	_, err = psql.Update("resources").
+		Set("pinned_version_id", nil).
+		Set("pin_comment", nil).
+		Where(sq.Eq{"id": r.id}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// touchPinnedVersion bumps the modified time of the version the resource is
+// currently pinned to, so that the pipeline's cached VersionsDB is reloaded.
+func (r *resource) touchPinnedVersion(tx Tx) error {
+	_, err := psql.Update("versioned_resources").
+		Set("modified_time", sq.Expr("now()")).
+		Where(sq.Expr("id = (SELECT pinned_version_id FROM resources WHERE id = ?)", r.id)).
+		RunWith(tx).
+		Exec()
+
+	return err
+}
+
 func (r *resource) SetResourceConfig(resourceConfigID int) error {
 	_, err := psql.Update("resources").
 		Set("resource_config_id", resourceConfigID).
@@ -167,16 +268,29 @@ func (r *resource) SetResourceConfig(resourceConfigID int) error {
 
 func scanResource(r *resource, row scannable) error {
 	var (
-		configBlob      []byte
-		checkErr, nonce sql.NullString
-		lastChecked     pq.NullTime
+		configBlob                  []byte
+		checkErr, nonce, pinComment sql.NullString
+		lastChecked                 pq.NullTime
+		pinnedVersionID             sql.NullInt64
+		pinnedVersion               []byte
 	)
 
-	err := row.Scan(&r.id, &r.name, &configBlob, &checkErr, &r.paused, &lastChecked, &r.pipelineID, &r.pipelineName, &nonce)
+	err := row.Scan(&r.id, &r.name, &configBlob, &checkErr, &r.paused, &lastChecked, &r.pipelineID, &r.pipelineName, &nonce, &pinnedVersionID, &pinnedVersion, &pinComment)
 	if err != nil {
 		return err
 	}
 
+	r.pinnedVersionID = int(pinnedVersionID.Int64)
+	r.pinComment = pinComment.String
+
+	r.pinnedVersion = nil
+	if pinnedVersion != nil {
+		err = json.Unmarshal(pinnedVersion, &r.pinnedVersion)
+		if err != nil {
+			return err
+		}
+	}
+
 	r.lastChecked = lastChecked.Time
 
 	es := r.conn.EncryptionStrategy()
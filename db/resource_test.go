@@ -167,4 +167,94 @@ var _ = Describe("Resource", func() {
 		})
 	})
 
+	Describe("PinVersion", func() {
+		var (
+			resource        db.Resource
+			savedVR         db.SavedVersionedResource
+			pinnedVersionID int
+			pinned          bool
+			err             error
+			found           bool
+		)
+
+		BeforeEach(func() {
+			err = pipeline.SaveResourceVersions(atc.ResourceConfig{
+				Name:   "some-resource",
+				Type:   "docker-image",
+				Source: atc.Source{"some": "repository"},
+			}, []atc.Version{{"version": "v1"}})
+			Expect(err).ToNot(HaveOccurred())
+
+			savedVR, found, err = pipeline.GetLatestVersionedResource("some-resource")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			pinnedVersionID = savedVR.ID
+		})
+
+		JustBeforeEach(func() {
+			resource, found, err = pipeline.Resource("some-resource")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			pinned, err = resource.PinVersion(pinnedVersionID, "some-comment")
+			Expect(err).ToNot(HaveOccurred())
+
+			found, err = resource.Reload()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+		})
+
+		It("pins the resource to the version", func() {
+			Expect(pinned).To(BeTrue())
+			Expect(resource.PinnedVersionID()).To(Equal(savedVR.ID))
+			Expect(resource.PinnedVersion()).To(Equal(atc.Version{"version": "v1"}))
+			Expect(resource.PinComment()).To(Equal("some-comment"))
+		})
+
+		It("pins the version in the pipeline's versions DB", func() {
+			versionsDB, err := pipeline.LoadVersionsDB()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(versionsDB.PinnedVersionIDs).To(Equal(map[int]int{resource.ID(): savedVR.ID}))
+		})
+
+		It("can be unpinned", func() {
+			err = resource.UnpinVersion()
+			Expect(err).ToNot(HaveOccurred())
+
+			found, err = resource.Reload()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			Expect(resource.PinnedVersionID()).To(BeZero())
+			Expect(resource.PinnedVersion()).To(BeNil())
+			Expect(resource.PinComment()).To(BeEmpty())
+
+			versionsDB, err := pipeline.LoadVersionsDB()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(versionsDB.PinnedVersionIDs).To(BeEmpty())
+		})
+
+		Context("when the version belongs to a different resource", func() {
+			BeforeEach(func() {
+				err = pipeline.SaveResourceVersions(atc.ResourceConfig{
+					Name:   "some-other-resource",
+					Type:   "git",
+					Source: atc.Source{"some": "other-repository"},
+				}, []atc.Version{{"version": "v2"}})
+				Expect(err).ToNot(HaveOccurred())
+
+				otherVR, found, err := pipeline.GetLatestVersionedResource("some-other-resource")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				pinnedVersionID = otherVR.ID
+			})
+
+			It("does not pin the resource", func() {
+				Expect(pinned).To(BeFalse())
+				Expect(resource.PinnedVersionID()).To(BeZero())
+			})
+		})
+	})
 })
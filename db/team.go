@@ -29,6 +29,7 @@ type Team interface {
 
 	// BasicAuth() *atc.BasicAuth
 	Auth() map[string]*json.RawMessage
+	Roles() atc.TeamRoles
 
 	Delete() error
 	Rename(string) error
@@ -39,22 +40,34 @@ type Team interface {
 		from ConfigVersion,
 		pausedState PipelinePausedState,
 	) (Pipeline, bool, error)
+	SavePipelineInstance(
+		pipelineName string,
+		instanceVars atc.InstanceVars,
+		config atc.Config,
+		from ConfigVersion,
+		pausedState PipelinePausedState,
+	) (Pipeline, bool, error)
 
 	Pipeline(pipelineName string) (Pipeline, bool, error)
+	PipelineInstance(pipelineName string, instanceVars atc.InstanceVars) (Pipeline, bool, error)
 	Pipelines() ([]Pipeline, error)
 	PublicPipelines() ([]Pipeline, error)
 	VisiblePipelines() ([]Pipeline, error)
 	OrderPipelines([]string) error
 
+	Events(from int) (TeamEventSource, error)
+	PublicEvents(from int) (TeamEventSource, error)
+
 	CreateOneOffBuild() (Build, error)
 	PrivateAndPublicBuilds(Page) ([]Build, Pagination, error)
+	SearchBuildLogs(BuildLogSearch, Page) ([]BuildLogMatch, Pagination, error)
 
 	SaveWorker(atcWorker atc.Worker, ttl time.Duration) (Worker, error)
 	Workers() ([]Worker, error)
 
 	FindContainerByHandle(string) (Container, bool, error)
 	FindContainersByMetadata(ContainerMetadata) ([]Container, error)
-	FindCheckContainers(lager.Logger, string, string, creds.VariablesFactory) ([]Container, error)
+	FindCheckContainers(lager.Logger, string, atc.InstanceVars, string, creds.VariablesFactory) ([]Container, error)
 
 	FindCreatedContainerByHandle(string) (CreatedContainer, bool, error)
 
@@ -63,9 +76,11 @@ type Team interface {
 	FindWorkerForContainerByOwner(ContainerOwner) (Worker, bool, error)
 	FindContainerOnWorker(workerName string, owner ContainerOwner) (CreatingContainer, CreatedContainer, error)
 	CreateContainer(workerName string, owner ContainerOwner, meta ContainerMetadata) (CreatingContainer, error)
+	ReserveContainer(workerName string, owner ContainerOwner, meta ContainerMetadata, maxContainers int) (CreatingContainer, bool, error)
 
 	// UpdateBasicAuth(basicAuth *atc.BasicAuth) error
 	UpdateProviderAuth(auth map[string]*json.RawMessage) error
+	UpdateRoles(roles atc.TeamRoles) error
 
 	CreatePipe(string, string) error
 	GetPipe(string) (Pipe, error)
@@ -81,7 +96,8 @@ type team struct {
 
 	// basicAuth *atc.BasicAuth
 
-	auth map[string]*json.RawMessage
+	auth  map[string]*json.RawMessage
+	roles atc.TeamRoles
 }
 
 func (t *team) ID() int      { return t.id }
@@ -90,6 +106,7 @@ func (t *team) Admin() bool  { return t.admin }
 
 // func (t *team) BasicAuth() *atc.BasicAuth         { return t.basicAuth }
 func (t *team) Auth() map[string]*json.RawMessage { return t.auth }
+func (t *team) Roles() atc.TeamRoles              { return t.roles }
 
 func (t *team) Delete() error {
 	tx, err := t.conn.Begin()
@@ -185,24 +202,97 @@ func (t *team) FindContainerOnWorker(workerName string, owner ContainerOwner) (C
 }
 
 func (t *team) CreateContainer(workerName string, owner ContainerOwner, meta ContainerMetadata) (CreatingContainer, error) {
-	handle, err := uuid.NewV4()
+	tx, err := t.conn.Begin()
 	if err != nil {
 		return nil, err
 	}
 
-	var containerID int
-	cols := []interface{}{&containerID}
+	defer Rollback(tx)
 
-	metadata := &ContainerMetadata{}
-	cols = append(cols, metadata.ScanTargets()...)
+	container, err := t.createContainer(tx, workerName, owner, meta)
+	if err != nil {
+		return nil, err
+	}
 
-	tx, err := t.conn.Begin()
+	err = tx.Commit()
 	if err != nil {
 		return nil, err
 	}
 
+	return container, nil
+}
+
+// ReserveContainer creates the container on the worker like CreateContainer
+// does, unless the worker already has maxContainers creating or created
+// containers. The worker is locked while its containers are counted, so that
+// reservations made at the same time, by any ATC, cannot exceed the limit.
+func (t *team) ReserveContainer(workerName string, owner ContainerOwner, meta ContainerMetadata, maxContainers int) (CreatingContainer, bool, error) {
+	tx, err := t.conn.Begin()
+	if err != nil {
+		return nil, false, err
+	}
+
 	defer Rollback(tx)
 
+	var name string
+	err = psql.Select("name").
+		From("workers").
+		Where(sq.Eq{"name": workerName}).
+		Suffix("FOR UPDATE").
+		RunWith(tx).
+		QueryRow().
+		Scan(&name)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, false, ErrWorkerNotPresent
+		}
+
+		return nil, false, err
+	}
+
+	var containers int
+	err = psql.Select("COUNT(*)").
+		From("containers").
+		Where(sq.Eq{
+			"worker_name": workerName,
+			"state":       []string{ContainerStateCreating, ContainerStateCreated},
+		}).
+		RunWith(tx).
+		QueryRow().
+		Scan(&containers)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if containers >= maxContainers {
+		return nil, false, nil
+	}
+
+	container, err := t.createContainer(tx, workerName, owner, meta)
+	if err != nil {
+		return nil, false, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, false, err
+	}
+
+	return container, true, nil
+}
+
+func (t *team) createContainer(tx Tx, workerName string, owner ContainerOwner, meta ContainerMetadata) (CreatingContainer, error) {
+	handle, err := uuid.NewV4()
+	if err != nil {
+		return nil, err
+	}
+
+	var containerID int
+	cols := []interface{}{&containerID}
+
+	metadata := &ContainerMetadata{}
+	cols = append(cols, metadata.ScanTargets()...)
+
 	insMap := meta.SQLMap()
 	insMap["worker_name"] = workerName
 	insMap["handle"] = handle.String()
@@ -231,11 +321,6 @@ func (t *team) CreateContainer(workerName string, owner ContainerOwner, meta Con
 		return nil, err
 	}
 
-	err = tx.Commit()
-	if err != nil {
-		return nil, err
-	}
-
 	return newCreatingContainer(
 		containerID,
 		handle.String(),
@@ -301,8 +386,8 @@ func (t *team) FindContainersByMetadata(metadata ContainerMetadata) ([]Container
 	return containers, nil
 }
 
-func (t *team) FindCheckContainers(logger lager.Logger, pipelineName string, resourceName string, variablesFactory creds.VariablesFactory) ([]Container, error) {
-	pipeline, found, err := t.Pipeline(pipelineName)
+func (t *team) FindCheckContainers(logger lager.Logger, pipelineName string, instanceVars atc.InstanceVars, resourceName string, variablesFactory creds.VariablesFactory) ([]Container, error) {
+	pipeline, found, err := t.PipelineInstance(pipelineName, instanceVars)
 	if err != nil {
 		return nil, err
 	}
@@ -404,12 +489,30 @@ func (t *team) SavePipeline(
 	config atc.Config,
 	from ConfigVersion,
 	pausedState PipelinePausedState,
+) (Pipeline, bool, error) {
+	return t.SavePipelineInstance(pipelineName, nil, config, from, pausedState)
+}
+
+// SavePipelineInstance saves the config of the instance of the pipeline
+// identified by the given instance vars. New instances are ordered along with
+// the other instances of the pipeline.
+func (t *team) SavePipelineInstance(
+	pipelineName string,
+	instanceVars atc.InstanceVars,
+	config atc.Config,
+	from ConfigVersion,
+	pausedState PipelinePausedState,
 ) (Pipeline, bool, error) {
 	groupsPayload, err := json.Marshal(config.Groups)
 	if err != nil {
 		return nil, false, err
 	}
 
+	instanceVarsPayload, err := marshalInstanceVars(instanceVars)
+	if err != nil {
+		return nil, false, err
+	}
+
 	var created bool
 	var existingConfig int
 
@@ -420,12 +523,16 @@ func (t *team) SavePipeline(
 
 	defer Rollback(tx)
 
-	err = tx.QueryRow(`
-		SELECT COUNT(1)
-		FROM pipelines
-		WHERE name = $1
-	  AND team_id = $2
-	`, pipelineName, t.id).Scan(&existingConfig)
+	err = psql.Select("COUNT(1)").
+		From("pipelines").
+		Where(sq.Eq{
+			"name":    pipelineName,
+			"team_id": t.id,
+		}).
+		Where(instanceVarsEq("instance_vars", instanceVarsPayload)).
+		RunWith(tx).
+		QueryRow().
+		Scan(&existingConfig)
 	if err != nil {
 		return nil, false, err
 	}
@@ -438,12 +545,13 @@ func (t *team) SavePipeline(
 
 		err = psql.Insert("pipelines").
 			SetMap(map[string]interface{}{
-				"name":     pipelineName,
-				"groups":   groupsPayload,
-				"version":  sq.Expr("nextval('config_version_seq')"),
-				"ordering": sq.Expr("currval('pipelines_id_seq')"),
-				"paused":   pausedState.Bool(),
-				"team_id":  t.id,
+				"name":          pipelineName,
+				"instance_vars": instanceVarsPayload,
+				"groups":        groupsPayload,
+				"version":       sq.Expr("nextval('config_version_seq')"),
+				"ordering":      sq.Expr("COALESCE((SELECT MIN(ordering) FROM pipelines WHERE name = ? AND team_id = ?), currval('pipelines_id_seq'))", pipelineName, t.id),
+				"paused":        pausedState.Bool(),
+				"team_id":       t.id,
 			}).
 			Suffix("RETURNING id").
 			RunWith(tx).
@@ -479,11 +587,13 @@ func (t *team) SavePipeline(
 		update := psql.Update("pipelines").
 			Set("groups", groupsPayload).
 			Set("version", sq.Expr("nextval('config_version_seq')")).
+			Set("archived", false).
 			Where(sq.Eq{
 				"name":    pipelineName,
 				"version": from,
 				"team_id": t.id,
 			}).
+			Where(instanceVarsEq("instance_vars", instanceVarsPayload)).
 			Suffix("RETURNING id")
 
 		if pausedState != PipelineNoChange {
@@ -499,41 +609,7 @@ func (t *team) SavePipeline(
 			return nil, false, err
 		}
 
-		_, err = tx.Exec(`
-      DELETE FROM jobs_serial_groups
-      WHERE job_id in (
-        SELECT j.id
-        FROM jobs j
-        WHERE j.pipeline_id = $1
-      )
-		`, pipelineID)
-		if err != nil {
-			return nil, false, err
-		}
-
-		_, err = tx.Exec(`
-			UPDATE jobs
-			SET active = false
-			WHERE pipeline_id = $1
-		`, pipelineID)
-		if err != nil {
-			return nil, false, err
-		}
-
-		_, err = tx.Exec(`
-			UPDATE resources
-			SET active = false
-			WHERE pipeline_id = $1
-		`, pipelineID)
-		if err != nil {
-			return nil, false, err
-		}
-
-		_, err = tx.Exec(`
-			UPDATE resource_types
-			SET active = false
-			WHERE pipeline_id = $1
-		`, pipelineID)
+		err = clearPipelineConfig(tx, pipelineID)
 		if err != nil {
 			return nil, false, err
 		}
@@ -567,6 +643,13 @@ func (t *team) SavePipeline(
 		}
 	}
 
+	for _, notification := range config.Notifications {
+		err = t.saveNotification(tx, notification, pipelineID)
+		if err != nil {
+			return nil, false, err
+		}
+	}
+
 	err = removeUnusedWorkerTaskCaches(tx, pipelineID, config.Jobs)
 	if err != nil {
 		return nil, false, err
@@ -594,15 +677,25 @@ func (t *team) SavePipeline(
 }
 
 func (t *team) Pipeline(pipelineName string) (Pipeline, bool, error) {
+	return t.PipelineInstance(pipelineName, nil)
+}
+
+func (t *team) PipelineInstance(pipelineName string, instanceVars atc.InstanceVars) (Pipeline, bool, error) {
+	instanceVarsPayload, err := marshalInstanceVars(instanceVars)
+	if err != nil {
+		return nil, false, err
+	}
+
 	pipeline := newPipeline(t.conn, t.lockFactory)
 
-	err := scanPipeline(
+	err = scanPipeline(
 		pipeline,
 		pipelinesQuery.
 			Where(sq.Eq{
 				"p.team_id": t.id,
 				"p.name":    pipelineName,
 			}).
+			Where(instanceVarsEq("p.instance_vars", instanceVarsPayload)).
 			RunWith(t.conn).
 			QueryRow(),
 	)
@@ -616,12 +709,14 @@ func (t *team) Pipeline(pipelineName string) (Pipeline, bool, error) {
 	return pipeline, true, nil
 }
 
+// Pipelines returns the team's pipelines, with the instances of a pipeline
+// grouped together in the order they were created.
 func (t *team) Pipelines() ([]Pipeline, error) {
 	rows, err := pipelinesQuery.
 		Where(sq.Eq{
 			"team_id": t.id,
 		}).
-		OrderBy("ordering").
+		OrderBy("ordering", "p.id").
 		RunWith(t.conn).
 		Query()
 	if err != nil {
@@ -642,7 +737,7 @@ func (t *team) PublicPipelines() ([]Pipeline, error) {
 			"team_id": t.id,
 			"public":  true,
 		}).
-		OrderBy("team_id ASC", "ordering ASC").
+		OrderBy("team_id ASC", "ordering ASC", "p.id ASC").
 		RunWith(t.conn).
 		Query()
 	if err != nil {
@@ -657,10 +752,15 @@ func (t *team) PublicPipelines() ([]Pipeline, error) {
 	return pipelines, nil
 }
 
+// VisiblePipelines returns the team's pipelines and other teams' public
+// pipelines, leaving out archived ones.
 func (t *team) VisiblePipelines() ([]Pipeline, error) {
 	rows, err := pipelinesQuery.
-		Where(sq.Eq{"team_id": t.id}).
-		OrderBy("team_id ASC", "ordering ASC").
+		Where(sq.Eq{
+			"team_id":    t.id,
+			"p.archived": false,
+		}).
+		OrderBy("team_id ASC", "ordering ASC", "p.id ASC").
 		RunWith(t.conn).
 		Query()
 	if err != nil {
@@ -674,8 +774,11 @@ func (t *team) VisiblePipelines() ([]Pipeline, error) {
 
 	rows, err = pipelinesQuery.
 		Where(sq.NotEq{"team_id": t.id}).
-		Where(sq.Eq{"public": true}).
-		OrderBy("team_id ASC", "ordering ASC").
+		Where(sq.Eq{
+			"public":     true,
+			"p.archived": false,
+		}).
+		OrderBy("team_id ASC", "ordering ASC", "p.id ASC").
 		RunWith(t.conn).
 		Query()
 	if err != nil {
@@ -715,6 +818,48 @@ func (t *team) OrderPipelines(pipelineNames []string) error {
 	return tx.Commit()
 }
 
+// Events streams the changes to the team's pipelines after the event with the
+// given ID, or from now on if the ID is 0.
+func (t *team) Events(from int) (TeamEventSource, error) {
+	return t.events(from, false)
+}
+
+// PublicEvents is like Events, but only streams the changes to the team's
+// public pipelines.
+func (t *team) PublicEvents(from int) (TeamEventSource, error) {
+	return t.events(from, true)
+}
+
+func (t *team) events(from int, publicOnly bool) (TeamEventSource, error) {
+	notifier, err := newConditionNotifier(t.conn.Bus(), teamEventsChannel(t.id), func() (bool, error) {
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if from == 0 {
+		err = psql.Select("COALESCE(MAX(id), 0)").
+			From("team_events").
+			Where(sq.Eq{"team_id": t.id}).
+			RunWith(t.conn).
+			QueryRow().
+			Scan(&from)
+		if err != nil {
+			_ = notifier.Close()
+			return nil, err
+		}
+	}
+
+	return newTeamEventSource(
+		t.id,
+		publicOnly,
+		t.conn,
+		notifier,
+		from,
+	), nil
+}
+
 func (t *team) CreateOneOffBuild() (Build, error) {
 	tx, err := t.conn.Begin()
 	if err != nil {
@@ -748,6 +893,22 @@ func (t *team) PrivateAndPublicBuilds(page Page) ([]Build, Pagination, error) {
 	return getBuildsWithPagination(newBuildsQuery, page, t.conn, t.lockFactory)
 }
 
+// SearchBuildLogs searches the logs of the team's builds and of the builds
+// of public jobs in public pipelines.
+func (t *team) SearchBuildLogs(search BuildLogSearch, page Page) ([]BuildLogMatch, Pagination, error) {
+	jobIDs, err := publicJobIDs(t.conn, t.lockFactory)
+	if err != nil {
+		return nil, Pagination{}, err
+	}
+
+	visible := sq.Or{sq.Eq{"t.id": t.id}}
+	if len(jobIDs) > 0 {
+		visible = append(visible, sq.Eq{"b.job_id": jobIDs})
+	}
+
+	return searchBuildLogs(buildsQuery.Where(visible), search, page, t.conn, t.lockFactory)
+}
+
 func (t *team) SaveWorker(atcWorker atc.Worker, ttl time.Duration) (Worker, error) {
 	tx, err := t.conn.Begin()
 	if err != nil {
@@ -803,12 +964,28 @@ func (t *team) UpdateProviderAuth(auth map[string]*json.RawMessage) error {
 		UPDATE teams
 		SET auth = $1, nonce = $3
 		WHERE id = $2
-		RETURNING id, name, admin, auth, nonce
+		RETURNING id, name, admin, auth, nonce, roles
 	`
 	params := []interface{}{string(encryptedAuth), t.id, nonce}
 	return t.queryTeam(query, params)
 }
 
+func (t *team) UpdateRoles(roles atc.TeamRoles) error {
+	jsonEncodedRoles, err := json.Marshal(roles)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE teams
+		SET roles = $1
+		WHERE id = $2
+		RETURNING id, name, admin, auth, nonce, roles
+	`
+	params := []interface{}{string(jsonEncodedRoles), t.id}
+	return t.queryTeam(query, params)
+}
+
 func (t *team) CreatePipe(pipeGUID string, url string) error {
 	tx, err := t.conn.Begin()
 	if err != nil {
@@ -943,6 +1120,39 @@ func (t *team) saveResource(tx Tx, resource atc.ResourceConfig, pipelineID int)
 	return swallowUniqueViolation(err)
 }
 
+func (t *team) saveNotification(tx Tx, notification atc.NotificationConfig, pipelineID int) error {
+	configPayload, err := json.Marshal(notification)
+	if err != nil {
+		return err
+	}
+
+	es := t.conn.EncryptionStrategy()
+	encryptedPayload, nonce, err := es.Encrypt(configPayload)
+	if err != nil {
+		return err
+	}
+
+	updated, err := checkIfRowsUpdated(tx, `
+		UPDATE notifications
+		SET config = $3, active = true, nonce = $4
+		WHERE name = $1 AND pipeline_id = $2
+	`, notification.Name, pipelineID, encryptedPayload, nonce)
+	if err != nil {
+		return err
+	}
+
+	if updated {
+		return nil
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO notifications (name, pipeline_id, config, active, nonce)
+		VALUES ($1, $2, $3, true, $4)
+	`, notification.Name, pipelineID, encryptedPayload, nonce)
+
+	return swallowUniqueViolation(err)
+}
+
 func (t *team) saveResourceType(tx Tx, resourceType atc.ResourceType, pipelineID int) error {
 	configPayload, err := json.Marshal(resourceType)
 	if err != nil {
@@ -1032,12 +1242,19 @@ func (t *team) findContainer(whereClause sq.Sqlizer) (CreatingContainer, Created
 }
 
 func scanPipeline(p *pipeline, scan scannable) error {
-	var groups sql.NullString
-	err := scan.Scan(&p.id, &p.name, &groups, &p.configVersion, &p.teamID, &p.teamName, &p.paused, &p.public)
+	var groups, instanceVars sql.NullString
+	err := scan.Scan(&p.id, &p.name, &instanceVars, &groups, &p.configVersion, &p.teamID, &p.teamName, &p.paused, &p.public, &p.archived)
 	if err != nil {
 		return err
 	}
 
+	if instanceVars.Valid {
+		err = json.Unmarshal([]byte(instanceVars.String), &p.instanceVars)
+		if err != nil {
+			return err
+		}
+	}
+
 	if groups.Valid {
 		var pipelineGroups atc.GroupConfigs
 		err = json.Unmarshal([]byte(groups.String), &pipelineGroups)
@@ -1051,6 +1268,31 @@ func scanPipeline(p *pipeline, scan scannable) error {
 	return nil
 }
 
+// marshalInstanceVars marshals the instance vars of a pipeline, returning nil
+// for a pipeline without instance vars so that it is stored as NULL.
+func marshalInstanceVars(instanceVars atc.InstanceVars) (*string, error) {
+	if len(instanceVars) == 0 {
+		return nil, nil
+	}
+
+	payload, err := json.Marshal(instanceVars)
+	if err != nil {
+		return nil, err
+	}
+
+	instanceVarsPayload := string(payload)
+
+	return &instanceVarsPayload, nil
+}
+
+func instanceVarsEq(column string, instanceVarsPayload *string) sq.Sqlizer {
+	if instanceVarsPayload == nil {
+		return sq.Expr("(" + column + " IS NULL OR " + column + " = '{}'::jsonb)")
+	}
+
+	return sq.Expr(column+" = ?::jsonb", *instanceVarsPayload)
+}
+
 func scanPipelines(conn Conn, lockFactory lock.LockFactory, rows *sql.Rows) ([]Pipeline, error) {
 	defer Close(rows)
 
@@ -1071,7 +1313,7 @@ func scanPipelines(conn Conn, lockFactory lock.LockFactory, rows *sql.Rows) ([]P
 }
 
 func (t *team) queryTeam(query string, params []interface{}) error {
-	var providerAuth, nonce sql.NullString
+	var providerAuth, nonce, roles sql.NullString
 
 	tx, err := t.conn.Begin()
 	if err != nil {
@@ -1085,6 +1327,7 @@ func (t *team) queryTeam(query string, params []interface{}) error {
 		&t.admin,
 		&providerAuth,
 		&nonce,
+		&roles,
 	)
 	if err != nil {
 		return err
@@ -1120,6 +1363,14 @@ func (t *team) queryTeam(query string, params []interface{}) error {
 		}
 	}
 
+	t.roles = nil
+	if roles.Valid {
+		err = json.Unmarshal([]byte(roles.String), &t.roles)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -0,0 +1,38 @@
+package db
+
+//go:generate counterfeiter . TeamEventLog
+
+// TeamEventLog holds the events streamed to clients watching a team's
+// pipelines, so that a client can resume the stream from the last event it
+// received.
+type TeamEventLog interface {
+	Prune(keep int) error
+}
+
+type teamEventLog struct {
+	conn Conn
+}
+
+func NewTeamEventLog(conn Conn) TeamEventLog {
+	return &teamEventLog{
+		conn: conn,
+	}
+}
+
+// Prune deletes all but the given number of each team's most recent events.
+// Clients resuming from a deleted event are streamed the events that are
+// left.
+func (l *teamEventLog) Prune(keep int) error {
+	_, err := l.conn.Exec(`
+		DELETE FROM team_events
+		WHERE id IN (
+			SELECT id FROM (
+				SELECT id, row_number() OVER (PARTITION BY team_id ORDER BY id DESC) AS n
+				FROM team_events
+			) e
+			WHERE n > $1
+		)
+	`, keep)
+
+	return err
+}
@@ -0,0 +1,74 @@
+package db_test
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TeamEventLog", func() {
+	var (
+		teamEventLog db.TeamEventLog
+		otherTeam    db.Team
+	)
+
+	teamEventIDs := func(team db.Team) []int {
+		rows, err := dbConn.Query("SELECT id FROM team_events WHERE team_id = $1 ORDER BY id ASC", team.ID())
+		Expect(err).NotTo(HaveOccurred())
+
+		defer rows.Close()
+
+		ids := []int{}
+		for rows.Next() {
+			var id int
+			Expect(rows.Scan(&id)).To(Succeed())
+			ids = append(ids, id)
+		}
+
+		return ids
+	}
+
+	BeforeEach(func() {
+		teamEventLog = db.NewTeamEventLog(dbConn)
+
+		var err error
+		otherTeam, err = teamFactory.CreateTeam(atc.Team{Name: "some-other-team"})
+		Expect(err).NotTo(HaveOccurred())
+
+		otherPipeline, _, err := otherTeam.SavePipeline("other-pipeline", atc.Config{}, db.ConfigVersion(0), db.PipelineUnpaused)
+		Expect(err).NotTo(HaveOccurred())
+
+		for i := 0; i < 2; i++ {
+			Expect(defaultPipeline.Pause()).To(Succeed())
+			Expect(defaultPipeline.Unpause()).To(Succeed())
+		}
+
+		Expect(otherPipeline.Pause()).To(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(otherTeam.Delete()).To(Succeed())
+	})
+
+	Describe("Prune", func() {
+		It("keeps the given number of each team's most recent events", func() {
+			ids := teamEventIDs(defaultTeam)
+			Expect(ids).To(HaveLen(4))
+
+			err := teamEventLog.Prune(3)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(teamEventIDs(defaultTeam)).To(Equal(ids[1:]))
+			Expect(teamEventIDs(otherTeam)).To(HaveLen(1))
+		})
+
+		It("keeps every event when there are fewer than the given number", func() {
+			err := teamEventLog.Prune(10)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(teamEventIDs(defaultTeam)).To(HaveLen(4))
+			Expect(teamEventIDs(otherTeam)).To(HaveLen(1))
+		})
+	})
+})
@@ -0,0 +1,206 @@
+package db
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/event"
+)
+
+var ErrTeamEventStreamClosed = errors.New("team event stream closed")
+
+// A TeamEvent is a change to one of a team's pipelines. Its ID increases with
+// each event, so that a client can resume the stream from the last event it
+// received.
+type TeamEvent struct {
+	ID       int
+	Envelope event.Envelope
+}
+
+//go:generate counterfeiter . TeamEventSource
+
+type TeamEventSource interface {
+	Next() (TeamEvent, error)
+	Close() error
+}
+
+func teamEventsChannel(teamID int) string {
+	return fmt.Sprintf("team_events_%d", teamID)
+}
+
+// saveTeamEvent records the event for the pipeline in the transaction which
+// makes the change. Listeners are notified once the transaction commits.
+func saveTeamEvent(tx Tx, teamID int, pipelineID int, ev atc.Event) error {
+	payload, err := json.Marshal(ev)
+	if err != nil {
+		return err
+	}
+
+	_, err = psql.Insert("team_events").
+		Columns("team_id", "pipeline_id", "type", "version", "payload").
+		Values(teamID, pipelineID, string(ev.EventType()), string(ev.Version()), payload).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec("NOTIFY " + teamEventsChannel(teamID))
+	return err
+}
+
+func newTeamEventSource(
+	teamID int,
+	publicOnly bool,
+	conn Conn,
+	notifier Notifier,
+	from int,
+) *teamEventSource {
+	wg := new(sync.WaitGroup)
+
+	source := &teamEventSource{
+		teamID:     teamID,
+		publicOnly: publicOnly,
+
+		conn: conn,
+
+		notifier: notifier,
+
+		events: make(chan TeamEvent, 2000),
+		stop:   make(chan struct{}),
+		wg:     wg,
+	}
+
+	wg.Add(1)
+	go source.collectEvents(from)
+
+	return source
+}
+
+type teamEventSource struct {
+	teamID     int
+	publicOnly bool
+
+	conn     Conn
+	notifier Notifier
+
+	events chan TeamEvent
+	stop   chan struct{}
+	err    error
+	wg     *sync.WaitGroup
+
+	closeOnce sync.Once
+	closeErr  error
+}
+
+func (source *teamEventSource) Next() (TeamEvent, error) {
+	e, ok := <-source.events
+	if !ok {
+		return TeamEvent{}, source.err
+	}
+
+	return e, nil
+}
+
+// Close stops the stream. It is safe to call more than once, so that the
+// stream can be closed both when the client goes away and when it is done
+// with.
+func (source *teamEventSource) Close() error {
+	source.closeOnce.Do(func() {
+		close(source.stop)
+		source.wg.Wait()
+		source.closeErr = source.notifier.Close()
+	})
+
+	return source.closeErr
+}
+
+func (source *teamEventSource) collectEvents(cursor int) {
+	defer source.wg.Done()
+
+	var batchSize = cap(source.events)
+
+	for {
+		select {
+		case <-source.stop:
+			source.err = ErrTeamEventStreamClosed
+			close(source.events)
+			return
+		default:
+		}
+
+		query := psql.Select("e.id", "e.type", "e.version", "e.payload").
+			From("team_events e").
+			Where(sq.Eq{"e.team_id": source.teamID}).
+			Where(sq.Gt{"e.id": cursor}).
+			OrderBy("e.id ASC").
+			Limit(uint64(batchSize))
+
+		if source.publicOnly {
+			query = query.
+				Join("pipelines p ON p.id = e.pipeline_id").
+				Where(sq.Eq{"p.public": true})
+		}
+
+		rows, err := query.RunWith(source.conn).Query()
+		if err != nil {
+			source.err = err
+			close(source.events)
+			return
+		}
+
+		rowsReturned := 0
+
+		for rows.Next() {
+			rowsReturned++
+
+			var t, v, p string
+			err := rows.Scan(&cursor, &t, &v, &p)
+			if err != nil {
+				_ = rows.Close()
+
+				source.err = err
+				close(source.events)
+				return
+			}
+
+			data := json.RawMessage(p)
+
+			ev := TeamEvent{
+				ID: cursor,
+				Envelope: event.Envelope{
+					Data:    &data,
+					Event:   atc.EventType(t),
+					Version: atc.EventVersion(v),
+				},
+			}
+
+			select {
+			case source.events <- ev:
+			case <-source.stop:
+				_ = rows.Close()
+
+				source.err = ErrTeamEventStreamClosed
+				close(source.events)
+				return
+			}
+		}
+
+		if rowsReturned == batchSize {
+			// still more events
+			continue
+		}
+
+		select {
+		case <-source.notifier.Notify():
+		case <-source.stop:
+			source.err = ErrTeamEventStreamClosed
+			close(source.events)
+			return
+		}
+	}
+}
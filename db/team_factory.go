@@ -62,11 +62,16 @@ func (factory *teamFactory) createTeam(t atc.Team, admin bool) (Team, error) {
 		return nil, err
 	}
 
+	roles, err := json.Marshal(t.Roles)
+	if err != nil {
+		return nil, err
+	}
+
 	row := psql.Insert("teams").
-		Columns("name, auth, nonce, admin").
+		Columns("name, auth, nonce, admin, roles").
 		// Values(t.Name, encryptedBasicAuthJSON, encryptedAuth, nonce, admin).
-		Values(t.Name, encryptedAuth, nonce, admin).
-		Suffix("RETURNING id, name, admin, auth, nonce").
+		Values(t.Name, encryptedAuth, nonce, admin, string(roles)).
+		Suffix("RETURNING id, name, admin, auth, nonce, roles").
 		RunWith(tx).
 		QueryRow()
 
@@ -110,7 +115,7 @@ func (factory *teamFactory) FindTeam(teamName string) (Team, bool, error) {
 		lockFactory: factory.lockFactory,
 	}
 
-	row := psql.Select("id, name, admin, auth, nonce").
+	row := psql.Select("id, name, admin, auth, nonce, roles").
 		From("teams").
 		Where(sq.Eq{"LOWER(name)": strings.ToLower(teamName)}).
 		RunWith(factory.conn).
@@ -129,7 +134,7 @@ func (factory *teamFactory) FindTeam(teamName string) (Team, bool, error) {
 }
 
 func (factory *teamFactory) GetTeams() ([]Team, error) {
-	rows, err := psql.Select("id, name, admin, auth, nonce").
+	rows, err := psql.Select("id, name, admin, auth, nonce, roles").
 		From("teams").
 		OrderBy("id ASC").
 		RunWith(factory.conn).
@@ -187,7 +192,7 @@ func (factory *teamFactory) CreateDefaultTeamIfNotExists() (Team, error) {
 }
 
 func (factory *teamFactory) scanTeam(t *team, rows scannable) error {
-	var providerAuth, nonce sql.NullString
+	var providerAuth, nonce, roles sql.NullString
 
 	err := rows.Scan(
 		&t.id,
@@ -195,6 +200,7 @@ func (factory *teamFactory) scanTeam(t *team, rows scannable) error {
 		&t.admin,
 		&providerAuth,
 		&nonce,
+		&roles,
 	)
 
 	// if basicAuth.Valid {
@@ -225,5 +231,12 @@ func (factory *teamFactory) scanTeam(t *team, rows scannable) error {
 		}
 	}
 
+	if roles.Valid {
+		err = json.Unmarshal([]byte(roles.String), &t.roles)
+		if err != nil {
+			return err
+		}
+	}
+
 	return err
 }
@@ -2,6 +2,9 @@ package db_test
 
 import (
 	"encoding/json"
+	"errors"
+	"regexp"
+	"sort"
 	"strconv"
 	"time"
 
@@ -12,6 +15,7 @@ import (
 	"github.com/concourse/atc/creds/credsfakes"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/dbfakes"
+	"github.com/concourse/atc/event"
 	uuid "github.com/nu7hatch/gouuid"
 
 	. "github.com/onsi/ginkgo"
@@ -435,7 +439,7 @@ var _ = Describe("Team", func() {
 					})
 
 					It("returns check container for resource", func() {
-						containers, err := defaultTeam.FindCheckContainers(logger, "default-pipeline", "some-resource", fakeVariablesFactory)
+						containers, err := defaultTeam.FindCheckContainers(logger, "default-pipeline", nil, "some-resource", fakeVariablesFactory)
 						Expect(err).ToNot(HaveOccurred())
 						Expect(containers).To(ContainElement(resourceContainer))
 					})
@@ -451,7 +455,7 @@ var _ = Describe("Team", func() {
 						})
 
 						It("only returns container for current team", func() {
-							containers, err := defaultTeam.FindCheckContainers(logger, "default-pipeline", "some-resource", fakeVariablesFactory)
+							containers, err := defaultTeam.FindCheckContainers(logger, "default-pipeline", nil, "some-resource", fakeVariablesFactory)
 							Expect(err).ToNot(HaveOccurred())
 							Expect(containers).To(HaveLen(1))
 							Expect(containers).To(ContainElement(resourceContainer))
@@ -461,7 +465,7 @@ var _ = Describe("Team", func() {
 
 				Context("when check container does not exist", func() {
 					It("returns empty list", func() {
-						containers, err := defaultTeam.FindCheckContainers(logger, "default-pipeline", "some-resource", fakeVariablesFactory)
+						containers, err := defaultTeam.FindCheckContainers(logger, "default-pipeline", nil, "some-resource", fakeVariablesFactory)
 						Expect(err).ToNot(HaveOccurred())
 						Expect(containers).To(BeEmpty())
 					})
@@ -470,7 +474,7 @@ var _ = Describe("Team", func() {
 
 			Context("when resource does not exist", func() {
 				It("returns empty list", func() {
-					containers, err := defaultTeam.FindCheckContainers(logger, "default-pipeline", "non-existent-resource", fakeVariablesFactory)
+					containers, err := defaultTeam.FindCheckContainers(logger, "default-pipeline", nil, "non-existent-resource", fakeVariablesFactory)
 					Expect(err).ToNot(HaveOccurred())
 					Expect(containers).To(BeEmpty())
 				})
@@ -479,7 +483,15 @@ var _ = Describe("Team", func() {
 
 		Context("when pipeline does not exist", func() {
 			It("returns empty list", func() {
-				containers, err := defaultTeam.FindCheckContainers(logger, "non-existent-pipeline", "some-resource", fakeVariablesFactory)
+				containers, err := defaultTeam.FindCheckContainers(logger, "non-existent-pipeline", nil, "some-resource", fakeVariablesFactory)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(containers).To(BeEmpty())
+			})
+		})
+
+		Context("when the instance of the pipeline does not exist", func() {
+			It("returns empty list", func() {
+				containers, err := defaultTeam.FindCheckContainers(logger, "default-pipeline", atc.InstanceVars{"branch": "some-branch"}, "some-resource", fakeVariablesFactory)
 				Expect(err).ToNot(HaveOccurred())
 				Expect(containers).To(BeEmpty())
 			})
@@ -700,6 +712,85 @@ var _ = Describe("Team", func() {
 		})
 	})
 
+	Describe("ReserveContainer", func() {
+		var (
+			limitedWorker db.Worker
+			build         db.Build
+			metadata      db.ContainerMetadata
+			existing      db.CreatingContainer
+		)
+
+		BeforeEach(func() {
+			payload := defaultWorkerPayload
+			payload.Name = "some-limited-worker"
+			payload.GardenAddr = "5.6.7.8:7777"
+			payload.BaggageclaimURL = "5.6.7.8:7788"
+
+			var err error
+			limitedWorker, err = workerFactory.SaveWorker(payload, 0)
+			Expect(err).ToNot(HaveOccurred())
+
+			build, err = defaultTeam.CreateOneOffBuild()
+			Expect(err).ToNot(HaveOccurred())
+
+			metadata = db.ContainerMetadata{Type: "task", StepName: "some-task"}
+
+			existing, err = defaultTeam.CreateContainer(limitedWorker.Name(), db.NewBuildStepContainerOwner(build.ID(), "some-plan"), metadata)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		Context("when the worker has room for another container", func() {
+			It("creates the container", func() {
+				owner := db.NewBuildStepContainerOwner(build.ID(), "some-other-plan")
+
+				container, reserved, err := defaultTeam.ReserveContainer(limitedWorker.Name(), owner, metadata, 2)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(reserved).To(BeTrue())
+				Expect(container.WorkerName()).To(Equal(limitedWorker.Name()))
+
+				worker, found, err := defaultTeam.FindWorkerForContainerByOwner(owner)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(worker.Name()).To(Equal(limitedWorker.Name()))
+			})
+		})
+
+		Context("when the worker is full", func() {
+			It("does not create the container", func() {
+				owner := db.NewBuildStepContainerOwner(build.ID(), "some-other-plan")
+
+				container, reserved, err := defaultTeam.ReserveContainer(limitedWorker.Name(), owner, metadata, 1)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(reserved).To(BeFalse())
+				Expect(container).To(BeNil())
+
+				_, found, err := defaultTeam.FindWorkerForContainerByOwner(owner)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+
+		Context("when the worker's containers have failed", func() {
+			BeforeEach(func() {
+				_, err := existing.Failed()
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("does not count them", func() {
+				_, reserved, err := defaultTeam.ReserveContainer(limitedWorker.Name(), db.NewBuildStepContainerOwner(build.ID(), "some-other-plan"), metadata, 1)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(reserved).To(BeTrue())
+			})
+		})
+
+		Context("when the worker does not exist", func() {
+			It("returns ErrWorkerNotPresent", func() {
+				_, _, err := defaultTeam.ReserveContainer("bogus-worker", db.NewBuildStepContainerOwner(build.ID(), "some-other-plan"), metadata, 1)
+				Expect(err).To(Equal(db.ErrWorkerNotPresent))
+			})
+		})
+	})
+
 	Describe("Updating Auth", func() {
 		var (
 			// basicAuth    *atc.BasicAuth
@@ -775,6 +866,40 @@ var _ = Describe("Team", func() {
 				// 	[]byte(basicAuth.BasicAuthPassword))).To(BeNil())
 			})
 		})
+
+		Describe("UpdateRoles", func() {
+			var roles atc.TeamRoles
+
+			BeforeEach(func() {
+				roles = atc.TeamRoles{
+					atc.PipelineOperatorRole: {
+						"github": {Users: []string{"some-user"}},
+					},
+				}
+			})
+
+			It("saves the roles to the existing team", func() {
+				err := team.UpdateRoles(roles)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(team.Roles()).To(Equal(roles))
+
+				reloadedTeam, found, err := teamFactory.FindTeam(team.Name())
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(reloadedTeam.Roles()).To(Equal(roles))
+			})
+
+			It("does not overwrite the team's auth", func() {
+				err := team.UpdateProviderAuth(authProvider)
+				Expect(err).ToNot(HaveOccurred())
+
+				err = team.UpdateRoles(roles)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(team.Auth()).To(Equal(authProvider))
+			})
+		})
 	})
 
 	Describe("Pipelines", func() {
@@ -811,6 +936,24 @@ var _ = Describe("Team", func() {
 			It("returns the pipelines", func() {
 				Expect(pipelines).To(Equal([]db.Pipeline{pipeline1, pipeline2}))
 			})
+
+			Context("when a pipeline has instances", func() {
+				var instance db.Pipeline
+
+				BeforeEach(func() {
+					var err error
+					instance, _, err = team.SavePipelineInstance("fake-pipeline", atc.InstanceVars{"branch": "some-branch"}, atc.Config{
+						Jobs: atc.JobConfigs{
+							{Name: "job-name"},
+						},
+					}, db.ConfigVersion(1), db.PipelineUnpaused)
+					Expect(err).ToNot(HaveOccurred())
+				})
+
+				It("groups the instances together", func() {
+					Expect(pipelines).To(Equal([]db.Pipeline{pipeline1, instance, pipeline2}))
+				})
+			})
 		})
 		Context("when the team has no configured pipelines", func() {
 			It("returns no pipelines", func() {
@@ -920,6 +1063,17 @@ var _ = Describe("Team", func() {
 					Expect(pipelines).To(Equal([]db.Pipeline{pipeline1, pipeline2}))
 				})
 			})
+
+			Context("when pipelines are archived", func() {
+				BeforeEach(func() {
+					Expect(pipeline1.Archive()).To(Succeed())
+					Expect(pipeline2.Archive()).To(Succeed())
+				})
+
+				It("does not return them", func() {
+					Expect(pipelines).To(BeEmpty())
+				})
+			})
 		})
 
 		Context("when the team has no configured pipelines", func() {
@@ -1153,6 +1307,245 @@ var _ = Describe("Team", func() {
 		})
 	})
 
+	Describe("SearchBuildLogs", func() {
+		var (
+			privateBuild    db.Build
+			publicBuild     db.Build
+			privateJobBuild db.Build
+			otherTeamBuild  db.Build
+		)
+
+		BeforeEach(func() {
+			var err error
+			privateBuild, err = team.CreateOneOffBuild()
+			Expect(err).ToNot(HaveOccurred())
+
+			err = privateBuild.SaveEvent(event.Log{Payload: "fetching\nsome-er"})
+			Expect(err).ToNot(HaveOccurred())
+
+			err = privateBuild.SaveEvent(event.Log{Payload: "ror happened\ndone\n"})
+			Expect(err).ToNot(HaveOccurred())
+
+			err = privateBuild.Finish(db.BuildStatusFailed)
+			Expect(err).ToNot(HaveOccurred())
+
+			config := atc.Config{
+				Jobs: atc.JobConfigs{
+					{Name: "public-job", Public: true},
+					{Name: "private-job"},
+				},
+			}
+
+			otherPipeline, _, err := otherTeam.SavePipeline("other-pipeline", config, db.ConfigVersion(0), db.PipelineUnpaused)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = otherPipeline.Expose()
+			Expect(err).ToNot(HaveOccurred())
+
+			publicJob, found, err := otherPipeline.Job("public-job")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			publicBuild, err = publicJob.CreateBuild()
+			Expect(err).ToNot(HaveOccurred())
+
+			err = publicBuild.SaveEvent(event.Log{Payload: "some-error happened\n"})
+			Expect(err).ToNot(HaveOccurred())
+
+			privateJob, found, err := otherPipeline.Job("private-job")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			privateJobBuild, err = privateJob.CreateBuild()
+			Expect(err).ToNot(HaveOccurred())
+
+			err = privateJobBuild.SaveEvent(event.Log{Payload: "some-error happened\n"})
+			Expect(err).ToNot(HaveOccurred())
+
+			otherTeamBuild, err = otherTeam.CreateOneOffBuild()
+			Expect(err).ToNot(HaveOccurred())
+
+			err = otherTeamBuild.SaveEvent(event.Log{Payload: "some-error happened\n"})
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		matchedBuildIDs := func(matches []db.BuildLogMatch) []int {
+			ids := []int{}
+			for _, match := range matches {
+				ids = append(ids, match.Build.ID())
+			}
+
+			return ids
+		}
+
+		It("searches the team's builds and the builds of public jobs of other teams", func() {
+			matches, _, err := team.SearchBuildLogs(db.BuildLogSearch{Text: "some-error"}, db.Page{Limit: 10})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(matchedBuildIDs(matches)).To(Equal([]int{publicBuild.ID(), privateBuild.ID()}))
+
+			matches, _, err = otherTeam.SearchBuildLogs(db.BuildLogSearch{Text: "some-error"}, db.Page{Limit: 10})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(matchedBuildIDs(matches)).To(Equal([]int{otherTeamBuild.ID(), privateJobBuild.ID(), publicBuild.ID()}))
+		})
+
+		It("returns the offsets of the matching lines, including lines split across events", func() {
+			matches, _, err := team.SearchBuildLogs(db.BuildLogSearch{
+				Text:   "some-error",
+				Regexp: regexp.MustCompile(`some-e.+r happened`),
+			}, db.Page{Limit: 10})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(matches).To(HaveLen(1))
+			Expect(matches[0].Build.ID()).To(Equal(privateBuild.ID()))
+			Expect(matches[0].Lines).To(Equal([]db.BuildLogLine{
+				{Offset: 1, Text: "some-error happened"},
+			}))
+		})
+
+		It("searches for the text literally", func() {
+			matches, _, err := team.SearchBuildLogs(db.BuildLogSearch{Text: "some-e.+r"}, db.Page{Limit: 10})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(matches).To(BeEmpty())
+		})
+
+		It("leaves out builds whose lines do not match the regexp", func() {
+			matches, _, err := team.SearchBuildLogs(db.BuildLogSearch{
+				Text:   "some-error",
+				Regexp: regexp.MustCompile(`^done$`),
+			}, db.Page{Limit: 10})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(matches).To(BeEmpty())
+		})
+
+		It("filters by build status", func() {
+			matches, _, err := team.SearchBuildLogs(db.BuildLogSearch{
+				Text:     "some-error",
+				Statuses: []db.BuildStatus{db.BuildStatusPending},
+			}, db.Page{Limit: 10})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(matches).To(BeEmpty())
+
+			matches, _, err = team.SearchBuildLogs(db.BuildLogSearch{
+				Text:     "some-error",
+				Statuses: []db.BuildStatus{db.BuildStatusFailed},
+			}, db.Page{Limit: 10})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(matchedBuildIDs(matches)).To(Equal([]int{privateBuild.ID()}))
+		})
+
+		It("filters by team", func() {
+			matches, _, err := team.SearchBuildLogs(db.BuildLogSearch{
+				Text:     "some-error",
+				TeamName: otherTeam.Name(),
+			}, db.Page{Limit: 10})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(matchedBuildIDs(matches)).To(Equal([]int{publicBuild.ID()}))
+		})
+
+		It("filters by pipeline and job", func() {
+			matches, _, err := otherTeam.SearchBuildLogs(db.BuildLogSearch{
+				Text:         "some-error",
+				PipelineName: "other-pipeline",
+				JobName:      "private-job",
+			}, db.Page{Limit: 10})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(matchedBuildIDs(matches)).To(Equal([]int{privateJobBuild.ID()}))
+		})
+
+		It("returns nothing when no line matches", func() {
+			matches, _, err := team.SearchBuildLogs(db.BuildLogSearch{Text: "no-such-error"}, db.Page{Limit: 10})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(matches).To(BeEmpty())
+		})
+	})
+
+	Describe("SavePipelineInstance", func() {
+		var (
+			config       atc.Config
+			instanceVars atc.InstanceVars
+			pipeline     db.Pipeline
+		)
+
+		BeforeEach(func() {
+			config = atc.Config{
+				Jobs: atc.JobConfigs{
+					{Name: "some-job"},
+				},
+			}
+
+			instanceVars = atc.InstanceVars{"branch": "some-branch"}
+
+			var err error
+			pipeline, _, err = team.SavePipeline("some-pipeline", config, 0, db.PipelineNoChange)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("creates an instance alongside the pipeline", func() {
+			instance, created, err := team.SavePipelineInstance("some-pipeline", instanceVars, config, 0, db.PipelineNoChange)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(created).To(BeTrue())
+
+			Expect(instance.ID()).ToNot(Equal(pipeline.ID()))
+			Expect(instance.Name()).To(Equal("some-pipeline"))
+			Expect(instance.InstanceVars()).To(Equal(instanceVars))
+		})
+
+		It("updates an existing instance", func() {
+			instance, _, err := team.SavePipelineInstance("some-pipeline", instanceVars, config, 0, db.PipelineNoChange)
+			Expect(err).ToNot(HaveOccurred())
+
+			updatedInstance, created, err := team.SavePipelineInstance("some-pipeline", instanceVars, config, instance.ConfigVersion(), db.PipelineNoChange)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(created).To(BeFalse())
+			Expect(updatedInstance.ID()).To(Equal(instance.ID()))
+		})
+
+		It("does not affect the pipeline without instance vars", func() {
+			_, _, err := team.SavePipelineInstance("some-pipeline", instanceVars, config, 0, db.PipelineNoChange)
+			Expect(err).ToNot(HaveOccurred())
+
+			foundPipeline, found, err := team.Pipeline("some-pipeline")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(foundPipeline.ID()).To(Equal(pipeline.ID()))
+			Expect(foundPipeline.InstanceVars()).To(BeNil())
+		})
+
+		Describe("PipelineInstance", func() {
+			var instance db.Pipeline
+
+			BeforeEach(func() {
+				var err error
+				instance, _, err = team.SavePipelineInstance("some-pipeline", instanceVars, config, 0, db.PipelineNoChange)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("finds the instance identified by the vars", func() {
+				foundInstance, found, err := team.PipelineInstance("some-pipeline", atc.InstanceVars{"branch": "some-branch"})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(foundInstance.ID()).To(Equal(instance.ID()))
+			})
+
+			It("does not find instances with other vars", func() {
+				_, found, err := team.PipelineInstance("some-pipeline", atc.InstanceVars{"branch": "some-other-branch"})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+
+			It("finds the pipeline without instance vars when given none", func() {
+				foundPipeline, found, err := team.PipelineInstance("some-pipeline", nil)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(foundPipeline.ID()).To(Equal(pipeline.ID()))
+			})
+		})
+	})
+
 	Describe("SavePipeline", func() {
 		type SerialGroup struct {
 			JobID int
@@ -1836,6 +2229,212 @@ var _ = Describe("Team", func() {
 		})
 	})
 
+	Describe("Events", func() {
+		var (
+			pipeline       db.Pipeline
+			publicPipeline db.Pipeline
+			events         db.TeamEventSource
+		)
+
+		config := atc.Config{
+			Jobs: atc.JobConfigs{
+				{Name: "some-job"},
+			},
+			Resources: atc.ResourceConfigs{
+				{Name: "some-resource", Type: "some-type"},
+			},
+		}
+
+		nextEvent := func() (int, atc.Event) {
+			ev, err := events.Next()
+			ExpectWithOffset(1, err).NotTo(HaveOccurred())
+
+			parsed, err := event.ParseEvent(ev.Envelope.Version, ev.Envelope.Event, *ev.Envelope.Data)
+			ExpectWithOffset(1, err).NotTo(HaveOccurred())
+
+			return ev.ID, parsed
+		}
+
+		BeforeEach(func() {
+			var err error
+			pipeline, _, err = team.SavePipeline("some-pipeline", config, db.ConfigVersion(0), db.PipelineUnpaused)
+			Expect(err).NotTo(HaveOccurred())
+
+			publicPipeline, _, err = team.SavePipeline("some-public-pipeline", config, db.ConfigVersion(0), db.PipelineUnpaused)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = publicPipeline.Expose()
+			Expect(err).NotTo(HaveOccurred())
+
+			// made before the stream is opened, so not streamed
+			err = pipeline.Pause()
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			Expect(events.Close()).To(Succeed())
+		})
+
+		Context("when streaming all events", func() {
+			BeforeEach(func() {
+				var err error
+				events, err = team.Events(0)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("streams the changes to the team's pipelines from now on", func() {
+				err := pipeline.Unpause()
+				Expect(err).NotTo(HaveOccurred())
+
+				// unchanged, so not streamed
+				err = pipeline.Unpause()
+				Expect(err).NotTo(HaveOccurred())
+
+				job, found, err := pipeline.Job("some-job")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				err = job.Pause()
+				Expect(err).NotTo(HaveOccurred())
+
+				build, err := job.CreateBuild()
+				Expect(err).NotTo(HaveOccurred())
+
+				started, err := build.Start("engine", `{}`, atc.Plan{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(started).To(BeTrue())
+
+				err = build.Finish(db.BuildStatusSucceeded)
+				Expect(err).NotTo(HaveOccurred())
+
+				found, err = build.Reload()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				resource, found, err := pipeline.Resource("some-resource")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				err = pipeline.SetResourceCheckError(resource, errors.New("oops"))
+				Expect(err).NotTo(HaveOccurred())
+
+				// unchanged, so not streamed
+				err = pipeline.SetResourceCheckError(resource, errors.New("oops"))
+				Expect(err).NotTo(HaveOccurred())
+
+				err = pipeline.SetResourceCheckError(resource, nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				var ids []int
+
+				id, ev := nextEvent()
+				ids = append(ids, id)
+				Expect(ev).To(BeAssignableToTypeOf(event.PipelineStatus{}))
+				Expect(ev.(event.PipelineStatus).PipelineName).To(Equal("some-pipeline"))
+				Expect(ev.(event.PipelineStatus).Paused).To(BeFalse())
+
+				id, ev = nextEvent()
+				ids = append(ids, id)
+				Expect(ev).To(BeAssignableToTypeOf(event.JobStatus{}))
+				Expect(ev.(event.JobStatus).JobName).To(Equal("some-job"))
+				Expect(ev.(event.JobStatus).Paused).To(BeTrue())
+
+				id, ev = nextEvent()
+				ids = append(ids, id)
+				Expect(ev).To(Equal(event.BuildStatus{
+					Time:         build.StartTime().Unix(),
+					PipelineID:   pipeline.ID(),
+					PipelineName: "some-pipeline",
+					JobName:      "some-job",
+					BuildID:      build.ID(),
+					BuildName:    build.Name(),
+					Status:       atc.StatusStarted,
+				}))
+
+				id, ev = nextEvent()
+				ids = append(ids, id)
+				Expect(ev).To(BeAssignableToTypeOf(event.BuildStatus{}))
+				Expect(ev.(event.BuildStatus).Status).To(Equal(atc.StatusSucceeded))
+
+				id, ev = nextEvent()
+				ids = append(ids, id)
+				Expect(ev).To(BeAssignableToTypeOf(event.ResourceCheckError{}))
+				Expect(ev.(event.ResourceCheckError).ResourceName).To(Equal("some-resource"))
+				Expect(ev.(event.ResourceCheckError).CheckError).To(Equal("oops"))
+
+				id, ev = nextEvent()
+				ids = append(ids, id)
+				Expect(ev).To(BeAssignableToTypeOf(event.ResourceCheckError{}))
+				Expect(ev.(event.ResourceCheckError).CheckError).To(BeEmpty())
+
+				Expect(sort.IntsAreSorted(ids)).To(BeTrue())
+			})
+
+			It("does not stream the changes to other teams' pipelines", func() {
+				otherPipeline, _, err := otherTeam.SavePipeline("other-pipeline", config, db.ConfigVersion(0), db.PipelineUnpaused)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = otherPipeline.Pause()
+				Expect(err).NotTo(HaveOccurred())
+
+				err = publicPipeline.Pause()
+				Expect(err).NotTo(HaveOccurred())
+
+				_, ev := nextEvent()
+				Expect(ev.(event.PipelineStatus).PipelineName).To(Equal("some-public-pipeline"))
+			})
+		})
+
+		Context("when resuming from an event", func() {
+			var lastID int
+
+			BeforeEach(func() {
+				var err error
+				events, err = team.Events(0)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = pipeline.Unpause()
+				Expect(err).NotTo(HaveOccurred())
+
+				lastID, _ = nextEvent()
+
+				Expect(events.Close()).To(Succeed())
+
+				err = publicPipeline.Pause()
+				Expect(err).NotTo(HaveOccurred())
+
+				events, err = team.Events(lastID)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("streams the events after it", func() {
+				id, ev := nextEvent()
+				Expect(id).To(BeNumerically(">", lastID))
+				Expect(ev.(event.PipelineStatus).PipelineName).To(Equal("some-public-pipeline"))
+			})
+		})
+
+		Context("when streaming public events", func() {
+			BeforeEach(func() {
+				var err error
+				events, err = team.PublicEvents(0)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("only streams the changes to public pipelines", func() {
+				err := pipeline.Unpause()
+				Expect(err).NotTo(HaveOccurred())
+
+				err = publicPipeline.Pause()
+				Expect(err).NotTo(HaveOccurred())
+
+				_, ev := nextEvent()
+				Expect(ev.(event.PipelineStatus).PipelineName).To(Equal("some-public-pipeline"))
+				Expect(ev.(event.PipelineStatus).Paused).To(BeTrue())
+			})
+		})
+	})
+
 	Describe("CreatePipe/GetPipe", func() {
 		It("saves a pipe to the db", func() {
 			myGuid, err := uuid.NewV4()
@@ -38,6 +38,8 @@ type Worker interface {
 	HTTPSProxyURL() string
 	NoProxy() string
 	ActiveContainers() int
+	ActiveVolumes() int
+	MaxContainers() int
 	ResourceTypes() []atc.WorkerResourceType
 	Platform() string
 	Tags() []string
@@ -66,6 +68,8 @@ type worker struct {
 	httpsProxyURL    string
 	noProxy          string
 	activeContainers int
+	activeVolumes    int
+	maxContainers    int
 	resourceTypes    []atc.WorkerResourceType
 	platform         string
 	tags             []string
@@ -86,6 +90,8 @@ func (worker *worker) HTTPProxyURL() string                    { return worker.h
 func (worker *worker) HTTPSProxyURL() string                   { return worker.httpsProxyURL }
 func (worker *worker) NoProxy() string                         { return worker.noProxy }
 func (worker *worker) ActiveContainers() int                   { return worker.activeContainers }
+func (worker *worker) ActiveVolumes() int                      { return worker.activeVolumes }
+func (worker *worker) MaxContainers() int                      { return worker.maxContainers }
 func (worker *worker) ResourceTypes() []atc.WorkerResourceType { return worker.resourceTypes }
 func (worker *worker) Platform() string                        { return worker.platform }
 func (worker *worker) Tags() []string                          { return worker.tags }
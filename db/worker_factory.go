@@ -41,6 +41,8 @@ var workersQuery = psql.Select(`
 		w.https_proxy_url,
 		w.no_proxy,
 		w.active_containers,
+		w.active_volumes,
+		w.max_containers,
 		w.resource_types,
 		w.platform,
 		w.tags,
@@ -129,6 +131,8 @@ func scanWorker(worker *worker, row scannable) error {
 		&httpsProxyURL,
 		&noProxy,
 		&worker.activeContainers,
+		&worker.activeVolumes,
+		&worker.maxContainers,
 		&resourceTypes,
 		&platform,
 		&tags,
@@ -249,6 +253,7 @@ func (f *workerFactory) HeartbeatWorker(atcWorker atc.Worker, ttl time.Duration)
 		Set("addr", sq.Expr("("+addrSQL+")")).
 		Set("baggageclaim_url", sq.Expr("("+bcSQL+")")).
 		Set("active_containers", atcWorker.ActiveContainers).
+		Set("active_volumes", atcWorker.ActiveVolumes).
 		Set("state", sq.Expr("("+cSQL+")")).
 		Where(sq.Eq{"name": atcWorker.Name}).
 		RunWith(tx).
@@ -343,6 +348,8 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 					"addr",
 					"expires",
 					"active_containers",
+					"active_volumes",
+					"max_containers",
 					"resource_types",
 					"tags",
 					"platform",
@@ -361,6 +368,8 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 					atcWorker.GardenAddr,
 					sq.Expr(expires),
 					atcWorker.ActiveContainers,
+					atcWorker.ActiveVolumes,
+					atcWorker.MaxContainers,
 					resourceTypes,
 					tags,
 					atcWorker.Platform,
@@ -393,6 +402,8 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 			Set("addr", atcWorker.GardenAddr).
 			Set("expires", sq.Expr(expires)).
 			Set("active_containers", atcWorker.ActiveContainers).
+			Set("active_volumes", atcWorker.ActiveVolumes).
+			Set("max_containers", atcWorker.MaxContainers).
 			Set("resource_types", resourceTypes).
 			Set("tags", tags).
 			Set("platform", atcWorker.Platform).
@@ -431,6 +442,8 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 		httpsProxyURL:    atcWorker.HTTPSProxyURL,
 		noProxy:          atcWorker.NoProxy,
 		activeContainers: atcWorker.ActiveContainers,
+		activeVolumes:    atcWorker.ActiveVolumes,
+		maxContainers:    atcWorker.MaxContainers,
 		resourceTypes:    atcWorker.ResourceTypes,
 		platform:         atcWorker.Platform,
 		tags:             atcWorker.Tags,
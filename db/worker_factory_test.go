@@ -25,6 +25,7 @@ var _ = Describe("WorkerFactory", func() {
 			HTTPSProxyURL:    "some-https-proxy-url",
 			NoProxy:          "some-no-proxy",
 			ActiveContainers: 140,
+			MaxContainers:    250,
 			ResourceTypes: []atc.WorkerResourceType{
 				{
 					Type:       "some-resource-type",
@@ -202,6 +203,7 @@ var _ = Describe("WorkerFactory", func() {
 				Expect(foundWorker.HTTPSProxyURL()).To(Equal("some-https-proxy-url"))
 				Expect(foundWorker.NoProxy()).To(Equal("some-no-proxy"))
 				Expect(foundWorker.ActiveContainers()).To(Equal(140))
+				Expect(foundWorker.MaxContainers()).To(Equal(250))
 				Expect(foundWorker.ResourceTypes()).To(Equal([]atc.WorkerResourceType{
 					{
 						Type:       "some-resource-type",
@@ -22,6 +22,24 @@ func (build *execBuild) buildAggregateStep(logger lager.Logger, plan atc.Plan) e
 	return step
 }
 
+func (build *execBuild) buildAcrossStep(logger lager.Logger, plan atc.Plan) exec.StepFactory {
+	logger = logger.Session("across")
+
+	step := exec.Across{
+		MaxInFlight: plan.Across.MaxInFlight,
+		FailFast:    plan.Across.FailFast,
+	}
+
+	for _, acrossStep := range plan.Across.Steps {
+		innerPlan := acrossStep.Step
+		innerPlan.Attempts = plan.Attempts
+		stepFactory := build.buildStepFactory(logger, innerPlan)
+		step.Steps = append(step.Steps, stepFactory)
+	}
+
+	return step
+}
+
 func (build *execBuild) buildDoStep(logger lager.Logger, plan atc.Plan) exec.StepFactory {
 	logger = logger.Session("do")
 
@@ -152,6 +170,48 @@ func (build *execBuild) buildPutStep(logger lager.Logger, plan atc.Plan) exec.St
 	)
 }
 
+func (build *execBuild) buildSetPipelineStep(logger lager.Logger, plan atc.Plan) exec.StepFactory {
+	logger = logger.Session("set-pipeline", lager.Data{
+		"name": plan.SetPipeline.Name,
+	})
+
+	return build.factory.SetPipeline(
+		logger,
+		plan,
+		build.dbBuild,
+		build.delegate.DBActionsBuildEventsDelegate(plan.ID),
+		build.delegate.BuildStepDelegate(plan.ID),
+	)
+}
+
+func (build *execBuild) buildLoadVarStep(logger lager.Logger, plan atc.Plan) exec.StepFactory {
+	logger = logger.Session("load-var", lager.Data{
+		"name": plan.LoadVar.Name,
+	})
+
+	return build.factory.LoadVar(
+		logger,
+		plan,
+		build.dbBuild,
+		build.delegate.DBActionsBuildEventsDelegate(plan.ID),
+		build.delegate.BuildStepDelegate(plan.ID),
+	)
+}
+
+func (build *execBuild) buildSetBuildMetadataStep(logger lager.Logger, plan atc.Plan) exec.StepFactory {
+	logger = logger.Session("set-build-metadata", lager.Data{
+		"file": plan.SetBuildMetadata.File,
+	})
+
+	return build.factory.SetBuildMetadata(
+		logger,
+		plan,
+		build.dbBuild,
+		build.delegate.DBActionsBuildEventsDelegate(plan.ID),
+		build.delegate.BuildStepDelegate(plan.ID),
+	)
+}
+
 func (build *execBuild) buildRetryStep(logger lager.Logger, plan atc.Plan) exec.StepFactory {
 	logger = logger.Session("retry")
 
@@ -35,6 +35,15 @@ func (delegate *BuildStepDelegate) ImageVersionDetermined(resourceCache *db.Used
 	return delegate.build.SaveImageResourceVersion(resourceCache)
 }
 
+func (delegate *BuildStepDelegate) WaitingForWorker() error {
+	return delegate.build.SaveEvent(event.WaitingForWorker{
+		Time: delegate.clock.Now().Unix(),
+		Origin: event.Origin{
+			ID: event.OriginID(delegate.planID),
+		},
+	})
+}
+
 func (delegate *BuildStepDelegate) Stdout() io.Writer {
 	return newDBEventWriter(
 		delegate.build,
@@ -48,6 +48,43 @@ var _ = Describe("BuildStepDelegate", func() {
 		})
 	})
 
+	Describe("WaitingForWorker", func() {
+		var waitingErr error
+
+		JustBeforeEach(func() {
+			waitingErr = delegate.WaitingForWorker()
+		})
+
+		Context("when saving the event succeeds", func() {
+			BeforeEach(func() {
+				fakeBuild.SaveEventReturns(nil)
+			})
+
+			It("saves a waiting-for-worker event", func() {
+				Expect(waitingErr).ToNot(HaveOccurred())
+				Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+				Expect(fakeBuild.SaveEventArgsForCall(0)).To(Equal(event.WaitingForWorker{
+					Time: 123456789,
+					Origin: event.Origin{
+						ID: "some-plan-id",
+					},
+				}))
+			})
+		})
+
+		Context("when saving the event fails", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				fakeBuild.SaveEventReturns(disaster)
+			})
+
+			It("returns the error", func() {
+				Expect(waitingErr).To(Equal(disaster))
+			})
+		})
+	})
+
 	Describe("Stdout", func() {
 		var writer io.Writer
 
@@ -132,6 +132,51 @@ func (d *dbActionsBuildEventsDelegate) ActionCompleted(logger lager.Logger, acti
 		}
 
 		logger.Info("finished", lager.Data{"version-info": versionInfo})
+	case *exec.LoadVarAction:
+		exitStatus := a.ExitStatus()
+
+		err := d.build.SaveEvent(event.FinishLoadVar{
+			Origin:     d.eventOrigin,
+			VarName:    a.Name,
+			ExitStatus: int(exitStatus),
+		})
+		if err != nil {
+			logger.Error("failed-to-save-finish-event", err)
+			return
+		}
+
+		logger.Info("finished", lager.Data{"exit-status": exitStatus})
+	case *exec.SetBuildMetadataAction:
+		exitStatus := a.ExitStatus()
+
+		err := d.build.SaveEvent(event.FinishSetBuildMetadata{
+			Origin:     d.eventOrigin,
+			Metadata:   a.Metadata(),
+			ExitStatus: int(exitStatus),
+		})
+		if err != nil {
+			logger.Error("failed-to-save-finish-event", err)
+			return
+		}
+
+		logger.Info("finished", lager.Data{"exit-status": exitStatus})
+	case *exec.SetPipelineAction:
+		exitStatus := a.ExitStatus()
+
+		err := d.build.SaveEvent(event.FinishSetPipeline{
+			Origin:        d.eventOrigin,
+			PipelineName:  a.Name,
+			ConfigVersion: int(a.ConfigVersion()),
+			Created:       a.Created(),
+			Changes:       event.ShadowConfigChanges(a.Changes()),
+			ExitStatus:    int(exitStatus),
+		})
+		if err != nil {
+			logger.Error("failed-to-save-finish-event", err)
+			return
+		}
+
+		logger.Info("finished", lager.Data{"exit-status": exitStatus})
 	default:
 		return
 	}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"strconv"
 	"strings"
+	"time"
 
 	"os"
 
@@ -11,6 +12,8 @@ import (
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/exec"
+	"github.com/concourse/atc/metric"
+	"github.com/concourse/atc/tracing"
 	"github.com/concourse/atc/worker"
 	"github.com/tedsuo/ifrit"
 )
@@ -128,6 +131,19 @@ func (build *execBuild) Abort(lager.Logger) error {
 }
 
 func (build *execBuild) Resume(logger lager.Logger) {
+	span := tracing.StartBuildRootSpan("build", build.stepMetadata.BuildID, build.spanAttributes(tracing.Attrs{
+		"build_name": build.stepMetadata.BuildName,
+		"team":       build.stepMetadata.TeamName,
+	}))
+
+	// the root span ends however the build stops running here, so that it
+	// is not left around for the build's later spans to be children of
+	var spanErr error
+	defer func() { span.End(spanErr) }()
+
+	// the build's steps are only constructed while it runs here
+	defer build.factory.ReleaseBuild(build.dbBuild)
+
 	stepFactory := build.buildStepFactory(logger, build.metadata.Plan)
 	source := stepFactory.Using(worker.NewArtifactRepository())
 
@@ -149,6 +165,7 @@ func (build *execBuild) Resume(logger lager.Logger) {
 			}
 
 			build.delegate.Finish(logger.Session("finish"), err, exec.Success(succeeded), aborted)
+			spanErr = err
 			return
 
 		case sig := <-build.signals:
@@ -162,6 +179,39 @@ func (build *execBuild) Resume(logger lager.Logger) {
 }
 
 func (build *execBuild) buildStepFactory(logger lager.Logger, plan atc.Plan) exec.StepFactory {
+	kind, name := stepKindAndName(plan)
+
+	stepFactory := exec.Traced(
+		build.buildUntracedStepFactory(logger, plan),
+		build.stepMetadata.BuildID,
+		"step."+kind,
+		build.spanAttributes(tracing.Attrs{
+			"plan_id": string(plan.ID),
+			"step":    name,
+		}),
+	)
+
+	switch kind {
+	case "get", "put", "task":
+		return exec.Timed(stepFactory, func(duration time.Duration, status db.BuildStatus) {
+			metric.StepFinished{
+				PipelineName: build.stepMetadata.PipelineName,
+				JobName:      build.stepMetadata.JobName,
+				BuildName:    build.stepMetadata.BuildName,
+				BuildID:      build.stepMetadata.BuildID,
+				TeamName:     build.stepMetadata.TeamName,
+				StepType:     kind,
+				StepName:     name,
+				StepStatus:   status,
+				StepDuration: duration,
+			}.Emit(logger)
+		})
+	default:
+		return stepFactory
+	}
+}
+
+func (build *execBuild) buildUntracedStepFactory(logger lager.Logger, plan atc.Plan) exec.StepFactory {
 	if plan.Aggregate != nil {
 		return build.buildAggregateStep(logger, plan)
 	}
@@ -170,6 +220,10 @@ func (build *execBuild) buildStepFactory(logger lager.Logger, plan atc.Plan) exe
 		return build.buildDoStep(logger, plan)
 	}
 
+	if plan.Across != nil {
+		return build.buildAcrossStep(logger, plan)
+	}
+
 	if plan.Timeout != nil {
 		return build.buildTimeoutStep(logger, plan)
 	}
@@ -210,9 +264,66 @@ func (build *execBuild) buildStepFactory(logger lager.Logger, plan atc.Plan) exe
 		return build.buildRetryStep(logger, plan)
 	}
 
+	if plan.SetPipeline != nil {
+		return build.buildSetPipelineStep(logger, plan)
+	}
+
+	if plan.LoadVar != nil {
+		return build.buildLoadVarStep(logger, plan)
+	}
+
+	if plan.SetBuildMetadata != nil {
+		return build.buildSetBuildMetadataStep(logger, plan)
+	}
+
 	return exec.Identity{}
 }
 
+func (build *execBuild) spanAttributes(attrs tracing.Attrs) tracing.Attrs {
+	attrs["pipeline"] = build.stepMetadata.PipelineName
+	attrs["job"] = build.stepMetadata.JobName
+	return attrs
+}
+
+func stepKindAndName(plan atc.Plan) (string, string) {
+	switch {
+	case plan.Aggregate != nil:
+		return "aggregate", ""
+	case plan.Do != nil:
+		return "do", ""
+	case plan.Across != nil:
+		return "across", ""
+	case plan.Timeout != nil:
+		return "timeout", ""
+	case plan.Try != nil:
+		return "try", ""
+	case plan.OnAbort != nil:
+		return "on_abort", ""
+	case plan.OnSuccess != nil:
+		return "on_success", ""
+	case plan.OnFailure != nil:
+		return "on_failure", ""
+	case plan.Ensure != nil:
+		return "ensure", ""
+	case plan.Task != nil:
+		return "task", plan.Task.Name
+	case plan.Get != nil:
+		return "get", plan.Get.Name
+	case plan.Put != nil:
+		return "put", plan.Put.Name
+	case plan.Retry != nil:
+		return "retry", ""
+	case plan.SetPipeline != nil:
+		return "set_pipeline", plan.SetPipeline.Name
+	case plan.LoadVar != nil:
+		return "load_var", plan.LoadVar.Name
+	case plan.SetBuildMetadata != nil:
+		return "set_build_metadata", plan.SetBuildMetadata.File
+	default:
+		return "identity", ""
+	}
+}
+
 func (build *execBuild) containerMetadata(
 	containerType db.ContainerType,
 	stepName string,
@@ -395,6 +395,152 @@ var _ = Describe("ExecEngine", func() {
 			})
 		})
 
+		Context("with an across plan", func() {
+			var (
+				firstPlan  atc.Plan
+				secondPlan atc.Plan
+				acrossPlan atc.Plan
+			)
+
+			BeforeEach(func() {
+				firstPlan = planFactory.NewPlan(atc.TaskPlan{
+					Name:       "some-task-a",
+					ConfigPath: "some-config-path",
+				})
+
+				secondPlan = planFactory.NewPlan(atc.TaskPlan{
+					Name:       "some-task-b",
+					ConfigPath: "some-config-path",
+				})
+
+				acrossPlan = planFactory.NewPlan(atc.AcrossPlan{
+					Vars: []string{"some-var"},
+					Steps: []atc.AcrossStep{
+						{Values: []string{"a"}, Step: firstPlan},
+						{Values: []string{"b"}, Step: secondPlan},
+					},
+					MaxInFlight: 1,
+				})
+
+				build, err := execEngine.CreateBuild(logger, dbBuild, acrossPlan)
+				Expect(err).NotTo(HaveOccurred())
+				build.Resume(logger)
+			})
+
+			It("constructs a step for each combination", func() {
+				Expect(fakeFactory.TaskCallCount()).To(Equal(2))
+
+				_, plan, _, _, _, _, _ := fakeFactory.TaskArgsForCall(0)
+				Expect(plan).To(Equal(firstPlan))
+
+				_, plan, _, _, _, _, _ = fakeFactory.TaskArgsForCall(1)
+				Expect(plan).To(Equal(secondPlan))
+			})
+
+			It("gives each combination its own origin", func() {
+				Expect(fakeDelegate.DBTaskBuildEventsDelegateCallCount()).To(Equal(2))
+				Expect(fakeDelegate.DBTaskBuildEventsDelegateArgsForCall(0)).To(Equal(firstPlan.ID))
+				Expect(fakeDelegate.DBTaskBuildEventsDelegateArgsForCall(1)).To(Equal(secondPlan.ID))
+			})
+
+			It("runs every combination", func() {
+				Expect(taskStep.RunCallCount()).To(Equal(2))
+			})
+		})
+
+		Context("with a set_pipeline plan", func() {
+			var (
+				setPipelineStepFactory *execfakes.FakeStepFactory
+				setPipelineStep        *execfakes.FakeStep
+				setPipelinePlan        atc.Plan
+			)
+
+			BeforeEach(func() {
+				setPipelineStepFactory = new(execfakes.FakeStepFactory)
+				setPipelineStep = new(execfakes.FakeStep)
+				setPipelineStep.SucceededReturns(true)
+				setPipelineStepFactory.UsingReturns(setPipelineStep)
+				fakeFactory.SetPipelineReturns(setPipelineStepFactory)
+
+				setPipelinePlan = planFactory.NewPlan(atc.SetPipelinePlan{
+					Name: "some-pipeline",
+					File: "some-input/pipeline.yml",
+				})
+
+				build, err := execEngine.CreateBuild(logger, dbBuild, setPipelinePlan)
+				Expect(err).NotTo(HaveOccurred())
+				build.Resume(logger)
+			})
+
+			It("constructs the set_pipeline step correctly", func() {
+				Expect(fakeFactory.SetPipelineCallCount()).To(Equal(1))
+
+				logger, plan, dBuild, _, _ := fakeFactory.SetPipelineArgsForCall(0)
+				Expect(logger).NotTo(BeNil())
+				Expect(dBuild).To(Equal(dbBuild))
+				Expect(plan).To(Equal(setPipelinePlan))
+
+				originID := fakeDelegate.DBActionsBuildEventsDelegateArgsForCall(0)
+				Expect(originID).To(Equal(setPipelinePlan.ID))
+
+				planID := fakeDelegate.BuildStepDelegateArgsForCall(0)
+				Expect(planID).To(Equal(setPipelinePlan.ID))
+			})
+
+			It("runs the step", func() {
+				Expect(setPipelineStep.RunCallCount()).To(Equal(1))
+			})
+		})
+
+		Context("with a load_var plan", func() {
+			var (
+				loadVarStepFactory *execfakes.FakeStepFactory
+				loadVarStep        *execfakes.FakeStep
+				loadVarPlan        atc.Plan
+			)
+
+			BeforeEach(func() {
+				loadVarStepFactory = new(execfakes.FakeStepFactory)
+				loadVarStep = new(execfakes.FakeStep)
+				loadVarStep.SucceededReturns(true)
+				loadVarStepFactory.UsingReturns(loadVarStep)
+				fakeFactory.LoadVarReturns(loadVarStepFactory)
+
+				loadVarPlan = planFactory.NewPlan(atc.LoadVarPlan{
+					Name: "some-var",
+					File: "some-input/version",
+				})
+
+				build, err := execEngine.CreateBuild(logger, dbBuild, loadVarPlan)
+				Expect(err).NotTo(HaveOccurred())
+				build.Resume(logger)
+			})
+
+			It("constructs the load_var step correctly", func() {
+				Expect(fakeFactory.LoadVarCallCount()).To(Equal(1))
+
+				logger, plan, dBuild, _, _ := fakeFactory.LoadVarArgsForCall(0)
+				Expect(logger).NotTo(BeNil())
+				Expect(dBuild).To(Equal(dbBuild))
+				Expect(plan).To(Equal(loadVarPlan))
+
+				originID := fakeDelegate.DBActionsBuildEventsDelegateArgsForCall(0)
+				Expect(originID).To(Equal(loadVarPlan.ID))
+
+				planID := fakeDelegate.BuildStepDelegateArgsForCall(0)
+				Expect(planID).To(Equal(loadVarPlan.ID))
+			})
+
+			It("runs the step", func() {
+				Expect(loadVarStep.RunCallCount()).To(Equal(1))
+			})
+
+			It("releases the build's vars once it finishes", func() {
+				Expect(fakeFactory.ReleaseBuildCallCount()).To(Equal(1))
+				Expect(fakeFactory.ReleaseBuildArgsForCall(0)).To(Equal(dbBuild))
+			})
+		})
+
 		Context("with a basic plan", func() {
 			var expectedPlan atc.Plan
 
@@ -143,3 +143,111 @@ type PutPlan struct {
 	Resource string `json:"resource"`
 	Type     string `json:"type"`
 }
+
+type FinishSetPipeline struct {
+	Origin        Origin           `json:"origin"`
+	PipelineName  string           `json:"pipeline_name"`
+	ConfigVersion int              `json:"config_version"`
+	Created       bool             `json:"created"`
+	Changes       []PipelineChange `json:"changes"`
+	ExitStatus    int              `json:"exit_status"`
+}
+
+func (FinishSetPipeline) EventType() atc.EventType  { return EventTypeFinishSetPipeline }
+func (FinishSetPipeline) Version() atc.EventVersion { return "1.0" }
+
+type FinishLoadVar struct {
+	Origin     Origin `json:"origin"`
+	VarName    string `json:"var_name"`
+	ExitStatus int    `json:"exit_status"`
+}
+
+func (FinishLoadVar) EventType() atc.EventType  { return EventTypeFinishLoadVar }
+func (FinishLoadVar) Version() atc.EventVersion { return "1.0" }
+
+type FinishSetBuildMetadata struct {
+	Origin     Origin            `json:"origin"`
+	Metadata   map[string]string `json:"metadata"`
+	ExitStatus int               `json:"exit_status"`
+}
+
+func (FinishSetBuildMetadata) EventType() atc.EventType  { return EventTypeFinishSetBuildMetadata }
+func (FinishSetBuildMetadata) Version() atc.EventVersion { return "1.0" }
+
+type WaitingForWorker struct {
+	Time   int64  `json:"time"`
+	Origin Origin `json:"origin"`
+}
+
+func (WaitingForWorker) EventType() atc.EventType  { return EventTypeWaitingForWorker }
+func (WaitingForWorker) Version() atc.EventVersion { return "1.0" }
+
+// PipelineStatus, JobStatus, BuildStatus and ResourceCheckError are the
+// changes to a team's pipelines streamed to dashboards, rather than events of
+// a single build.
+
+type PipelineStatus struct {
+	Time         int64  `json:"time"`
+	PipelineID   int    `json:"pipeline_id"`
+	PipelineName string `json:"pipeline_name"`
+	Paused       bool   `json:"paused"`
+}
+
+func (PipelineStatus) EventType() atc.EventType  { return EventTypePipelineStatus }
+func (PipelineStatus) Version() atc.EventVersion { return "1.0" }
+
+type JobStatus struct {
+	Time         int64  `json:"time"`
+	PipelineID   int    `json:"pipeline_id"`
+	PipelineName string `json:"pipeline_name"`
+	JobName      string `json:"job_name"`
+	Paused       bool   `json:"paused"`
+}
+
+func (JobStatus) EventType() atc.EventType  { return EventTypeJobStatus }
+func (JobStatus) Version() atc.EventVersion { return "1.0" }
+
+type BuildStatus struct {
+	Time         int64           `json:"time"`
+	PipelineID   int             `json:"pipeline_id"`
+	PipelineName string          `json:"pipeline_name"`
+	JobName      string          `json:"job_name,omitempty"`
+	BuildID      int             `json:"build_id"`
+	BuildName    string          `json:"build_name"`
+	Status       atc.BuildStatus `json:"status"`
+}
+
+func (BuildStatus) EventType() atc.EventType  { return EventTypeBuildStatus }
+func (BuildStatus) Version() atc.EventVersion { return "1.0" }
+
+type ResourceCheckError struct {
+	Time         int64  `json:"time"`
+	PipelineID   int    `json:"pipeline_id"`
+	PipelineName string `json:"pipeline_name"`
+	ResourceName string `json:"resource_name"`
+	CheckError   string `json:"check_error,omitempty"`
+}
+
+func (ResourceCheckError) EventType() atc.EventType  { return EventTypeResourceCheckError }
+func (ResourceCheckError) Version() atc.EventVersion { return "1.0" }
+
+// shadow the real atc.ConfigChange
+type PipelineChange struct {
+	Kind   string `json:"kind"`
+	Name   string `json:"name"`
+	Action string `json:"action"`
+}
+
+func ShadowConfigChanges(changes []atc.ConfigChange) []PipelineChange {
+	pipelineChanges := []PipelineChange{}
+
+	for _, change := range changes {
+		pipelineChanges = append(pipelineChanges, PipelineChange{
+			Kind:   change.Kind,
+			Name:   change.Name,
+			Action: change.Action,
+		})
+	}
+
+	return pipelineChanges
+}
@@ -38,6 +38,14 @@ func init() {
 	registerEvent(FinishTask{})
 	registerEvent(FinishGet{})
 	registerEvent(FinishPut{})
+	registerEvent(FinishSetPipeline{})
+	registerEvent(FinishLoadVar{})
+	registerEvent(FinishSetBuildMetadata{})
+	registerEvent(WaitingForWorker{})
+	registerEvent(PipelineStatus{})
+	registerEvent(JobStatus{})
+	registerEvent(BuildStatus{})
+	registerEvent(ResourceCheckError{})
 	registerEvent(Status{})
 	registerEvent(Log{})
 	registerEvent(Error{})
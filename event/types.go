@@ -27,6 +27,30 @@ const (
 	// finished putting something
 	EventTypeFinishPut atc.EventType = "finish-put"
 
+	// finished configuring a pipeline
+	EventTypeFinishSetPipeline atc.EventType = "finish-set-pipeline"
+
+	// finished loading a build-local var
+	EventTypeFinishLoadVar atc.EventType = "finish-load-var"
+
+	// finished recording the build's metadata
+	EventTypeFinishSetBuildMetadata atc.EventType = "finish-set-build-metadata"
+
+	// step waiting for a worker with room for its container
+	EventTypeWaitingForWorker atc.EventType = "waiting-for-worker"
+
 	// error occurred
 	EventTypeError atc.EventType = "error"
+
+	// pipeline paused or unpaused
+	EventTypePipelineStatus atc.EventType = "pipeline-status"
+
+	// job paused or unpaused
+	EventTypeJobStatus atc.EventType = "job-status"
+
+	// job build started or finished
+	EventTypeBuildStatus atc.EventType = "build-status"
+
+	// resource check error set or cleared
+	EventTypeResourceCheckError atc.EventType = "resource-check-error"
 )
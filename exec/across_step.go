@@ -0,0 +1,85 @@
+package exec
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/concourse/atc/worker"
+)
+
+// Across constructs a Step that will run each step in parallel, like
+// Aggregate, but with at most MaxInFlight steps running at once.
+type Across struct {
+	Steps       []StepFactory
+	MaxInFlight int
+	FailFast    bool
+}
+
+// Using delegates to each StepFactory and returns an *AcrossStep.
+func (a Across) Using(repo *worker.ArtifactRepository) Step {
+	step := &AcrossStep{
+		MaxInFlight: a.MaxInFlight,
+		FailFast:    a.FailFast,
+	}
+
+	for _, subStepFactory := range a.Steps {
+		step.Steps = append(step.Steps, subStepFactory.Using(repo))
+	}
+
+	return step
+}
+
+// AcrossStep is a step of steps to run in parallel, one for each combination
+// of an across step's vars.
+type AcrossStep struct {
+	Steps       []Step
+	MaxInFlight int
+	FailFast    bool
+
+	failed bool
+}
+
+// Run executes the steps in parallel, starting the next step whenever a
+// running step exits and fewer than MaxInFlight steps are running. A
+// MaxInFlight of 0 runs every step at once. Any signal received is propagated
+// to all running steps.
+//
+// If FailFast is set, the first step to fail or error causes the remaining
+// running steps to be interrupted and no further steps to be started.
+// Otherwise it will wait for all steps to exit. Their errors (if any) will be
+// aggregated and returned as a single error.
+func (step *AcrossStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	result := parallelSteps{
+		steps:       step.Steps,
+		maxInFlight: step.MaxInFlight,
+		failFast:    step.FailFast,
+	}.run(signals, ready)
+
+	step.failed = result.Failed
+
+	if result.Interrupted {
+		return ErrInterrupted
+	}
+
+	if len(result.Errors) > 0 {
+		return fmt.Errorf("steps failed:\n%s", strings.Join(result.Errors, "\n"))
+	}
+
+	return nil
+}
+
+// Succeeded is true if all of the steps ran and succeeded.
+func (step *AcrossStep) Succeeded() bool {
+	if step.failed {
+		return false
+	}
+
+	for _, src := range step.Steps {
+		if !src.Succeeded() {
+			return false
+		}
+	}
+
+	return true
+}
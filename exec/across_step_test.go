@@ -0,0 +1,225 @@
+package exec_test
+
+import (
+	"errors"
+	"os"
+	"sync"
+
+	. "github.com/concourse/atc/exec"
+	"github.com/concourse/atc/worker"
+
+	"github.com/concourse/atc/exec/execfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/tedsuo/ifrit"
+)
+
+var _ = Describe("Across", func() {
+	var (
+		fakeStepA *execfakes.FakeStepFactory
+		fakeStepB *execfakes.FakeStepFactory
+		fakeStepC *execfakes.FakeStepFactory
+
+		across Across
+
+		repo *worker.ArtifactRepository
+
+		outStepA *execfakes.FakeStep
+		outStepB *execfakes.FakeStep
+		outStepC *execfakes.FakeStep
+
+		step    Step
+		process ifrit.Process
+	)
+
+	BeforeEach(func() {
+		fakeStepA = new(execfakes.FakeStepFactory)
+		fakeStepB = new(execfakes.FakeStepFactory)
+		fakeStepC = new(execfakes.FakeStepFactory)
+
+		across = Across{
+			Steps: []StepFactory{
+				fakeStepA,
+				fakeStepB,
+				fakeStepC,
+			},
+		}
+
+		repo = worker.NewArtifactRepository()
+
+		outStepA = new(execfakes.FakeStep)
+		outStepA.SucceededReturns(true)
+		fakeStepA.UsingReturns(outStepA)
+
+		outStepB = new(execfakes.FakeStep)
+		outStepB.SucceededReturns(true)
+		fakeStepB.UsingReturns(outStepB)
+
+		outStepC = new(execfakes.FakeStep)
+		outStepC.SucceededReturns(true)
+		fakeStepC.UsingReturns(outStepC)
+	})
+
+	JustBeforeEach(func() {
+		step = across.Using(repo)
+		process = ifrit.Invoke(step)
+	})
+
+	It("uses the input source for all steps", func() {
+		Expect(fakeStepA.UsingCallCount()).To(Equal(1))
+		Expect(fakeStepA.UsingArgsForCall(0)).To(Equal(repo))
+
+		Expect(fakeStepB.UsingCallCount()).To(Equal(1))
+		Expect(fakeStepB.UsingArgsForCall(0)).To(Equal(repo))
+
+		Expect(fakeStepC.UsingCallCount()).To(Equal(1))
+		Expect(fakeStepC.UsingArgsForCall(0)).To(Equal(repo))
+	})
+
+	It("exits successfully", func() {
+		Eventually(process.Wait()).Should(Receive(BeNil()))
+		Expect(step.Succeeded()).To(BeTrue())
+	})
+
+	Context("when max in flight is not set", func() {
+		BeforeEach(func() {
+			wg := new(sync.WaitGroup)
+			wg.Add(3)
+
+			stub := func(signals <-chan os.Signal, ready chan<- struct{}) error {
+				wg.Done()
+				wg.Wait()
+				return nil
+			}
+
+			outStepA.RunStub = stub
+			outStepB.RunStub = stub
+			outStepC.RunStub = stub
+		})
+
+		It("runs every step concurrently", func() {
+			Eventually(process.Wait()).Should(Receive(BeNil()))
+			Expect(outStepA.RunCallCount()).To(Equal(1))
+			Expect(outStepB.RunCallCount()).To(Equal(1))
+			Expect(outStepC.RunCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when max in flight is set", func() {
+		var finishA chan struct{}
+
+		BeforeEach(func() {
+			across.MaxInFlight = 2
+
+			finishA = make(chan struct{})
+
+			outStepA.RunStub = func(signals <-chan os.Signal, ready chan<- struct{}) error {
+				<-finishA
+				return nil
+			}
+
+			outStepB.RunStub = func(signals <-chan os.Signal, ready chan<- struct{}) error {
+				<-signals
+				return ErrInterrupted
+			}
+		})
+
+		It("only starts another step once a running step exits", func() {
+			Eventually(outStepA.RunCallCount).Should(Equal(1))
+			Eventually(outStepB.RunCallCount).Should(Equal(1))
+			Consistently(outStepC.RunCallCount).Should(Equal(0))
+
+			close(finishA)
+
+			Eventually(outStepC.RunCallCount).Should(Equal(1))
+
+			process.Signal(os.Interrupt)
+			Eventually(process.Wait()).Should(Receive(Equal(ErrInterrupted)))
+		})
+	})
+
+	Context("when a step fails", func() {
+		BeforeEach(func() {
+			across.MaxInFlight = 1
+			outStepA.RunReturns(errors.New("nope A"))
+		})
+
+		It("runs the rest of the steps", func() {
+			var err error
+			Eventually(process.Wait()).Should(Receive(&err))
+			Expect(err.Error()).To(ContainSubstring("nope A"))
+
+			Expect(outStepB.RunCallCount()).To(Equal(1))
+			Expect(outStepC.RunCallCount()).To(Equal(1))
+			Expect(step.Succeeded()).To(BeFalse())
+		})
+
+		Context("when fail fast is set", func() {
+			BeforeEach(func() {
+				across.FailFast = true
+			})
+
+			It("does not start any more steps", func() {
+				var err error
+				Eventually(process.Wait()).Should(Receive(&err))
+				Expect(err.Error()).To(ContainSubstring("nope A"))
+
+				Expect(outStepB.RunCallCount()).To(Equal(0))
+				Expect(outStepC.RunCallCount()).To(Equal(0))
+				Expect(step.Succeeded()).To(BeFalse())
+			})
+		})
+	})
+
+	Context("when a step does not succeed and fail fast is set", func() {
+		BeforeEach(func() {
+			across.FailFast = true
+
+			outStepA.SucceededReturns(false)
+			outStepA.RunStub = func(signals <-chan os.Signal, ready chan<- struct{}) error {
+				return nil
+			}
+
+			outStepB.RunStub = func(signals <-chan os.Signal, ready chan<- struct{}) error {
+				<-signals
+				return ErrInterrupted
+			}
+
+			outStepC.RunStub = func(signals <-chan os.Signal, ready chan<- struct{}) error {
+				<-signals
+				return ErrInterrupted
+			}
+		})
+
+		It("interrupts the running steps", func() {
+			Eventually(process.Wait()).Should(Receive(BeNil()))
+			Expect(step.Succeeded()).To(BeFalse())
+		})
+	})
+
+	Describe("signalling", func() {
+		var receivedSignals chan os.Signal
+
+		BeforeEach(func() {
+			receivedSignals = make(chan os.Signal, 3)
+
+			stub := func(signals <-chan os.Signal, ready chan<- struct{}) error {
+				receivedSignals <- <-signals
+				return ErrInterrupted
+			}
+
+			outStepA.RunStub = stub
+			outStepB.RunStub = stub
+			outStepC.RunStub = stub
+		})
+
+		It("propagates the signal and returns ErrInterrupted", func() {
+			process.Signal(os.Interrupt)
+
+			Eventually(receivedSignals).Should(Receive(Equal(os.Interrupt)))
+			Eventually(receivedSignals).Should(Receive(Equal(os.Interrupt)))
+			Eventually(receivedSignals).Should(Receive(Equal(os.Interrupt)))
+			Eventually(process.Wait()).Should(Receive(Equal(ErrInterrupted)))
+		})
+	})
+})
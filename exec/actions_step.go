@@ -60,7 +60,7 @@ func (s *ActionsStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error
 	for _, action := range s.actions {
 		err := action.Run(s.logger, s.repository, signals, ready)
 		if err != nil {
-			if err == resource.ErrAborted {
+			if err == resource.ErrAborted || err == worker.ErrAborted {
 				s.logger.Debug("resource-aborted")
 				s.buildEventsDelegate.Failed(s.logger, ErrInterrupted)
 				return ErrInterrupted
@@ -6,7 +6,6 @@ import (
 	"strings"
 
 	"github.com/concourse/atc/worker"
-	"github.com/tedsuo/ifrit"
 )
 
 // Aggregate constructs a Step that will run each step in parallel.
@@ -34,45 +33,17 @@ type AggregateStep []Step
 // all steps finish, their errors (if any) will be aggregated and returned as a
 // single error.
 func (step AggregateStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
-	members := []ifrit.Process{}
+	result := parallelSteps{
+		steps:        step,
+		waitForReady: true,
+	}.run(signals, ready)
 
-	for _, ms := range step {
-		process := ifrit.Background(ms)
-		members = append(members, process)
+	if result.Interrupted {
+		return ErrInterrupted
 	}
 
-	for _, mp := range members {
-		select {
-		case <-mp.Ready():
-		case <-mp.Wait():
-		}
-	}
-
-	close(ready)
-
-	var errorMessages []string
-
-	for _, mp := range members {
-		select {
-		case sig := <-signals:
-			for _, mp := range members {
-				mp.Signal(sig)
-			}
-
-			for _, mp := range members {
-				<-mp.Wait()
-			}
-
-			return ErrInterrupted
-		case err := <-mp.Wait():
-			if err != nil {
-				errorMessages = append(errorMessages, err.Error())
-			}
-		}
-	}
-
-	if len(errorMessages) > 0 {
-		return fmt.Errorf("sources failed:\n%s", strings.Join(errorMessages, "\n"))
+	if len(result.Errors) > 0 {
+		return fmt.Errorf("sources failed:\n%s", strings.Join(result.Errors, "\n"))
 	}
 
 	return nil
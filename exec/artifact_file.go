@@ -0,0 +1,42 @@
+package exec
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/concourse/atc/worker"
+	"github.com/concourse/baggageclaim"
+)
+
+// readArtifactFile reads the file at the given path out of the
+// worker.ArtifactRepository.
+//
+// The path must be in the format SOURCE_NAME/FILE/PATH. The SOURCE_NAME will
+// be used to determine the ArtifactSource to stream the file out of.
+func readArtifactFile(repo *worker.ArtifactRepository, path string) ([]byte, error) {
+	segs := strings.SplitN(path, "/", 2)
+	if len(segs) != 2 {
+		return nil, UnspecifiedArtifactSourceError{path}
+	}
+
+	sourceName := worker.ArtifactName(segs[0])
+	filePath := segs[1]
+
+	source, found := repo.SourceFor(sourceName)
+	if !found {
+		return nil, UnknownArtifactSourceError{sourceName}
+	}
+
+	stream, err := source.StreamFile(filePath)
+	if err != nil {
+		if err == baggageclaim.ErrFileNotFound {
+			return nil, fmt.Errorf("file '%s/%s' not found", sourceName, filePath)
+		}
+		return nil, err
+	}
+
+	defer stream.Close()
+
+	return ioutil.ReadAll(stream)
+}
@@ -0,0 +1,331 @@
+package exec
+
+import (
+	"bytes"
+	"io"
+	"sync"
+
+	"code.cloudfoundry.org/lager"
+
+	"github.com/cloudfoundry/bosh-cli/director/template"
+	"github.com/concourse/atc/creds"
+)
+
+const redactedValue = "((redacted))"
+
+// BuildVariables holds the vars set by load_var steps during a single build.
+//
+// They are layered on top of the credential manager's variables via Wrap, so
+// later steps in the same build interpolate them as ((name)) through the same
+// evaluation path as any other var. Vars take precedence over credentials of
+// the same name.
+type BuildVariables struct {
+	lock sync.RWMutex
+
+	vars     map[string]interface{}
+	redacted map[string]bool
+}
+
+func NewBuildVariables() *BuildVariables {
+	return &BuildVariables{
+		vars:     map[string]interface{}{},
+		redacted: map[string]bool{},
+	}
+}
+
+// AddVar sets a var, replacing any previous value with the same name. If
+// redact is true, any string values within it are hidden from build logs
+// written through a delegate returned by RedactingDelegates.
+func (v *BuildVariables) AddVar(name string, value interface{}, redact bool) {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	v.vars[name] = value
+	v.redacted[name] = redact
+}
+
+func (v *BuildVariables) Get(varDef template.VariableDefinition) (interface{}, bool, error) {
+	v.lock.RLock()
+	defer v.lock.RUnlock()
+
+	value, found := v.vars[varDef.Name]
+	return value, found, nil
+}
+
+func (v *BuildVariables) List() ([]template.VariableDefinition, error) {
+	v.lock.RLock()
+	defer v.lock.RUnlock()
+
+	defs := []template.VariableDefinition{}
+	for name := range v.vars {
+		defs = append(defs, template.VariableDefinition{Name: name})
+	}
+
+	return defs, nil
+}
+
+// Wrap returns variables which resolve the build's vars first, falling back
+// to the given variables.
+func (v *BuildVariables) Wrap(parent creds.Variables) creds.Variables {
+	return buildScopedVariables{
+		build:  v,
+		parent: parent,
+	}
+}
+
+// RedactingDelegates returns a BuildStepDelegate whose Stdout and Stderr
+// replace the values of redacted vars with ((redacted)). Vars set after the
+// writers are acquired are redacted too.
+//
+// Output ending in what may be the start of a secret is held back until more
+// is written, so that secrets split across writes are redacted as well. It is
+// written once an action completes or fails, as reported to the returned
+// ActionsBuildEventsDelegate.
+func (v *BuildVariables) RedactingDelegates(
+	buildEventsDelegate ActionsBuildEventsDelegate,
+	buildStepDelegate BuildStepDelegate,
+) (ActionsBuildEventsDelegate, BuildStepDelegate) {
+	redactingDelegate := &redactingBuildStepDelegate{
+		BuildStepDelegate: buildStepDelegate,
+		build:             v,
+	}
+
+	flushingDelegate := flushingBuildEventsDelegate{
+		ActionsBuildEventsDelegate: buildEventsDelegate,
+		buildStepDelegate:          redactingDelegate,
+	}
+
+	return flushingDelegate, redactingDelegate
+}
+
+func (v *BuildVariables) secrets() []string {
+	v.lock.RLock()
+	defer v.lock.RUnlock()
+
+	secrets := []string{}
+	for name, value := range v.vars {
+		if v.redacted[name] {
+			secrets = appendSecrets(secrets, value)
+		}
+	}
+
+	return secrets
+}
+
+func appendSecrets(secrets []string, value interface{}) []string {
+	switch v := value.(type) {
+	case string:
+		if v != "" {
+			secrets = append(secrets, v)
+		}
+	case []interface{}:
+		for _, elem := range v {
+			secrets = appendSecrets(secrets, elem)
+		}
+	case map[string]interface{}:
+		for _, elem := range v {
+			secrets = appendSecrets(secrets, elem)
+		}
+	case map[interface{}]interface{}:
+		for _, elem := range v {
+			secrets = appendSecrets(secrets, elem)
+		}
+	}
+
+	return secrets
+}
+
+type buildScopedVariables struct {
+	build  *BuildVariables
+	parent creds.Variables
+}
+
+func (v buildScopedVariables) Get(varDef template.VariableDefinition) (interface{}, bool, error) {
+	value, found, err := v.build.Get(varDef)
+	if err != nil || found {
+		return value, found, err
+	}
+
+	return v.parent.Get(varDef)
+}
+
+func (v buildScopedVariables) List() ([]template.VariableDefinition, error) {
+	defs, err := v.parent.List()
+	if err != nil {
+		return nil, err
+	}
+
+	buildDefs, err := v.build.List()
+	if err != nil {
+		return nil, err
+	}
+
+	return append(defs, buildDefs...), nil
+}
+
+// redactingBuildStepDelegate hands out the same writers for every call to
+// Stdout and Stderr, so that output held back by one is not lost to the next.
+type redactingBuildStepDelegate struct {
+	BuildStepDelegate
+
+	build *BuildVariables
+
+	lock   sync.Mutex
+	stdout *redactingWriter
+	stderr *redactingWriter
+}
+
+func (d *redactingBuildStepDelegate) Stdout() io.Writer {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	if d.stdout == nil {
+		d.stdout = &redactingWriter{
+			writer: d.BuildStepDelegate.Stdout(),
+			build:  d.build,
+		}
+	}
+
+	return d.stdout
+}
+
+func (d *redactingBuildStepDelegate) Stderr() io.Writer {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	if d.stderr == nil {
+		d.stderr = &redactingWriter{
+			writer: d.BuildStepDelegate.Stderr(),
+			build:  d.build,
+		}
+	}
+
+	return d.stderr
+}
+
+func (d *redactingBuildStepDelegate) flush(logger lager.Logger) {
+	d.lock.Lock()
+	writers := []*redactingWriter{d.stdout, d.stderr}
+	d.lock.Unlock()
+
+	for _, writer := range writers {
+		if writer == nil {
+			continue
+		}
+
+		err := writer.flush()
+		if err != nil {
+			logger.Error("failed-to-flush-output", err)
+		}
+	}
+}
+
+type flushingBuildEventsDelegate struct {
+	ActionsBuildEventsDelegate
+
+	buildStepDelegate *redactingBuildStepDelegate
+}
+
+func (d flushingBuildEventsDelegate) ActionCompleted(logger lager.Logger, action Action) {
+	d.buildStepDelegate.flush(logger)
+	d.ActionsBuildEventsDelegate.ActionCompleted(logger, action)
+}
+
+func (d flushingBuildEventsDelegate) Failed(logger lager.Logger, err error) {
+	d.buildStepDelegate.flush(logger)
+	d.ActionsBuildEventsDelegate.Failed(logger, err)
+}
+
+type redactingWriter struct {
+	writer io.Writer
+	build  *BuildVariables
+
+	lock    sync.Mutex
+	pending []byte
+}
+
+func (w *redactingWriter) Write(p []byte) (int, error) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	secrets := w.build.secrets()
+	if len(secrets) == 0 && len(w.pending) == 0 {
+		return w.writer.Write(p)
+	}
+
+	output, pending := redact(append(w.pending, p...), secrets, false)
+	w.pending = pending
+
+	if len(output) > 0 {
+		_, err := w.writer.Write(output)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return len(p), nil
+}
+
+// flush writes the output held back, as no more of a secret will follow it.
+func (w *redactingWriter) flush() error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if len(w.pending) == 0 {
+		return nil
+	}
+
+	output, _ := redact(w.pending, w.build.secrets(), true)
+	w.pending = nil
+
+	_, err := w.writer.Write(output)
+	return err
+}
+
+// redact replaces the secrets in the output. Unless final is true, the output
+// from the first point at which it ends with the start of a secret is held
+// back and returned as pending.
+func redact(output []byte, secrets []string, final bool) ([]byte, []byte) {
+	secretBytes := make([][]byte, len(secrets))
+	for i, secret := range secrets {
+		secretBytes[i] = []byte(secret)
+	}
+
+	redacted := bytes.Buffer{}
+
+	i := 0
+	for i < len(output) {
+		rest := output[i:]
+
+		var (
+			found   int
+			partial bool
+		)
+
+		for _, secret := range secretBytes {
+			if bytes.HasPrefix(rest, secret) {
+				if len(secret) > found {
+					found = len(secret)
+				}
+			} else if bytes.HasPrefix(secret, rest) {
+				partial = true
+			}
+		}
+
+		// a longer secret may yet follow what has been found so far
+		if partial && !final {
+			return redacted.Bytes(), append([]byte{}, rest...)
+		}
+
+		if found > 0 {
+			redacted.WriteString(redactedValue)
+			i += found
+			continue
+		}
+
+		redacted.WriteByte(output[i])
+		i++
+	}
+
+	return redacted.Bytes(), nil
+}
@@ -0,0 +1,167 @@
+package exec_test
+
+import (
+	"errors"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/cloudfoundry/bosh-cli/director/template"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
+	. "github.com/concourse/atc/exec"
+	"github.com/concourse/atc/exec/execfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("BuildVariables", func() {
+	var buildVariables *BuildVariables
+
+	BeforeEach(func() {
+		buildVariables = NewBuildVariables()
+	})
+
+	Describe("Wrap", func() {
+		var variables creds.Variables
+
+		BeforeEach(func() {
+			variables = buildVariables.Wrap(template.StaticVariables{
+				"some-cred":   "some-cred-value",
+				"shadowed":    "cred-value",
+				"another-var": "another-value",
+			})
+
+			buildVariables.AddVar("shadowed", "build-value", true)
+		})
+
+		It("prefers the build's vars", func() {
+			value, found, err := variables.Get(template.VariableDefinition{Name: "shadowed"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(value).To(Equal("build-value"))
+		})
+
+		It("falls back to the wrapped variables", func() {
+			value, found, err := variables.Get(template.VariableDefinition{Name: "some-cred"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(value).To(Equal("some-cred-value"))
+		})
+
+		It("evaluates params using the build's vars", func() {
+			params, err := creds.NewParams(variables, atc.Params{
+				"version": "v((shadowed))",
+				"cred":    "((some-cred))",
+			}).Evaluate()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(params).To(Equal(atc.Params{
+				"version": "vbuild-value",
+				"cred":    "some-cred-value",
+			}))
+		})
+	})
+
+	Describe("RedactingDelegates", func() {
+		var (
+			fakeBuildEventsDelegate *execfakes.FakeActionsBuildEventsDelegate
+			fakeBuildStepDelegate   *execfakes.FakeBuildStepDelegate
+			stdoutBuf               *gbytes.Buffer
+			stderrBuf               *gbytes.Buffer
+			eventsDelegate          ActionsBuildEventsDelegate
+			delegate                BuildStepDelegate
+		)
+
+		BeforeEach(func() {
+			fakeBuildEventsDelegate = new(execfakes.FakeActionsBuildEventsDelegate)
+			fakeBuildStepDelegate = new(execfakes.FakeBuildStepDelegate)
+			stdoutBuf = gbytes.NewBuffer()
+			stderrBuf = gbytes.NewBuffer()
+			fakeBuildStepDelegate.StdoutReturns(stdoutBuf)
+			fakeBuildStepDelegate.StderrReturns(stderrBuf)
+
+			eventsDelegate, delegate = buildVariables.RedactingDelegates(fakeBuildEventsDelegate, fakeBuildStepDelegate)
+		})
+
+		It("redacts vars loaded after the writers were acquired", func() {
+			stdout := delegate.Stdout()
+			stderr := delegate.Stderr()
+
+			buildVariables.AddVar("some-var", map[interface{}]interface{}{
+				"nested": []interface{}{"some-secret"},
+			}, true)
+
+			_, err := stdout.Write([]byte("out: some-secret\n"))
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = stderr.Write([]byte("err: some-secret\n"))
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(stdoutBuf).To(gbytes.Say(`out: \(\(redacted\)\)`))
+			Expect(stderrBuf).To(gbytes.Say(`err: \(\(redacted\)\)`))
+		})
+
+		It("reports the length of the original output as written", func() {
+			buildVariables.AddVar("some-var", "x", true)
+
+			n, err := delegate.Stdout().Write([]byte("xxx"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(n).To(Equal(3))
+		})
+
+		It("does not redact revealed vars", func() {
+			buildVariables.AddVar("some-var", "not-a-secret", false)
+
+			_, err := delegate.Stdout().Write([]byte("out: not-a-secret\n"))
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(stdoutBuf).To(gbytes.Say("out: not-a-secret"))
+		})
+
+		Context("when a secret is split across writes", func() {
+			BeforeEach(func() {
+				buildVariables.AddVar("some-var", "some-secret", true)
+
+				_, err := delegate.Stdout().Write([]byte("out: some-se"))
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = delegate.Stdout().Write([]byte("cret\n"))
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("redacts it", func() {
+				Expect(string(stdoutBuf.Contents())).To(Equal("out: ((redacted))\n"))
+			})
+		})
+
+		Context("when the output ends with the start of a secret", func() {
+			BeforeEach(func() {
+				buildVariables.AddVar("some-var", "some-secret", true)
+
+				_, err := delegate.Stdout().Write([]byte("out: some-se"))
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("holds it back", func() {
+				Expect(string(stdoutBuf.Contents())).To(Equal("out: "))
+			})
+
+			It("writes it once the action completes", func() {
+				eventsDelegate.ActionCompleted(lagertest.NewTestLogger("test"), nil)
+
+				Expect(string(stdoutBuf.Contents())).To(Equal("out: some-se"))
+				Expect(fakeBuildEventsDelegate.ActionCompletedCallCount()).To(Equal(1))
+			})
+
+			It("writes it once the action fails", func() {
+				disaster := errors.New("nope")
+				eventsDelegate.Failed(lagertest.NewTestLogger("test"), disaster)
+
+				Expect(string(stdoutBuf.Contents())).To(Equal("out: some-se"))
+				Expect(fakeBuildEventsDelegate.FailedCallCount()).To(Equal(1))
+				_, err := fakeBuildEventsDelegate.FailedArgsForCall(0)
+				Expect(err).To(Equal(disaster))
+			})
+		})
+	})
+})
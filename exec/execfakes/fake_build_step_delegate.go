@@ -39,6 +39,15 @@ type FakeBuildStepDelegate struct {
 	stderrReturnsOnCall map[int]struct {
 		result1 io.Writer
 	}
+	WaitingForWorkerStub        func() error
+	waitingForWorkerMutex       sync.RWMutex
+	waitingForWorkerArgsForCall []struct{}
+	waitingForWorkerReturns     struct {
+		result1 error
+	}
+	waitingForWorkerReturnsOnCall map[int]struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -171,6 +180,46 @@ func (fake *FakeBuildStepDelegate) StderrReturnsOnCall(i int, result1 io.Writer)
 	}{result1}
 }
 
+func (fake *FakeBuildStepDelegate) WaitingForWorker() error {
+	fake.waitingForWorkerMutex.Lock()
+	ret, specificReturn := fake.waitingForWorkerReturnsOnCall[len(fake.waitingForWorkerArgsForCall)]
+	fake.waitingForWorkerArgsForCall = append(fake.waitingForWorkerArgsForCall, struct{}{})
+	fake.recordInvocation("WaitingForWorker", []interface{}{})
+	fake.waitingForWorkerMutex.Unlock()
+	if fake.WaitingForWorkerStub != nil {
+		return fake.WaitingForWorkerStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.waitingForWorkerReturns.result1
+}
+
+func (fake *FakeBuildStepDelegate) WaitingForWorkerCallCount() int {
+	fake.waitingForWorkerMutex.RLock()
+	defer fake.waitingForWorkerMutex.RUnlock()
+	return len(fake.waitingForWorkerArgsForCall)
+}
+
+func (fake *FakeBuildStepDelegate) WaitingForWorkerReturns(result1 error) {
+	fake.WaitingForWorkerStub = nil
+	fake.waitingForWorkerReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuildStepDelegate) WaitingForWorkerReturnsOnCall(i int, result1 error) {
+	fake.WaitingForWorkerStub = nil
+	if fake.waitingForWorkerReturnsOnCall == nil {
+		fake.waitingForWorkerReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.waitingForWorkerReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeBuildStepDelegate) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -180,6 +229,8 @@ func (fake *FakeBuildStepDelegate) Invocations() map[string][][]interface{} {
 	defer fake.stdoutMutex.RUnlock()
 	fake.stderrMutex.RLock()
 	defer fake.stderrMutex.RUnlock()
+	fake.waitingForWorkerMutex.RLock()
+	defer fake.waitingForWorkerMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
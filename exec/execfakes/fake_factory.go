@@ -62,6 +62,56 @@ type FakeFactory struct {
 	taskReturnsOnCall map[int]struct {
 		result1 exec.StepFactory
 	}
+	SetPipelineStub        func(lager.Logger, atc.Plan, db.Build, exec.ActionsBuildEventsDelegate, exec.BuildStepDelegate) exec.StepFactory
+	setPipelineMutex       sync.RWMutex
+	setPipelineArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 atc.Plan
+		arg3 db.Build
+		arg4 exec.ActionsBuildEventsDelegate
+		arg5 exec.BuildStepDelegate
+	}
+	setPipelineReturns struct {
+		result1 exec.StepFactory
+	}
+	setPipelineReturnsOnCall map[int]struct {
+		result1 exec.StepFactory
+	}
+	LoadVarStub        func(lager.Logger, atc.Plan, db.Build, exec.ActionsBuildEventsDelegate, exec.BuildStepDelegate) exec.StepFactory
+	loadVarMutex       sync.RWMutex
+	loadVarArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 atc.Plan
+		arg3 db.Build
+		arg4 exec.ActionsBuildEventsDelegate
+		arg5 exec.BuildStepDelegate
+	}
+	loadVarReturns struct {
+		result1 exec.StepFactory
+	}
+	loadVarReturnsOnCall map[int]struct {
+		result1 exec.StepFactory
+	}
+	SetBuildMetadataStub        func(arg1 lager.Logger, arg2 atc.Plan, arg3 db.Build, arg4 exec.ActionsBuildEventsDelegate, arg5 exec.BuildStepDelegate) exec.StepFactory
+	setBuildMetadataMutex       sync.RWMutex
+	setBuildMetadataArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 atc.Plan
+		arg3 db.Build
+		arg4 exec.ActionsBuildEventsDelegate
+		arg5 exec.BuildStepDelegate
+	}
+	setBuildMetadataReturns struct {
+		result1 exec.StepFactory
+	}
+	setBuildMetadataReturnsOnCall map[int]struct {
+		result1 exec.StepFactory
+	}
+	ReleaseBuildStub        func(db.Build)
+	releaseBuildMutex       sync.RWMutex
+	releaseBuildArgsForCall []struct {
+		arg1 db.Build
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -228,6 +278,186 @@ func (fake *FakeFactory) TaskReturnsOnCall(i int, result1 exec.StepFactory) {
 	}{result1}
 }
 
+func (fake *FakeFactory) SetPipeline(arg1 lager.Logger, arg2 atc.Plan, arg3 db.Build, arg4 exec.ActionsBuildEventsDelegate, arg5 exec.BuildStepDelegate) exec.StepFactory {
+	fake.setPipelineMutex.Lock()
+	ret, specificReturn := fake.setPipelineReturnsOnCall[len(fake.setPipelineArgsForCall)]
+	fake.setPipelineArgsForCall = append(fake.setPipelineArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 atc.Plan
+		arg3 db.Build
+		arg4 exec.ActionsBuildEventsDelegate
+		arg5 exec.BuildStepDelegate
+	}{arg1, arg2, arg3, arg4, arg5})
+	fake.recordInvocation("SetPipeline", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.setPipelineMutex.Unlock()
+	if fake.SetPipelineStub != nil {
+		return fake.SetPipelineStub(arg1, arg2, arg3, arg4, arg5)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.setPipelineReturns.result1
+}
+
+func (fake *FakeFactory) SetPipelineCallCount() int {
+	fake.setPipelineMutex.RLock()
+	defer fake.setPipelineMutex.RUnlock()
+	return len(fake.setPipelineArgsForCall)
+}
+
+func (fake *FakeFactory) SetPipelineArgsForCall(i int) (lager.Logger, atc.Plan, db.Build, exec.ActionsBuildEventsDelegate, exec.BuildStepDelegate) {
+	fake.setPipelineMutex.RLock()
+	defer fake.setPipelineMutex.RUnlock()
+	return fake.setPipelineArgsForCall[i].arg1, fake.setPipelineArgsForCall[i].arg2, fake.setPipelineArgsForCall[i].arg3, fake.setPipelineArgsForCall[i].arg4, fake.setPipelineArgsForCall[i].arg5
+}
+
+func (fake *FakeFactory) SetPipelineReturns(result1 exec.StepFactory) {
+	fake.SetPipelineStub = nil
+	fake.setPipelineReturns = struct {
+		result1 exec.StepFactory
+	}{result1}
+}
+
+func (fake *FakeFactory) SetPipelineReturnsOnCall(i int, result1 exec.StepFactory) {
+	fake.SetPipelineStub = nil
+	if fake.setPipelineReturnsOnCall == nil {
+		fake.setPipelineReturnsOnCall = make(map[int]struct {
+			result1 exec.StepFactory
+		})
+	}
+	fake.setPipelineReturnsOnCall[i] = struct {
+		result1 exec.StepFactory
+	}{result1}
+}
+
+func (fake *FakeFactory) LoadVar(arg1 lager.Logger, arg2 atc.Plan, arg3 db.Build, arg4 exec.ActionsBuildEventsDelegate, arg5 exec.BuildStepDelegate) exec.StepFactory {
+	fake.loadVarMutex.Lock()
+	ret, specificReturn := fake.loadVarReturnsOnCall[len(fake.loadVarArgsForCall)]
+	fake.loadVarArgsForCall = append(fake.loadVarArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 atc.Plan
+		arg3 db.Build
+		arg4 exec.ActionsBuildEventsDelegate
+		arg5 exec.BuildStepDelegate
+	}{arg1, arg2, arg3, arg4, arg5})
+	fake.recordInvocation("LoadVar", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.loadVarMutex.Unlock()
+	if fake.LoadVarStub != nil {
+		return fake.LoadVarStub(arg1, arg2, arg3, arg4, arg5)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.loadVarReturns.result1
+}
+
+func (fake *FakeFactory) LoadVarCallCount() int {
+	fake.loadVarMutex.RLock()
+	defer fake.loadVarMutex.RUnlock()
+	return len(fake.loadVarArgsForCall)
+}
+
+func (fake *FakeFactory) LoadVarArgsForCall(i int) (lager.Logger, atc.Plan, db.Build, exec.ActionsBuildEventsDelegate, exec.BuildStepDelegate) {
+	fake.loadVarMutex.RLock()
+	defer fake.loadVarMutex.RUnlock()
+	return fake.loadVarArgsForCall[i].arg1, fake.loadVarArgsForCall[i].arg2, fake.loadVarArgsForCall[i].arg3, fake.loadVarArgsForCall[i].arg4, fake.loadVarArgsForCall[i].arg5
+}
+
+func (fake *FakeFactory) LoadVarReturns(result1 exec.StepFactory) {
+	fake.LoadVarStub = nil
+	fake.loadVarReturns = struct {
+		result1 exec.StepFactory
+	}{result1}
+}
+
+func (fake *FakeFactory) LoadVarReturnsOnCall(i int, result1 exec.StepFactory) {
+	fake.LoadVarStub = nil
+	if fake.loadVarReturnsOnCall == nil {
+		fake.loadVarReturnsOnCall = make(map[int]struct {
+			result1 exec.StepFactory
+		})
+	}
+	fake.loadVarReturnsOnCall[i] = struct {
+		result1 exec.StepFactory
+	}{result1}
+}
+
+func (fake *FakeFactory) SetBuildMetadata(arg1 lager.Logger, arg2 atc.Plan, arg3 db.Build, arg4 exec.ActionsBuildEventsDelegate, arg5 exec.BuildStepDelegate) exec.StepFactory {
+	fake.setBuildMetadataMutex.Lock()
+	ret, specificReturn := fake.setBuildMetadataReturnsOnCall[len(fake.setBuildMetadataArgsForCall)]
+	fake.setBuildMetadataArgsForCall = append(fake.setBuildMetadataArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 atc.Plan
+		arg3 db.Build
+		arg4 exec.ActionsBuildEventsDelegate
+		arg5 exec.BuildStepDelegate
+	}{arg1, arg2, arg3, arg4, arg5})
+	fake.recordInvocation("SetBuildMetadata", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.setBuildMetadataMutex.Unlock()
+	if fake.SetBuildMetadataStub != nil {
+		return fake.SetBuildMetadataStub(arg1, arg2, arg3, arg4, arg5)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.setBuildMetadataReturns.result1
+}
+
+func (fake *FakeFactory) SetBuildMetadataCallCount() int {
+	fake.setBuildMetadataMutex.RLock()
+	defer fake.setBuildMetadataMutex.RUnlock()
+	return len(fake.setBuildMetadataArgsForCall)
+}
+
+func (fake *FakeFactory) SetBuildMetadataArgsForCall(i int) (lager.Logger, atc.Plan, db.Build, exec.ActionsBuildEventsDelegate, exec.BuildStepDelegate) {
+	fake.setBuildMetadataMutex.RLock()
+	defer fake.setBuildMetadataMutex.RUnlock()
+	return fake.setBuildMetadataArgsForCall[i].arg1, fake.setBuildMetadataArgsForCall[i].arg2, fake.setBuildMetadataArgsForCall[i].arg3, fake.setBuildMetadataArgsForCall[i].arg4, fake.setBuildMetadataArgsForCall[i].arg5
+}
+
+func (fake *FakeFactory) SetBuildMetadataReturns(result1 exec.StepFactory) {
+	fake.SetBuildMetadataStub = nil
+	fake.setBuildMetadataReturns = struct {
+		result1 exec.StepFactory
+	}{result1}
+}
+
+func (fake *FakeFactory) SetBuildMetadataReturnsOnCall(i int, result1 exec.StepFactory) {
+	fake.SetBuildMetadataStub = nil
+	if fake.setBuildMetadataReturnsOnCall == nil {
+		fake.setBuildMetadataReturnsOnCall = make(map[int]struct {
+			result1 exec.StepFactory
+		})
+	}
+	fake.setBuildMetadataReturnsOnCall[i] = struct {
+		result1 exec.StepFactory
+	}{result1}
+}
+
+func (fake *FakeFactory) ReleaseBuild(arg1 db.Build) {
+	fake.releaseBuildMutex.Lock()
+	fake.releaseBuildArgsForCall = append(fake.releaseBuildArgsForCall, struct {
+		arg1 db.Build
+	}{arg1})
+	fake.recordInvocation("ReleaseBuild", []interface{}{arg1})
+	fake.releaseBuildMutex.Unlock()
+	if fake.ReleaseBuildStub != nil {
+		fake.ReleaseBuildStub(arg1)
+	}
+}
+
+func (fake *FakeFactory) ReleaseBuildCallCount() int {
+	fake.releaseBuildMutex.RLock()
+	defer fake.releaseBuildMutex.RUnlock()
+	return len(fake.releaseBuildArgsForCall)
+}
+
+func (fake *FakeFactory) ReleaseBuildArgsForCall(i int) db.Build {
+	fake.releaseBuildMutex.RLock()
+	defer fake.releaseBuildMutex.RUnlock()
+	return fake.releaseBuildArgsForCall[i].arg1
+}
+
 func (fake *FakeFactory) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -237,6 +467,14 @@ func (fake *FakeFactory) Invocations() map[string][][]interface{} {
 	defer fake.putMutex.RUnlock()
 	fake.taskMutex.RLock()
 	defer fake.taskMutex.RUnlock()
+	fake.setPipelineMutex.RLock()
+	defer fake.setPipelineMutex.RUnlock()
+	fake.loadVarMutex.RLock()
+	defer fake.loadVarMutex.RUnlock()
+	fake.setBuildMetadataMutex.RLock()
+	defer fake.setBuildMetadataMutex.RUnlock()
+	fake.releaseBuildMutex.RLock()
+	defer fake.releaseBuildMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
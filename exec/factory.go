@@ -44,6 +44,38 @@ type Factory interface {
 		ActionsBuildEventsDelegate,
 		BuildStepDelegate,
 	) StepFactory
+
+	// SetPipeline constructs a ActionsStep factory for SetPipeline.
+	SetPipeline(
+		lager.Logger,
+		atc.Plan,
+		db.Build,
+		ActionsBuildEventsDelegate,
+		BuildStepDelegate,
+	) StepFactory
+
+	// LoadVar constructs a ActionsStep factory for LoadVar.
+	LoadVar(
+		lager.Logger,
+		atc.Plan,
+		db.Build,
+		ActionsBuildEventsDelegate,
+		BuildStepDelegate,
+	) StepFactory
+
+	// SetBuildMetadata constructs a ActionsStep factory for SetBuildMetadata.
+	SetBuildMetadata(
+		lager.Logger,
+		atc.Plan,
+		db.Build,
+		ActionsBuildEventsDelegate,
+		BuildStepDelegate,
+	) StepFactory
+
+	// ReleaseBuild forgets what was kept for the build's steps, like the vars
+	// loaded by its load_var steps. It is called once the build stops running
+	// on this ATC.
+	ReleaseBuild(db.Build)
 }
 
 // StepMetadata is used to inject metadata to make available to the step when
@@ -56,6 +88,7 @@ type StepMetadata interface {
 
 type BuildStepDelegate interface {
 	ImageVersionDetermined(*db.UsedResourceCache) error
+	WaitingForWorker() error
 	Stdout() io.Writer
 	Stderr() io.Writer
 }
@@ -4,6 +4,7 @@ import (
 	"crypto/sha1"
 	"fmt"
 	"path/filepath"
+	"sync"
 
 	"code.cloudfoundry.org/lager"
 
@@ -20,8 +21,12 @@ type gardenFactory struct {
 	resourceFactory        resource.ResourceFactory
 	dbResourceCacheFactory db.ResourceCacheFactory
 	variablesFactory       creds.VariablesFactory
+	teamFactory            db.TeamFactory
 
 	putActions map[atc.PlanID]*PutAction
+
+	buildVariables     map[int]*BuildVariables
+	buildVariablesLock sync.Mutex
 }
 
 func NewGardenFactory(
@@ -30,6 +35,7 @@ func NewGardenFactory(
 	resourceFactory resource.ResourceFactory,
 	dbResourceCacheFactory db.ResourceCacheFactory,
 	variablesFactory creds.VariablesFactory,
+	teamFactory db.TeamFactory,
 ) Factory {
 	return &gardenFactory{
 		workerClient:           workerClient,
@@ -37,7 +43,9 @@ func NewGardenFactory(
 		resourceFactory:        resourceFactory,
 		dbResourceCacheFactory: dbResourceCacheFactory,
 		variablesFactory:       variablesFactory,
+		teamFactory:            teamFactory,
 		putActions:             map[atc.PlanID]*PutAction{},
+		buildVariables:         map[int]*BuildVariables{},
 	}
 }
 
@@ -70,6 +78,36 @@ func (s TaskStepFactory) Using(repository *worker.ArtifactRepository) Step {
 	return TaskStep(s.ActionsStep.Using(repository))
 }
 
+type SetPipelineStepFactory struct {
+	ActionsStep
+}
+
+type SetPipelineStep Step
+
+func (s SetPipelineStepFactory) Using(repository *worker.ArtifactRepository) Step {
+	return SetPipelineStep(s.ActionsStep.Using(repository))
+}
+
+type LoadVarStepFactory struct {
+	ActionsStep
+}
+
+type LoadVarStep Step
+
+func (s LoadVarStepFactory) Using(repository *worker.ArtifactRepository) Step {
+	return LoadVarStep(s.ActionsStep.Using(repository))
+}
+
+type SetBuildMetadataStepFactory struct {
+	ActionsStep
+}
+
+type SetBuildMetadataStep Step
+
+func (s SetBuildMetadataStepFactory) Using(repository *worker.ArtifactRepository) Step {
+	return SetBuildMetadataStep(s.ActionsStep.Using(repository))
+}
+
 func (factory *gardenFactory) Get(
 	logger lager.Logger,
 	plan atc.Plan,
@@ -81,7 +119,9 @@ func (factory *gardenFactory) Get(
 ) StepFactory {
 	workerMetadata.WorkingDirectory = resource.ResourcesDir("get")
 
-	variables := factory.variablesFactory.NewVariables(build.TeamName(), build.PipelineName())
+	buildVariables := factory.buildVariablesFor(build)
+	variables := buildVariables.Wrap(factory.variablesFactory.NewVariables(build.TeamName(), build.PipelineName()))
+	buildEventsDelegate, buildStepDelegate = buildVariables.RedactingDelegates(buildEventsDelegate, buildStepDelegate)
 
 	getAction := &GetAction{
 		Type:          plan.Get.Type,
@@ -122,7 +162,9 @@ func (factory *gardenFactory) Put(
 ) StepFactory {
 	workerMetadata.WorkingDirectory = resource.ResourcesDir("put")
 
-	variables := factory.variablesFactory.NewVariables(build.TeamName(), build.PipelineName())
+	buildVariables := factory.buildVariablesFor(build)
+	variables := buildVariables.Wrap(factory.variablesFactory.NewVariables(build.TeamName(), build.PipelineName()))
+	buildEventsDelegate, buildStepDelegate = buildVariables.RedactingDelegates(buildEventsDelegate, buildStepDelegate)
 
 	putAction := &PutAction{
 		Type:     plan.Put.Type,
@@ -158,6 +200,10 @@ func (factory *gardenFactory) Task(
 	buildEventsDelegate ActionsBuildEventsDelegate,
 	buildStepDelegate BuildStepDelegate,
 ) StepFactory {
+	buildVariables := factory.buildVariablesFor(build)
+	variables := buildVariables.Wrap(factory.variablesFactory.NewVariables(build.TeamName(), build.PipelineName()))
+	buildEventsDelegate, buildStepDelegate = buildVariables.RedactingDelegates(buildEventsDelegate, buildStepDelegate)
+
 	workingDirectory := factory.taskWorkingDirectory(worker.ArtifactName(plan.Task.Name))
 	containerMetadata.WorkingDirectory = workingDirectory
 
@@ -188,8 +234,6 @@ func (factory *gardenFactory) Task(
 		Action: fetchConfigAction,
 	}
 
-	variables := factory.variablesFactory.NewVariables(build.TeamName(), build.PipelineName())
-
 	taskAction := &TaskAction{
 		privileged:    Privileged(plan.Task.Privileged),
 		configSource:  configSource,
@@ -220,6 +264,92 @@ func (factory *gardenFactory) Task(
 	return TaskStepFactory{NewActionsStep(logger, actions, buildEventsDelegate)}
 }
 
+func (factory *gardenFactory) SetPipeline(
+	logger lager.Logger,
+	plan atc.Plan,
+	build db.Build,
+	buildEventsDelegate ActionsBuildEventsDelegate,
+	buildStepDelegate BuildStepDelegate,
+) StepFactory {
+	buildEventsDelegate, buildStepDelegate = factory.buildVariablesFor(build).RedactingDelegates(buildEventsDelegate, buildStepDelegate)
+
+	setPipelineAction := NewSetPipelineAction(
+		plan.SetPipeline.Name,
+		plan.SetPipeline.File,
+		plan.SetPipeline.InstanceVars,
+		buildStepDelegate,
+		factory.teamFactory,
+		build.TeamID(),
+	)
+
+	actions := []Action{setPipelineAction}
+
+	return SetPipelineStepFactory{NewActionsStep(logger, actions, buildEventsDelegate)}
+}
+
+func (factory *gardenFactory) LoadVar(
+	logger lager.Logger,
+	plan atc.Plan,
+	build db.Build,
+	buildEventsDelegate ActionsBuildEventsDelegate,
+	buildStepDelegate BuildStepDelegate,
+) StepFactory {
+	buildVariables := factory.buildVariablesFor(build)
+	buildEventsDelegate, buildStepDelegate = buildVariables.RedactingDelegates(buildEventsDelegate, buildStepDelegate)
+
+	loadVarAction := NewLoadVarAction(
+		plan.LoadVar.Name,
+		plan.LoadVar.File,
+		plan.LoadVar.Format,
+		plan.LoadVar.Reveal,
+		buildStepDelegate,
+		buildVariables,
+	)
+
+	actions := []Action{loadVarAction}
+
+	return LoadVarStepFactory{NewActionsStep(logger, actions, buildEventsDelegate)}
+}
+
+func (factory *gardenFactory) SetBuildMetadata(
+	logger lager.Logger,
+	plan atc.Plan,
+	build db.Build,
+	buildEventsDelegate ActionsBuildEventsDelegate,
+	buildStepDelegate BuildStepDelegate,
+) StepFactory {
+	setBuildMetadataAction := NewSetBuildMetadataAction(
+		plan.SetBuildMetadata.File,
+		build,
+		buildStepDelegate,
+	)
+
+	actions := []Action{setBuildMetadataAction}
+
+	return SetBuildMetadataStepFactory{NewActionsStep(logger, actions, buildEventsDelegate)}
+}
+
+// buildVariablesFor returns the vars loaded so far by the build's load_var
+// steps, shared by every step constructed for the build.
+func (factory *gardenFactory) buildVariablesFor(build db.Build) *BuildVariables {
+	factory.buildVariablesLock.Lock()
+	defer factory.buildVariablesLock.Unlock()
+
+	buildVariables, found := factory.buildVariables[build.ID()]
+	if !found {
+		buildVariables = NewBuildVariables()
+		factory.buildVariables[build.ID()] = buildVariables
+	}
+
+	return buildVariables
+}
+
+func (factory *gardenFactory) ReleaseBuild(build db.Build) {
+	factory.buildVariablesLock.Lock()
+	delete(factory.buildVariables, build.ID())
+	factory.buildVariablesLock.Unlock()
+}
+
 func (factory *gardenFactory) taskWorkingDirectory(sourceName worker.ArtifactName) string {
 	sum := sha1.Sum([]byte(sourceName))
 	return filepath.Join("/tmp", "build", fmt.Sprintf("%x", sum[:4]))
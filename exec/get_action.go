@@ -169,13 +169,31 @@ type getArtifactSource struct {
 }
 
 // VolumeOn locates the cache for the GetStep's resource and version on the
-// given worker.
+// given worker, or a volume with the same content which was streamed to it
+// before.
 func (s *getArtifactSource) VolumeOn(worker worker.Worker) (worker.Volume, bool, error) {
-	return s.resourceInstance.FindOn(s.logger.Session("volume-on"), worker)
+	volume, found, err := s.resourceInstance.FindOn(s.logger.Session("volume-on"), worker)
+	if err != nil || found {
+		return volume, found, err
+	}
+
+	sourceVolume := s.versionedSource.Volume()
+	if sourceVolume == nil {
+		return nil, false, nil
+	}
+
+	return worker.FindVolumeWithSameContent(s.logger.Session("volume-on"), sourceVolume)
 }
 
-// StreamTo streams the resource's data to the destination.
+// StreamTo streams the resource's data to the destination. Volumes on other
+// workers are streamed to directly, if they can be.
 func (s *getArtifactSource) StreamTo(destination worker.ArtifactDestination) error {
+	if destVolume, ok := destination.(worker.Volume); ok {
+		if sourceVolume := s.versionedSource.Volume(); sourceVolume != nil {
+			return worker.StreamVolume(s.logger, sourceVolume, destVolume)
+		}
+	}
+
 	out, err := s.versionedSource.StreamOut(".")
 	if err != nil {
 		return err
@@ -94,7 +94,7 @@ var _ = Describe("GetAction", func() {
 			},
 		}
 
-		factory = exec.NewGardenFactory(fakeWorkerClient, fakeResourceFetcher, fakeResourceFactory, fakeDBResourceCacheFactory, fakeVariablesFactory)
+		factory = exec.NewGardenFactory(fakeWorkerClient, fakeResourceFetcher, fakeResourceFactory, fakeDBResourceCacheFactory, fakeVariablesFactory, new(dbfakes.FakeTeamFactory))
 	})
 
 	JustBeforeEach(func() {
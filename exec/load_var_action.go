@@ -0,0 +1,131 @@
+package exec
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/worker"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// LoadVarAction reads a file from the worker.ArtifactRepository into a var
+// local to the build.
+type LoadVarAction struct {
+	Name   string
+	File   string
+	Format string
+	Reveal bool
+
+	buildStepDelegate BuildStepDelegate
+	buildVariables    *BuildVariables
+
+	exitStatus ExitStatus
+}
+
+func NewLoadVarAction(
+	name string,
+	file string,
+	format string,
+	reveal bool,
+	buildStepDelegate BuildStepDelegate,
+	buildVariables *BuildVariables,
+) *LoadVarAction {
+	return &LoadVarAction{
+		Name:              name,
+		File:              file,
+		Format:            format,
+		Reveal:            reveal,
+		buildStepDelegate: buildStepDelegate,
+		buildVariables:    buildVariables,
+	}
+}
+
+// Run reads the file and parses it according to the format. If no format is
+// given it is determined by the file's extension: .json files are parsed as
+// JSON, .yml and .yaml files as YAML, and any other file is read as a string
+// with surrounding whitespace trimmed.
+//
+// Unless Reveal is set, the value is redacted from the build's logs.
+func (action *LoadVarAction) Run(
+	logger lager.Logger,
+	repository *worker.ArtifactRepository,
+	signals <-chan os.Signal,
+	ready chan<- struct{},
+) error {
+	content, err := readArtifactFile(repository, action.File)
+	if err != nil {
+		return err
+	}
+
+	value, err := action.parse(content)
+	if err != nil {
+		return fmt.Errorf("failed to parse %s as %s: %s", action.File, action.format(), err)
+	}
+
+	action.buildVariables.AddVar(action.Name, value, !action.Reveal)
+
+	logger.Debug("loaded-var", lager.Data{"name": action.Name})
+
+	fmt.Fprintf(action.buildStepDelegate.Stdout(), "loaded var %s from %s\n", action.Name, action.File)
+
+	action.exitStatus = ExitStatus(0)
+
+	return nil
+}
+
+func (action *LoadVarAction) format() string {
+	if action.Format != "" {
+		return action.Format
+	}
+
+	switch filepath.Ext(action.File) {
+	case ".json":
+		return atc.LoadVarFormatJSON
+	case ".yml", ".yaml":
+		return atc.LoadVarFormatYAML
+	default:
+		return atc.LoadVarFormatTrim
+	}
+}
+
+func (action *LoadVarAction) parse(content []byte) (interface{}, error) {
+	switch format := action.format(); format {
+	case atc.LoadVarFormatRaw:
+		return string(content), nil
+
+	case atc.LoadVarFormatTrim:
+		return strings.TrimSpace(string(content)), nil
+
+	case atc.LoadVarFormatJSON:
+		var value interface{}
+		err := json.Unmarshal(content, &value)
+		if err != nil {
+			return nil, err
+		}
+
+		return value, nil
+
+	case atc.LoadVarFormatYAML:
+		var value interface{}
+		err := yaml.Unmarshal(content, &value)
+		if err != nil {
+			return nil, err
+		}
+
+		return value, nil
+
+	default:
+		return nil, fmt.Errorf("unknown format '%s'", format)
+	}
+}
+
+// ExitStatus returns exit status of the action, which is always 0 once the
+// var has been loaded.
+func (action *LoadVarAction) ExitStatus() ExitStatus {
+	return action.exitStatus
+}
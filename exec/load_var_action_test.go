@@ -0,0 +1,167 @@
+package exec_test
+
+import (
+	"os"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/cloudfoundry/bosh-cli/director/template"
+	. "github.com/concourse/atc/exec"
+	"github.com/concourse/atc/exec/execfakes"
+	"github.com/concourse/atc/worker"
+	"github.com/concourse/atc/worker/workerfakes"
+	"github.com/concourse/baggageclaim"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("LoadVarAction", func() {
+	var (
+		fakeBuildStepDelegate *execfakes.FakeBuildStepDelegate
+		fakeArtifactSource    *workerfakes.FakeArtifactSource
+
+		stdoutBuf *gbytes.Buffer
+
+		buildVariables *BuildVariables
+		repo           *worker.ArtifactRepository
+
+		action *LoadVarAction
+		runErr error
+	)
+
+	BeforeEach(func() {
+		fakeBuildStepDelegate = new(execfakes.FakeBuildStepDelegate)
+		stdoutBuf = gbytes.NewBuffer()
+		fakeBuildStepDelegate.StdoutReturns(stdoutBuf)
+
+		fakeArtifactSource = new(workerfakes.FakeArtifactSource)
+		fakeArtifactSource.StreamFileReturns(gbytes.BufferWithBytes([]byte("1.2.3\n")), nil)
+
+		repo = worker.NewArtifactRepository()
+		repo.RegisterSource("some-source", fakeArtifactSource)
+
+		buildVariables = NewBuildVariables()
+
+		action = NewLoadVarAction(
+			"some-var",
+			"some-source/version",
+			"",
+			false,
+			fakeBuildStepDelegate,
+			buildVariables,
+		)
+	})
+
+	JustBeforeEach(func() {
+		runErr = action.Run(lagertest.NewTestLogger("test"), repo, make(chan os.Signal, 1), make(chan struct{}))
+	})
+
+	loadedVar := func() interface{} {
+		value, found, err := buildVariables.Get(template.VariableDefinition{Name: "some-var"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeTrue())
+		return value
+	}
+
+	It("reads the file out of the artifact source", func() {
+		Expect(fakeArtifactSource.StreamFileCallCount()).To(Equal(1))
+		Expect(fakeArtifactSource.StreamFileArgsForCall(0)).To(Equal("version"))
+	})
+
+	It("loads the trimmed contents into the var", func() {
+		Expect(runErr).NotTo(HaveOccurred())
+		Expect(loadedVar()).To(Equal("1.2.3"))
+	})
+
+	It("exits with status 0", func() {
+		Expect(action.ExitStatus()).To(Equal(ExitStatus(0)))
+	})
+
+	It("prints the var that was loaded", func() {
+		Expect(stdoutBuf).To(gbytes.Say("loaded var some-var from some-source/version"))
+	})
+
+	Context("when the format is raw", func() {
+		BeforeEach(func() {
+			action.Format = "raw"
+		})
+
+		It("loads the contents as-is", func() {
+			Expect(loadedVar()).To(Equal("1.2.3\n"))
+		})
+	})
+
+	Context("when the file is JSON", func() {
+		BeforeEach(func() {
+			action.File = "some-source/version.json"
+			fakeArtifactSource.StreamFileReturns(gbytes.BufferWithBytes([]byte(`{"version":"1.2.3"}`)), nil)
+		})
+
+		It("parses the contents as JSON", func() {
+			Expect(loadedVar()).To(Equal(map[string]interface{}{"version": "1.2.3"}))
+		})
+
+		Context("when the file is malformed", func() {
+			BeforeEach(func() {
+				fakeArtifactSource.StreamFileReturns(gbytes.BufferWithBytes([]byte(`{"version":`)), nil)
+			})
+
+			It("returns an error", func() {
+				Expect(runErr).To(HaveOccurred())
+				Expect(runErr.Error()).To(ContainSubstring("failed to parse some-source/version.json as json"))
+			})
+		})
+	})
+
+	Context("when the format is yaml", func() {
+		BeforeEach(func() {
+			action.Format = "yaml"
+			fakeArtifactSource.StreamFileReturns(gbytes.BufferWithBytes([]byte("version: 1.2.3\n")), nil)
+		})
+
+		It("parses the contents as YAML", func() {
+			Expect(loadedVar()).To(Equal(map[interface{}]interface{}{"version": "1.2.3"}))
+		})
+	})
+
+	Context("when the file cannot be found", func() {
+		BeforeEach(func() {
+			fakeArtifactSource.StreamFileReturns(nil, baggageclaim.ErrFileNotFound)
+		})
+
+		It("returns an error", func() {
+			Expect(runErr).To(MatchError("file 'some-source/version' not found"))
+		})
+	})
+
+	Describe("redaction", func() {
+		var redactedBuf *gbytes.Buffer
+
+		JustBeforeEach(func() {
+			redactedBuf = gbytes.NewBuffer()
+
+			otherDelegate := new(execfakes.FakeBuildStepDelegate)
+			otherDelegate.StdoutReturns(redactedBuf)
+
+			_, redactingDelegate := buildVariables.RedactingDelegates(new(execfakes.FakeActionsBuildEventsDelegate), otherDelegate)
+
+			_, err := redactingDelegate.Stdout().Write([]byte("version is 1.2.3\n"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("redacts the value from build logs", func() {
+			Expect(redactedBuf).To(gbytes.Say(`version is \(\(redacted\)\)`))
+		})
+
+		Context("when the var is revealed", func() {
+			BeforeEach(func() {
+				action.Reveal = true
+			})
+
+			It("does not redact the value", func() {
+				Expect(redactedBuf).To(gbytes.Say(`version is 1\.2\.3`))
+			})
+		})
+	})
+})
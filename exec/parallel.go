@@ -0,0 +1,146 @@
+package exec
+
+import (
+	"os"
+
+	"github.com/tedsuo/ifrit"
+)
+
+// parallelSteps runs steps in parallel, with at most maxInFlight of them
+// running at once. It is shared by the aggregate and across steps, so that
+// they start, interrupt and fail their steps alike.
+type parallelSteps struct {
+	steps []Step
+
+	// maxInFlight limits how many steps run at once. 0 runs every step at
+	// once.
+	maxInFlight int
+
+	// failFast interrupts the running steps and starts no more once a step
+	// fails or errors.
+	failFast bool
+
+	// waitForReady closes ready once the steps started at first are ready,
+	// rather than straight away.
+	waitForReady bool
+}
+
+type parallelExit struct {
+	index int
+	err   error
+}
+
+// parallelResult is how a run of parallel steps went.
+type parallelResult struct {
+	// Interrupted is true if a signal was received. The steps were signalled
+	// and waited for.
+	Interrupted bool
+
+	// Failed is true if a step errored or did not succeed.
+	Failed bool
+
+	// Errors are the messages of the errors the steps returned, other than
+	// those from being interrupted.
+	Errors []string
+}
+
+// run starts the next step whenever a running step exits and fewer than
+// maxInFlight steps are running, and returns once every running step has
+// exited. Any signal received is propagated to all running steps, and no
+// more steps are started.
+func (p parallelSteps) run(signals <-chan os.Signal, ready chan<- struct{}) parallelResult {
+	maxInFlight := p.maxInFlight
+	if maxInFlight <= 0 || maxInFlight > len(p.steps) {
+		maxInFlight = len(p.steps)
+	}
+
+	exits := make(chan parallelExit, len(p.steps))
+	running := map[int]ifrit.Process{}
+	next := 0
+
+	start := func() ifrit.Process {
+		index := next
+		process := ifrit.Background(p.steps[index])
+		running[index] = process
+		next++
+
+		go func() {
+			exits <- parallelExit{index: index, err: <-process.Wait()}
+		}()
+
+		return process
+	}
+
+	started := []ifrit.Process{}
+	for len(running) < maxInFlight && next < len(p.steps) {
+		started = append(started, start())
+	}
+
+	readies := make(chan struct{}, len(started))
+	notReady := len(started)
+
+	if p.waitForReady && notReady > 0 {
+		for _, process := range started {
+			go func(process ifrit.Process) {
+				select {
+				case <-process.Ready():
+				case <-process.Wait():
+				}
+
+				readies <- struct{}{}
+			}(process)
+		}
+	} else {
+		close(ready)
+		notReady = 0
+	}
+
+	var result parallelResult
+	var stopping bool
+
+	for len(running) > 0 {
+		select {
+		case <-readies:
+			notReady--
+			if notReady == 0 {
+				close(ready)
+			}
+
+		case sig := <-signals:
+			result.Interrupted = true
+
+			for _, process := range running {
+				process.Signal(sig)
+			}
+
+		case exit := <-exits:
+			delete(running, exit.index)
+
+			if exit.err == ErrInterrupted && (result.Interrupted || stopping) {
+				continue
+			}
+
+			if exit.err != nil {
+				result.Errors = append(result.Errors, exit.err.Error())
+			}
+
+			if exit.err != nil || !p.steps[exit.index].Succeeded() {
+				result.Failed = true
+
+				if p.failFast && !stopping {
+					stopping = true
+
+					for _, process := range running {
+						process.Signal(os.Interrupt)
+					}
+				}
+			}
+
+			if !result.Interrupted && !stopping && next < len(p.steps) {
+				start()
+			}
+		}
+	}
+
+	return result
+}
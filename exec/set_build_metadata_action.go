@@ -0,0 +1,92 @@
+package exec
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/worker"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// SetBuildMetadataAction reads a file of key/value pairs from the
+// worker.ArtifactRepository and records them as the build's metadata.
+type SetBuildMetadataAction struct {
+	File string
+
+	build             db.Build
+	buildStepDelegate BuildStepDelegate
+
+	metadata   map[string]string
+	exitStatus ExitStatus
+}
+
+func NewSetBuildMetadataAction(
+	file string,
+	build db.Build,
+	buildStepDelegate BuildStepDelegate,
+) *SetBuildMetadataAction {
+	return &SetBuildMetadataAction{
+		File:              file,
+		build:             build,
+		buildStepDelegate: buildStepDelegate,
+	}
+}
+
+// Run reads the file as a JSON or YAML object and merges its keys into the
+// build's metadata. Values which are not strings are recorded as they are
+// written in the file.
+func (action *SetBuildMetadataAction) Run(
+	logger lager.Logger,
+	repository *worker.ArtifactRepository,
+	signals <-chan os.Signal,
+	ready chan<- struct{},
+) error {
+	content, err := readArtifactFile(repository, action.File)
+	if err != nil {
+		return err
+	}
+
+	var metadata map[string]string
+	err = yaml.Unmarshal(content, &metadata)
+	if err != nil {
+		return fmt.Errorf("failed to parse %s as key/value pairs: %s", action.File, err)
+	}
+
+	err = action.build.SaveMetadata(metadata)
+	if err != nil {
+		return err
+	}
+
+	action.metadata = metadata
+
+	logger.Debug("saved-build-metadata", lager.Data{"keys": len(metadata)})
+
+	keys := make([]string, 0, len(metadata))
+	for key := range metadata {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		fmt.Fprintf(action.buildStepDelegate.Stdout(), "%s: %s\n", key, metadata[key])
+	}
+
+	action.exitStatus = ExitStatus(0)
+
+	return nil
+}
+
+// Metadata returns the key/value pairs recorded by the action.
+func (action *SetBuildMetadataAction) Metadata() map[string]string {
+	return action.metadata
+}
+
+// ExitStatus returns exit status of the action, which is always 0 once the
+// metadata has been recorded.
+func (action *SetBuildMetadataAction) ExitStatus() ExitStatus {
+	return action.exitStatus
+}
@@ -0,0 +1,133 @@
+package exec_test
+
+import (
+	"errors"
+	"os"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc/db/dbfakes"
+	. "github.com/concourse/atc/exec"
+	"github.com/concourse/atc/exec/execfakes"
+	"github.com/concourse/atc/worker"
+	"github.com/concourse/atc/worker/workerfakes"
+	"github.com/concourse/baggageclaim"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("SetBuildMetadataAction", func() {
+	var (
+		fakeBuild             *dbfakes.FakeBuild
+		fakeBuildStepDelegate *execfakes.FakeBuildStepDelegate
+		fakeArtifactSource    *workerfakes.FakeArtifactSource
+
+		stdoutBuf *gbytes.Buffer
+
+		repo *worker.ArtifactRepository
+
+		action *SetBuildMetadataAction
+		runErr error
+	)
+
+	BeforeEach(func() {
+		fakeBuild = new(dbfakes.FakeBuild)
+
+		fakeBuildStepDelegate = new(execfakes.FakeBuildStepDelegate)
+		stdoutBuf = gbytes.NewBuffer()
+		fakeBuildStepDelegate.StdoutReturns(stdoutBuf)
+
+		fakeArtifactSource = new(workerfakes.FakeArtifactSource)
+		fakeArtifactSource.StreamFileReturns(gbytes.BufferWithBytes([]byte(`{"sha":"abc123","ticket":"REL-42"}`)), nil)
+
+		repo = worker.NewArtifactRepository()
+		repo.RegisterSource("some-source", fakeArtifactSource)
+
+		action = NewSetBuildMetadataAction(
+			"some-source/metadata.json",
+			fakeBuild,
+			fakeBuildStepDelegate,
+		)
+	})
+
+	JustBeforeEach(func() {
+		runErr = action.Run(lagertest.NewTestLogger("test"), repo, make(chan os.Signal, 1), make(chan struct{}))
+	})
+
+	It("reads the file out of the artifact source", func() {
+		Expect(fakeArtifactSource.StreamFileCallCount()).To(Equal(1))
+		Expect(fakeArtifactSource.StreamFileArgsForCall(0)).To(Equal("metadata.json"))
+	})
+
+	It("saves the key/value pairs as the build's metadata", func() {
+		Expect(runErr).NotTo(HaveOccurred())
+
+		Expect(fakeBuild.SaveMetadataCallCount()).To(Equal(1))
+		Expect(fakeBuild.SaveMetadataArgsForCall(0)).To(Equal(map[string]string{
+			"sha":    "abc123",
+			"ticket": "REL-42",
+		}))
+
+		Expect(action.Metadata()).To(Equal(map[string]string{
+			"sha":    "abc123",
+			"ticket": "REL-42",
+		}))
+	})
+
+	It("exits with status 0", func() {
+		Expect(action.ExitStatus()).To(Equal(ExitStatus(0)))
+	})
+
+	It("prints the metadata that was saved", func() {
+		Expect(stdoutBuf).To(gbytes.Say("sha: abc123\nticket: REL-42\n"))
+	})
+
+	Context("when the file is YAML with values that are not strings", func() {
+		BeforeEach(func() {
+			fakeArtifactSource.StreamFileReturns(gbytes.BufferWithBytes([]byte("build_number: 42\nrelease: true\n")), nil)
+		})
+
+		It("saves the values as they are written", func() {
+			Expect(runErr).NotTo(HaveOccurred())
+			Expect(fakeBuild.SaveMetadataArgsForCall(0)).To(Equal(map[string]string{
+				"build_number": "42",
+				"release":      "true",
+			}))
+		})
+	})
+
+	Context("when the file is not key/value pairs", func() {
+		BeforeEach(func() {
+			fakeArtifactSource.StreamFileReturns(gbytes.BufferWithBytes([]byte(`["sha"]`)), nil)
+		})
+
+		It("returns an error without saving anything", func() {
+			Expect(runErr).To(HaveOccurred())
+			Expect(runErr.Error()).To(ContainSubstring("failed to parse some-source/metadata.json as key/value pairs"))
+			Expect(fakeBuild.SaveMetadataCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when the file cannot be found", func() {
+		BeforeEach(func() {
+			fakeArtifactSource.StreamFileReturns(nil, baggageclaim.ErrFileNotFound)
+		})
+
+		It("returns an error", func() {
+			Expect(runErr).To(MatchError("file 'some-source/metadata.json' not found"))
+		})
+	})
+
+	Context("when saving the metadata fails", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			fakeBuild.SaveMetadataReturns(disaster)
+		})
+
+		It("returns the error", func() {
+			Expect(runErr).To(Equal(disaster))
+		})
+	})
+})
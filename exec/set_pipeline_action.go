@@ -0,0 +1,225 @@
+package exec
+
+import (
+	"fmt"
+	"os"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/template"
+	"github.com/concourse/atc/worker"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// SetPipelineAction configures a pipeline of the build's team using a config
+// file loaded from the worker.ArtifactRepository. If InstanceVars are given,
+// the instance of the pipeline they identify is configured instead.
+type SetPipelineAction struct {
+	Name         string
+	File         string
+	InstanceVars atc.InstanceVars
+
+	buildStepDelegate BuildStepDelegate
+	teamFactory       db.TeamFactory
+	teamID            int
+
+	changes       []atc.ConfigChange
+	configVersion db.ConfigVersion
+	created       bool
+	exitStatus    ExitStatus
+}
+
+func NewSetPipelineAction(
+	name string,
+	file string,
+	instanceVars atc.InstanceVars,
+	buildStepDelegate BuildStepDelegate,
+	teamFactory db.TeamFactory,
+	teamID int,
+) *SetPipelineAction {
+	return &SetPipelineAction{
+		Name:              name,
+		File:              file,
+		InstanceVars:      instanceVars,
+		buildStepDelegate: buildStepDelegate,
+		teamFactory:       teamFactory,
+		teamID:            teamID,
+	}
+}
+
+// Run loads the pipeline config from the file, interpolating the instance
+// vars into it, and validates it. Validation
+// warnings are written to stderr. If the config is invalid the errors are
+// written to stderr and the action exits with status 1.
+//
+// Otherwise the config is saved to the build's team, creating the pipeline if
+// it does not exist yet. Newly created pipelines start out paused, the same
+// as with `fly set-pipeline`.
+func (action *SetPipelineAction) Run(
+	logger lager.Logger,
+	repository *worker.ArtifactRepository,
+	signals <-chan os.Signal,
+	ready chan<- struct{},
+) error {
+	configBytes, err := readArtifactFile(repository, action.File)
+	if err != nil {
+		return err
+	}
+
+	if len(action.InstanceVars) > 0 {
+		configBytes, err = action.interpolateInstanceVars(configBytes)
+		if err != nil {
+			return fmt.Errorf("failed to interpolate instance vars into %s: %s", action.File, err)
+		}
+	}
+
+	config, err := atc.NewConfig(configBytes)
+	if err != nil {
+		return fmt.Errorf("failed to load %s: %s", action.File, err)
+	}
+
+	stdout := action.buildStepDelegate.Stdout()
+	stderr := action.buildStepDelegate.Stderr()
+
+	warnings, errorMessages := config.Validate()
+
+	for _, warning := range warnings {
+		fmt.Fprintf(stderr, "WARNING: %s\n", warning.Message)
+	}
+
+	if len(errorMessages) > 0 {
+		fmt.Fprintf(stderr, "invalid pipeline config in %s:\n", action.File)
+
+		for _, message := range errorMessages {
+			fmt.Fprint(stderr, message)
+		}
+
+		action.exitStatus = ExitStatus(1)
+		return nil
+	}
+
+	team := action.teamFactory.GetByID(action.teamID)
+
+	existingConfig, fromVersion, err := action.existingConfig(team)
+	if err != nil {
+		return err
+	}
+
+	action.changes = existingConfig.Diff(config)
+
+	var (
+		pipeline db.Pipeline
+		created  bool
+	)
+
+	if len(action.InstanceVars) == 0 {
+		pipeline, created, err = team.SavePipeline(action.Name, config, fromVersion, db.PipelineNoChange)
+	} else {
+		pipeline, created, err = team.SavePipelineInstance(action.Name, action.InstanceVars, config, fromVersion, db.PipelineNoChange)
+	}
+	if err != nil {
+		logger.Error("failed-to-save-pipeline", err)
+		return err
+	}
+
+	action.created = created
+	action.configVersion = pipeline.ConfigVersion()
+
+	if created {
+		fmt.Fprintf(stdout, "created pipeline %s (paused)\n", action.Name)
+	} else {
+		fmt.Fprintf(stdout, "configured pipeline %s\n", action.Name)
+	}
+
+	if len(action.changes) == 0 {
+		fmt.Fprintln(stdout, "no changes to apply")
+	}
+
+	for _, change := range action.changes {
+		fmt.Fprintf(stdout, "%s %s %s\n", change.Kind, change.Name, change.Action)
+	}
+
+	action.exitStatus = ExitStatus(0)
+
+	return nil
+}
+
+// interpolateInstanceVars interpolates the instance vars into the config.
+// Any other vars are left to be resolved by the credential manager when the
+// pipeline runs.
+func (action *SetPipelineAction) interpolateInstanceVars(configBytes []byte) ([]byte, error) {
+	params, err := yaml.Marshal(action.InstanceVars)
+	if err != nil {
+		return nil, err
+	}
+
+	source := &template.FileVarsSource{ParamsContent: params}
+
+	return source.Evaluate(configBytes)
+}
+
+func (action *SetPipelineAction) existingConfig(team db.Team) (atc.Config, db.ConfigVersion, error) {
+	var (
+		pipeline db.Pipeline
+		found    bool
+		err      error
+	)
+
+	if len(action.InstanceVars) == 0 {
+		pipeline, found, err = team.Pipeline(action.Name)
+	} else {
+		pipeline, found, err = team.PipelineInstance(action.Name, action.InstanceVars)
+	}
+	if err != nil {
+		return atc.Config{}, 0, err
+	}
+
+	if !found {
+		return atc.Config{}, 0, nil
+	}
+
+	jobs, err := pipeline.Jobs()
+	if err != nil {
+		return atc.Config{}, 0, err
+	}
+
+	resources, err := pipeline.Resources()
+	if err != nil {
+		return atc.Config{}, 0, err
+	}
+
+	resourceTypes, err := pipeline.ResourceTypes()
+	if err != nil {
+		return atc.Config{}, 0, err
+	}
+
+	config := atc.Config{
+		Groups:        pipeline.Groups(),
+		Resources:     resources.Configs(),
+		ResourceTypes: resourceTypes.Configs(),
+		Jobs:          jobs.Configs(),
+	}
+
+	return config, pipeline.ConfigVersion(), nil
+}
+
+// Changes returns the changes that were made to the pipeline's config.
+func (action *SetPipelineAction) Changes() []atc.ConfigChange {
+	return action.changes
+}
+
+// ConfigVersion returns the version of the config that was saved.
+func (action *SetPipelineAction) ConfigVersion() db.ConfigVersion {
+	return action.configVersion
+}
+
+// Created returns true if the pipeline did not exist before.
+func (action *SetPipelineAction) Created() bool {
+	return action.created
+}
+
+// ExitStatus returns exit status of the action, 1 if the config was invalid.
+func (action *SetPipelineAction) ExitStatus() ExitStatus {
+	return action.exitStatus
+}
@@ -0,0 +1,283 @@
+package exec_test
+
+import (
+	"errors"
+	"os"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/dbfakes"
+	. "github.com/concourse/atc/exec"
+	"github.com/concourse/atc/exec/execfakes"
+	"github.com/concourse/atc/worker"
+	"github.com/concourse/atc/worker/workerfakes"
+	"github.com/concourse/baggageclaim"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("SetPipelineAction", func() {
+	var (
+		fakeBuildStepDelegate *execfakes.FakeBuildStepDelegate
+		fakeTeamFactory       *dbfakes.FakeTeamFactory
+		fakeTeam              *dbfakes.FakeTeam
+		fakeArtifactSource    *workerfakes.FakeArtifactSource
+
+		stdoutBuf *gbytes.Buffer
+		stderrBuf *gbytes.Buffer
+
+		configFile *gbytes.Buffer
+
+		repo *worker.ArtifactRepository
+
+		action *SetPipelineAction
+		runErr error
+	)
+
+	BeforeEach(func() {
+		fakeBuildStepDelegate = new(execfakes.FakeBuildStepDelegate)
+		stdoutBuf = gbytes.NewBuffer()
+		stderrBuf = gbytes.NewBuffer()
+		fakeBuildStepDelegate.StdoutReturns(stdoutBuf)
+		fakeBuildStepDelegate.StderrReturns(stderrBuf)
+
+		fakeTeam = new(dbfakes.FakeTeam)
+		fakeTeam.SavePipelineReturns(new(dbfakes.FakePipeline), true, nil)
+
+		fakeTeamFactory = new(dbfakes.FakeTeamFactory)
+		fakeTeamFactory.GetByIDReturns(fakeTeam)
+
+		configFile = gbytes.BufferWithBytes([]byte(`
+resources:
+- name: some-resource
+  type: git
+  source: {uri: some-uri}
+
+jobs:
+- name: some-job
+  plan:
+  - get: some-resource
+`))
+
+		fakeArtifactSource = new(workerfakes.FakeArtifactSource)
+		fakeArtifactSource.StreamFileReturns(configFile, nil)
+
+		repo = worker.NewArtifactRepository()
+		repo.RegisterSource("some-source", fakeArtifactSource)
+
+		action = NewSetPipelineAction(
+			"some-pipeline",
+			"some-source/pipeline.yml",
+			nil,
+			fakeBuildStepDelegate,
+			fakeTeamFactory,
+			123,
+		)
+	})
+
+	JustBeforeEach(func() {
+		runErr = action.Run(lagertest.NewTestLogger("test"), repo, make(chan os.Signal, 1), make(chan struct{}))
+	})
+
+	It("reads the config file out of the artifact source", func() {
+		Expect(fakeArtifactSource.StreamFileCallCount()).To(Equal(1))
+		Expect(fakeArtifactSource.StreamFileArgsForCall(0)).To(Equal("pipeline.yml"))
+		Expect(configFile.Closed()).To(BeTrue())
+	})
+
+	It("looks up the build's team", func() {
+		Expect(fakeTeamFactory.GetByIDCallCount()).To(Equal(1))
+		Expect(fakeTeamFactory.GetByIDArgsForCall(0)).To(Equal(123))
+	})
+
+	Context("when the pipeline does not exist", func() {
+		BeforeEach(func() {
+			fakeTeam.PipelineReturns(nil, false, nil)
+
+			fakePipeline := new(dbfakes.FakePipeline)
+			fakePipeline.ConfigVersionReturns(1)
+			fakeTeam.SavePipelineReturns(fakePipeline, true, nil)
+		})
+
+		It("creates the pipeline with the config", func() {
+			Expect(runErr).NotTo(HaveOccurred())
+
+			Expect(fakeTeam.SavePipelineCallCount()).To(Equal(1))
+			name, config, from, pausedState := fakeTeam.SavePipelineArgsForCall(0)
+			Expect(name).To(Equal("some-pipeline"))
+			Expect(config.Jobs).To(HaveLen(1))
+			Expect(config.Jobs[0].Name).To(Equal("some-job"))
+			Expect(config.Resources).To(HaveLen(1))
+			Expect(config.Resources[0].Name).To(Equal("some-resource"))
+			Expect(from).To(Equal(db.ConfigVersion(0)))
+			Expect(pausedState).To(Equal(db.PipelineNoChange))
+		})
+
+		It("records the changes and the saved version", func() {
+			Expect(action.Created()).To(BeTrue())
+			Expect(action.ConfigVersion()).To(Equal(db.ConfigVersion(1)))
+			Expect(action.Changes()).To(ConsistOf(
+				atc.ConfigChange{Kind: "resource", Name: "some-resource", Action: atc.ConfigChangeAdded},
+				atc.ConfigChange{Kind: "job", Name: "some-job", Action: atc.ConfigChangeAdded},
+			))
+		})
+
+		It("exits with status 0", func() {
+			Expect(action.ExitStatus()).To(Equal(ExitStatus(0)))
+		})
+
+		It("prints the changes to stdout", func() {
+			Expect(stdoutBuf).To(gbytes.Say("created pipeline some-pipeline"))
+			Expect(stdoutBuf).To(gbytes.Say("resource some-resource added"))
+			Expect(stdoutBuf).To(gbytes.Say("job some-job added"))
+		})
+	})
+
+	Context("when the pipeline already exists", func() {
+		BeforeEach(func() {
+			fakeExistingPipeline := new(dbfakes.FakePipeline)
+			fakeExistingPipeline.ConfigVersionReturns(41)
+			fakeExistingPipeline.JobsReturns(db.Jobs{}, nil)
+			fakeExistingPipeline.ResourcesReturns(db.Resources{}, nil)
+			fakeExistingPipeline.ResourceTypesReturns(db.ResourceTypes{}, nil)
+			fakeTeam.PipelineReturns(fakeExistingPipeline, true, nil)
+
+			fakePipeline := new(dbfakes.FakePipeline)
+			fakePipeline.ConfigVersionReturns(42)
+			fakeTeam.SavePipelineReturns(fakePipeline, false, nil)
+		})
+
+		It("saves the config from the existing version", func() {
+			Expect(runErr).NotTo(HaveOccurred())
+
+			Expect(fakeTeam.SavePipelineCallCount()).To(Equal(1))
+			_, _, from, _ := fakeTeam.SavePipelineArgsForCall(0)
+			Expect(from).To(Equal(db.ConfigVersion(41)))
+		})
+
+		It("records the saved version", func() {
+			Expect(action.Created()).To(BeFalse())
+			Expect(action.ConfigVersion()).To(Equal(db.ConfigVersion(42)))
+		})
+
+		It("prints that the pipeline was configured", func() {
+			Expect(stdoutBuf).To(gbytes.Say("configured pipeline some-pipeline"))
+		})
+
+		Context("when saving the pipeline fails", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				fakeTeam.SavePipelineReturns(nil, false, disaster)
+			})
+
+			It("returns the error", func() {
+				Expect(runErr).To(Equal(disaster))
+			})
+		})
+	})
+
+	Context("when instance vars are given", func() {
+		BeforeEach(func() {
+			configFile = gbytes.BufferWithBytes([]byte(`
+resources:
+- name: some-resource
+  type: git
+  source: {uri: some-uri, branch: ((branch)), private_key: ((private-key))}
+
+jobs:
+- name: some-job
+  plan:
+  - get: some-resource
+`))
+			fakeArtifactSource.StreamFileReturns(configFile, nil)
+
+			action.InstanceVars = atc.InstanceVars{"branch": "some-branch"}
+
+			fakeExistingInstance := new(dbfakes.FakePipeline)
+			fakeExistingInstance.ConfigVersionReturns(41)
+			fakeExistingInstance.JobsReturns(db.Jobs{}, nil)
+			fakeExistingInstance.ResourcesReturns(db.Resources{}, nil)
+			fakeExistingInstance.ResourceTypesReturns(db.ResourceTypes{}, nil)
+			fakeTeam.PipelineInstanceReturns(fakeExistingInstance, true, nil)
+
+			fakeTeam.SavePipelineInstanceReturns(new(dbfakes.FakePipeline), false, nil)
+		})
+
+		It("looks up the instance", func() {
+			Expect(fakeTeam.PipelineCallCount()).To(BeZero())
+
+			Expect(fakeTeam.PipelineInstanceCallCount()).To(Equal(1))
+			name, instanceVars := fakeTeam.PipelineInstanceArgsForCall(0)
+			Expect(name).To(Equal("some-pipeline"))
+			Expect(instanceVars).To(Equal(atc.InstanceVars{"branch": "some-branch"}))
+		})
+
+		It("saves the instance with the vars interpolated into the config", func() {
+			Expect(runErr).NotTo(HaveOccurred())
+
+			Expect(fakeTeam.SavePipelineCallCount()).To(BeZero())
+
+			Expect(fakeTeam.SavePipelineInstanceCallCount()).To(Equal(1))
+			name, instanceVars, config, from, _ := fakeTeam.SavePipelineInstanceArgsForCall(0)
+			Expect(name).To(Equal("some-pipeline"))
+			Expect(instanceVars).To(Equal(atc.InstanceVars{"branch": "some-branch"}))
+			Expect(from).To(Equal(db.ConfigVersion(41)))
+			Expect(config.Resources).To(HaveLen(1))
+			Expect(config.Resources[0].Source).To(Equal(atc.Source{
+				"uri":         "some-uri",
+				"branch":      "some-branch",
+				"private_key": "((private-key))",
+			}))
+		})
+	})
+
+	Context("when the config is invalid", func() {
+		BeforeEach(func() {
+			configFile = gbytes.BufferWithBytes([]byte(`
+jobs:
+- name: some-job
+  plan:
+  - get: some-resource
+`))
+			fakeArtifactSource.StreamFileReturns(configFile, nil)
+		})
+
+		It("does not save the pipeline", func() {
+			Expect(runErr).NotTo(HaveOccurred())
+			Expect(fakeTeam.SavePipelineCallCount()).To(BeZero())
+		})
+
+		It("prints the errors to stderr", func() {
+			Expect(stderrBuf).To(gbytes.Say("invalid pipeline config in some-source/pipeline.yml"))
+			Expect(stderrBuf).To(gbytes.Say("refers to a resource that does not exist \\('some-resource'\\)"))
+		})
+
+		It("exits with status 1", func() {
+			Expect(action.ExitStatus()).To(Equal(ExitStatus(1)))
+		})
+	})
+
+	Context("when the config file cannot be found", func() {
+		BeforeEach(func() {
+			fakeArtifactSource.StreamFileReturns(nil, baggageclaim.ErrFileNotFound)
+		})
+
+		It("returns an error", func() {
+			Expect(runErr).To(MatchError("file 'some-source/pipeline.yml' not found"))
+		})
+	})
+
+	Context("when the config file's artifact source is unknown", func() {
+		BeforeEach(func() {
+			action.File = "bogus-source/pipeline.yml"
+		})
+
+		It("returns an error", func() {
+			Expect(runErr).To(Equal(UnknownArtifactSourceError{"bogus-source"}))
+		})
+	})
+})
@@ -456,6 +456,10 @@ func newTaskArtifactSource(
 }
 
 func (src *taskArtifactSource) StreamTo(destination worker.ArtifactDestination) error {
+	if destVolume, ok := destination.(worker.Volume); ok {
+		return worker.StreamVolume(src.logger, src.volume, destVolume)
+	}
+
 	out, err := src.volume.StreamOut(".")
 	if err != nil {
 		return err
@@ -485,8 +489,15 @@ func (src *taskArtifactSource) StreamFile(filename string) (io.ReadCloser, error
 	}, nil
 }
 
+// VolumeOn finds the volume on the worker, or a volume with the same content
+// which was streamed to it before.
 func (src *taskArtifactSource) VolumeOn(w worker.Worker) (worker.Volume, bool, error) {
-	return w.LookupVolume(src.logger, src.volume.Handle())
+	volume, found, err := w.LookupVolume(src.logger, src.volume.Handle())
+	if err != nil || found {
+		return volume, found, err
+	}
+
+	return w.FindVolumeWithSameContent(src.logger, src.volume)
 }
 
 type taskInputSource struct {
@@ -877,6 +877,85 @@ var _ = Describe("TaskAction", func() {
 									Expect(dest).To(Equal("."))
 									Expect(src).To(Equal(streamedOut))
 								})
+
+								Context("when the destination is a volume which can stream P2P", func() {
+									var destVolume *workerfakes.FakeVolume
+
+									BeforeEach(func() {
+										fakeVolume1.StreamOutP2PURLReturns("http://some-worker/volumes/some-handle/stream-out?path=.", true)
+
+										destVolume = new(workerfakes.FakeVolume)
+										destVolume.StreamInP2PReturns(1024, "abc", nil)
+									})
+
+									It("has the volume's worker stream the data directly", func() {
+										err := artifactSource1.StreamTo(destVolume)
+										Expect(err).NotTo(HaveOccurred())
+
+										Expect(fakeVolume1.StreamOutCallCount()).To(BeZero())
+
+										Expect(destVolume.StreamInP2PCallCount()).To(Equal(1))
+										_, path, sourceURL := destVolume.StreamInP2PArgsForCall(0)
+										Expect(path).To(Equal("."))
+										Expect(sourceURL).To(Equal("http://some-worker/volumes/some-handle/stream-out?path=."))
+									})
+								})
+							})
+
+							Describe("finding the volume on a worker", func() {
+								var (
+									fakeOtherWorker *workerfakes.FakeWorker
+
+									foundVolume worker.Volume
+									found       bool
+									findErr     error
+								)
+
+								BeforeEach(func() {
+									fakeOtherWorker = new(workerfakes.FakeWorker)
+								})
+
+								JustBeforeEach(func() {
+									foundVolume, found, findErr = artifactSource1.VolumeOn(fakeOtherWorker)
+								})
+
+								Context("when the worker has the volume", func() {
+									BeforeEach(func() {
+										fakeOtherWorker.LookupVolumeReturns(fakeVolume1, true, nil)
+									})
+
+									It("returns it", func() {
+										Expect(findErr).NotTo(HaveOccurred())
+										Expect(found).To(BeTrue())
+										Expect(foundVolume).To(Equal(fakeVolume1))
+
+										_, handle := fakeOtherWorker.LookupVolumeArgsForCall(0)
+										Expect(handle).To(Equal("some-handle-1"))
+									})
+
+									It("does not look for a volume with the same content", func() {
+										Expect(fakeOtherWorker.FindVolumeWithSameContentCallCount()).To(BeZero())
+									})
+								})
+
+								Context("when the worker does not have the volume", func() {
+									var streamedCopy *workerfakes.FakeVolume
+
+									BeforeEach(func() {
+										streamedCopy = new(workerfakes.FakeVolume)
+										fakeOtherWorker.LookupVolumeReturns(nil, false, nil)
+										fakeOtherWorker.FindVolumeWithSameContentReturns(streamedCopy, true, nil)
+									})
+
+									It("returns a volume with the same content", func() {
+										Expect(findErr).NotTo(HaveOccurred())
+										Expect(found).To(BeTrue())
+										Expect(foundVolume).To(Equal(streamedCopy))
+
+										_, volume := fakeOtherWorker.FindVolumeWithSameContentArgsForCall(0)
+										Expect(volume).To(Equal(fakeVolume1))
+									})
+								})
 							})
 
 							Describe("streaming a file out", func() {
@@ -0,0 +1,62 @@
+package exec
+
+import (
+	"os"
+	"time"
+
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/worker"
+)
+
+// TimedStep reports how long every run of the step it wraps took, and how it
+// finished.
+type TimedStep struct {
+	stepFactory StepFactory
+	finished    func(time.Duration, db.BuildStatus)
+
+	step Step
+}
+
+// Timed constructs a TimedStep factory.
+func Timed(stepFactory StepFactory, finished func(time.Duration, db.BuildStatus)) TimedStep {
+	return TimedStep{
+		stepFactory: stepFactory,
+		finished:    finished,
+	}
+}
+
+// Using constructs a *TimedStep.
+func (ts TimedStep) Using(repo *worker.ArtifactRepository) Step {
+	ts.step = ts.stepFactory.Using(repo)
+	return &ts
+}
+
+// Run runs the wrapped step, and then reports its duration and status. A step
+// which was interrupted is reported as aborted, and one which returned an error
+// as errored.
+func (ts *TimedStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	start := time.Now()
+
+	err := ts.step.Run(signals, ready)
+
+	var status db.BuildStatus
+	switch {
+	case err == ErrInterrupted:
+		status = db.BuildStatusAborted
+	case err != nil:
+		status = db.BuildStatusErrored
+	case ts.step.Succeeded():
+		status = db.BuildStatusSucceeded
+	default:
+		status = db.BuildStatusFailed
+	}
+
+	ts.finished(time.Since(start), status)
+
+	return err
+}
+
+// Succeeded delegates to the wrapped step.
+func (ts *TimedStep) Succeeded() bool {
+	return ts.step.Succeeded()
+}
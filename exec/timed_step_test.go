@@ -0,0 +1,124 @@
+package exec_test
+
+import (
+	"errors"
+	"os"
+	"time"
+
+	"github.com/concourse/atc/db"
+	. "github.com/concourse/atc/exec"
+
+	"github.com/concourse/atc/exec/execfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Timed Step", func() {
+	var (
+		fakeStepFactory *execfakes.FakeStepFactory
+		fakeStep        *execfakes.FakeStep
+
+		reportedDuration time.Duration
+		reportedStatus   db.BuildStatus
+		reports          int
+
+		step Step
+	)
+
+	BeforeEach(func() {
+		fakeStepFactory = new(execfakes.FakeStepFactory)
+		fakeStep = new(execfakes.FakeStep)
+		fakeStepFactory.UsingReturns(fakeStep)
+
+		reports = 0
+
+		step = Timed(fakeStepFactory, func(duration time.Duration, status db.BuildStatus) {
+			reportedDuration = duration
+			reportedStatus = status
+			reports++
+		}).Using(nil)
+	})
+
+	Describe("Run", func() {
+		BeforeEach(func() {
+			fakeStep.RunStub = func(<-chan os.Signal, chan<- struct{}) error {
+				time.Sleep(10 * time.Millisecond)
+				return nil
+			}
+		})
+
+		It("runs the wrapped step", func() {
+			err := step.Run(nil, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeStep.RunCallCount()).To(Equal(1))
+		})
+
+		It("reports how long the step took", func() {
+			Expect(step.Run(nil, nil)).To(Succeed())
+			Expect(reports).To(Equal(1))
+			Expect(reportedDuration).To(BeNumerically(">=", 10*time.Millisecond))
+		})
+
+		Context("when the wrapped step succeeds", func() {
+			BeforeEach(func() {
+				fakeStep.SucceededReturns(true)
+			})
+
+			It("reports it as succeeded", func() {
+				Expect(step.Run(nil, nil)).To(Succeed())
+				Expect(reportedStatus).To(Equal(db.BuildStatusSucceeded))
+			})
+		})
+
+		Context("when the wrapped step fails", func() {
+			BeforeEach(func() {
+				fakeStep.SucceededReturns(false)
+			})
+
+			It("reports it as failed", func() {
+				Expect(step.Run(nil, nil)).To(Succeed())
+				Expect(reportedStatus).To(Equal(db.BuildStatusFailed))
+			})
+		})
+
+		Context("when the wrapped step errors", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				fakeStep.RunStub = nil
+				fakeStep.RunReturns(disaster)
+			})
+
+			It("returns the error", func() {
+				Expect(step.Run(nil, nil)).To(Equal(disaster))
+			})
+
+			It("reports it as errored", func() {
+				step.Run(nil, nil)
+				Expect(reportedStatus).To(Equal(db.BuildStatusErrored))
+			})
+		})
+
+		Context("when the wrapped step is interrupted", func() {
+			BeforeEach(func() {
+				fakeStep.RunStub = nil
+				fakeStep.RunReturns(ErrInterrupted)
+			})
+
+			It("reports it as aborted", func() {
+				step.Run(nil, nil)
+				Expect(reportedStatus).To(Equal(db.BuildStatusAborted))
+			})
+		})
+	})
+
+	Describe("Succeeded", func() {
+		It("delegates to the wrapped step", func() {
+			fakeStep.SucceededReturns(true)
+			Expect(step.Succeeded()).To(BeTrue())
+
+			fakeStep.SucceededReturns(false)
+			Expect(step.Succeeded()).To(BeFalse())
+		})
+	})
+})
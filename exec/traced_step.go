@@ -0,0 +1,52 @@
+package exec
+
+import (
+	"os"
+
+	"github.com/concourse/atc/tracing"
+	"github.com/concourse/atc/worker"
+)
+
+// TracedStep records a span in the trace of its build for every run of the
+// step it wraps.
+type TracedStep struct {
+	stepFactory StepFactory
+	buildID     int
+	spanName    string
+	attrs       tracing.Attrs
+
+	step Step
+}
+
+// Traced constructs a TracedStep factory.
+func Traced(stepFactory StepFactory, buildID int, spanName string, attrs tracing.Attrs) TracedStep {
+	return TracedStep{
+		stepFactory: stepFactory,
+		buildID:     buildID,
+		spanName:    spanName,
+		attrs:       attrs,
+	}
+}
+
+// Using constructs a *TracedStep.
+func (ts TracedStep) Using(repo *worker.ArtifactRepository) Step {
+	ts.step = ts.stepFactory.Using(repo)
+	return &ts
+}
+
+// Run runs the wrapped step within a span, which ends with the step's error,
+// if any.
+func (ts *TracedStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	span := tracing.StartBuildSpan(ts.spanName, ts.buildID, ts.attrs)
+
+	err := ts.step.Run(signals, ready)
+
+	span.End(err)
+
+	return err
+}
+
+// Succeeded delegates to the wrapped step.
+func (ts *TracedStep) Succeeded() bool {
+	return ts.step.Succeeded()
+}
@@ -0,0 +1,59 @@
+package exec_test
+
+import (
+	"errors"
+
+	. "github.com/concourse/atc/exec"
+	"github.com/concourse/atc/tracing"
+
+	"github.com/concourse/atc/exec/execfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Traced Step", func() {
+	var (
+		fakeStepFactory *execfakes.FakeStepFactory
+		fakeStep        *execfakes.FakeStep
+
+		step Step
+	)
+
+	BeforeEach(func() {
+		fakeStepFactory = new(execfakes.FakeStepFactory)
+		fakeStep = new(execfakes.FakeStep)
+		fakeStepFactory.UsingReturns(fakeStep)
+
+		step = Traced(fakeStepFactory, 42, "step.task", tracing.Attrs{"step": "some-task"}).Using(nil)
+	})
+
+	Describe("Run", func() {
+		It("runs the wrapped step", func() {
+			err := step.Run(nil, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeStep.RunCallCount()).To(Equal(1))
+		})
+
+		Context("when the wrapped step errors", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				fakeStep.RunReturns(disaster)
+			})
+
+			It("returns the error", func() {
+				Expect(step.Run(nil, nil)).To(Equal(disaster))
+			})
+		})
+	})
+
+	Describe("Succeeded", func() {
+		It("delegates to the wrapped step", func() {
+			fakeStep.SucceededReturns(true)
+			Expect(step.Succeeded()).To(BeTrue())
+
+			fakeStep.SucceededReturns(false)
+			Expect(step.Succeeded()).To(BeFalse())
+		})
+	})
+})
@@ -0,0 +1,43 @@
+package gc
+
+import (
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/db"
+)
+
+type auditCollector struct {
+	logger    lager.Logger
+	auditLog  db.AuditLog
+	retention time.Duration
+	clock     clock.Clock
+}
+
+func NewAuditCollector(
+	logger lager.Logger,
+	auditLog db.AuditLog,
+	retention time.Duration,
+	clock clock.Clock,
+) Collector {
+	return &auditCollector{
+		logger:    logger,
+		auditLog:  auditLog,
+		retention: retention,
+		clock:     clock,
+	}
+}
+
+func (c *auditCollector) Run() error {
+	c.logger.Debug("start")
+	defer c.logger.Debug("done")
+
+	err := c.auditLog.DeleteEventsBefore(c.clock.Now().Add(-c.retention))
+	if err != nil {
+		c.logger.Error("failed-to-delete-expired-audit-events", err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,53 @@
+package gc_test
+
+import (
+	"errors"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc/db/dbfakes"
+	"github.com/concourse/atc/gc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AuditCollector", func() {
+	var (
+		auditCollector gc.Collector
+		fakeAuditLog   *dbfakes.FakeAuditLog
+		fakeClock      *fakeclock.FakeClock
+	)
+
+	BeforeEach(func() {
+		logger := lagertest.NewTestLogger("audit-collector")
+		fakeAuditLog = new(dbfakes.FakeAuditLog)
+		fakeClock = fakeclock.NewFakeClock(time.Unix(123456789, 0))
+
+		auditCollector = gc.NewAuditCollector(
+			logger,
+			fakeAuditLog,
+			24*time.Hour,
+			fakeClock,
+		)
+	})
+
+	Describe("Run", func() {
+		It("deletes the events older than the retention period", func() {
+			err := auditCollector.Run()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeAuditLog.DeleteEventsBeforeCallCount()).To(Equal(1))
+			Expect(fakeAuditLog.DeleteEventsBeforeArgsForCall(0)).To(Equal(time.Unix(123456789, 0).Add(-24 * time.Hour)))
+		})
+
+		It("returns an error if deleting the events fails", func() {
+			returnedErr := errors.New("some-error")
+			fakeAuditLog.DeleteEventsBeforeReturns(returnedErr)
+
+			err := auditCollector.Run()
+			Expect(err).To(MatchError(returnedErr))
+		})
+	})
+})
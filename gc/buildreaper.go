@@ -1,8 +1,13 @@
 package gc
 
 import (
+	"time"
+
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/logarchive"
+	"github.com/concourse/atc/metric"
 )
 
 type BuildReaper interface {
@@ -10,20 +15,26 @@ type BuildReaper interface {
 }
 
 type buildReaper struct {
-	logger          lager.Logger
-	pipelineFactory db.PipelineFactory
-	batchSize       int
+	logger           lager.Logger
+	pipelineFactory  db.PipelineFactory
+	batchSize        int
+	defaultRetention atc.BuildLogRetention
+	logArchive       logarchive.Archive
 }
 
 func NewBuildReaper(
 	logger lager.Logger,
 	pipelineFactory db.PipelineFactory,
 	batchSize int,
+	defaultRetention atc.BuildLogRetention,
+	logArchive logarchive.Archive,
 ) BuildReaper {
 	return &buildReaper{
-		logger:          logger,
-		pipelineFactory: pipelineFactory,
-		batchSize:       batchSize,
+		logger:           logger,
+		pipelineFactory:  pipelineFactory,
+		batchSize:        batchSize,
+		defaultRetention: defaultRetention,
+		logArchive:       logArchive,
 	}
 }
 
@@ -46,95 +57,337 @@ func (br *buildReaper) Run() error {
 		}
 
 		for _, job := range jobs {
-			if job.Config().BuildLogsToRetain == 0 {
+			retention, byCount := br.retention(job.Config())
+			if !byCount && retention.IsEmpty() {
 				continue
 			}
 
-			buildsToConsiderDeleting := []db.Build{}
-			until := job.FirstLoggedBuildID() - 1
-			limit := br.batchSize
+			var reaped int
+			if byCount {
+				reaped, err = br.reapByCount(pipeline, job)
+			} else {
+				reaped, err = br.reapByRetention(pipeline, jobs, job, retention)
+			}
+			if err != nil {
+				return err
+			}
 
-			if job.FirstLoggedBuildID() <= 1 {
-				until = 1
+			if reaped > 0 {
+				metric.BuildLogsReaped{
+					TeamName:     pipeline.TeamName(),
+					PipelineName: pipeline.Name(),
+					JobName:      job.Name(),
+					Builds:       reaped,
+				}.Emit(br.logger)
+			}
+		}
+	}
 
-				buildsToConsiderDeleting, _, err = job.Builds(
-					db.Page{Since: 2, Limit: 1},
-				)
-				if err != nil {
-					br.logger.Error("could-not-get-job-build-1-to-delete", err)
-					return err
-				}
+	return nil
+}
 
-				limit -= len(buildsToConsiderDeleting)
-			}
+// retention returns the rules for reaping the logs of the job's builds, or
+// whether to reap them by build_logs_to_retain instead. Jobs which configure
+// neither get the default retention.
+func (br *buildReaper) retention(config atc.JobConfig) (atc.BuildLogRetention, bool) {
+	if config.BuildLogRetention != nil {
+		return *config.BuildLogRetention, false
+	}
 
-			if limit > 0 {
-				moreBuildsToConsiderDeleting, _, err := job.Builds(
-					db.Page{Until: until, Limit: limit},
-				)
-				if err != nil {
-					br.logger.Error("could-not-get-job-builds-to-delete", err)
-					return err
-				}
+	if config.BuildLogsToRetain != 0 {
+		return atc.BuildLogRetention{}, true
+	}
 
-				buildsToConsiderDeleting = append(
-					moreBuildsToConsiderDeleting,
-					buildsToConsiderDeleting...,
-				)
-			}
+	return br.defaultRetention, false
+}
 
-			buildIDsToConsiderDeleting := []int{}
-			for _, build := range buildsToConsiderDeleting {
-				buildIDsToConsiderDeleting = append(buildIDsToConsiderDeleting, build.ID())
-			}
+// reapByCount reaps the logs of the oldest builds of the job, up to the batch
+// size, keeping the logs of the last build_logs_to_retain builds.
+func (br *buildReaper) reapByCount(pipeline db.Pipeline, job db.Job) (int, error) {
+	var err error
 
-			buildsToRetain, _, err := job.Builds(
-				db.Page{Limit: job.Config().BuildLogsToRetain},
-			)
-			if err != nil {
-				br.logger.Error("could-not-get-job-builds-to-retain", err)
-				return err
-			}
+	buildsToConsiderDeleting := []db.Build{}
+	until := job.FirstLoggedBuildID() - 1
+	limit := br.batchSize
 
-			buildIDsToRetain := []int{}
-			for _, build := range buildsToRetain {
-				buildIDsToRetain = append(buildIDsToRetain, build.ID())
-			}
+	if job.FirstLoggedBuildID() <= 1 {
+		until = 1
 
-			if len(buildsToRetain) == 0 {
-				continue
+		buildsToConsiderDeleting, _, err = job.Builds(
+			db.Page{Since: 2, Limit: 1},
+		)
+		if err != nil {
+			br.logger.Error("could-not-get-job-build-1-to-delete", err)
+			return 0, err
+		}
+
+		limit -= len(buildsToConsiderDeleting)
+	}
+
+	if limit > 0 {
+		moreBuildsToConsiderDeleting, _, err := job.Builds(
+			db.Page{Until: until, Limit: limit},
+		)
+		if err != nil {
+			br.logger.Error("could-not-get-job-builds-to-delete", err)
+			return 0, err
+		}
+
+		buildsToConsiderDeleting = append(
+			moreBuildsToConsiderDeleting,
+			buildsToConsiderDeleting...,
+		)
+	}
+
+	buildIDsToConsiderDeleting := []int{}
+	for _, build := range buildsToConsiderDeleting {
+		buildIDsToConsiderDeleting = append(buildIDsToConsiderDeleting, build.ID())
+	}
+
+	buildsToRetain, _, err := job.Builds(
+		db.Page{Limit: job.Config().BuildLogsToRetain},
+	)
+	if err != nil {
+		br.logger.Error("could-not-get-job-builds-to-retain", err)
+		return 0, err
+	}
+
+	buildIDsToRetain := []int{}
+	for _, build := range buildsToRetain {
+		buildIDsToRetain = append(buildIDsToRetain, build.ID())
+	}
+
+	if len(buildsToRetain) == 0 {
+		return 0, nil
+	}
+
+	firstBuildToRetain := buildsToRetain[len(buildsToRetain)-1].ID()
+
+	buildsToDelete := []db.Build{}
+	buildIDsToDelete := []int{}
+	for i := len(buildsToConsiderDeleting) - 1; i >= 0; i-- {
+		build := buildsToConsiderDeleting[i]
+
+		if build.ID() >= firstBuildToRetain || build.IsRunning() {
+			break
+		}
+
+		buildsToDelete = append(buildsToDelete, build)
+		buildIDsToDelete = append(buildIDsToDelete, build.ID())
+	}
+
+	if len(buildIDsToDelete) == 0 {
+		return 0, nil
+	}
+
+	err = br.archiveBuilds(buildsToDelete)
+	if err != nil {
+		return 0, err
+	}
+
+	err = pipeline.DeleteBuildEventsByBuildIDs(buildIDsToDelete)
+	if err != nil {
+		br.logger.Error("could-not-delete-build-events", err)
+		return 0, err
+	}
+
+	err = job.UpdateFirstLoggedBuildID(buildIDsToDelete[len(buildIDsToDelete)-1] + 1)
+	if err != nil {
+		br.logger.Error("could-not-update-first-logged-build-id", err)
+		return 0, err
+	}
+
+	return len(buildIDsToDelete), nil
+}
+
+// reapByRetention goes through the builds of the job which still have their
+// logs in batches, oldest first, and reaps the logs of the builds which none
+// of the rules keep.
+func (br *buildReaper) reapByRetention(pipeline db.Pipeline, jobs []db.Job, job db.Job, retention atc.BuildLogRetention) (int, error) {
+	// builds from this one onwards are kept by count
+	var keepFromID int
+	if retention.Builds > 0 {
+		buildsToRetain, _, err := job.Builds(db.Page{Limit: retention.Builds})
+		if err != nil {
+			br.logger.Error("could-not-get-job-builds-to-retain", err)
+			return 0, err
+		}
+
+		if len(buildsToRetain) < retention.Builds {
+			return 0, nil
+		}
+
+		keepFromID = buildsToRetain[len(buildsToRetain)-1].ID()
+	}
+
+	// builds which finished after this are kept by age
+	var keepFinishedAfter time.Time
+	if retention.Days > 0 {
+		keepFinishedAfter = time.Now().AddDate(0, 0, -retention.Days)
+	}
+
+	buildIDsToRetain := map[int]bool{}
+
+	if retention.MinimumSucceededBuilds > 0 {
+		succeededBuildIDs, err := job.LatestSucceededBuildIDs(retention.MinimumSucceededBuilds)
+		if err != nil {
+			br.logger.Error("could-not-get-job-succeeded-builds-to-retain", err)
+			return 0, err
+		}
+
+		for _, id := range succeededBuildIDs {
+			buildIDsToRetain[id] = true
+		}
+	}
+
+	downstreamJobIDs := downstreamJobIDs(jobs, job.Name())
+
+	reaped := 0
+	newFirstLoggedBuildID := 0
+	lastReapedBuildID := 0
+
+	cursor := job.FirstLoggedBuildID() - 1
+
+	for {
+		buildsToConsiderDeleting, err := job.UnreapedBuilds(cursor, br.batchSize)
+		if err != nil {
+			br.logger.Error("could-not-get-job-builds-to-delete", err)
+			return 0, err
+		}
+
+		if len(buildsToConsiderDeleting) == 0 {
+			break
+		}
+
+		buildIDsToConsiderDeleting := []int{}
+		for _, build := range buildsToConsiderDeleting {
+			buildIDsToConsiderDeleting = append(buildIDsToConsiderDeleting, build.ID())
+		}
+
+		usedBuildIDs, err := job.BuildIDsUsedByJobs(buildIDsToConsiderDeleting, downstreamJobIDs)
+		if err != nil {
+			br.logger.Error("could-not-get-job-builds-used-downstream", err)
+			return 0, err
+		}
+
+		for _, id := range usedBuildIDs {
+			buildIDsToRetain[id] = true
+		}
+
+		reachedBuildsToRetain := false
+
+		buildsToDelete := []db.Build{}
+		buildIDsToDelete := []int{}
+		for _, build := range buildsToConsiderDeleting {
+			if keepFromID != 0 && build.ID() >= keepFromID {
+				reachedBuildsToRetain = true
 			}
 
-			firstBuildToRetain := buildsToRetain[len(buildsToRetain)-1].ID()
+			retain := reachedBuildsToRetain ||
+				build.IsRunning() ||
+				buildIDsToRetain[build.ID()] ||
+				(!keepFinishedAfter.IsZero() && build.EndTime().After(keepFinishedAfter))
 
-			buildIDsToDelete := []int{}
-			for i := len(buildsToConsiderDeleting) - 1; i >= 0; i-- {
-				build := buildsToConsiderDeleting[i]
+			if retain {
+				if newFirstLoggedBuildID == 0 {
+					newFirstLoggedBuildID = build.ID()
+				}
 
-				if build.ID() >= firstBuildToRetain || build.IsRunning() {
+				if reachedBuildsToRetain {
 					break
 				}
 
-				buildIDsToDelete = append(buildIDsToDelete, build.ID())
+				continue
 			}
 
-			if len(buildIDsToDelete) == 0 {
-				continue
+			buildsToDelete = append(buildsToDelete, build)
+			buildIDsToDelete = append(buildIDsToDelete, build.ID())
+		}
+
+		if len(buildIDsToDelete) > 0 {
+			err = br.archiveBuilds(buildsToDelete)
+			if err != nil {
+				return 0, err
 			}
 
 			err = pipeline.DeleteBuildEventsByBuildIDs(buildIDsToDelete)
 			if err != nil {
 				br.logger.Error("could-not-delete-build-events", err)
-				return err
+				return 0, err
 			}
 
-			err = job.UpdateFirstLoggedBuildID(buildIDsToDelete[len(buildIDsToDelete)-1] + 1)
-			if err != nil {
-				br.logger.Error("could-not-update-first-logged-build-id", err)
-				return err
-			}
+			reaped += len(buildIDsToDelete)
+			lastReapedBuildID = buildIDsToDelete[len(buildIDsToDelete)-1]
+		}
+
+		if reachedBuildsToRetain || len(buildsToConsiderDeleting) < br.batchSize {
+			break
+		}
+
+		cursor = buildsToConsiderDeleting[len(buildsToConsiderDeleting)-1].ID()
+	}
+
+	if newFirstLoggedBuildID == 0 {
+		newFirstLoggedBuildID = lastReapedBuildID + 1
+	}
+
+	if reaped > 0 && newFirstLoggedBuildID > job.FirstLoggedBuildID() {
+		err := job.UpdateFirstLoggedBuildID(newFirstLoggedBuildID)
+		if err != nil {
+			br.logger.Error("could-not-update-first-logged-build-id", err)
+			return 0, err
+		}
+	}
+
+	return reaped, nil
+}
+
+// archiveBuilds archives the logs of the builds before they are reaped, if a
+// log archive is configured. None of the logs are reaped unless all of them
+// are archived.
+func (br *buildReaper) archiveBuilds(builds []db.Build) error {
+	if br.logArchive == nil {
+		return nil
+	}
+
+	for _, build := range builds {
+		if build.IsArchived() {
+			continue
+		}
+
+		err := br.logArchive.Archive(build)
+		if err != nil {
+			br.logger.Error("could-not-archive-build-events", err, lager.Data{"build-id": build.ID()})
+			return err
 		}
 	}
 
 	return nil
 }
+
+// downstreamJobIDs returns the IDs of the jobs with an input passed through
+// the named job.
+func downstreamJobIDs(jobs []db.Job, jobName string) []int {
+	ids := []int{}
+
+	for _, job := range jobs {
+		for _, input := range job.Config().Inputs() {
+			if passedThrough(input, jobName) {
+				ids = append(ids, job.ID())
+				break
+			}
+		}
+	}
+
+	return ids
+}
+
+func passedThrough(input atc.JobInput, jobName string) bool {
+	for _, passed := range input.Passed {
+		if passed == jobName {
+			return true
+		}
+	}
+
+	return false
+}
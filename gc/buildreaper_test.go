@@ -3,12 +3,15 @@ package gc_test
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/dbfakes"
 	. "github.com/concourse/atc/gc"
+	"github.com/concourse/atc/logarchive"
+	"github.com/concourse/atc/logarchive/logarchivefakes"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -19,11 +22,15 @@ var _ = Describe("BuildReaper", func() {
 		buildReaper         BuildReaper
 		fakePipelineFactory *dbfakes.FakePipelineFactory
 		batchSize           int
+		defaultRetention    atc.BuildLogRetention
+		logArchive          logarchive.Archive
 	)
 
 	BeforeEach(func() {
 		fakePipelineFactory = new(dbfakes.FakePipelineFactory)
 		batchSize = 5
+		defaultRetention = atc.BuildLogRetention{}
+		logArchive = nil
 	})
 
 	JustBeforeEach(func() {
@@ -32,6 +39,8 @@ var _ = Describe("BuildReaper", func() {
 			buildReaperLogger,
 			fakePipelineFactory,
 			batchSize,
+			defaultRetention,
+			logArchive,
 		)
 	})
 
@@ -110,6 +119,27 @@ var _ = Describe("BuildReaper", func() {
 						actualNewFirstLoggedBuildID := fakeJob.UpdateFirstLoggedBuildIDArgsForCall(0)
 						Expect(actualNewFirstLoggedBuildID).To(Equal(11))
 					})
+
+					Context("when a log archive is configured", func() {
+						var fakeLogArchive *logarchivefakes.FakeArchive
+
+						BeforeEach(func() {
+							fakeLogArchive = new(logarchivefakes.FakeArchive)
+							logArchive = fakeLogArchive
+						})
+
+						It("archives the builds it reaps", func() {
+							err := buildReaper.Run()
+							Expect(err).NotTo(HaveOccurred())
+
+							archivedBuildIDs := []int{}
+							for i := 0; i < fakeLogArchive.ArchiveCallCount(); i++ {
+								archivedBuildIDs = append(archivedBuildIDs, fakeLogArchive.ArchiveArgsForCall(i).ID())
+							}
+
+							Expect(archivedBuildIDs).To(ConsistOf(6, 7, 8, 9, 10))
+						})
+					})
 				})
 
 				Context("when deleting build events fails", func() {
@@ -470,6 +500,289 @@ var _ = Describe("BuildReaper", func() {
 			})
 		})
 
+		Context("when the job has a build log retention", func() {
+			var fakeJob *dbfakes.FakeJob
+			var fakeDownstreamJob *dbfakes.FakeJob
+
+			BeforeEach(func() {
+				fakeJob = new(dbfakes.FakeJob)
+				fakeJob.IDReturns(1)
+				fakeJob.NameReturns("job-1")
+				fakeJob.FirstLoggedBuildIDReturns(1)
+				fakeJob.ConfigReturns(atc.JobConfig{
+					Name: "job-1",
+					BuildLogRetention: &atc.BuildLogRetention{
+						Builds:                 3,
+						MinimumSucceededBuilds: 1,
+					},
+				})
+
+				fakeDownstreamJob = new(dbfakes.FakeJob)
+				fakeDownstreamJob.IDReturns(2)
+				fakeDownstreamJob.NameReturns("job-2")
+				fakeDownstreamJob.ConfigReturns(atc.JobConfig{
+					Name: "job-2",
+					Plan: atc.PlanSequence{
+						{Get: "some-resource", Passed: []string{"job-1"}},
+					},
+				})
+
+				fakePipeline.JobsReturns([]db.Job{fakeJob, fakeDownstreamJob}, nil)
+
+				fakeJob.BuildsStub = func(page db.Page) ([]db.Build, db.Pagination, error) {
+					if page == (db.Page{Limit: 3}) {
+						return []db.Build{sb(10), sb(9), sb(8)}, db.Pagination{}, nil
+					} else {
+						Fail(fmt.Sprintf("Builds called with unexpected argument: page=%#v", page))
+					}
+					return nil, db.Pagination{}, nil
+				}
+
+				fakeJob.UnreapedBuildsStub = func(afterID int, limit int) ([]db.Build, error) {
+					Expect(limit).To(Equal(5))
+
+					switch afterID {
+					case 0:
+						return []db.Build{sb(1), sb(2), sb(3), runningBuild(4), sb(5)}, nil
+					case 5:
+						return []db.Build{sb(6), sb(7), sb(8), sb(9), sb(10)}, nil
+					default:
+						Fail(fmt.Sprintf("UnreapedBuilds called with unexpected argument: afterID=%d", afterID))
+					}
+					return nil, nil
+				}
+
+				fakeJob.LatestSucceededBuildIDsReturns([]int{2}, nil)
+
+				fakeJob.BuildIDsUsedByJobsStub = func(buildIDs []int, jobIDs []int) ([]int, error) {
+					Expect(jobIDs).To(Equal([]int{2}))
+
+					for _, id := range buildIDs {
+						if id == 6 {
+							return []int{6}, nil
+						}
+					}
+
+					return []int{}, nil
+				}
+			})
+
+			It("reaps the builds which none of the rules keep, in batches", func() {
+				err := buildReaper.Run()
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeJob.LatestSucceededBuildIDsArgsForCall(0)).To(Equal(1))
+
+				Expect(fakePipeline.DeleteBuildEventsByBuildIDsCallCount()).To(Equal(2))
+				Expect(fakePipeline.DeleteBuildEventsByBuildIDsArgsForCall(0)).To(Equal([]int{1, 3, 5}))
+				Expect(fakePipeline.DeleteBuildEventsByBuildIDsArgsForCall(1)).To(Equal([]int{7}))
+			})
+
+			It("updates FirstLoggedBuildID to the oldest build which was kept", func() {
+				err := buildReaper.Run()
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeJob.UpdateFirstLoggedBuildIDCallCount()).To(Equal(1))
+				Expect(fakeJob.UpdateFirstLoggedBuildIDArgsForCall(0)).To(Equal(2))
+			})
+
+			Context("when the job has fewer builds than it retains", func() {
+				BeforeEach(func() {
+					fakeJob.BuildsStub = nil
+					fakeJob.BuildsReturns([]db.Build{sb(2), sb(1)}, db.Pagination{}, nil)
+				})
+
+				It("reaps nothing", func() {
+					err := buildReaper.Run()
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakeJob.UnreapedBuildsCallCount()).To(BeZero())
+					Expect(fakePipeline.DeleteBuildEventsByBuildIDsCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when getting the builds to consider reaping fails", func() {
+				var disaster error
+
+				BeforeEach(func() {
+					disaster = errors.New("major malfunction")
+
+					fakeJob.UnreapedBuildsStub = nil
+					fakeJob.UnreapedBuildsReturns(nil, disaster)
+				})
+
+				It("returns the error", func() {
+					err := buildReaper.Run()
+					Expect(err).To(Equal(disaster))
+				})
+			})
+
+			Context("when deleting build events fails", func() {
+				var disaster error
+
+				BeforeEach(func() {
+					disaster = errors.New("major malfunction")
+
+					fakePipeline.DeleteBuildEventsByBuildIDsReturns(disaster)
+				})
+
+				It("returns the error", func() {
+					err := buildReaper.Run()
+					Expect(err).To(Equal(disaster))
+				})
+
+				It("does not update FirstLoggedBuildID", func() {
+					buildReaper.Run()
+
+					Expect(fakeJob.UpdateFirstLoggedBuildIDCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when a log archive is configured", func() {
+				var fakeLogArchive *logarchivefakes.FakeArchive
+
+				BeforeEach(func() {
+					fakeLogArchive = new(logarchivefakes.FakeArchive)
+					logArchive = fakeLogArchive
+				})
+
+				It("archives each build before reaping it", func() {
+					fakePipeline.DeleteBuildEventsByBuildIDsStub = func(buildIDs []int) error {
+						archivedBuildIDs := []int{}
+						for i := 0; i < fakeLogArchive.ArchiveCallCount(); i++ {
+							archivedBuildIDs = append(archivedBuildIDs, fakeLogArchive.ArchiveArgsForCall(i).ID())
+						}
+
+						Expect(archivedBuildIDs).To(ContainElement(buildIDs[len(buildIDs)-1]))
+						return nil
+					}
+
+					err := buildReaper.Run()
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakeLogArchive.ArchiveCallCount()).To(Equal(4))
+				})
+
+				Context("when a build has already been archived", func() {
+					BeforeEach(func() {
+						unreapedBuildsStub := fakeJob.UnreapedBuildsStub
+						fakeJob.UnreapedBuildsStub = func(afterID int, limit int) ([]db.Build, error) {
+							builds, err := unreapedBuildsStub(afterID, limit)
+							if afterID == 5 {
+								archivedBuild := new(dbfakes.FakeBuild)
+								archivedBuild.IDReturns(7)
+								archivedBuild.IsArchivedReturns(true)
+								builds[1] = archivedBuild
+							}
+							return builds, err
+						}
+					})
+
+					It("does not archive it again", func() {
+						err := buildReaper.Run()
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(fakeLogArchive.ArchiveCallCount()).To(Equal(3))
+						Expect(fakePipeline.DeleteBuildEventsByBuildIDsArgsForCall(1)).To(Equal([]int{7}))
+					})
+				})
+
+				Context("when archiving a build fails", func() {
+					var disaster error
+
+					BeforeEach(func() {
+						disaster = errors.New("bucket is full")
+						fakeLogArchive.ArchiveReturns(disaster)
+					})
+
+					It("returns the error", func() {
+						err := buildReaper.Run()
+						Expect(err).To(Equal(disaster))
+					})
+
+					It("does not reap the build's events", func() {
+						buildReaper.Run()
+
+						Expect(fakePipeline.DeleteBuildEventsByBuildIDsCallCount()).To(BeZero())
+						Expect(fakeJob.UpdateFirstLoggedBuildIDCallCount()).To(BeZero())
+					})
+				})
+			})
+		})
+
+		Context("when the job keeps builds by age", func() {
+			var fakeJob *dbfakes.FakeJob
+
+			BeforeEach(func() {
+				fakeJob = new(dbfakes.FakeJob)
+				fakeJob.NameReturns("job-1")
+				fakeJob.FirstLoggedBuildIDReturns(3)
+				fakeJob.ConfigReturns(atc.JobConfig{
+					Name:              "job-1",
+					BuildLogRetention: &atc.BuildLogRetention{Days: 2},
+				})
+
+				fakePipeline.JobsReturns([]db.Job{fakeJob}, nil)
+
+				fakeJob.UnreapedBuildsReturns([]db.Build{
+					finishedBuild(3, time.Now().Add(-72*time.Hour)),
+					finishedBuild(4, time.Now().Add(-24*time.Hour)),
+					finishedBuild(5, time.Now().Add(-96*time.Hour)),
+				}, nil)
+			})
+
+			It("reaps the builds which finished before then", func() {
+				err := buildReaper.Run()
+				Expect(err).NotTo(HaveOccurred())
+
+				afterID, _ := fakeJob.UnreapedBuildsArgsForCall(0)
+				Expect(afterID).To(Equal(2))
+
+				Expect(fakePipeline.DeleteBuildEventsByBuildIDsCallCount()).To(Equal(1))
+				Expect(fakePipeline.DeleteBuildEventsByBuildIDsArgsForCall(0)).To(Equal([]int{3, 5}))
+
+				Expect(fakeJob.UpdateFirstLoggedBuildIDArgsForCall(0)).To(Equal(4))
+			})
+		})
+
+		Context("when the job configures no retention", func() {
+			var fakeJob *dbfakes.FakeJob
+
+			BeforeEach(func() {
+				fakeJob = new(dbfakes.FakeJob)
+				fakeJob.NameReturns("job-1")
+				fakeJob.FirstLoggedBuildIDReturns(1)
+				fakeJob.ConfigReturns(atc.JobConfig{Name: "job-1"})
+
+				fakePipeline.JobsReturns([]db.Job{fakeJob}, nil)
+
+				fakeJob.UnreapedBuildsReturns([]db.Build{sb(1), sb(2)}, nil)
+			})
+
+			It("skips the reaping step for that job", func() {
+				err := buildReaper.Run()
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeJob.UnreapedBuildsCallCount()).To(BeZero())
+				Expect(fakePipeline.DeleteBuildEventsByBuildIDsCallCount()).To(BeZero())
+			})
+
+			Context("when there is a default retention", func() {
+				BeforeEach(func() {
+					defaultRetention = atc.BuildLogRetention{MinimumSucceededBuilds: 1}
+					fakeJob.LatestSucceededBuildIDsReturns([]int{2}, nil)
+				})
+
+				It("reaps by the default retention", func() {
+					err := buildReaper.Run()
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakePipeline.DeleteBuildEventsByBuildIDsCallCount()).To(Equal(1))
+					Expect(fakePipeline.DeleteBuildEventsByBuildIDsArgsForCall(0)).To(Equal([]int{1}))
+				})
+			})
+		})
+
 		Context("when the dashboard job says retain 0 builds", func() {
 			var fakeJob *dbfakes.FakeJob
 
@@ -541,6 +854,14 @@ func sb(id int) db.Build {
 	return build
 }
 
+func finishedBuild(id int, endTime time.Time) db.Build {
+	build := new(dbfakes.FakeBuild)
+	build.IDReturns(id)
+	build.IsRunningReturns(false)
+	build.EndTimeReturns(endTime)
+	return build
+}
+
 func runningBuild(id int) db.Build {
 	build := new(dbfakes.FakeBuild)
 	build.IDReturns(id)
@@ -0,0 +1,37 @@
+package gc
+
+import (
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/db"
+)
+
+type teamEventCollector struct {
+	logger       lager.Logger
+	teamEventLog db.TeamEventLog
+	retention    int
+}
+
+func NewTeamEventCollector(
+	logger lager.Logger,
+	teamEventLog db.TeamEventLog,
+	retention int,
+) Collector {
+	return &teamEventCollector{
+		logger:       logger,
+		teamEventLog: teamEventLog,
+		retention:    retention,
+	}
+}
+
+func (c *teamEventCollector) Run() error {
+	c.logger.Debug("start")
+	defer c.logger.Debug("done")
+
+	err := c.teamEventLog.Prune(c.retention)
+	if err != nil {
+		c.logger.Error("failed-to-prune-team-events", err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,48 @@
+package gc_test
+
+import (
+	"errors"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc/db/dbfakes"
+	"github.com/concourse/atc/gc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TeamEventCollector", func() {
+	var (
+		teamEventCollector gc.Collector
+		fakeTeamEventLog   *dbfakes.FakeTeamEventLog
+	)
+
+	BeforeEach(func() {
+		logger := lagertest.NewTestLogger("team-event-collector")
+		fakeTeamEventLog = new(dbfakes.FakeTeamEventLog)
+
+		teamEventCollector = gc.NewTeamEventCollector(
+			logger,
+			fakeTeamEventLog,
+			1000,
+		)
+	})
+
+	Describe("Run", func() {
+		It("keeps the most recent events of each team", func() {
+			err := teamEventCollector.Run()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeTeamEventLog.PruneCallCount()).To(Equal(1))
+			Expect(fakeTeamEventLog.PruneArgsForCall(0)).To(Equal(1000))
+		})
+
+		It("returns an error if pruning the events fails", func() {
+			returnedErr := errors.New("some-error")
+			fakeTeamEventLog.PruneReturns(returnedErr)
+
+			err := teamEventCollector.Run()
+			Expect(err).To(MatchError(returnedErr))
+		})
+	})
+})
@@ -11,6 +11,8 @@ type JobConfig struct {
 	RawMaxInFlight       int      `yaml:"max_in_flight,omitempty" json:"max_in_flight,omitempty" mapstructure:"max_in_flight"`
 	BuildLogsToRetain    int      `yaml:"build_logs_to_retain,omitempty" json:"build_logs_to_retain,omitempty" mapstructure:"build_logs_to_retain"`
 
+	BuildLogRetention *BuildLogRetention `yaml:"build_log_retention,omitempty" json:"build_log_retention,omitempty" mapstructure:"build_log_retention"`
+
 	Plan PlanSequence `yaml:"plan,omitempty" json:"plan,omitempty" mapstructure:"plan"`
 
 	Abort   *PlanConfig `yaml:"on_abort,omitempty" json:"on_abort,omitempty" mapstructure:"on_abort"`
@@ -19,6 +21,22 @@ type JobConfig struct {
 	Success *PlanConfig `yaml:"on_success,omitempty" json:"on_success,omitempty" mapstructure:"on_success"`
 }
 
+// BuildLogRetention is how long to keep the logs of a job's builds. The logs of
+// a build are kept for as long as any of the rules keeps them. Builds which
+// are still running, and builds whose outputs were used by builds of
+// downstream jobs which still have their logs, are always kept.
+type BuildLogRetention struct {
+	Builds                 int `yaml:"builds,omitempty" json:"builds,omitempty" mapstructure:"builds"`
+	Days                   int `yaml:"days,omitempty" json:"days,omitempty" mapstructure:"days"`
+	MinimumSucceededBuilds int `yaml:"minimum_succeeded_builds,omitempty" json:"minimum_succeeded_builds,omitempty" mapstructure:"minimum_succeeded_builds"`
+}
+
+// IsEmpty returns true if the retention has no rules, in which case no logs
+// are reaped.
+func (retention BuildLogRetention) IsEmpty() bool {
+	return retention.Builds == 0 && retention.Days == 0 && retention.MinimumSucceededBuilds == 0
+}
+
 func (config JobConfig) Hooks() Hooks {
 	return Hooks{Abort: config.Abort, Failure: config.Failure, Ensure: config.Ensure, Success: config.Success}
 }
@@ -0,0 +1,11 @@
+package atc
+
+type KeyRotationStatus struct {
+	Table       string `json:"table"`
+	Column      string `json:"column"`
+	LastID      int    `json:"last_id"`
+	RowsRotated int    `json:"rows_rotated"`
+	RowsSkipped int    `json:"rows_skipped"`
+	Finished    bool   `json:"finished"`
+	UpdatedAt   int64  `json:"updated_at"`
+}
@@ -0,0 +1,166 @@
+package logarchive
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/event"
+)
+
+var ErrBuildNotArchived = errors.New("build events not found in archive")
+
+//go:generate counterfeiter . Archive
+
+// An Archive keeps the events of builds so that they can still be streamed
+// once they are reaped from the database.
+type Archive interface {
+	Archive(build db.Build) error
+	Events(build db.Build, from uint) (db.EventSource, error)
+}
+
+type archive struct {
+	sink Sink
+}
+
+func NewArchive(sink Sink) Archive {
+	return &archive{sink: sink}
+}
+
+// Archive writes the build's events to the sink as gzipped JSON, one
+// event.Envelope per line, just as they are sent by the events endpoint. The
+// events are streamed to the sink as they are read, rather than buffered. The
+// build is marked as archived once they are stored.
+func (archive *archive) Archive(build db.Build) error {
+	events, err := build.Events(0)
+	if err != nil {
+		return err
+	}
+
+	defer db.Close(events)
+
+	reader, writer := io.Pipe()
+
+	encoded := make(chan error, 1)
+	go func() {
+		err := encode(events, writer)
+		_ = writer.CloseWithError(err)
+		encoded <- err
+	}()
+
+	err = archive.sink.Put(key(build), reader)
+
+	// stops the encoding if the sink gave up before reading everything
+	_ = reader.Close()
+
+	encodeErr := <-encoded
+
+	// the pipe is only closed early when the sink fails, whose error says more
+	if encodeErr == io.ErrClosedPipe && err != nil {
+		return err
+	}
+
+	if encodeErr != nil {
+		return encodeErr
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return build.MarkAsArchived()
+}
+
+func encode(events db.EventSource, w io.Writer) error {
+	gz := gzip.NewWriter(w)
+	encoder := json.NewEncoder(gz)
+
+	for {
+		ev, err := events.Next()
+		if err == db.ErrEndOfBuildEventStream {
+			break
+		}
+
+		if err != nil {
+			return err
+		}
+
+		err = encoder.Encode(ev)
+		if err != nil {
+			return err
+		}
+	}
+
+	return gz.Close()
+}
+
+// Events reads the build's events back from the sink, starting from the
+// given event.
+func (archive *archive) Events(build db.Build, from uint) (db.EventSource, error) {
+	reader, found, err := archive.sink.Get(key(build))
+	if err != nil {
+		return nil, err
+	}
+
+	if !found {
+		return nil, ErrBuildNotArchived
+	}
+
+	gz, err := gzip.NewReader(reader)
+	if err != nil {
+		_ = reader.Close()
+		return nil, err
+	}
+
+	source := &archivedEventSource{
+		reader:  reader,
+		gz:      gz,
+		decoder: json.NewDecoder(gz),
+	}
+
+	for i := uint(0); i < from; i++ {
+		_, err := source.Next()
+		if err == db.ErrEndOfBuildEventStream {
+			break
+		}
+
+		if err != nil {
+			_ = source.Close()
+			return nil, err
+		}
+	}
+
+	return source, nil
+}
+
+func key(build db.Build) string {
+	return fmt.Sprintf("builds/%d.json.gz", build.ID())
+}
+
+type archivedEventSource struct {
+	reader  io.ReadCloser
+	gz      *gzip.Reader
+	decoder *json.Decoder
+}
+
+func (source *archivedEventSource) Next() (event.Envelope, error) {
+	var envelope event.Envelope
+	err := source.decoder.Decode(&envelope)
+	if err == io.EOF {
+		return event.Envelope{}, db.ErrEndOfBuildEventStream
+	}
+
+	if err != nil {
+		return event.Envelope{}, err
+	}
+
+	return envelope, nil
+}
+
+func (source *archivedEventSource) Close() error {
+	_ = source.gz.Close()
+	return source.reader.Close()
+}
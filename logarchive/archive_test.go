@@ -0,0 +1,194 @@
+package logarchive_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/dbfakes"
+	"github.com/concourse/atc/event"
+	"github.com/concourse/atc/logarchive"
+	"github.com/concourse/atc/logarchive/logarchivefakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Archive", func() {
+	var (
+		tmpdir  string
+		sink    logarchive.Sink
+		archive logarchive.Archive
+
+		build          *dbfakes.FakeBuild
+		archivedEvents []event.Envelope
+	)
+
+	BeforeEach(func() {
+		var err error
+		tmpdir, err = ioutil.TempDir("", "log-archive")
+		Expect(err).NotTo(HaveOccurred())
+
+		sink = logarchive.NewDirSink(tmpdir)
+
+		archivedEvents = []event.Envelope{
+			envelope(`{"event":1}`),
+			envelope(`{"event":2}`),
+			envelope(`{"event":3}`),
+		}
+
+		build = new(dbfakes.FakeBuild)
+		build.IDReturns(42)
+		build.EventsStub = func(from uint) (db.EventSource, error) {
+			events := new(dbfakes.FakeEventSource)
+			events.NextStub = func() (event.Envelope, error) {
+				if from >= uint(len(archivedEvents)) {
+					return event.Envelope{}, db.ErrEndOfBuildEventStream
+				}
+
+				from++
+
+				return archivedEvents[from-1], nil
+			}
+
+			return events, nil
+		}
+	})
+
+	JustBeforeEach(func() {
+		archive = logarchive.NewArchive(sink)
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(tmpdir)).To(Succeed())
+	})
+
+	Describe("Archive", func() {
+		It("stores the build's events and marks the build as archived", func() {
+			Expect(archive.Archive(build)).To(Succeed())
+
+			Expect(build.EventsCallCount()).To(Equal(1))
+			Expect(build.EventsArgsForCall(0)).To(BeZero())
+
+			_, found, err := sink.Get("builds/42.json.gz")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			Expect(build.MarkAsArchivedCallCount()).To(Equal(1))
+		})
+
+		Context("when reading the build's events fails", func() {
+			var disaster error
+
+			BeforeEach(func() {
+				disaster = errors.New("nope")
+
+				build.EventsStub = nil
+				build.EventsReturns(nil, disaster)
+			})
+
+			It("returns the error without marking the build as archived", func() {
+				Expect(archive.Archive(build)).To(Equal(disaster))
+				Expect(build.MarkAsArchivedCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when reading an event fails part way through", func() {
+			var disaster error
+
+			BeforeEach(func() {
+				disaster = errors.New("nope")
+
+				build.EventsStub = func(from uint) (db.EventSource, error) {
+					events := new(dbfakes.FakeEventSource)
+					events.NextReturnsOnCall(0, archivedEvents[0], nil)
+					events.NextReturnsOnCall(1, event.Envelope{}, disaster)
+					return events, nil
+				}
+			})
+
+			It("returns the error without storing the events or marking the build as archived", func() {
+				Expect(archive.Archive(build)).To(Equal(disaster))
+
+				_, found, err := sink.Get("builds/42.json.gz")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+
+				Expect(build.MarkAsArchivedCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when storing the events fails", func() {
+			var disaster error
+
+			BeforeEach(func() {
+				disaster = errors.New("nope")
+
+				fakeSink := new(logarchivefakes.FakeSink)
+				fakeSink.PutReturns(disaster)
+				sink = fakeSink
+			})
+
+			It("returns the error without marking the build as archived", func() {
+				Expect(archive.Archive(build)).To(Equal(disaster))
+				Expect(build.MarkAsArchivedCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("Events", func() {
+		Context("when the build has been archived", func() {
+			JustBeforeEach(func() {
+				Expect(archive.Archive(build)).To(Succeed())
+			})
+
+			It("streams the archived events, followed by the end of the stream", func() {
+				events, err := archive.Events(build, 0)
+				Expect(err).NotTo(HaveOccurred())
+
+				defer db.Close(events)
+
+				for _, expected := range archivedEvents {
+					ev, err := events.Next()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(ev).To(Equal(expected))
+				}
+
+				_, err = events.Next()
+				Expect(err).To(Equal(db.ErrEndOfBuildEventStream))
+			})
+
+			It("starts from the given event", func() {
+				events, err := archive.Events(build, 2)
+				Expect(err).NotTo(HaveOccurred())
+
+				defer db.Close(events)
+
+				ev, err := events.Next()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ev).To(Equal(archivedEvents[2]))
+
+				_, err = events.Next()
+				Expect(err).To(Equal(db.ErrEndOfBuildEventStream))
+			})
+		})
+
+		Context("when the build has not been archived", func() {
+			It("returns ErrBuildNotArchived", func() {
+				_, err := archive.Events(build, 0)
+				Expect(err).To(Equal(logarchive.ErrBuildNotArchived))
+			})
+		})
+	})
+})
+
+func envelope(payload string) event.Envelope {
+	data := json.RawMessage(payload)
+	return event.Envelope{
+		Data:    &data,
+		Event:   "fake",
+		Version: "42.0",
+	}
+}
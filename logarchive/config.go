@@ -0,0 +1,61 @@
+package logarchive
+
+import (
+	"errors"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+)
+
+type Config struct {
+	Dir string `long:"dir" description:"Directory to archive the logs of builds to before they are reaped."`
+
+	S3Endpoint        string `long:"s3-endpoint"          description:"URL of an S3-compatible API to archive the logs of builds to. Defaults to AWS S3."`
+	S3Bucket          string `long:"s3-bucket"            description:"Bucket to archive the logs of builds to before they are reaped."`
+	S3Region          string `long:"s3-region"            default:"us-east-1" description:"Region of the bucket."`
+	S3AccessKeyID     string `long:"s3-access-key-id"     description:"Access key ID for the bucket. Taken from the environment if not set."`
+	S3SecretAccessKey string `long:"s3-secret-access-key" description:"Secret access key for the bucket."`
+}
+
+func (config Config) IsConfigured() bool {
+	return config.Dir != "" || config.S3Bucket != ""
+}
+
+func (config Config) Validate() error {
+	if config.Dir != "" && config.S3Bucket != "" {
+		return errors.New("must configure either an archive directory or an S3 bucket, not both")
+	}
+
+	if config.S3AccessKeyID != "" && config.S3SecretAccessKey == "" {
+		return errors.New("must provide S3 secret access key")
+	}
+
+	return nil
+}
+
+func (config Config) NewArchive() (Archive, error) {
+	if config.Dir != "" {
+		return NewArchive(NewDirSink(config.Dir)), nil
+	}
+
+	awsConfig := &aws.Config{Region: aws.String(config.S3Region)}
+
+	if config.S3Endpoint != "" {
+		// S3-compatible APIs do not generally serve buckets as subdomains
+		awsConfig.Endpoint = aws.String(config.S3Endpoint)
+		awsConfig.S3ForcePathStyle = aws.Bool(true)
+	}
+
+	if config.S3AccessKeyID != "" {
+		awsConfig.Credentials = credentials.NewStaticCredentials(config.S3AccessKeyID, config.S3SecretAccessKey, "")
+	}
+
+	session, err := session.NewSession(awsConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewArchive(NewS3Sink(s3.New(session), config.S3Bucket)), nil
+}
@@ -0,0 +1,126 @@
+package logarchive
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+)
+
+//go:generate counterfeiter . Sink
+
+// A Sink stores archived build events by key. Put reads the data until EOF,
+// so that an archive is stored as it is written rather than held in memory.
+type Sink interface {
+	Put(key string, data io.Reader) error
+	Get(key string) (io.ReadCloser, bool, error)
+}
+
+type dirSink struct {
+	dir string
+}
+
+// NewDirSink returns a Sink which stores each key as a file under the
+// directory.
+func NewDirSink(dir string) Sink {
+	return &dirSink{dir: dir}
+}
+
+func (sink *dirSink) Put(key string, data io.Reader) error {
+	path := sink.path(key)
+
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return err
+	}
+
+	// write to a temporary file first so that readers never see a partial
+	// archive
+	tmp, err := ioutil.TempFile(filepath.Dir(path), ".archive")
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(tmp, data)
+	if err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+
+	err = tmp.Close()
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+func (sink *dirSink) Get(key string) (io.ReadCloser, bool, error) {
+	file, err := os.Open(sink.path(key))
+	if os.IsNotExist(err) {
+		return nil, false, nil
+	}
+
+	if err != nil {
+		return nil, false, err
+	}
+
+	return file, true, nil
+}
+
+func (sink *dirSink) path(key string) string {
+	return filepath.Join(sink.dir, filepath.FromSlash(key))
+}
+
+type s3Sink struct {
+	client   s3iface.S3API
+	uploader *s3manager.Uploader
+	bucket   string
+}
+
+// NewS3Sink returns a Sink which stores each key as an object in the bucket of
+// an S3-compatible API.
+func NewS3Sink(client s3iface.S3API, bucket string) Sink {
+	return &s3Sink{
+		client:   client,
+		uploader: s3manager.NewUploaderWithClient(client),
+		bucket:   bucket,
+	}
+}
+
+// Put uploads the data in parts, as its size is not known up front, so that
+// only a few parts are held in memory at once. Data which fits in one part is
+// put as a single object.
+func (sink *s3Sink) Put(key string, data io.Reader) error {
+	_, err := sink.uploader.Upload(&s3manager.UploadInput{
+		Bucket:      aws.String(sink.bucket),
+		Key:         aws.String(key),
+		Body:        data,
+		ContentType: aws.String("application/gzip"),
+	})
+	return err
+}
+
+func (sink *s3Sink) Get(key string) (io.ReadCloser, bool, error) {
+	output, err := sink.client.GetObject(&s3.GetObjectInput{
+		Bucket: aws.String(sink.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == s3.ErrCodeNoSuchKey {
+			return nil, false, nil
+		}
+
+		return nil, false, err
+	}
+
+	return output.Body, true, nil
+}
@@ -0,0 +1,233 @@
+package logarchive_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/concourse/atc/logarchive"
+	"github.com/onsi/gomega/ghttp"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Sinks", func() {
+	Describe("DirSink", func() {
+		var (
+			tmpdir string
+			sink   logarchive.Sink
+		)
+
+		BeforeEach(func() {
+			var err error
+			tmpdir, err = ioutil.TempDir("", "dir-sink")
+			Expect(err).NotTo(HaveOccurred())
+
+			sink = logarchive.NewDirSink(tmpdir)
+		})
+
+		AfterEach(func() {
+			Expect(os.RemoveAll(tmpdir)).To(Succeed())
+		})
+
+		It("reads back what was put under the key", func() {
+			Expect(sink.Put("builds/1.json.gz", strings.NewReader("some-data"))).To(Succeed())
+
+			reader, found, err := sink.Get("builds/1.json.gz")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			defer reader.Close()
+
+			Expect(ioutil.ReadAll(reader)).To(Equal([]byte("some-data")))
+		})
+
+		It("does not find keys which were never put", func() {
+			_, found, err := sink.Get("builds/2.json.gz")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		Context("when reading the data fails", func() {
+			It("returns the error and stores nothing", func() {
+				disaster := errors.New("nope")
+
+				reader, writer := io.Pipe()
+				go func() {
+					writer.Write([]byte("some-partial-data"))
+					writer.CloseWithError(disaster)
+				}()
+
+				Expect(sink.Put("builds/1.json.gz", reader)).To(Equal(disaster))
+
+				_, found, err := sink.Get("builds/1.json.gz")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+
+				files, err := ioutil.ReadDir(tmpdir + "/builds")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(files).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("S3Sink", func() {
+		var (
+			server *ghttp.Server
+			sink   logarchive.Sink
+		)
+
+		BeforeEach(func() {
+			server = ghttp.NewServer()
+
+			session, err := session.NewSession(&aws.Config{
+				Region:           aws.String("us-east-1"),
+				Endpoint:         aws.String(server.URL()),
+				S3ForcePathStyle: aws.Bool(true),
+				Credentials:      credentials.NewStaticCredentials("some-access-key-id", "some-secret-access-key", ""),
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			sink = logarchive.NewS3Sink(s3.New(session), "some-bucket")
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		It("puts the data as an object in the bucket", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/some-bucket/builds/1.json.gz"),
+					ghttp.VerifyBody([]byte("some-data")),
+					ghttp.RespondWith(http.StatusOK, ""),
+				),
+			)
+
+			Expect(sink.Put("builds/1.json.gz", strings.NewReader("some-data"))).To(Succeed())
+			Expect(server.ReceivedRequests()).To(HaveLen(1))
+		})
+
+		Context("when the data does not fit in one part", func() {
+			var (
+				lock      sync.Mutex
+				partSizes map[int]int
+				completed bool
+			)
+
+			BeforeEach(func() {
+				partSizes = map[int]int{}
+				completed = false
+
+				server.RouteToHandler("POST", "/some-bucket/builds/1.json.gz", func(w http.ResponseWriter, r *http.Request) {
+					if _, initiating := r.URL.Query()["uploads"]; initiating {
+						w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?>
+<InitiateMultipartUploadResult><Bucket>some-bucket</Bucket><Key>builds/1.json.gz</Key><UploadId>some-upload-id</UploadId></InitiateMultipartUploadResult>`))
+						return
+					}
+
+					Expect(r.URL.Query().Get("uploadId")).To(Equal("some-upload-id"))
+
+					lock.Lock()
+					completed = true
+					lock.Unlock()
+
+					w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?>
+<CompleteMultipartUploadResult><Bucket>some-bucket</Bucket><Key>builds/1.json.gz</Key><ETag>"some-etag"</ETag></CompleteMultipartUploadResult>`))
+				})
+
+				server.RouteToHandler("PUT", "/some-bucket/builds/1.json.gz", func(w http.ResponseWriter, r *http.Request) {
+					Expect(r.URL.Query().Get("uploadId")).To(Equal("some-upload-id"))
+
+					partNumber, err := strconv.Atoi(r.URL.Query().Get("partNumber"))
+					Expect(err).NotTo(HaveOccurred())
+
+					body, err := ioutil.ReadAll(r.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					lock.Lock()
+					partSizes[partNumber] = len(body)
+					lock.Unlock()
+
+					w.Header().Set("ETag", `"some-part-etag"`)
+				})
+			})
+
+			It("uploads it in parts", func() {
+				// hide the size of the data, as the archive does
+				data := io.MultiReader(bytes.NewReader(make([]byte, 6*1024*1024)))
+
+				Expect(sink.Put("builds/1.json.gz", data)).To(Succeed())
+
+				lock.Lock()
+				defer lock.Unlock()
+
+				Expect(partSizes).To(Equal(map[int]int{
+					1: 5 * 1024 * 1024,
+					2: 1024 * 1024,
+				}))
+				Expect(completed).To(BeTrue())
+			})
+		})
+
+		It("gets the object from the bucket", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/some-bucket/builds/1.json.gz"),
+					ghttp.RespondWith(http.StatusOK, "some-data"),
+				),
+			)
+
+			reader, found, err := sink.Get("builds/1.json.gz")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			defer reader.Close()
+
+			Expect(ioutil.ReadAll(reader)).To(Equal([]byte("some-data")))
+		})
+
+		Context("when the object does not exist", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/some-bucket/builds/2.json.gz"),
+						ghttp.RespondWith(http.StatusNotFound, `<?xml version="1.0" encoding="UTF-8"?>
+<Error><Code>NoSuchKey</Code><Message>The specified key does not exist.</Message></Error>`),
+					),
+				)
+			})
+
+			It("is not found", func() {
+				_, found, err := sink.Get("builds/2.json.gz")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+
+		Context("when the API fails", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.RespondWith(http.StatusForbidden, `<?xml version="1.0" encoding="UTF-8"?>
+<Error><Code>AccessDenied</Code><Message>Access Denied</Message></Error>`),
+				)
+			})
+
+			It("returns an error", func() {
+				_, _, err := sink.Get("builds/1.json.gz")
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+})
@@ -2,6 +2,7 @@ package metric
 
 import (
 	"fmt"
+	"io"
 	"strings"
 	"time"
 
@@ -97,6 +98,20 @@ func Initialize(logger lager.Logger, host string, attributes map[string]string)
 	return nil
 }
 
+// Deinitialize closes the emitter, if it holds on to anything which needs
+// closing, so that what it has batched up is not lost when the ATC exits.
+func Deinitialize(logger lager.Logger) {
+	closer, ok := emitter.(io.Closer)
+	if !ok {
+		return
+	}
+
+	err := closer.Close()
+	if err != nil {
+		logger.Error("failed-to-close-emitter", err)
+	}
+}
+
 func emit(logger lager.Logger, event Event) {
 	if emitter == nil {
 		return
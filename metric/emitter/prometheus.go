@@ -23,9 +23,17 @@ type PrometheusEmitter struct {
 	buildsFinishedVec *prometheus.CounterVec
 	buildDurationsVec *prometheus.HistogramVec
 
+	jobBuildsFinishedVec *prometheus.CounterVec
+	jobBuildDurationsVec *prometheus.HistogramVec
+	stepDurationsVec     *prometheus.HistogramVec
+
 	workerContainers *prometheus.GaugeVec
 	workerVolumes    *prometheus.GaugeVec
 
+	volumesStreamedBytes      *prometheus.CounterVec
+	volumesStreamingDuration  *prometheus.HistogramVec
+	volumesStreamDeduplicated *prometheus.CounterVec
+
 	httpRequestsDuration *prometheus.HistogramVec
 
 	schedulingFullDuration    *prometheus.GaugeVec
@@ -36,8 +44,14 @@ type PrometheusEmitter struct {
 type PrometheusConfig struct {
 	BindIP   string `long:"prometheus-bind-ip" description:"IP to listen on to expose Prometheus metrics."`
 	BindPort string `long:"prometheus-bind-port" description:"Port to listen on to expose Prometheus metrics."`
+
+	BuildDurationBuckets []float64 `long:"prometheus-build-duration-bucket" description:"Upper bound, in seconds, of a bucket of the build duration histograms. Can be specified multiple times." value-name:"SECONDS"`
+	StepDurationBuckets  []float64 `long:"prometheus-step-duration-bucket"  description:"Upper bound, in seconds, of a bucket of the step duration histogram. Can be specified multiple times." value-name:"SECONDS"`
 }
 
+var defaultBuildDurationBuckets = []float64{1, 60, 180, 300, 600, 900, 1200, 1800, 2700, 3600, 7200, 18000, 36000}
+var defaultStepDurationBuckets = []float64{1, 5, 15, 30, 60, 120, 300, 600, 1200, 1800, 3600, 7200}
+
 func init() {
 	metric.RegisterEmitter(&PrometheusConfig{})
 }
@@ -50,6 +64,22 @@ func (config *PrometheusConfig) bind() string {
 	return fmt.Sprintf("%s:%s", config.BindIP, config.BindPort)
 }
 
+func (config *PrometheusConfig) buildDurationBuckets() []float64 {
+	if len(config.BuildDurationBuckets) == 0 {
+		return defaultBuildDurationBuckets
+	}
+
+	return config.BuildDurationBuckets
+}
+
+func (config *PrometheusConfig) stepDurationBuckets() []float64 {
+	if len(config.StepDurationBuckets) == 0 {
+		return defaultStepDurationBuckets
+	}
+
+	return config.StepDurationBuckets
+}
+
 func (config *PrometheusConfig) NewEmitter() (metric.Emitter, error) {
 	// build metrics
 	buildsStarted := prometheus.NewCounter(prometheus.CounterOpts{
@@ -116,12 +146,49 @@ func (config *PrometheusConfig) NewEmitter() (metric.Emitter, error) {
 			Subsystem: "builds",
 			Name:      "duration_seconds",
 			Help:      "Build time in seconds",
-			Buckets:   []float64{1, 60, 180, 300, 600, 900, 1200, 1800, 2700, 3600, 7200, 18000, 36000},
+			Buckets:   config.buildDurationBuckets(),
 		},
 		[]string{"team", "pipeline"},
 	)
 	prometheus.MustRegister(buildDurationsVec)
 
+	// job metrics
+	jobBuildsFinishedVec := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "concourse",
+			Subsystem: "jobs",
+			Name:      "builds_finished_total",
+			Help:      "Total number of builds finished per job and status.",
+		},
+		[]string{"team", "pipeline", "job", "status"},
+	)
+	prometheus.MustRegister(jobBuildsFinishedVec)
+
+	jobBuildDurationsVec := prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "concourse",
+			Subsystem: "jobs",
+			Name:      "build_duration_seconds",
+			Help:      "Build time in seconds per job and status.",
+			Buckets:   config.buildDurationBuckets(),
+		},
+		[]string{"team", "pipeline", "job", "status"},
+	)
+	prometheus.MustRegister(jobBuildDurationsVec)
+
+	// step metrics
+	stepDurationsVec := prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "concourse",
+			Subsystem: "steps",
+			Name:      "duration_seconds",
+			Help:      "Time taken by get, put and task steps in seconds.",
+			Buckets:   config.stepDurationBuckets(),
+		},
+		[]string{"team", "pipeline", "job", "type", "status"},
+	)
+	prometheus.MustRegister(stepDurationsVec)
+
 	// worker metrics
 	workerContainers := prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
@@ -145,6 +212,39 @@ func (config *PrometheusConfig) NewEmitter() (metric.Emitter, error) {
 	)
 	prometheus.MustRegister(workerVolumes)
 
+	volumesStreamedBytes := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "concourse",
+			Subsystem: "volumes",
+			Name:      "streamed_bytes_total",
+			Help:      "Total bytes of volumes streamed between workers, directly (p2p) or through the ATC (atc), as sent in the encoding they were compressed with. P2P streams are counted uncompressed (raw)",
+		},
+		[]string{"mode", "encoding"},
+	)
+	prometheus.MustRegister(volumesStreamedBytes)
+
+	volumesStreamingDuration := prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "concourse",
+			Subsystem: "volumes",
+			Name:      "streaming_duration_seconds",
+			Help:      "Time taken to stream volumes between workers, by mode and by the encoding streams through the ATC were compressed with",
+		},
+		[]string{"mode", "encoding"},
+	)
+	prometheus.MustRegister(volumesStreamingDuration)
+
+	volumesStreamDeduplicated := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "concourse",
+			Subsystem: "volumes",
+			Name:      "stream_deduplicated_total",
+			Help:      "Number of times a worker already had a copy of a volume, so it was not streamed again",
+		},
+		[]string{"worker"},
+	)
+	prometheus.MustRegister(volumesStreamDeduplicated)
+
 	// http metrics
 	httpRequestsDuration := prometheus.NewHistogramVec(
 		prometheus.HistogramOpts{
@@ -211,9 +311,17 @@ func (config *PrometheusConfig) NewEmitter() (metric.Emitter, error) {
 		buildsFailed:      buildsFailed,
 		buildsAborted:     buildsAborted,
 
+		jobBuildsFinishedVec: jobBuildsFinishedVec,
+		jobBuildDurationsVec: jobBuildDurationsVec,
+		stepDurationsVec:     stepDurationsVec,
+
 		workerContainers: workerContainers,
 		workerVolumes:    workerVolumes,
 
+		volumesStreamedBytes:      volumesStreamedBytes,
+		volumesStreamingDuration:  volumesStreamingDuration,
+		volumesStreamDeduplicated: volumesStreamDeduplicated,
+
 		httpRequestsDuration: httpRequestsDuration,
 
 		schedulingFullDuration:    schedulingFullDuration,
@@ -232,10 +340,18 @@ func (emitter *PrometheusEmitter) Emit(logger lager.Logger, event metric.Event)
 		emitter.buildsStarted.Inc()
 	case "build finished":
 		emitter.buildFinishedMetrics(logger, event)
+	case "step finished":
+		emitter.stepFinishedMetrics(logger, event)
 	case "worker containers":
 		emitter.workerContainersMetric(logger, event)
 	case "worker volumes":
 		emitter.workerVolumesMetric(logger, event)
+	case "volume streamed":
+		emitter.volumeStreamedMetric(logger, event)
+	case "volume streaming duration (ms)":
+		emitter.volumeStreamingDurationMetric(logger, event)
+	case "volume stream deduplicated":
+		emitter.volumeStreamDeduplicatedMetric(logger, event)
 	case "http response time":
 		emitter.httpResponseTimeMetrics(logger, event)
 	case "scheduling: full duration (ms)":
@@ -340,6 +456,53 @@ func (emitter *PrometheusEmitter) buildFinishedMetrics(logger lager.Logger, even
 	// seconds are the standard prometheus base unit for time
 	duration = duration / 1000
 	emitter.buildDurationsVec.WithLabelValues(team, pipeline).Observe(duration)
+
+	// one-off builds have no job to label them with
+	job, exists := event.Attributes["job"]
+	if !exists || job == "" {
+		return
+	}
+
+	// concourse_jobs_builds_finished_total
+	emitter.jobBuildsFinishedVec.WithLabelValues(team, pipeline, job, buildStatus).Inc()
+
+	// concourse_jobs_build_duration_seconds
+	emitter.jobBuildDurationsVec.WithLabelValues(team, pipeline, job, buildStatus).Observe(duration)
+}
+
+func (emitter *PrometheusEmitter) stepFinishedMetrics(logger lager.Logger, event metric.Event) {
+	team, exists := event.Attributes["team_name"]
+	if !exists {
+		logger.Error("failed-to-find-team-name-in-event", fmt.Errorf("expected team_name to exist in event.Attributes"))
+	}
+
+	pipeline, exists := event.Attributes["pipeline"]
+	if !exists {
+		logger.Error("failed-to-find-pipeline-in-event", fmt.Errorf("expected pipeline to exist in event.Attributes"))
+	}
+
+	job, exists := event.Attributes["job"]
+	if !exists {
+		logger.Error("failed-to-find-job-in-event", fmt.Errorf("expected job to exist in event.Attributes"))
+	}
+
+	stepType, exists := event.Attributes["step_type"]
+	if !exists {
+		logger.Error("failed-to-find-step_type-in-event", fmt.Errorf("expected step_type to exist in event.Attributes"))
+	}
+
+	stepStatus, exists := event.Attributes["step_status"]
+	if !exists {
+		logger.Error("failed-to-find-step_status-in-event", fmt.Errorf("expected step_status to exist in event.Attributes"))
+	}
+
+	duration, ok := event.Value.(float64)
+	if !ok {
+		logger.Error("step-finished-event-value-type-mismatch", fmt.Errorf("expected event.Value to be a float64"))
+	}
+
+	// concourse_steps_duration_seconds
+	emitter.stepDurationsVec.WithLabelValues(team, pipeline, job, stepType, stepStatus).Observe(duration / 1000)
 }
 
 func (emitter *PrometheusEmitter) workerContainersMetric(logger lager.Logger, event metric.Event) {
@@ -370,6 +533,54 @@ func (emitter *PrometheusEmitter) workerVolumesMetric(logger lager.Logger, event
 	emitter.workerVolumes.WithLabelValues(worker).Set(float64(volumes))
 }
 
+func (emitter *PrometheusEmitter) volumeStreamedMetric(logger lager.Logger, event metric.Event) {
+	mode, exists := event.Attributes["mode"]
+	if !exists {
+		logger.Error("failed-to-find-mode-in-event", fmt.Errorf("expected mode to exist in event.Attributes"))
+	}
+
+	encoding, exists := event.Attributes["encoding"]
+	if !exists {
+		logger.Error("failed-to-find-encoding-in-event", fmt.Errorf("expected encoding to exist in event.Attributes"))
+	}
+
+	bytes, ok := event.Value.(int)
+	if !ok {
+		logger.Error("volume-streamed-event-value-type-mismatch", fmt.Errorf("expected event.Value to be an int"))
+	}
+
+	// concourse_volumes_streamed_bytes_total
+	emitter.volumesStreamedBytes.WithLabelValues(mode, encoding).Add(float64(bytes))
+}
+
+func (emitter *PrometheusEmitter) volumeStreamingDurationMetric(logger lager.Logger, event metric.Event) {
+	mode, exists := event.Attributes["mode"]
+	if !exists {
+		logger.Error("failed-to-find-mode-in-event", fmt.Errorf("expected mode to exist in event.Attributes"))
+	}
+
+	// only streams through the ATC have an encoding
+	encoding := event.Attributes["encoding"]
+
+	duration, ok := event.Value.(float64)
+	if !ok {
+		logger.Error("volume-streaming-duration-event-value-type-mismatch", fmt.Errorf("expected event.Value to be a float64"))
+	}
+
+	// concourse_volumes_streaming_duration_seconds
+	emitter.volumesStreamingDuration.WithLabelValues(mode, encoding).Observe(duration / 1000)
+}
+
+func (emitter *PrometheusEmitter) volumeStreamDeduplicatedMetric(logger lager.Logger, event metric.Event) {
+	worker, exists := event.Attributes["worker"]
+	if !exists {
+		logger.Error("failed-to-find-worker-in-event", fmt.Errorf("expected worker to exist in event.Attributes"))
+	}
+
+	// concourse_volumes_stream_deduplicated_total
+	emitter.volumesStreamDeduplicated.WithLabelValues(worker).Inc()
+}
+
 func (emitter *PrometheusEmitter) httpResponseTimeMetrics(logger lager.Logger, event metric.Event) {
 	route, exists := event.Attributes["route"]
 	if !exists {
@@ -0,0 +1,215 @@
+package emitter_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc/metric"
+	"github.com/concourse/atc/metric/emitter"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PrometheusEmitter", func() {
+	// the metrics are registered with the default registry, so the emitter
+	// can only be made once per process; each test uses its own label values
+	// so that they do not see each other's metrics
+	var (
+		config *emitter.PrometheusConfig
+
+		prometheus metric.Emitter
+		logger     *lagertest.TestLogger
+	)
+
+	BeforeEach(func() {
+		logger = lagertest.NewTestLogger("test")
+
+		if prometheus != nil {
+			return
+		}
+
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		Expect(err).NotTo(HaveOccurred())
+
+		port := listener.Addr().(*net.TCPAddr).Port
+		Expect(listener.Close()).To(Succeed())
+
+		config = &emitter.PrometheusConfig{
+			BindIP:   "127.0.0.1",
+			BindPort: strconv.Itoa(port),
+		}
+
+		prometheus, err = config.NewEmitter()
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	scrape := func() []string {
+		response, err := http.Get(fmt.Sprintf("http://%s:%s/metrics", config.BindIP, config.BindPort))
+		Expect(err).NotTo(HaveOccurred())
+
+		defer response.Body.Close()
+
+		body, err := ioutil.ReadAll(response.Body)
+		Expect(err).NotTo(HaveOccurred())
+
+		return strings.Split(string(body), "\n")
+	}
+
+	It("is configured by its address", func() {
+		Expect(config.IsConfigured()).To(BeTrue())
+		Expect((&emitter.PrometheusConfig{BindPort: "9391"}).IsConfigured()).To(BeFalse())
+	})
+
+	It("counts builds started", func() {
+		before := scrape()
+
+		prometheus.Emit(logger, metric.Event{Name: "build started"})
+
+		Expect(counterValue(scrape(), "concourse_builds_started_total")).To(Equal(counterValue(before, "concourse_builds_started_total") + 1))
+	})
+
+	Context("when a build of a job finishes", func() {
+		BeforeEach(func() {
+			prometheus.Emit(logger, metric.Event{
+				Name:  "build finished",
+				Value: 2000.0,
+				Attributes: map[string]string{
+					"team_name":    "build-team",
+					"pipeline":     "build-pipeline",
+					"job":          "build-job",
+					"build_status": "succeeded",
+				},
+			})
+		})
+
+		It("counts the build by team, pipeline and status", func() {
+			Expect(scrape()).To(ContainElement(`concourse_builds_finished{pipeline="build-pipeline",status="succeeded",team="build-team"} 1`))
+		})
+
+		It("observes the build's duration in seconds", func() {
+			metrics := scrape()
+			Expect(metrics).To(ContainElement(`concourse_builds_duration_seconds_sum{pipeline="build-pipeline",team="build-team"} 2`))
+			Expect(metrics).To(ContainElement(`concourse_builds_duration_seconds_count{pipeline="build-pipeline",team="build-team"} 1`))
+		})
+
+		It("counts and observes the build by job", func() {
+			metrics := scrape()
+			Expect(metrics).To(ContainElement(`concourse_jobs_builds_finished_total{job="build-job",pipeline="build-pipeline",status="succeeded",team="build-team"} 1`))
+			Expect(metrics).To(ContainElement(`concourse_jobs_build_duration_seconds_sum{job="build-job",pipeline="build-pipeline",status="succeeded",team="build-team"} 2`))
+		})
+	})
+
+	Context("when a one-off build finishes", func() {
+		BeforeEach(func() {
+			prometheus.Emit(logger, metric.Event{
+				Name:  "build finished",
+				Value: 1000.0,
+				Attributes: map[string]string{
+					"team_name":    "one-off-team",
+					"pipeline":     "",
+					"build_status": "failed",
+				},
+			})
+		})
+
+		It("counts the build without a job", func() {
+			metrics := scrape()
+			Expect(metrics).To(ContainElement(`concourse_builds_finished{pipeline="",status="failed",team="one-off-team"} 1`))
+			Expect(metrics).NotTo(ContainElement(ContainSubstring(`concourse_jobs_builds_finished_total{job="",pipeline="",status="failed",team="one-off-team"}`)))
+		})
+	})
+
+	It("observes step durations in seconds by team, pipeline, job, type and status", func() {
+		prometheus.Emit(logger, metric.Event{
+			Name:  "step finished",
+			Value: 1500.0,
+			Attributes: map[string]string{
+				"team_name":   "step-team",
+				"pipeline":    "step-pipeline",
+				"job":         "step-job",
+				"step_type":   "task",
+				"step_status": "succeeded",
+			},
+		})
+
+		Expect(scrape()).To(ContainElement(`concourse_steps_duration_seconds_sum{job="step-job",pipeline="step-pipeline",status="succeeded",team="step-team",type="task"} 1.5`))
+	})
+
+	It("sets the containers and volumes of each worker", func() {
+		prometheus.Emit(logger, metric.Event{Name: "worker containers", Value: 3, Attributes: map[string]string{"worker": "gauge-worker"}})
+		prometheus.Emit(logger, metric.Event{Name: "worker volumes", Value: 7, Attributes: map[string]string{"worker": "gauge-worker"}})
+		prometheus.Emit(logger, metric.Event{Name: "worker containers", Value: 2, Attributes: map[string]string{"worker": "gauge-worker"}})
+
+		metrics := scrape()
+		Expect(metrics).To(ContainElement(`concourse_workers_containers{worker="gauge-worker"} 2`))
+		Expect(metrics).To(ContainElement(`concourse_workers_volumes{worker="gauge-worker"} 7`))
+	})
+
+	It("adds up the bytes of volumes streamed by mode and encoding", func() {
+		prometheus.Emit(logger, metric.Event{Name: "volume streamed", Value: 2048, Attributes: map[string]string{"mode": "p2p", "encoding": "raw"}})
+		prometheus.Emit(logger, metric.Event{Name: "volume streamed", Value: 1024, Attributes: map[string]string{"mode": "p2p", "encoding": "raw"}})
+		prometheus.Emit(logger, metric.Event{Name: "volume streamed", Value: 512, Attributes: map[string]string{"mode": "atc", "encoding": "zstd"}})
+
+		metrics := scrape()
+		Expect(metrics).To(ContainElement(`concourse_volumes_streamed_bytes_total{encoding="raw",mode="p2p"} 3072`))
+		Expect(metrics).To(ContainElement(`concourse_volumes_streamed_bytes_total{encoding="zstd",mode="atc"} 512`))
+	})
+
+	It("observes volume streaming durations in seconds by mode and encoding", func() {
+		prometheus.Emit(logger, metric.Event{
+			Name:       "volume streaming duration (ms)",
+			Value:      250.0,
+			Attributes: map[string]string{"mode": "atc", "encoding": "zstd"},
+		})
+
+		metrics := scrape()
+		Expect(metrics).To(ContainElement(`concourse_volumes_streaming_duration_seconds_sum{encoding="zstd",mode="atc"} 0.25`))
+		Expect(metrics).To(ContainElement(`concourse_volumes_streaming_duration_seconds_count{encoding="zstd",mode="atc"} 1`))
+	})
+
+	It("counts deduplicated volume streams by worker", func() {
+		prometheus.Emit(logger, metric.Event{Name: "volume stream deduplicated", Attributes: map[string]string{"worker": "dedup-worker"}})
+
+		Expect(scrape()).To(ContainElement(`concourse_volumes_stream_deduplicated_total{worker="dedup-worker"} 1`))
+	})
+
+	It("observes http response times in seconds by method and route", func() {
+		prometheus.Emit(logger, metric.Event{
+			Name:       "http response time",
+			Value:      100.0,
+			Attributes: map[string]string{"method": "GET", "route": "ListWorkers"},
+		})
+
+		Expect(scrape()).To(ContainElement(`concourse_http_responses_duration_seconds_sum{method="GET",route="ListWorkers"} 0.1`))
+	})
+
+	It("sets the last scheduling durations of each pipeline in seconds", func() {
+		prometheus.Emit(logger, metric.Event{Name: "scheduling: full duration (ms)", Value: 500.0, Attributes: map[string]string{"pipeline": "scheduled-pipeline"}})
+		prometheus.Emit(logger, metric.Event{Name: "scheduling: loading versions duration (ms)", Value: 250.0, Attributes: map[string]string{"pipeline": "scheduled-pipeline"}})
+		prometheus.Emit(logger, metric.Event{Name: "scheduling: job duration (ms)", Value: 125.0, Attributes: map[string]string{"pipeline": "scheduled-pipeline"}})
+
+		metrics := scrape()
+		Expect(metrics).To(ContainElement(`concourse_scheduling_full_duration_seconds{pipeline="scheduled-pipeline"} 0.5`))
+		Expect(metrics).To(ContainElement(`concourse_scheduling_loading_duration_seconds{pipeline="scheduled-pipeline"} 0.25`))
+		Expect(metrics).To(ContainElement(`concourse_scheduling_job_duration_seconds{pipeline="scheduled-pipeline"} 0.125`))
+	})
+})
+
+func counterValue(metrics []string, name string) float64 {
+	for _, line := range metrics {
+		if strings.HasPrefix(line, name+" ") {
+			value, err := strconv.ParseFloat(strings.TrimPrefix(line, name+" "), 64)
+			Expect(err).NotTo(HaveOccurred())
+			return value
+		}
+	}
+
+	Fail("metric not found: " + name)
+	return 0
+}
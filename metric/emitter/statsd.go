@@ -0,0 +1,245 @@
+package emitter
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/metric"
+)
+
+const (
+	StatsDTagFormatNone      = "none"
+	StatsDTagFormatDogStatsD = "dogstatsd"
+)
+
+type StatsDEmitter struct {
+	conn          net.Conn
+	prefix        string
+	tagFormat     string
+	maxPacketSize int
+
+	bufferL sync.Mutex
+	buffer  bytes.Buffer
+
+	stop      chan struct{}
+	stopped   *sync.WaitGroup
+	closeOnce sync.Once
+	closeErr  error
+}
+
+type StatsDConfig struct {
+	Host   string `long:"statsd-host"                        description:"StatsD agent address to emit metrics to."`
+	Port   uint16 `long:"statsd-port"   default:"8125"       description:"Port of the StatsD agent to emit metrics to."`
+	Prefix string `long:"statsd-prefix" default:"concourse." description:"Prefix for the names of emitted metrics."`
+
+	TagFormat string `long:"statsd-tag-format" default:"none" choice:"none" choice:"dogstatsd" description:"How to send the attributes of metrics. Plain StatsD has no tags, so they are only sent in the DogStatsD format."`
+
+	MaxPacketSize int           `long:"statsd-max-packet-size" default:"1432" description:"Maximum size in bytes of the UDP packets the metrics are batched into."`
+	FlushInterval time.Duration `long:"statsd-flush-interval"  default:"1s"   description:"Interval on which batched metrics are sent even if the packet is not full."`
+}
+
+func init() {
+	metric.RegisterEmitter(&StatsDConfig{})
+}
+
+func (config *StatsDConfig) Description() string { return "StatsD" }
+func (config *StatsDConfig) IsConfigured() bool  { return config.Host != "" }
+
+func (config *StatsDConfig) NewEmitter() (metric.Emitter, error) {
+	conn, err := net.Dial("udp", net.JoinHostPort(config.Host, strconv.Itoa(int(config.Port))))
+	if err != nil {
+		return nil, err
+	}
+
+	emitter := &StatsDEmitter{
+		conn:          conn,
+		prefix:        config.Prefix,
+		tagFormat:     config.TagFormat,
+		maxPacketSize: config.MaxPacketSize,
+
+		stop:    make(chan struct{}),
+		stopped: new(sync.WaitGroup),
+	}
+
+	if config.FlushInterval > 0 {
+		emitter.stopped.Add(1)
+		go emitter.flushPeriodically(config.FlushInterval)
+	}
+
+	return emitter, nil
+}
+
+// Emit adds the event to the batch of metrics to send, sending the batch
+// first if the event does not fit in its packet.
+func (emitter *StatsDEmitter) Emit(logger lager.Logger, event metric.Event) {
+	line, err := emitter.format(event)
+	if err != nil {
+		logger.Error("failed-to-format-event", err, lager.Data{"event": event.Name})
+		return
+	}
+
+	emitter.bufferL.Lock()
+	defer emitter.bufferL.Unlock()
+
+	if emitter.buffer.Len() > 0 && emitter.buffer.Len()+1+len(line) > emitter.maxPacketSize {
+		err := emitter.flush()
+		if err != nil {
+			logger.Error("failed-to-send-metrics", err)
+		}
+	}
+
+	if emitter.buffer.Len() > 0 {
+		emitter.buffer.WriteByte('\n')
+	}
+
+	emitter.buffer.WriteString(line)
+}
+
+// Close stops sending the batch periodically, sends what is left of it and
+// closes the connection. It is safe to call more than once.
+func (emitter *StatsDEmitter) Close() error {
+	emitter.closeOnce.Do(func() {
+		close(emitter.stop)
+		emitter.stopped.Wait()
+
+		emitter.bufferL.Lock()
+		flushErr := emitter.flush()
+		emitter.bufferL.Unlock()
+
+		emitter.closeErr = emitter.conn.Close()
+		if flushErr != nil {
+			emitter.closeErr = flushErr
+		}
+	})
+
+	return emitter.closeErr
+}
+
+func (emitter *StatsDEmitter) flushPeriodically(interval time.Duration) {
+	defer emitter.stopped.Done()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-emitter.stop:
+			return
+		case <-ticker.C:
+			emitter.bufferL.Lock()
+			// sending a UDP packet only fails locally, and there is no logger to
+			// tell about it here; the next Emit will log the same error
+			_ = emitter.flush()
+			emitter.bufferL.Unlock()
+		}
+	}
+}
+
+// flush sends the batched metrics. It must be called with the buffer locked.
+func (emitter *StatsDEmitter) flush() error {
+	if emitter.buffer.Len() == 0 {
+		return nil
+	}
+
+	_, err := emitter.conn.Write(emitter.buffer.Bytes())
+	emitter.buffer.Reset()
+
+	return err
+}
+
+// statsDTimers are the events whose values are durations in milliseconds.
+var statsDTimers = map[string]bool{
+	"build finished":                             true,
+	"step finished":                              true,
+	"http response time":                         true,
+	"scheduling: full duration (ms)":             true,
+	"scheduling: loading versions duration (ms)": true,
+	"scheduling: job duration (ms)":              true,
+	"volume streaming duration (ms)":             true,
+}
+
+// statsDCounters are the events which count occurrences, whatever their value.
+var statsDCounters = map[string]bool{
+	"build started":                      true,
+	"GC container collector job dropped": true,
+	"GC volume collector job dropped":    true,
+	"vault secret cache hit":             true,
+	"vault secret cache miss":            true,
+	"volume stream deduplicated":         true,
+}
+
+// statsDSums are the events which count their values, like bytes transferred.
+var statsDSums = map[string]bool{
+	"volume streamed": true,
+}
+
+func (emitter *StatsDEmitter) format(event metric.Event) (string, error) {
+	var value string
+	switch v := event.Value.(type) {
+	case int:
+		value = strconv.Itoa(v)
+	case float64:
+		value = strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return "", fmt.Errorf("unsupported value type %T", event.Value)
+	}
+
+	metricType := "g"
+	switch {
+	case statsDTimers[event.Name]:
+		metricType = "ms"
+	case statsDCounters[event.Name]:
+		metricType = "c"
+		value = "1"
+	case statsDSums[event.Name]:
+		metricType = "c"
+	}
+
+	line := fmt.Sprintf("%s%s:%s|%s", emitter.prefix, statsDName(event.Name), value, metricType)
+
+	if emitter.tagFormat == StatsDTagFormatDogStatsD {
+		line += statsDTags(event)
+	}
+
+	return line, nil
+}
+
+// statsDName turns an event name like "scheduling: job duration (ms)" into a
+// metric name like "scheduling.job_duration_ms".
+func statsDName(name string) string {
+	name = strings.ToLower(name)
+	name = strings.NewReplacer(": ", ".", " ", "_", "(", "", ")", "").Replace(name)
+	return name
+}
+
+func statsDTags(event metric.Event) string {
+	tags := []string{}
+
+	if event.Host != "" {
+		tags = append(tags, "host:"+statsDTagValue(event.Host))
+	}
+
+	for k, v := range event.Attributes {
+		tags = append(tags, statsDTagValue(k)+":"+statsDTagValue(v))
+	}
+
+	if len(tags) == 0 {
+		return ""
+	}
+
+	sort.Strings(tags)
+
+	return "|#" + strings.Join(tags, ",")
+}
+
+// statsDTagValue replaces the characters which delimit DogStatsD tags.
+func statsDTagValue(value string) string {
+	return strings.NewReplacer(",", "_", "|", "_", "#", "_", "\n", "_").Replace(value)
+}
@@ -0,0 +1,182 @@
+package emitter_test
+
+import (
+	"io"
+	"net"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc/metric"
+	"github.com/concourse/atc/metric/emitter"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("StatsDEmitter", func() {
+	var (
+		listener net.PacketConn
+		config   *emitter.StatsDConfig
+
+		statsd metric.Emitter
+		logger *lagertest.TestLogger
+	)
+
+	BeforeEach(func() {
+		var err error
+		listener, err = net.ListenPacket("udp", "127.0.0.1:0")
+		Expect(err).NotTo(HaveOccurred())
+
+		config = &emitter.StatsDConfig{
+			Host:          "127.0.0.1",
+			Port:          uint16(listener.LocalAddr().(*net.UDPAddr).Port),
+			Prefix:        "concourse.",
+			TagFormat:     emitter.StatsDTagFormatNone,
+			MaxPacketSize: 1432,
+			FlushInterval: 10 * time.Millisecond,
+		}
+
+		logger = lagertest.NewTestLogger("test")
+	})
+
+	AfterEach(func() {
+		Expect(statsd.(io.Closer).Close()).To(Succeed())
+		Expect(listener.Close()).To(Succeed())
+	})
+
+	JustBeforeEach(func() {
+		var err error
+		statsd, err = config.NewEmitter()
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	readPacket := func() []string {
+		buf := make([]byte, 65536)
+
+		err := listener.SetReadDeadline(time.Now().Add(time.Second))
+		Expect(err).NotTo(HaveOccurred())
+
+		n, _, err := listener.ReadFrom(buf)
+		Expect(err).NotTo(HaveOccurred())
+
+		return strings.Split(string(buf[:n]), "\n")
+	}
+
+	It("is configured by its host", func() {
+		Expect(config.IsConfigured()).To(BeTrue())
+		Expect((&emitter.StatsDConfig{}).IsConfigured()).To(BeFalse())
+	})
+
+	It("sends durations as timers", func() {
+		statsd.Emit(logger, metric.Event{
+			Name:  "scheduling: job duration (ms)",
+			Value: 12.5,
+			Attributes: map[string]string{
+				"pipeline": "some-pipeline",
+			},
+		})
+
+		Expect(readPacket()).To(Equal([]string{"concourse.scheduling.job_duration_ms:12.5|ms"}))
+	})
+
+	It("sends counts as counters", func() {
+		statsd.Emit(logger, metric.Event{Name: "build started", Value: 42})
+
+		Expect(readPacket()).To(Equal([]string{"concourse.build_started:1|c"}))
+	})
+
+	It("sends byte counts as counters of their value", func() {
+		statsd.Emit(logger, metric.Event{Name: "volume streamed", Value: 2048})
+
+		Expect(readPacket()).To(Equal([]string{"concourse.volume_streamed:2048|c"}))
+	})
+
+	It("sends everything else as gauges", func() {
+		statsd.Emit(logger, metric.Event{Name: "worker containers", Value: 3})
+
+		Expect(readPacket()).To(Equal([]string{"concourse.worker_containers:3|g"}))
+	})
+
+	It("does not send events without a numeric value", func() {
+		statsd.Emit(logger, metric.Event{Name: "worker containers", Value: "three"})
+		statsd.Emit(logger, metric.Event{Name: "worker volumes", Value: 4})
+
+		Expect(readPacket()).To(Equal([]string{"concourse.worker_volumes:4|g"}))
+	})
+
+	Context("when the batch fills a packet", func() {
+		BeforeEach(func() {
+			config.MaxPacketSize = len("concourse.worker_containers:3|g\nconcourse.worker_volumes:4|g")
+			config.FlushInterval = time.Hour
+		})
+
+		It("sends the batched metrics together before adding to it", func() {
+			statsd.Emit(logger, metric.Event{Name: "worker containers", Value: 3})
+			statsd.Emit(logger, metric.Event{Name: "worker volumes", Value: 4})
+			statsd.Emit(logger, metric.Event{Name: "worker containers", Value: 5})
+
+			Expect(readPacket()).To(Equal([]string{
+				"concourse.worker_containers:3|g",
+				"concourse.worker_volumes:4|g",
+			}))
+		})
+	})
+
+	Context("when closed", func() {
+		BeforeEach(func() {
+			config.FlushInterval = time.Hour
+		})
+
+		It("sends what is left of the batch", func() {
+			statsd.Emit(logger, metric.Event{Name: "worker containers", Value: 3})
+
+			Expect(statsd.(io.Closer).Close()).To(Succeed())
+
+			Expect(readPacket()).To(Equal([]string{"concourse.worker_containers:3|g"}))
+		})
+
+		It("can be closed again", func() {
+			Expect(statsd.(io.Closer).Close()).To(Succeed())
+			Expect(statsd.(io.Closer).Close()).To(Succeed())
+		})
+	})
+
+	Context("when the tag format is DogStatsD", func() {
+		BeforeEach(func() {
+			config.TagFormat = emitter.StatsDTagFormatDogStatsD
+		})
+
+		It("sends the host and attributes as tags", func() {
+			statsd.Emit(logger, metric.Event{
+				Name:  "build finished",
+				Value: 1500.0,
+				Host:  "some-host",
+				Attributes: map[string]string{
+					"pipeline":     "some-pipeline",
+					"build_status": "succeeded",
+					"job":          "some,job",
+				},
+			})
+
+			Expect(readPacket()).To(Equal([]string{
+				"concourse.build_finished:1500|ms|#build_status:succeeded,host:some-host,job:some_job,pipeline:some-pipeline",
+			}))
+		})
+
+		It("tags the bytes of volumes streamed with the encoding they were counted in", func() {
+			statsd.Emit(logger, metric.Event{
+				Name:  "volume streamed",
+				Value: 2048,
+				Attributes: map[string]string{
+					"mode":     "atc",
+					"encoding": "zstd",
+				},
+			})
+
+			Expect(readPacket()).To(Equal([]string{
+				"concourse.volume_streamed:2048|c|#encoding:zstd,mode:atc",
+			}))
+		})
+	})
+})
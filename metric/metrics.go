@@ -20,6 +20,8 @@ var FailedVolumes = Meter(0)
 var ContainersDeleted = Meter(0)
 var VolumesDeleted = Meter(0)
 
+var ContainersWaitingForWorker = &Gauge{}
+
 type SchedulingFullDuration struct {
 	PipelineName string
 	Duration     time.Duration
@@ -295,6 +297,55 @@ func (event GarbageCollectionVolumeCollectorJobDropped) Emit(logger lager.Logger
 	)
 }
 
+type BuildLogsReaped struct {
+	TeamName     string
+	PipelineName string
+	JobName      string
+	Builds       int
+}
+
+func (event BuildLogsReaped) Emit(logger lager.Logger) {
+	emit(
+		logger.Session("build-logs-reaped"),
+		Event{
+			Name:  "build logs reaped",
+			Value: event.Builds,
+			State: EventStateOK,
+			Attributes: map[string]string{
+				"team_name": event.TeamName,
+				"pipeline":  event.PipelineName,
+				"job":       event.JobName,
+			},
+		},
+	)
+}
+
+type VaultSecretCacheHit struct{}
+
+func (event VaultSecretCacheHit) Emit(logger lager.Logger) {
+	emit(
+		logger.Session("vault-secret-cache-hit"),
+		Event{
+			Name:  "vault secret cache hit",
+			Value: 1,
+			State: EventStateOK,
+		},
+	)
+}
+
+type VaultSecretCacheMiss struct{}
+
+func (event VaultSecretCacheMiss) Emit(logger lager.Logger) {
+	emit(
+		logger.Session("vault-secret-cache-miss"),
+		Event{
+			Name:  "vault secret cache miss",
+			Value: 1,
+			State: EventStateOK,
+		},
+	)
+}
+
 type BuildStarted struct {
 	PipelineName string
 	JobName      string
@@ -350,6 +401,39 @@ func (event BuildFinished) Emit(logger lager.Logger) {
 	)
 }
 
+type StepFinished struct {
+	PipelineName string
+	JobName      string
+	BuildName    string
+	BuildID      int
+	TeamName     string
+	StepType     string
+	StepName     string
+	StepStatus   db.BuildStatus
+	StepDuration time.Duration
+}
+
+func (event StepFinished) Emit(logger lager.Logger) {
+	emit(
+		logger.Session("step-finished"),
+		Event{
+			Name:  "step finished",
+			Value: ms(event.StepDuration),
+			State: EventStateOK,
+			Attributes: map[string]string{
+				"pipeline":    event.PipelineName,
+				"job":         event.JobName,
+				"build_name":  event.BuildName,
+				"build_id":    strconv.Itoa(event.BuildID),
+				"team_name":   event.TeamName,
+				"step_type":   event.StepType,
+				"step_name":   event.StepName,
+				"step_status": string(event.StepStatus),
+			},
+		},
+	)
+}
+
 func ms(duration time.Duration) float64 {
 	return float64(duration) / 1000000
 }
@@ -386,3 +470,85 @@ func (event HTTPResponseTime) Emit(logger lager.Logger) {
 		},
 	)
 }
+
+const (
+	VolumeStreamedP2P = "p2p"
+	VolumeStreamedATC = "atc"
+)
+
+// VolumeStreamed counts the bytes of a volume streamed between workers, in
+// the encoding they were counted in: as sent through the ATC, or uncompressed
+// for P2P streams.
+type VolumeStreamed struct {
+	SourceWorker      string
+	DestinationWorker string
+	Mode              string
+	Encoding          string
+	Bytes             int
+}
+
+func (event VolumeStreamed) Emit(logger lager.Logger) {
+	emit(
+		logger.Session("volume-streamed"),
+		Event{
+			Name:       "volume streamed",
+			Value:      event.Bytes,
+			State:      EventStateOK,
+			Attributes: volumeStreamingAttributes(event.SourceWorker, event.DestinationWorker, event.Mode, event.Encoding),
+		},
+	)
+}
+
+type VolumeStreamingDuration struct {
+	SourceWorker      string
+	DestinationWorker string
+	Mode              string
+	Encoding          string
+	Duration          time.Duration
+}
+
+func (event VolumeStreamingDuration) Emit(logger lager.Logger) {
+	emit(
+		logger.Session("volume-streaming-duration"),
+		Event{
+			Name:       "volume streaming duration (ms)",
+			Value:      ms(event.Duration),
+			State:      EventStateOK,
+			Attributes: volumeStreamingAttributes(event.SourceWorker, event.DestinationWorker, event.Mode, event.Encoding),
+		},
+	)
+}
+
+// volumeStreamingAttributes leaves out the encoding if it is not known, as for
+// the duration of P2P streams, which the ATC does not see.
+func volumeStreamingAttributes(sourceWorker string, destinationWorker string, mode string, encoding string) map[string]string {
+	attributes := map[string]string{
+		"source_worker":      sourceWorker,
+		"destination_worker": destinationWorker,
+		"mode":               mode,
+	}
+
+	if encoding != "" {
+		attributes["encoding"] = encoding
+	}
+
+	return attributes
+}
+
+type VolumeStreamDeduplicated struct {
+	WorkerName string
+}
+
+func (event VolumeStreamDeduplicated) Emit(logger lager.Logger) {
+	emit(
+		logger.Session("volume-stream-deduplicated"),
+		Event{
+			Name:  "volume stream deduplicated",
+			Value: 1,
+			State: EventStateOK,
+			Attributes: map[string]string{
+				"worker": event.WorkerName,
+			},
+		},
+	)
+}
@@ -75,6 +75,15 @@ func PeriodicallyEmit(logger lager.Logger, interval time.Duration) {
 			},
 		)
 
+		emit(
+			logger.Session("containers-waiting-for-worker"),
+			Event{
+				Name:  "containers waiting for worker",
+				Value: ContainersWaitingForWorker.Max(),
+				State: EventStateOK,
+			},
+		)
+
 		emit(
 			logger.Session("failed-containers"),
 			Event{
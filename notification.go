@@ -0,0 +1,97 @@
+package atc
+
+// NotificationConfig configures an HTTP endpoint to notify when the builds of
+// a pipeline's jobs change state. No events or jobs means every event of
+// every job.
+type NotificationConfig struct {
+	Name   string        `yaml:"name" json:"name" mapstructure:"name"`
+	URL    string        `yaml:"url" json:"url" mapstructure:"url"`
+	Secret string        `yaml:"secret,omitempty" json:"secret,omitempty" mapstructure:"secret"`
+	Events []BuildStatus `yaml:"events,omitempty" json:"events,omitempty" mapstructure:"events"`
+	Jobs   []string      `yaml:"jobs,omitempty" json:"jobs,omitempty" mapstructure:"jobs"`
+}
+
+type NotificationConfigs []NotificationConfig
+
+func (notifications NotificationConfigs) Lookup(name string) (NotificationConfig, bool) {
+	for _, notification := range notifications {
+		if notification.Name == name {
+			return notification, true
+		}
+	}
+
+	return NotificationConfig{}, false
+}
+
+// NotificationEvents are the build statuses notifications can be sent for.
+var NotificationEvents = []BuildStatus{
+	StatusStarted,
+	StatusSucceeded,
+	StatusFailed,
+	StatusErrored,
+	StatusAborted,
+}
+
+// Matches returns whether the notification is to be sent when a build of the
+// given job changes to the given status.
+func (config NotificationConfig) Matches(jobName string, status BuildStatus) bool {
+	if len(config.Events) > 0 && !containsStatus(config.Events, status) {
+		return false
+	}
+
+	if len(config.Jobs) > 0 && !containsString(config.Jobs, jobName) {
+		return false
+	}
+
+	return true
+}
+
+// NotificationSignatureHeader carries the hex-encoded HMAC-SHA256 of the
+// payload, keyed with the notification's secret.
+const NotificationSignatureHeader = "X-Concourse-Signature"
+
+// NotificationPayload is the JSON body POSTed to a notification's URL.
+type NotificationPayload struct {
+	Notification string      `json:"notification"`
+	Event        BuildStatus `json:"event"`
+	Build        Build       `json:"build"`
+}
+
+type Notification struct {
+	Name         string                `json:"name"`
+	URL          string                `json:"url"`
+	Events       []BuildStatus         `json:"events,omitempty"`
+	Jobs         []string              `json:"jobs,omitempty"`
+	LastDelivery *NotificationDelivery `json:"last_delivery,omitempty"`
+}
+
+type NotificationDelivery struct {
+	ID              int         `json:"id"`
+	BuildID         int         `json:"build_id"`
+	Event           BuildStatus `json:"event"`
+	Status          string      `json:"status"`
+	Attempts        int         `json:"attempts"`
+	LastAttemptTime int64       `json:"last_attempt_time,omitempty"`
+	ResponseStatus  int         `json:"response_status,omitempty"`
+	Error           string      `json:"error,omitempty"`
+}
+
+func containsStatus(statuses []BuildStatus, status BuildStatus) bool {
+	for _, s := range statuses {
+		if s == status {
+			return true
+		}
+	}
+
+	return false
+}
+
+func containsString(strs []string, str string) bool {
+	for _, s := range strs {
+		if s == str {
+			return true
+		}
+	}
+
+	return false
+}
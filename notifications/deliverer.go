@@ -0,0 +1,208 @@
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
+	"github.com/concourse/atc/db"
+)
+
+const (
+	// MaxAttempts is how many times a notification is POSTed before giving
+	// up on it.
+	MaxAttempts = 10
+
+	batchSize = 100
+
+	// maxInFlight is how many notifications are POSTed at once.
+	maxInFlight = 10
+
+	// maxRunTime bounds how long a run may take. Deliveries not started by
+	// then are left due for the next run, and those still in flight are
+	// cancelled and retried later.
+	maxRunTime = time.Minute
+
+	// finishedRetention is how long delivered and failed deliveries are kept
+	// before they are deleted. The last delivery of each notification is kept
+	// regardless, to be shown with the notification.
+	finishedRetention = 7 * 24 * time.Hour
+
+	initialBackoff = 10 * time.Second
+	maxBackoff     = time.Hour
+)
+
+// Deliverer POSTs the due notifications in the outbox to their endpoints.
+type Deliverer interface {
+	Run() error
+}
+
+type deliverer struct {
+	logger           lager.Logger
+	outbox           db.NotificationOutbox
+	httpClient       *http.Client
+	variablesFactory creds.VariablesFactory
+	clock            clock.Clock
+}
+
+func NewDeliverer(
+	logger lager.Logger,
+	outbox db.NotificationOutbox,
+	httpClient *http.Client,
+	variablesFactory creds.VariablesFactory,
+	clock clock.Clock,
+) Deliverer {
+	return &deliverer{
+		logger:           logger,
+		outbox:           outbox,
+		httpClient:       httpClient,
+		variablesFactory: variablesFactory,
+		clock:            clock,
+	}
+}
+
+func (d *deliverer) Run() error {
+	d.logger.Debug("start")
+	defer d.logger.Debug("done")
+
+	deliveries, err := d.outbox.DueDeliveries(batchSize)
+	if err != nil {
+		d.logger.Error("failed-to-get-due-deliveries", err)
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), maxRunTime)
+	defer cancel()
+
+	deadline := d.clock.Now().Add(maxRunTime)
+
+	wg := new(sync.WaitGroup)
+	inFlight := make(chan struct{}, maxInFlight)
+
+	for i, delivery := range deliveries {
+		inFlight <- struct{}{}
+
+		if d.clock.Now().After(deadline) {
+			d.logger.Info("ran-out-of-time", lager.Data{"remaining": len(deliveries) - i})
+			<-inFlight
+			break
+		}
+
+		logger := d.logger.Session("deliver", lager.Data{
+			"delivery":     delivery.ID,
+			"notification": delivery.Config.Name,
+			"build":        delivery.BuildID,
+		})
+
+		wg.Add(1)
+		go func(delivery db.NotificationDelivery) {
+			defer wg.Done()
+			defer func() { <-inFlight }()
+
+			err := d.deliver(ctx, logger, delivery)
+			if err != nil {
+				logger.Error("failed-to-record-delivery", err)
+			}
+		}(delivery)
+	}
+
+	wg.Wait()
+
+	err = d.outbox.DeleteFinished(d.clock.Now().Add(-finishedRetention))
+	if err != nil {
+		d.logger.Error("failed-to-delete-finished-deliveries", err)
+		return err
+	}
+
+	return nil
+}
+
+func (d *deliverer) deliver(ctx context.Context, logger lager.Logger, delivery db.NotificationDelivery) error {
+	responseStatus, err := d.post(ctx, delivery)
+	if err == nil {
+		logger.Debug("delivered", lager.Data{"status": responseStatus})
+		return d.outbox.Delivered(delivery, responseStatus)
+	}
+
+	attempts := delivery.Attempts + 1
+	if attempts >= MaxAttempts {
+		logger.Info("giving-up", lager.Data{"attempts": attempts, "error": err.Error()})
+		return d.outbox.GiveUp(delivery, responseStatus, err)
+	}
+
+	logger.Info("will-retry", lager.Data{"attempts": attempts, "error": err.Error()})
+
+	return d.outbox.Retry(delivery, responseStatus, err, d.clock.Now().Add(backoff(attempts)))
+}
+
+func (d *deliverer) post(ctx context.Context, delivery db.NotificationDelivery) (int, error) {
+	payload := []byte(delivery.Payload)
+
+	req, err := http.NewRequest("POST", delivery.Config.URL, bytes.NewReader(payload))
+	if err != nil {
+		return 0, err
+	}
+
+	req = req.WithContext(ctx)
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Concourse-Event", string(delivery.Event))
+	req.Header.Set("X-Concourse-Delivery", strconv.Itoa(delivery.ID))
+
+	if delivery.Config.Secret != "" {
+		variables := d.variablesFactory.NewVariables(delivery.TeamName, delivery.PipelineName)
+
+		secret, err := creds.NewString(variables, delivery.Config.Secret).Evaluate()
+		if err != nil {
+			return 0, err
+		}
+
+		req.Header.Set(atc.NotificationSignatureHeader, "sha256="+Sign(secret, payload))
+	}
+
+	response, err := d.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return response.StatusCode, fmt.Errorf("unexpected response: %s", response.Status)
+	}
+
+	return response.StatusCode, nil
+}
+
+// Sign returns the hex-encoded HMAC-SHA256 of the payload, keyed with the
+// secret, as sent in the signature header.
+func Sign(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// backoff doubles the time between attempts, starting at initialBackoff and
+// leveling off at maxBackoff.
+func backoff(attempts int) time.Duration {
+	wait := initialBackoff
+	for i := 1; i < attempts; i++ {
+		wait *= 2
+		if wait >= maxBackoff {
+			return maxBackoff
+		}
+	}
+
+	return wait
+}
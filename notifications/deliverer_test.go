@@ -0,0 +1,277 @@
+package notifications_test
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/cloudfoundry/bosh-cli/director/template"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds/credsfakes"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/dbfakes"
+	"github.com/concourse/atc/notifications"
+	"github.com/onsi/gomega/ghttp"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Deliverer", func() {
+	var (
+		fakeOutbox           *dbfakes.FakeNotificationOutbox
+		fakeVariablesFactory *credsfakes.FakeVariablesFactory
+		fakeClock            *fakeclock.FakeClock
+		server               *ghttp.Server
+
+		delivery db.NotificationDelivery
+
+		deliverer notifications.Deliverer
+		runErr    error
+	)
+
+	BeforeEach(func() {
+		fakeOutbox = new(dbfakes.FakeNotificationOutbox)
+		fakeVariablesFactory = new(credsfakes.FakeVariablesFactory)
+		fakeVariablesFactory.NewVariablesReturns(template.StaticVariables{
+			"hook-secret": "some-interpolated-secret",
+		})
+		fakeClock = fakeclock.NewFakeClock(time.Unix(123456789, 0))
+		server = ghttp.NewServer()
+
+		delivery = db.NotificationDelivery{
+			ID:           42,
+			BuildID:      12,
+			Event:        atc.StatusSucceeded,
+			Payload:      `{"event":"succeeded"}`,
+			Attempts:     2,
+			TeamName:     "some-team",
+			PipelineName: "some-pipeline",
+			Config: atc.NotificationConfig{
+				Name:   "some-notification",
+				URL:    server.URL() + "/hook",
+				Secret: "some-secret",
+			},
+		}
+
+		fakeOutbox.DueDeliveriesReturns([]db.NotificationDelivery{delivery}, nil)
+
+		deliverer = notifications.NewDeliverer(
+			lagertest.NewTestLogger("deliverer"),
+			fakeOutbox,
+			http.DefaultClient,
+			fakeVariablesFactory,
+			fakeClock,
+		)
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	JustBeforeEach(func() {
+		runErr = deliverer.Run()
+	})
+
+	Context("when the endpoint accepts the notification", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/hook"),
+					ghttp.VerifyContentType("application/json"),
+					ghttp.VerifyHeaderKV("X-Concourse-Event", "succeeded"),
+					ghttp.VerifyHeaderKV("X-Concourse-Delivery", "42"),
+					ghttp.VerifyHeaderKV(atc.NotificationSignatureHeader, "sha256="+notifications.Sign("some-secret", []byte(`{"event":"succeeded"}`))),
+					ghttp.VerifyBody([]byte(`{"event":"succeeded"}`)),
+					ghttp.RespondWith(http.StatusNoContent, nil),
+				),
+			)
+		})
+
+		It("POSTs the signed payload", func() {
+			Expect(runErr).NotTo(HaveOccurred())
+			Expect(server.ReceivedRequests()).To(HaveLen(1))
+		})
+
+		It("records the delivery", func() {
+			Expect(fakeOutbox.DeliveredCallCount()).To(Equal(1))
+
+			actualDelivery, responseStatus := fakeOutbox.DeliveredArgsForCall(0)
+			Expect(actualDelivery).To(Equal(delivery))
+			Expect(responseStatus).To(Equal(http.StatusNoContent))
+		})
+	})
+
+	Context("when the secret is a credential", func() {
+		BeforeEach(func() {
+			delivery.Config.Secret = "((hook-secret))"
+			fakeOutbox.DueDeliveriesReturns([]db.NotificationDelivery{delivery}, nil)
+
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyHeaderKV(atc.NotificationSignatureHeader, "sha256="+notifications.Sign("some-interpolated-secret", []byte(`{"event":"succeeded"}`))),
+					ghttp.RespondWith(http.StatusOK, nil),
+				),
+			)
+		})
+
+		It("signs the payload with the credential of the pipeline", func() {
+			Expect(server.ReceivedRequests()).To(HaveLen(1))
+
+			Expect(fakeVariablesFactory.NewVariablesCallCount()).To(Equal(1))
+			teamName, pipelineName := fakeVariablesFactory.NewVariablesArgsForCall(0)
+			Expect(teamName).To(Equal("some-team"))
+			Expect(pipelineName).To(Equal("some-pipeline"))
+
+			Expect(fakeOutbox.DeliveredCallCount()).To(Equal(1))
+		})
+
+		Context("when the credential cannot be found", func() {
+			BeforeEach(func() {
+				fakeVariablesFactory.NewVariablesReturns(template.StaticVariables{})
+			})
+
+			It("does not POST the payload and retries later", func() {
+				Expect(server.ReceivedRequests()).To(BeEmpty())
+				Expect(fakeOutbox.RetryCallCount()).To(Equal(1))
+			})
+		})
+	})
+
+	Context("when many notifications are due", func() {
+		BeforeEach(func() {
+			deliveries := []db.NotificationDelivery{}
+			for i := 0; i < 25; i++ {
+				delivery.ID = i + 1
+				deliveries = append(deliveries, delivery)
+			}
+
+			fakeOutbox.DueDeliveriesReturns(deliveries, nil)
+
+			server.RouteToHandler("POST", "/hook", ghttp.RespondWith(http.StatusOK, nil))
+		})
+
+		It("delivers all of them", func() {
+			Expect(server.ReceivedRequests()).To(HaveLen(25))
+			Expect(fakeOutbox.DeliveredCallCount()).To(Equal(25))
+		})
+
+		Context("when the run takes too long", func() {
+			BeforeEach(func() {
+				server.RouteToHandler("POST", "/hook", ghttp.CombineHandlers(
+					func(http.ResponseWriter, *http.Request) {
+						fakeClock.Increment(2 * time.Minute)
+					},
+					ghttp.RespondWith(http.StatusOK, nil),
+				))
+			})
+
+			It("leaves the deliveries it did not start for the next run", func() {
+				Expect(runErr).NotTo(HaveOccurred())
+				Expect(len(server.ReceivedRequests())).To(BeNumerically("<=", 10))
+				Expect(fakeOutbox.DeliveredCallCount()).To(Equal(len(server.ReceivedRequests())))
+				Expect(fakeOutbox.RetryCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Context("when the run is done", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(ghttp.RespondWith(http.StatusOK, nil))
+		})
+
+		It("deletes the finished deliveries older than a week", func() {
+			Expect(fakeOutbox.DeleteFinishedCallCount()).To(Equal(1))
+			Expect(fakeOutbox.DeleteFinishedArgsForCall(0)).To(Equal(fakeClock.Now().Add(-7 * 24 * time.Hour)))
+		})
+	})
+
+	Context("when deleting the finished deliveries fails", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			server.AppendHandlers(ghttp.RespondWith(http.StatusOK, nil))
+			fakeOutbox.DeleteFinishedReturns(disaster)
+		})
+
+		It("returns the error", func() {
+			Expect(runErr).To(Equal(disaster))
+		})
+	})
+
+	Context("when the notification has no secret", func() {
+		BeforeEach(func() {
+			delivery.Config.Secret = ""
+			fakeOutbox.DueDeliveriesReturns([]db.NotificationDelivery{delivery}, nil)
+
+			server.AppendHandlers(ghttp.RespondWith(http.StatusOK, nil))
+		})
+
+		It("does not sign the payload", func() {
+			Expect(server.ReceivedRequests()).To(HaveLen(1))
+			Expect(server.ReceivedRequests()[0].Header).NotTo(HaveKey(atc.NotificationSignatureHeader))
+		})
+	})
+
+	Context("when the endpoint rejects the notification", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(ghttp.RespondWith(http.StatusInternalServerError, nil))
+		})
+
+		It("backs off before retrying", func() {
+			Expect(fakeOutbox.RetryCallCount()).To(Equal(1))
+
+			actualDelivery, responseStatus, cause, nextAttempt := fakeOutbox.RetryArgsForCall(0)
+			Expect(actualDelivery).To(Equal(delivery))
+			Expect(responseStatus).To(Equal(http.StatusInternalServerError))
+			Expect(cause).To(HaveOccurred())
+			Expect(nextAttempt).To(Equal(fakeClock.Now().Add(40 * time.Second)))
+		})
+
+		Context("when it has been attempted too many times", func() {
+			BeforeEach(func() {
+				delivery.Attempts = notifications.MaxAttempts - 1
+				fakeOutbox.DueDeliveriesReturns([]db.NotificationDelivery{delivery}, nil)
+			})
+
+			It("gives up on it", func() {
+				Expect(fakeOutbox.RetryCallCount()).To(BeZero())
+				Expect(fakeOutbox.GiveUpCallCount()).To(Equal(1))
+
+				actualDelivery, responseStatus, cause := fakeOutbox.GiveUpArgsForCall(0)
+				Expect(actualDelivery).To(Equal(delivery))
+				Expect(responseStatus).To(Equal(http.StatusInternalServerError))
+				Expect(cause).To(HaveOccurred())
+			})
+		})
+	})
+
+	Context("when the endpoint cannot be reached", func() {
+		BeforeEach(func() {
+			delivery.Config.URL = "http://127.0.0.1:1/hook"
+			fakeOutbox.DueDeliveriesReturns([]db.NotificationDelivery{delivery}, nil)
+		})
+
+		It("retries without a response status", func() {
+			Expect(fakeOutbox.RetryCallCount()).To(Equal(1))
+
+			_, responseStatus, cause, _ := fakeOutbox.RetryArgsForCall(0)
+			Expect(responseStatus).To(BeZero())
+			Expect(cause).To(HaveOccurred())
+		})
+	})
+
+	Context("when getting the due deliveries fails", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			fakeOutbox.DueDeliveriesReturns(nil, disaster)
+		})
+
+		It("returns the error", func() {
+			Expect(runErr).To(Equal(disaster))
+		})
+	})
+})
@@ -1,12 +1,40 @@
 package atc
 
+import "encoding/json"
+
 type Pipeline struct {
-	ID       int          `json:"id"`
-	Name     string       `json:"name"`
-	Paused   bool         `json:"paused"`
-	Public   bool         `json:"public"`
-	Groups   GroupConfigs `json:"groups,omitempty"`
-	TeamName string       `json:"team_name"`
+	ID           int          `json:"id"`
+	Name         string       `json:"name"`
+	InstanceVars InstanceVars `json:"instance_vars,omitempty"`
+	Paused       bool         `json:"paused"`
+	Public       bool         `json:"public"`
+	Archived     bool         `json:"archived"`
+	Groups       GroupConfigs `json:"groups,omitempty"`
+	TeamName     string       `json:"team_name"`
+}
+
+// InstanceVars identify an instance of a pipeline. Every instance of a
+// pipeline shares its name, and the vars are interpolated into its config.
+type InstanceVars map[string]interface{}
+
+// InstanceVarsQueryParam is the query parameter addressing an instance of a
+// pipeline in the API, e.g. ?instance_vars={"branch":"release-1.0"}.
+const InstanceVarsQueryParam = "instance_vars"
+
+// ParseInstanceVars parses the JSON value of the instance vars query
+// parameter. An empty value addresses the pipeline without instance vars.
+func ParseInstanceVars(value string) (InstanceVars, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	var instanceVars InstanceVars
+	err := json.Unmarshal([]byte(value), &instanceVars)
+	if err != nil {
+		return nil, err
+	}
+
+	return instanceVars, nil
 }
 
 type RenameRequest struct {
@@ -16,6 +16,12 @@ type Plan struct {
 	Try       *TryPlan       `json:"try,omitempty"`
 	Timeout   *TimeoutPlan   `json:"timeout,omitempty"`
 	Retry     *RetryPlan     `json:"retry,omitempty"`
+	Across    *AcrossPlan    `json:"across,omitempty"`
+
+	SetPipeline *SetPipelinePlan `json:"set_pipeline,omitempty"`
+	LoadVar     *LoadVarPlan     `json:"load_var,omitempty"`
+
+	SetBuildMetadata *SetBuildMetadataPlan `json:"set_build_metadata,omitempty"`
 
 	// deprecated, kept for backwards compatibility to be able to show old builds
 	DependentGet *DependentGetPlan `json:"dependent_get,omitempty"`
@@ -97,8 +103,46 @@ type TaskPlan struct {
 	VersionedResourceTypes VersionedResourceTypes `json:"resource_types,omitempty"`
 }
 
+type SetPipelinePlan struct {
+	Name         string       `json:"name"`
+	File         string       `json:"file"`
+	InstanceVars InstanceVars `json:"instance_vars,omitempty"`
+}
+
+const (
+	LoadVarFormatRaw  = "raw"
+	LoadVarFormatTrim = "trim"
+	LoadVarFormatJSON = "json"
+	LoadVarFormatYAML = "yaml"
+)
+
+type LoadVarPlan struct {
+	Name   string `json:"name"`
+	File   string `json:"file"`
+	Format string `json:"format,omitempty"`
+	Reveal bool   `json:"reveal,omitempty"`
+}
+
+// SetBuildMetadataPlan records the key/value pairs in a JSON or YAML file as
+// the build's metadata.
+type SetBuildMetadataPlan struct {
+	File string `json:"file"`
+}
+
 type RetryPlan []Plan
 
+type AcrossPlan struct {
+	Vars        []string     `json:"vars"`
+	Steps       []AcrossStep `json:"steps"`
+	MaxInFlight int          `json:"max_in_flight,omitempty"`
+	FailFast    bool         `json:"fail_fast,omitempty"`
+}
+
+type AcrossStep struct {
+	Values []string `json:"values"`
+	Step   Plan     `json:"step"`
+}
+
 type DependentGetPlan struct {
 	Type     string `json:"type"`
 	Name     string `json:"name,omitempty"`
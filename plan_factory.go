@@ -49,6 +49,14 @@ func (factory PlanFactory) NewPlan(step Step) Plan {
 		plan.Timeout = &t
 	case RetryPlan:
 		plan.Retry = &t
+	case AcrossPlan:
+		plan.Across = &t
+	case SetPipelinePlan:
+		plan.SetPipeline = &t
+	case LoadVarPlan:
+		plan.LoadVar = &t
+	case SetBuildMetadataPlan:
+		plan.SetBuildMetadata = &t
 	default:
 		panic(fmt.Sprintf("don't know how to construct plan from %T", step))
 	}
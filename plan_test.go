@@ -280,6 +280,43 @@ var _ = Describe("Plan", func() {
 						},
 					},
 				},
+
+				atc.Plan{
+					ID: "31",
+					Across: &atc.AcrossPlan{
+						Vars:        []string{"some-var"},
+						MaxInFlight: 2,
+						FailFast:    true,
+						Steps: []atc.AcrossStep{
+							{
+								Values: []string{"a"},
+								Step: atc.Plan{
+									ID: "32",
+									Task: &atc.TaskPlan{
+										Name:       "name",
+										ConfigPath: "some/config/path.yml",
+										Config: &atc.TaskConfig{
+											Params: map[string]string{"some": "secret"},
+										},
+									},
+								},
+							},
+							{
+								Values: []string{"b"},
+								Step: atc.Plan{
+									ID: "33",
+									Task: &atc.TaskPlan{
+										Name:       "name",
+										ConfigPath: "some/config/path.yml",
+										Config: &atc.TaskConfig{
+											Params: map[string]string{"some": "secret"},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
 			},
 		}
 
@@ -483,6 +520,36 @@ var _ = Describe("Plan", func() {
           }
 	    }
       }
+    },
+    {
+      "id": "31",
+      "across": {
+        "vars": ["some-var"],
+        "steps": [
+          {
+            "values": ["a"],
+            "step": {
+              "id": "32",
+              "task": {
+                "name": "name",
+                "privileged": false
+              }
+            }
+          },
+          {
+            "values": ["b"],
+            "step": {
+              "id": "33",
+              "task": {
+                "name": "name",
+                "privileged": false
+              }
+            }
+          }
+        ],
+        "max_in_flight": 2,
+        "fail_fast": true
+      }
     }
   ]
 }
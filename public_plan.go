@@ -19,6 +19,11 @@ func (plan Plan) Public() *json.RawMessage {
 		DependentGet *json.RawMessage `json:"dependent_get,omitempty"`
 		Timeout      *json.RawMessage `json:"timeout,omitempty"`
 		Retry        *json.RawMessage `json:"retry,omitempty"`
+		Across       *json.RawMessage `json:"across,omitempty"`
+		SetPipeline  *json.RawMessage `json:"set_pipeline,omitempty"`
+		LoadVar      *json.RawMessage `json:"load_var,omitempty"`
+
+		SetBuildMetadata *json.RawMessage `json:"set_build_metadata,omitempty"`
 	}
 
 	public.ID = plan.ID
@@ -71,6 +76,22 @@ func (plan Plan) Public() *json.RawMessage {
 		public.Retry = plan.Retry.Public()
 	}
 
+	if plan.Across != nil {
+		public.Across = plan.Across.Public()
+	}
+
+	if plan.SetPipeline != nil {
+		public.SetPipeline = plan.SetPipeline.Public()
+	}
+
+	if plan.LoadVar != nil {
+		public.LoadVar = plan.LoadVar.Public()
+	}
+
+	if plan.SetBuildMetadata != nil {
+		public.SetBuildMetadata = plan.SetBuildMetadata.Public()
+	}
+
 	if plan.DependentGet != nil {
 		public.DependentGet = plan.DependentGet.Public()
 	}
@@ -186,6 +207,30 @@ func (plan TaskPlan) Public() *json.RawMessage {
 	})
 }
 
+func (plan SetPipelinePlan) Public() *json.RawMessage {
+	return enc(struct {
+		Name string `json:"name"`
+	}{
+		Name: plan.Name,
+	})
+}
+
+func (plan LoadVarPlan) Public() *json.RawMessage {
+	return enc(struct {
+		Name string `json:"name"`
+	}{
+		Name: plan.Name,
+	})
+}
+
+func (plan SetBuildMetadataPlan) Public() *json.RawMessage {
+	return enc(struct {
+		File string `json:"file"`
+	}{
+		File: plan.File,
+	})
+}
+
 func (plan TimeoutPlan) Public() *json.RawMessage {
 	return enc(struct {
 		Step     *json.RawMessage `json:"step"`
@@ -214,6 +259,34 @@ func (plan RetryPlan) Public() *json.RawMessage {
 	return enc(public)
 }
 
+func (plan AcrossPlan) Public() *json.RawMessage {
+	type publicStep struct {
+		Values []string         `json:"values"`
+		Step   *json.RawMessage `json:"step"`
+	}
+
+	steps := make([]publicStep, len(plan.Steps))
+
+	for i := 0; i < len(plan.Steps); i++ {
+		steps[i] = publicStep{
+			Values: plan.Steps[i].Values,
+			Step:   plan.Steps[i].Step.Public(),
+		}
+	}
+
+	return enc(struct {
+		Vars        []string     `json:"vars"`
+		Steps       []publicStep `json:"steps"`
+		MaxInFlight int          `json:"max_in_flight,omitempty"`
+		FailFast    bool         `json:"fail_fast,omitempty"`
+	}{
+		Vars:        plan.Vars,
+		Steps:       steps,
+		MaxInFlight: plan.MaxInFlight,
+		FailFast:    plan.FailFast,
+	})
+}
+
 func enc(public interface{}) *json.RawMessage {
 	enc, _ := json.Marshal(public)
 	return (*json.RawMessage)(&enc)
@@ -2,6 +2,7 @@ package radar
 
 import (
 	"context"
+	"os"
 	"time"
 
 	"code.cloudfoundry.org/clock"
@@ -47,7 +48,7 @@ func (r *intervalRunner) Run(ctx context.Context) error {
 			return nil
 		case <-timer.C():
 			var err error
-			interval, err = r.scanner.Run(r.logger, r.name)
+			interval, err = r.scanner.Run(ctx, r.logger, r.name)
 			if err != nil {
 				if err == ErrFailedToAcquireLock {
 					break
@@ -57,3 +58,21 @@ func (r *intervalRunner) Run(ctx context.Context) error {
 		}
 	}
 }
+
+// contextSignals returns a channel which is sent an interrupt once the context
+// is done, so that a scan stops waiting, e.g. for a worker, when its runner
+// stops. stop must be called once the scan is over.
+func contextSignals(ctx context.Context) (<-chan os.Signal, func()) {
+	signals := make(chan os.Signal, 1)
+	done := make(chan struct{})
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			signals <- os.Interrupt
+		case <-done:
+		}
+	}()
+
+	return signals, func() { close(done) }
+}
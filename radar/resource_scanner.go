@@ -1,7 +1,9 @@
 package radar
 
 import (
+	"context"
 	"errors"
+	"os"
 	"reflect"
 	"time"
 
@@ -49,8 +51,11 @@ func NewResourceScanner(
 
 var ErrFailedToAcquireLock = errors.New("failed-to-acquire-lock")
 
-func (scanner *resourceScanner) Run(logger lager.Logger, resourceName string) (time.Duration, error) {
-	interval, err := scanner.scan(logger.Session("tick"), resourceName, nil, false)
+func (scanner *resourceScanner) Run(ctx context.Context, logger lager.Logger, resourceName string) (time.Duration, error) {
+	signals, stop := contextSignals(ctx)
+	defer stop()
+
+	interval, err := scanner.scan(logger.Session("tick"), signals, resourceName, nil, false)
 
 	err = swallowErrResourceScriptFailed(err)
 
@@ -58,20 +63,20 @@ func (scanner *resourceScanner) Run(logger lager.Logger, resourceName string) (t
 }
 
 func (scanner *resourceScanner) ScanFromVersion(logger lager.Logger, resourceName string, fromVersion atc.Version) error {
-	_, err := scanner.scan(logger, resourceName, fromVersion, true)
+	_, err := scanner.scan(logger, nil, resourceName, fromVersion, true)
 
 	return err
 }
 
 func (scanner *resourceScanner) Scan(logger lager.Logger, resourceName string) error {
-	_, err := scanner.scan(logger, resourceName, nil, true)
+	_, err := scanner.scan(logger, nil, resourceName, nil, true)
 
 	err = swallowErrResourceScriptFailed(err)
 
 	return err
 }
 
-func (scanner *resourceScanner) scan(logger lager.Logger, resourceName string, fromVersion atc.Version, mustComplete bool) (time.Duration, error) {
+func (scanner *resourceScanner) scan(logger lager.Logger, signals <-chan os.Signal, resourceName string, fromVersion atc.Version, mustComplete bool) (time.Duration, error) {
 	lockLogger := logger.Session("lock", lager.Data{
 		"resource": resourceName,
 	})
@@ -193,6 +198,7 @@ func (scanner *resourceScanner) scan(logger lager.Logger, resourceName string, f
 
 	return interval, scanner.check(
 		logger,
+		signals,
 		savedResource,
 		resourceConfigCheckSession,
 		fromVersion,
@@ -203,6 +209,7 @@ func (scanner *resourceScanner) scan(logger lager.Logger, resourceName string, f
 
 func (scanner *resourceScanner) check(
 	logger lager.Logger,
+	signals <-chan os.Signal,
 	savedResource db.Resource,
 	resourceConfigCheckSession db.ResourceConfigCheckSession,
 	fromVersion atc.Version,
@@ -252,7 +259,7 @@ func (scanner *resourceScanner) check(
 
 	res, err := scanner.resourceFactory.NewResource(
 		logger,
-		nil,
+		signals,
 		db.NewResourceConfigCheckSessionContainerOwner(resourceConfigCheckSession, scanner.dbPipeline.TeamID()),
 		db.ContainerMetadata{
 			Type: db.ContainerTypeCheck,
@@ -1,7 +1,9 @@
 package radar_test
 
 import (
+	"context"
 	"errors"
+	"os"
 	"time"
 
 	"code.cloudfoundry.org/clock/fakeclock"
@@ -128,15 +130,24 @@ var _ = Describe("ResourceScanner", func() {
 			fakeResource   *rfakes.FakeResource
 			actualInterval time.Duration
 			runErr         error
+
+			runCtx    context.Context
+			cancelRun context.CancelFunc
 		)
 
 		BeforeEach(func() {
 			fakeResource = new(rfakes.FakeResource)
 			fakeResourceFactory.NewResourceReturns(fakeResource, nil)
+
+			runCtx, cancelRun = context.WithCancel(context.Background())
+		})
+
+		AfterEach(func() {
+			cancelRun()
 		})
 
 		JustBeforeEach(func() {
-			actualInterval, runErr = scanner.Run(lagertest.NewTestLogger("test"), "some-resource")
+			actualInterval, runErr = scanner.Run(runCtx, lagertest.NewTestLogger("test"), "some-resource")
 		})
 
 		Context("when the lock cannot be acquired", func() {
@@ -163,6 +174,29 @@ var _ = Describe("ResourceScanner", func() {
 				Expect(fakeResource.CheckCallCount()).To(Equal(1))
 			})
 
+			Context("when the run is cancelled while the check container is being created", func() {
+				BeforeEach(func() {
+					fakeResourceFactory.NewResourceStub = func(
+						_ lager.Logger,
+						signals <-chan os.Signal,
+						_ db.ContainerOwner,
+						_ db.ContainerMetadata,
+						_ worker.ContainerSpec,
+						_ creds.VersionedResourceTypes,
+						_ worker.ImageFetchingDelegate,
+					) (resource.Resource, error) {
+						cancelRun()
+						Eventually(signals).Should(Receive(Equal(os.Interrupt)))
+						return nil, worker.ErrAborted
+					}
+				})
+
+				It("interrupts the container creation", func() {
+					Expect(runErr).To(Equal(worker.ErrAborted))
+					Expect(fakeResource.CheckCallCount()).To(BeZero())
+				})
+			})
+
 			It("constructs the resource of the correct type", func() {
 				Expect(fakeResourceConfigCheckSessionFactory.FindOrCreateResourceConfigCheckSessionCallCount()).To(Equal(1))
 				_, resourceType, resourceSource, resourceTypes, _ := fakeResourceConfigCheckSessionFactory.FindOrCreateResourceConfigCheckSessionArgsForCall(0)
@@ -1,6 +1,8 @@
 package radar
 
 import (
+	"context"
+	"os"
 	"reflect"
 	"time"
 
@@ -43,8 +45,11 @@ func NewResourceTypeScanner(
 	}
 }
 
-func (scanner *resourceTypeScanner) Run(logger lager.Logger, resourceTypeName string) (time.Duration, error) {
-	return scanner.scan(logger.Session("tick"), resourceTypeName, nil, false)
+func (scanner *resourceTypeScanner) Run(ctx context.Context, logger lager.Logger, resourceTypeName string) (time.Duration, error) {
+	signals, stop := contextSignals(ctx)
+	defer stop()
+
+	return scanner.scan(logger.Session("tick"), signals, resourceTypeName, nil, false)
 }
 
 func (scanner *resourceTypeScanner) ScanFromVersion(logger lager.Logger, resourceTypeName string, fromVersion atc.Version) error {
@@ -52,12 +57,12 @@ func (scanner *resourceTypeScanner) ScanFromVersion(logger lager.Logger, resourc
 }
 
 func (scanner *resourceTypeScanner) Scan(logger lager.Logger, resourceTypeName string) error {
-	_, err := scanner.scan(logger, resourceTypeName, nil, true)
+	_, err := scanner.scan(logger, nil, resourceTypeName, nil, true)
 
 	return err
 }
 
-func (scanner *resourceTypeScanner) scan(logger lager.Logger, resourceTypeName string, fromVersion atc.Version, mustComplete bool) (time.Duration, error) {
+func (scanner *resourceTypeScanner) scan(logger lager.Logger, signals <-chan os.Signal, resourceTypeName string, fromVersion atc.Version, mustComplete bool) (time.Duration, error) {
 	lockLogger := logger.Session("lock", lager.Data{
 		"resource-type": resourceTypeName,
 	})
@@ -169,6 +174,7 @@ func (scanner *resourceTypeScanner) scan(logger lager.Logger, resourceTypeName s
 
 	return interval, scanner.check(
 		logger,
+		signals,
 		savedResourceType,
 		resourceConfigCheckSession,
 		fromVersion,
@@ -179,6 +185,7 @@ func (scanner *resourceTypeScanner) scan(logger lager.Logger, resourceTypeName s
 
 func (scanner *resourceTypeScanner) check(
 	logger lager.Logger,
+	signals <-chan os.Signal,
 	savedResourceType db.ResourceType,
 	resourceConfigCheckSession db.ResourceConfigCheckSession,
 	fromVersion atc.Version,
@@ -206,7 +213,7 @@ func (scanner *resourceTypeScanner) check(
 
 	res, err := scanner.resourceFactory.NewResource(
 		logger,
-		nil,
+		signals,
 		db.NewResourceConfigCheckSessionContainerOwner(resourceConfigCheckSession, scanner.dbPipeline.TeamID()),
 		db.ContainerMetadata{
 			Type: db.ContainerTypeCheck,
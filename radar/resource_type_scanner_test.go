@@ -1,6 +1,7 @@
 package radar_test
 
 import (
+	"context"
 	"errors"
 	"time"
 
@@ -108,7 +109,7 @@ var _ = Describe("ResourceTypeScanner", func() {
 		})
 
 		JustBeforeEach(func() {
-			actualInterval, runErr = scanner.Run(lagertest.NewTestLogger("test"), fakeResourceType.Name())
+			actualInterval, runErr = scanner.Run(context.Background(), lagertest.NewTestLogger("test"), fakeResourceType.Name())
 		})
 
 		Context("when the lock cannot be acquired", func() {
@@ -1,6 +1,7 @@
 package radar
 
 import (
+	"context"
 	"time"
 
 	"github.com/concourse/atc"
@@ -15,7 +16,7 @@ import (
 //go:generate counterfeiter . Scanner
 
 type Scanner interface {
-	Run(lager.Logger, string) (time.Duration, error)
+	Run(context.Context, lager.Logger, string) (time.Duration, error)
 	Scan(lager.Logger, string) error
 	ScanFromVersion(lager.Logger, string, atc.Version) error
 }
@@ -113,7 +113,7 @@ func (s *resourceInstanceFetchSource) Create(signals <-chan os.Signal, ready cha
 	resourceFactory := NewResourceFactory(s.worker)
 	resource, err := resourceFactory.NewResource(
 		s.logger,
-		nil,
+		signals,
 		s.resourceInstance.ContainerOwner(),
 		s.session.Metadata,
 		containerSpec,
@@ -121,6 +121,11 @@ func (s *resourceInstanceFetchSource) Create(signals <-chan os.Signal, ready cha
 		s.imageFetchingDelegate,
 	)
 	if err != nil {
+		if err == worker.ErrAborted {
+			sLog.Error("get-waiting-for-worker-aborted", err)
+			return nil, ErrInterrupted
+		}
+
 		sLog.Error("failed-to-construct-resource", err)
 		return nil, err
 	}
@@ -13,10 +13,13 @@ const (
 	BuildEvents         = "BuildEvents"
 	BuildResources      = "BuildResources"
 	AbortBuild          = "AbortBuild"
+	SetBuildMetadata    = "SetBuildMetadata"
 	GetBuildPreparation = "GetBuildPreparation"
+	SearchBuildLogs     = "SearchBuildLogs"
 
 	GetJob         = "GetJob"
 	CreateJobBuild = "CreateJobBuild"
+	RerunJobBuild  = "RerunJobBuild"
 	ListJobs       = "ListJobs"
 	ListJobBuilds  = "ListJobBuilds"
 	ListJobInputs  = "ListJobInputs"
@@ -38,6 +41,8 @@ const (
 	GetResourceVersion            = "GetResourceVersion"
 	EnableResourceVersion         = "EnableResourceVersion"
 	DisableResourceVersion        = "DisableResourceVersion"
+	PinResourceVersion            = "PinResourceVersion"
+	UnpinResource                 = "UnpinResource"
 	ListBuildsWithVersionAsInput  = "ListBuildsWithVersionAsInput"
 	ListBuildsWithVersionAsOutput = "ListBuildsWithVersionAsOutput"
 	GetResourceCausality          = "GetResourceCausality"
@@ -49,12 +54,15 @@ const (
 	OrderPipelines      = "OrderPipelines"
 	PausePipeline       = "PausePipeline"
 	UnpausePipeline     = "UnpausePipeline"
+	ArchivePipeline     = "ArchivePipeline"
 	ExposePipeline      = "ExposePipeline"
 	HidePipeline        = "HidePipeline"
 	RenamePipeline      = "RenamePipeline"
 	CreatePipelineBuild = "CreatePipelineBuild"
 	PipelineBadge       = "PipelineBadge"
 
+	ListPipelineNotifications = "ListPipelineNotifications"
+
 	CreatePipe = "CreatePipe"
 	WritePipe  = "WritePipe"
 	ReadPipe   = "ReadPipe"
@@ -87,6 +95,11 @@ const (
 	SetTeam     = "SetTeam"
 	RenameTeam  = "RenameTeam"
 	DestroyTeam = "DestroyTeam"
+	TeamEvents  = "TeamEvents"
+
+	ListAuditEvents = "ListAuditEvents"
+
+	GetKeyRotationStatus = "GetKeyRotationStatus"
 )
 
 var Routes = rata.Routes([]rata.Route{
@@ -100,7 +113,9 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/builds/:build_id/events", Method: "GET", Name: BuildEvents},
 	{Path: "/api/v1/builds/:build_id/resources", Method: "GET", Name: BuildResources},
 	{Path: "/api/v1/builds/:build_id/abort", Method: "PUT", Name: AbortBuild},
+	{Path: "/api/v1/builds/:build_id/metadata", Method: "PUT", Name: SetBuildMetadata},
 	{Path: "/api/v1/builds/:build_id/preparation", Method: "GET", Name: GetBuildPreparation},
+	{Path: "/api/v1/build_logs/search", Method: "GET", Name: SearchBuildLogs},
 
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs", Method: "GET", Name: ListJobs},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name", Method: "GET", Name: GetJob},
@@ -108,6 +123,7 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/builds", Method: "POST", Name: CreateJobBuild},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/inputs", Method: "GET", Name: ListJobInputs},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/builds/:build_name", Method: "GET", Name: GetJobBuild},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/builds/:build_name/rerun", Method: "POST", Name: RerunJobBuild},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/pause", Method: "PUT", Name: PauseJob},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/unpause", Method: "PUT", Name: UnpauseJob},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/badge", Method: "GET", Name: JobBadge},
@@ -120,9 +136,11 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/teams/:team_name/pipelines/ordering", Method: "PUT", Name: OrderPipelines},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/pause", Method: "PUT", Name: PausePipeline},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/unpause", Method: "PUT", Name: UnpausePipeline},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/archive", Method: "PUT", Name: ArchivePipeline},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/expose", Method: "PUT", Name: ExposePipeline},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/hide", Method: "PUT", Name: HidePipeline},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/versions-db", Method: "GET", Name: GetVersionsDB},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/notifications", Method: "GET", Name: ListPipelineNotifications},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/rename", Method: "PUT", Name: RenamePipeline},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/builds", Method: "POST", Name: CreatePipelineBuild},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/badge", Method: "GET", Name: PipelineBadge},
@@ -138,6 +156,8 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id", Method: "GET", Name: GetResourceVersion},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/enable", Method: "PUT", Name: EnableResourceVersion},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/disable", Method: "PUT", Name: DisableResourceVersion},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/pin", Method: "PUT", Name: PinResourceVersion},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/unpin", Method: "PUT", Name: UnpinResource},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/input_to", Method: "GET", Name: ListBuildsWithVersionAsInput},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/output_of", Method: "GET", Name: ListBuildsWithVersionAsOutput},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/causality", Method: "GET", Name: GetResourceCausality},
@@ -174,4 +194,9 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/teams/:team_name", Method: "PUT", Name: SetTeam},
 	{Path: "/api/v1/teams/:team_name/rename", Method: "PUT", Name: RenameTeam},
 	{Path: "/api/v1/teams/:team_name", Method: "DELETE", Name: DestroyTeam},
+	{Path: "/api/v1/teams/:team_name/events", Method: "GET", Name: TeamEvents},
+
+	{Path: "/api/v1/audit", Method: "GET", Name: ListAuditEvents},
+
+	{Path: "/api/v1/encryption/rotation", Method: "GET", Name: GetKeyRotationStatus},
 })
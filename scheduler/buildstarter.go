@@ -1,12 +1,15 @@
 package scheduler
 
 import (
+	"strconv"
+
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/engine"
 	"github.com/concourse/atc/scheduler/inputmapper"
 	"github.com/concourse/atc/scheduler/maxinflight"
+	"github.com/concourse/atc/tracing"
 )
 
 //go:generate counterfeiter . BuildStarter
@@ -62,7 +65,16 @@ func (s *buildStarter) TryStartPendingBuildsForJob(
 	nextPendingBuildsForJob []db.Build,
 ) error {
 	for _, nextPendingBuild := range nextPendingBuildsForJob {
+		span := tracing.StartBuildSpan("scheduler.start-build", nextPendingBuild.ID(), tracing.Attrs{
+			"pipeline": nextPendingBuild.PipelineName(),
+			"job":      job.Name(),
+		})
+
 		started, err := s.tryStartNextPendingBuild(logger, nextPendingBuild, job, resources, resourceTypes)
+
+		span.Attributes["started"] = strconv.FormatBool(started)
+		span.End(err)
+
 		if err != nil {
 			return err
 		}
@@ -95,45 +107,60 @@ func (s *buildStarter) tryStartNextPendingBuild(
 		return false, nil
 	}
 
-	if nextPendingBuild.IsManuallyTriggered() {
-		jobBuildInputs := job.Config().Inputs()
-		for _, input := range jobBuildInputs {
-			scanLog := logger.Session("scan", lager.Data{
-				"input":    input.Name,
-				"resource": input.Resource,
-			})
+	// re-runs and builds triggered with chosen versions have their inputs
+	// saved when they are created, so the inputs are not mapped again
+	inputsChosen := nextPendingBuild.RerunOf() != 0 || len(nextPendingBuild.InputOverrides()) > 0
 
-			err := s.scanner.Scan(scanLog, input.Resource)
-			if err != nil {
-				return false, err
-			}
-		}
+	var buildInputs []db.BuildInput
 
-		versions, err := s.pipeline.LoadVersionsDB()
+	if inputsChosen {
+		buildInputs, err = nextPendingBuild.Inputs()
 		if err != nil {
-			logger.Error("failed-to-load-versions-db", err)
+			logger.Error("failed-to-get-chosen-build-inputs", err)
 			return false, err
 		}
+	} else {
+		if nextPendingBuild.IsManuallyTriggered() {
+			jobBuildInputs := job.Config().Inputs()
+			for _, input := range jobBuildInputs {
+				scanLog := logger.Session("scan", lager.Data{
+					"input":    input.Name,
+					"resource": input.Resource,
+				})
+
+				err := s.scanner.Scan(scanLog, input.Resource)
+				if err != nil {
+					return false, err
+				}
+			}
 
-		_, err = s.inputMapper.SaveNextInputMapping(logger, versions, job)
-		if err != nil {
-			return false, err
+			versions, err := s.pipeline.LoadVersionsDB()
+			if err != nil {
+				logger.Error("failed-to-load-versions-db", err)
+				return false, err
+			}
+
+			_, err = s.inputMapper.SaveNextInputMapping(logger, versions, job)
+			if err != nil {
+				return false, err
+			}
+
+			dbResourceTypes, err := s.pipeline.ResourceTypes()
+			if err != nil {
+				return false, err
+			}
+			resourceTypes = dbResourceTypes.Deserialize()
 		}
 
-		dbResourceTypes, err := s.pipeline.ResourceTypes()
+		var found bool
+		buildInputs, found, err = job.GetNextBuildInputs()
 		if err != nil {
+			logger.Error("failed-to-get-next-build-inputs", err)
 			return false, err
 		}
-		resourceTypes = dbResourceTypes.Deserialize()
-	}
-
-	buildInputs, found, err := job.GetNextBuildInputs()
-	if err != nil {
-		logger.Error("failed-to-get-next-build-inputs", err)
-		return false, err
-	}
-	if !found {
-		return false, nil
+		if !found {
+			return false, nil
+		}
 	}
 
 	pipelinePaused, err := s.pipeline.CheckPaused()
@@ -160,9 +187,11 @@ func (s *buildStarter) tryStartNextPendingBuild(
 		return false, nil
 	}
 
-	err = nextPendingBuild.UseInputs(buildInputs)
-	if err != nil {
-		return false, err
+	if !inputsChosen {
+		err = nextPendingBuild.UseInputs(buildInputs)
+		if err != nil {
+			return false, err
+		}
 	}
 
 	resourceConfigs := atc.ResourceConfigs{}
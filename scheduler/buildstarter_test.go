@@ -629,5 +629,137 @@ var _ = Describe("I'm a BuildStarter", func() {
 				})
 			})
 		})
+
+		Context("when the build is a re-run", func() {
+			var rerunInputs []db.BuildInput
+
+			BeforeEach(func() {
+				job = new(dbfakes.FakeJob)
+				job.NameReturns("some-job")
+				job.ConfigReturns(atc.JobConfig{Plan: atc.PlanSequence{{Get: "input-1"}}})
+
+				rerunInputs = []db.BuildInput{
+					{
+						Name: "input-1",
+						VersionedResource: db.VersionedResource{
+							Resource: "some-resource",
+							Version:  db.ResourceVersion{"ref": "abc"},
+						},
+					},
+				}
+
+				createdBuild.RerunOfReturns(42)
+				createdBuild.InputsReturns(rerunInputs, nil)
+				createdBuild.ScheduleReturns(true, nil)
+
+				fakeUpdater.UpdateMaxInFlightReachedReturns(false, nil)
+				fakeFactory.CreateReturns(atc.Plan{}, nil)
+				fakeEngine.CreateBuildReturns(new(enginefakes.FakeBuild), nil)
+			})
+
+			JustBeforeEach(func() {
+				tryStartErr = buildStarter.TryStartPendingBuildsForJob(
+					lagertest.NewTestLogger("test"),
+					job,
+					db.Resources{resource},
+					versionedResourceTypes,
+					pendingBuilds,
+				)
+			})
+
+			It("does not check the resources or map the inputs again", func() {
+				Expect(fakeScanner.ScanCallCount()).To(BeZero())
+				Expect(fakeInputMapper.SaveNextInputMappingCallCount()).To(BeZero())
+				Expect(job.GetNextBuildInputsCallCount()).To(BeZero())
+			})
+
+			It("creates the plan with the inputs of the build it re-runs", func() {
+				Expect(tryStartErr).NotTo(HaveOccurred())
+				Expect(fakeFactory.CreateCallCount()).To(Equal(1))
+				_, _, _, actualInputs := fakeFactory.CreateArgsForCall(0)
+				Expect(actualInputs).To(Equal(rerunInputs))
+			})
+
+			It("does not save the inputs again", func() {
+				Expect(createdBuild.UseInputsCallCount()).To(BeZero())
+			})
+
+			It("starts the build", func() {
+				Expect(createdBuild.ScheduleCallCount()).To(Equal(1))
+				Expect(fakeEngine.CreateBuildCallCount()).To(Equal(1))
+			})
+
+			Context("when getting the inputs fails", func() {
+				BeforeEach(func() {
+					createdBuild.InputsReturns(nil, disaster)
+				})
+
+				It("returns the error", func() {
+					Expect(tryStartErr).To(Equal(disaster))
+				})
+
+				It("does not schedule the build", func() {
+					Expect(createdBuild.ScheduleCallCount()).To(BeZero())
+				})
+			})
+		})
+
+		Context("when the build was triggered with chosen input versions", func() {
+			var chosenInputs []db.BuildInput
+
+			BeforeEach(func() {
+				job = new(dbfakes.FakeJob)
+				job.NameReturns("some-job")
+				job.ConfigReturns(atc.JobConfig{Plan: atc.PlanSequence{{Get: "input-1"}}})
+
+				chosenInputs = []db.BuildInput{
+					{
+						Name: "input-1",
+						VersionedResource: db.VersionedResource{
+							Resource: "some-resource",
+							Version:  db.ResourceVersion{"ref": "old"},
+						},
+					},
+				}
+
+				createdBuild.IsManuallyTriggeredReturns(true)
+				createdBuild.InputOverridesReturns([]atc.BuildInputOverride{
+					{Name: "input-1", Version: atc.Version{"ref": "old"}},
+				})
+				createdBuild.InputsReturns(chosenInputs, nil)
+				createdBuild.ScheduleReturns(true, nil)
+
+				fakeUpdater.UpdateMaxInFlightReachedReturns(false, nil)
+				fakeFactory.CreateReturns(atc.Plan{}, nil)
+				fakeEngine.CreateBuildReturns(new(enginefakes.FakeBuild), nil)
+			})
+
+			JustBeforeEach(func() {
+				tryStartErr = buildStarter.TryStartPendingBuildsForJob(
+					lagertest.NewTestLogger("test"),
+					job,
+					db.Resources{resource},
+					versionedResourceTypes,
+					pendingBuilds,
+				)
+			})
+
+			It("does not check the resources or map the inputs", func() {
+				Expect(fakeScanner.ScanCallCount()).To(BeZero())
+				Expect(fakeInputMapper.SaveNextInputMappingCallCount()).To(BeZero())
+				Expect(job.GetNextBuildInputsCallCount()).To(BeZero())
+			})
+
+			It("creates the plan with the chosen inputs", func() {
+				Expect(tryStartErr).NotTo(HaveOccurred())
+				Expect(fakeFactory.CreateCallCount()).To(Equal(1))
+				_, _, _, actualInputs := fakeFactory.CreateArgsForCall(0)
+				Expect(actualInputs).To(Equal(chosenInputs))
+			})
+
+			It("does not save the inputs again", func() {
+				Expect(createdBuild.UseInputsCallCount()).To(BeZero())
+			})
+		})
 	})
 })
@@ -1,10 +1,14 @@
 package factory
 
 import (
+	"encoding/json"
 	"errors"
 
+	"github.com/cloudfoundry/bosh-cli/director/template"
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
+	"github.com/mitchellh/mapstructure"
+	yaml "gopkg.in/yaml.v2"
 )
 
 var ErrResourceNotFound = errors.New("resource not found")
@@ -99,6 +103,10 @@ func (factory *buildFactory) constructPlanFromConfig(
 	resourceTypes atc.VersionedResourceTypes,
 	inputs []db.BuildInput,
 ) (atc.Plan, error) {
+	if planConfig.Across != nil {
+		return factory.across(planConfig, resources, resourceTypes, inputs)
+	}
+
 	var plan atc.Plan
 	var err error
 
@@ -131,6 +139,97 @@ func (factory *buildFactory) constructPlanFromConfig(
 	})
 }
 
+// across constructs the step once for every combination of the across vars,
+// with the combination's values interpolated into the step's config. Hooks,
+// attempts and timeouts apply to each combination individually.
+func (factory *buildFactory) across(
+	planConfig atc.PlanConfig,
+	resources atc.ResourceConfigs,
+	resourceTypes atc.VersionedResourceTypes,
+	inputs []db.BuildInput,
+) (atc.Plan, error) {
+	across := *planConfig.Across
+	planConfig.Across = nil
+
+	acrossPlan := atc.AcrossPlan{
+		MaxInFlight: across.MaxInFlight,
+		FailFast:    across.FailFast,
+	}
+
+	for _, v := range across.Vars {
+		acrossPlan.Vars = append(acrossPlan.Vars, v.Name)
+	}
+
+	for _, values := range across.Combinations() {
+		vars := template.StaticVariables{}
+		for i, v := range across.Vars {
+			vars[v.Name] = values[i]
+		}
+
+		stepConfig, err := interpolateAcrossVars(planConfig, vars)
+		if err != nil {
+			return atc.Plan{}, err
+		}
+
+		step, err := factory.constructPlanFromConfig(
+			stepConfig,
+			resources,
+			resourceTypes,
+			inputs,
+		)
+		if err != nil {
+			return atc.Plan{}, err
+		}
+
+		acrossPlan.Steps = append(acrossPlan.Steps, atc.AcrossStep{
+			Values: values,
+			Step:   step,
+		})
+	}
+
+	return factory.planFactory.NewPlan(acrossPlan), nil
+}
+
+// interpolateAcrossVars substitutes the given vars into the plan config,
+// leaving any other ((vars)) to be resolved by the credential manager.
+func interpolateAcrossVars(planConfig atc.PlanConfig, vars template.StaticVariables) (atc.PlanConfig, error) {
+	byteConfig, err := json.Marshal(planConfig)
+	if err != nil {
+		return atc.PlanConfig{}, err
+	}
+
+	bytes, err := template.NewTemplate(byteConfig).Evaluate(vars, nil, template.EvaluateOpts{})
+	if err != nil {
+		return atc.PlanConfig{}, err
+	}
+
+	var untypedConfig interface{}
+	err = yaml.Unmarshal(bytes, &untypedConfig)
+	if err != nil {
+		return atc.PlanConfig{}, err
+	}
+
+	var interpolated atc.PlanConfig
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		Result:           &interpolated,
+		WeaklyTypedInput: true,
+		DecodeHook: mapstructure.ComposeDecodeHookFunc(
+			atc.SanitizeDecodeHook,
+			atc.VersionConfigDecodeHook,
+		),
+	})
+	if err != nil {
+		return atc.PlanConfig{}, err
+	}
+
+	err = decoder.Decode(untypedConfig)
+	if err != nil {
+		return atc.PlanConfig{}, err
+	}
+
+	return interpolated, nil
+}
+
 func (factory *buildFactory) constructUnhookedPlan(
 	planConfig atc.PlanConfig,
 	resources atc.ResourceConfigs,
@@ -240,6 +339,21 @@ func (factory *buildFactory) constructUnhookedPlan(
 
 			VersionedResourceTypes: resourceTypes,
 		})
+	case planConfig.SetPipeline != "":
+		plan = factory.planFactory.NewPlan(atc.SetPipelinePlan{
+			Name:         planConfig.SetPipeline,
+			File:         planConfig.TaskConfigPath,
+			InstanceVars: planConfig.InstanceVars,
+		})
+
+	case planConfig.LoadVar != "":
+		plan = factory.planFactory.NewPlan(atc.LoadVarPlan{
+			Name:   planConfig.LoadVar,
+			File:   planConfig.TaskConfigPath,
+			Format: planConfig.Format,
+			Reveal: planConfig.Reveal,
+		})
+
 	case planConfig.Try != nil:
 		nextStep, err := factory.constructPlanFromConfig(
 			*planConfig.Try,
@@ -282,6 +396,15 @@ func (factory *buildFactory) constructUnhookedPlan(
 		})
 	}
 
+	if planConfig.BuildMetadata != "" {
+		plan = factory.planFactory.NewPlan(atc.OnSuccessPlan{
+			Step: plan,
+			Next: factory.planFactory.NewPlan(atc.SetBuildMetadataPlan{
+				File: planConfig.BuildMetadata,
+			}),
+		})
+	}
+
 	return plan, nil
 }
 
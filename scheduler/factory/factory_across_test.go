@@ -0,0 +1,91 @@
+package factory_test
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/scheduler/factory"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Factory Across Step", func() {
+	var (
+		resourceTypes atc.VersionedResourceTypes
+
+		buildFactory        factory.BuildFactory
+		actualPlanFactory   atc.PlanFactory
+		expectedPlanFactory atc.PlanFactory
+	)
+
+	BeforeEach(func() {
+		actualPlanFactory = atc.NewPlanFactory(123)
+		expectedPlanFactory = atc.NewPlanFactory(123)
+		buildFactory = factory.NewBuildFactory(42, actualPlanFactory)
+
+		resourceTypes = atc.VersionedResourceTypes{
+			{
+				ResourceType: atc.ResourceType{
+					Name:   "some-custom-resource",
+					Type:   "docker-image",
+					Source: atc.Source{"some": "custom-source"},
+				},
+				Version: atc.Version{"some": "version"},
+			},
+		}
+	})
+
+	Context("when there is a task annotated with 'across'", func() {
+		It("builds a step for every combination of values", func() {
+			actual, err := buildFactory.Create(atc.JobConfig{
+				Plan: atc.PlanSequence{
+					{
+						Task: "unit-((go))-((os))",
+						Params: atc.Params{
+							"GO_VERSION": "((go))",
+							"SECRET":     "((some-secret))",
+						},
+						Across: &atc.AcrossConfig{
+							Vars: []atc.AcrossVarConfig{
+								{Name: "go", Values: []string{"1.8", "1.9"}},
+								{Name: "os", Values: []string{"linux"}},
+							},
+							MaxInFlight: 1,
+							FailFast:    true,
+						},
+					},
+				},
+			}, nil, resourceTypes, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			firstPlan := expectedPlanFactory.NewPlan(atc.TaskPlan{
+				Name: "unit-1.8-linux",
+				Params: atc.Params{
+					"GO_VERSION": "1.8",
+					"SECRET":     "((some-secret))",
+				},
+				VersionedResourceTypes: resourceTypes,
+			})
+
+			secondPlan := expectedPlanFactory.NewPlan(atc.TaskPlan{
+				Name: "unit-1.9-linux",
+				Params: atc.Params{
+					"GO_VERSION": "1.9",
+					"SECRET":     "((some-secret))",
+				},
+				VersionedResourceTypes: resourceTypes,
+			})
+
+			expected := expectedPlanFactory.NewPlan(atc.AcrossPlan{
+				Vars: []string{"go", "os"},
+				Steps: []atc.AcrossStep{
+					{Values: []string{"1.8", "linux"}, Step: firstPlan},
+					{Values: []string{"1.9", "linux"}, Step: secondPlan},
+				},
+				MaxInFlight: 1,
+				FailFast:    true,
+			})
+
+			Expect(actual).To(Equal(expected))
+		})
+	})
+})
@@ -0,0 +1,52 @@
+package factory_test
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/scheduler/factory"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Factory Build Metadata", func() {
+	var (
+		buildFactory        factory.BuildFactory
+		actualPlanFactory   atc.PlanFactory
+		expectedPlanFactory atc.PlanFactory
+	)
+
+	BeforeEach(func() {
+		actualPlanFactory = atc.NewPlanFactory(123)
+		expectedPlanFactory = atc.NewPlanFactory(123)
+		buildFactory = factory.NewBuildFactory(42, actualPlanFactory)
+	})
+
+	Context("when a step records build metadata", func() {
+		It("records it once the step succeeds", func() {
+			actual, err := buildFactory.Create(atc.JobConfig{
+				Plan: atc.PlanSequence{
+					{
+						Task:           "release",
+						TaskConfigPath: "some-input/release.yml",
+						BuildMetadata:  "release-output/metadata.json",
+					},
+				},
+			}, nil, nil, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			taskPlan := expectedPlanFactory.NewPlan(atc.TaskPlan{
+				Name:       "release",
+				ConfigPath: "some-input/release.yml",
+			})
+
+			expected := expectedPlanFactory.NewPlan(atc.OnSuccessPlan{
+				Step: taskPlan,
+				Next: expectedPlanFactory.NewPlan(atc.SetBuildMetadataPlan{
+					File: "release-output/metadata.json",
+				}),
+			})
+
+			Expect(actual).To(Equal(expected))
+		})
+	})
+})
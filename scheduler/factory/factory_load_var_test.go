@@ -0,0 +1,48 @@
+package factory_test
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/scheduler/factory"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Factory LoadVar Step", func() {
+	var (
+		buildFactory        factory.BuildFactory
+		actualPlanFactory   atc.PlanFactory
+		expectedPlanFactory atc.PlanFactory
+	)
+
+	BeforeEach(func() {
+		actualPlanFactory = atc.NewPlanFactory(123)
+		expectedPlanFactory = atc.NewPlanFactory(123)
+		buildFactory = factory.NewBuildFactory(42, actualPlanFactory)
+	})
+
+	Context("when there is a load_var step", func() {
+		It("builds correctly", func() {
+			actual, err := buildFactory.Create(atc.JobConfig{
+				Plan: atc.PlanSequence{
+					{
+						LoadVar:        "some-var",
+						TaskConfigPath: "some-input/version",
+						Format:         "trim",
+						Reveal:         true,
+					},
+				},
+			}, nil, nil, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			expected := expectedPlanFactory.NewPlan(atc.LoadVarPlan{
+				Name:   "some-var",
+				File:   "some-input/version",
+				Format: "trim",
+				Reveal: true,
+			})
+
+			Expect(actual).To(Equal(expected))
+		})
+	})
+})
@@ -0,0 +1,67 @@
+package factory_test
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/scheduler/factory"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Factory SetPipeline Step", func() {
+	var (
+		buildFactory        factory.BuildFactory
+		actualPlanFactory   atc.PlanFactory
+		expectedPlanFactory atc.PlanFactory
+	)
+
+	BeforeEach(func() {
+		actualPlanFactory = atc.NewPlanFactory(123)
+		expectedPlanFactory = atc.NewPlanFactory(123)
+		buildFactory = factory.NewBuildFactory(42, actualPlanFactory)
+	})
+
+	Context("when there is a set_pipeline step", func() {
+		It("builds correctly", func() {
+			actual, err := buildFactory.Create(atc.JobConfig{
+				Plan: atc.PlanSequence{
+					{
+						SetPipeline:    "some-pipeline",
+						TaskConfigPath: "some-input/pipeline.yml",
+					},
+				},
+			}, nil, nil, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			expected := expectedPlanFactory.NewPlan(atc.SetPipelinePlan{
+				Name: "some-pipeline",
+				File: "some-input/pipeline.yml",
+			})
+
+			Expect(actual).To(Equal(expected))
+		})
+
+		Context("when instance vars are given", func() {
+			It("builds a plan for the instance", func() {
+				actual, err := buildFactory.Create(atc.JobConfig{
+					Plan: atc.PlanSequence{
+						{
+							SetPipeline:    "some-pipeline",
+							TaskConfigPath: "some-input/pipeline.yml",
+							InstanceVars:   atc.InstanceVars{"branch": "some-branch"},
+						},
+					},
+				}, nil, nil, nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				expected := expectedPlanFactory.NewPlan(atc.SetPipelinePlan{
+					Name:         "some-pipeline",
+					File:         "some-input/pipeline.yml",
+					InstanceVars: atc.InstanceVars{"branch": "some-branch"},
+				})
+
+				Expect(actual).To(Equal(expected))
+			})
+		})
+	})
+})
@@ -1,7 +1,10 @@
 package inputmapper
 
 import (
+	"fmt"
+
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/algorithm"
 	"github.com/concourse/atc/scheduler/inputmapper/inputconfig"
@@ -15,6 +18,28 @@ type InputMapper interface {
 		versions *algorithm.VersionsDB,
 		job db.Job,
 	) (algorithm.InputMapping, error)
+
+	MapInputsWithOverrides(
+		logger lager.Logger,
+		versions *algorithm.VersionsDB,
+		job db.Job,
+		overrides []atc.BuildInputOverride,
+	) (algorithm.InputMapping, error)
+}
+
+// An InputOverrideError is returned when the versions chosen for the inputs of
+// a build cannot be used.
+type InputOverrideError struct {
+	Input  string
+	Reason string
+}
+
+func (err InputOverrideError) Error() string {
+	if err.Input == "" {
+		return err.Reason
+	}
+
+	return fmt.Sprintf("input '%s': %s", err.Input, err.Reason)
 }
 
 func NewInputMapper(pipeline db.Pipeline, transformer inputconfig.Transformer) InputMapper {
@@ -83,3 +108,83 @@ func (i *inputMapper) SaveNextInputMapping(
 
 	return resolvedMapping, nil
 }
+
+// MapInputsWithOverrides maps the inputs of the job to the chosen versions,
+// and the rest of the inputs to the versions the scheduler would choose. The
+// chosen versions must have been saved for the resource and, unless the
+// override ignores them, satisfy the input's passed constraints.
+func (i *inputMapper) MapInputsWithOverrides(
+	logger lager.Logger,
+	versions *algorithm.VersionsDB,
+	job db.Job,
+	overrides []atc.BuildInputOverride,
+) (algorithm.InputMapping, error) {
+	logger = logger.Session("map-inputs-with-overrides")
+
+	inputConfigs := job.Config().Inputs()
+
+	algorithmInputConfigs, err := i.transformer.TransformInputConfigs(versions, job.Name(), inputConfigs)
+	if err != nil {
+		logger.Error("failed-to-get-algorithm-input-configs", err)
+		return nil, err
+	}
+
+	chosenVersionIDs := map[string]int{}
+
+	for _, override := range overrides {
+		var resourceName string
+		for _, input := range inputConfigs {
+			if input.Name == override.Name {
+				resourceName = input.Resource
+				break
+			}
+		}
+
+		if resourceName == "" {
+			return nil, InputOverrideError{Input: override.Name, Reason: "not an input of the job"}
+		}
+
+		savedVersion, found, err := i.pipeline.GetVersionedResourceByVersion(override.Version, resourceName)
+		if err != nil {
+			logger.Error("failed-to-get-versioned-resource", err, lager.Data{"input": override.Name})
+			return nil, err
+		}
+
+		if !found {
+			return nil, InputOverrideError{Input: override.Name, Reason: "version not found"}
+		}
+
+		chosenVersionIDs[override.Name] = savedVersion.ID
+
+		for j, inputConfig := range algorithmInputConfigs {
+			if inputConfig.Name != override.Name {
+				continue
+			}
+
+			algorithmInputConfigs[j].PinnedVersionID = savedVersion.ID
+			algorithmInputConfigs[j].UseEveryVersion = false
+
+			if override.IgnorePassed {
+				algorithmInputConfigs[j].Passed = algorithm.JobSet{}
+			}
+		}
+	}
+
+	if len(algorithmInputConfigs) < len(inputConfigs) {
+		return nil, InputOverrideError{Reason: "a version pinned in the job's config was not found"}
+	}
+
+	mapping, ok := algorithmInputConfigs.Resolve(versions)
+	if !ok {
+		return nil, InputOverrideError{Reason: "no versions satisfy the passed constraints of the job with the chosen versions"}
+	}
+
+	// a resource pinned via the API wins over the chosen version
+	for inputName, versionID := range chosenVersionIDs {
+		if mapping[inputName].VersionID != versionID {
+			return nil, InputOverrideError{Input: inputName, Reason: "the resource is pinned to another version"}
+		}
+	}
+
+	return mapping, nil
+}
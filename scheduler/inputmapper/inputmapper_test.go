@@ -5,6 +5,7 @@ import (
 
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/algorithm"
 	"github.com/concourse/atc/db/dbfakes"
 	"github.com/concourse/atc/scheduler/inputmapper"
@@ -378,4 +379,169 @@ var _ = Describe("Inputmapper", func() {
 			})
 		})
 	})
+
+	Describe("MapInputsWithOverrides", func() {
+		var (
+			versionsDB   *algorithm.VersionsDB
+			fakeJob      *dbfakes.FakeJob
+			overrides    []atc.BuildInputOverride
+			inputMapping algorithm.InputMapping
+			mappingErr   error
+		)
+
+		BeforeEach(func() {
+			versionsDB = &algorithm.VersionsDB{
+				JobIDs:      map[string]int{"some-job": 1, "upstream": 2},
+				ResourceIDs: map[string]int{"a": 11, "b": 12},
+				ResourceVersions: []algorithm.ResourceVersion{
+					{VersionID: 1, ResourceID: 11, CheckOrder: 1},
+					{VersionID: 3, ResourceID: 11, CheckOrder: 2},
+					{VersionID: 2, ResourceID: 12, CheckOrder: 1},
+					{VersionID: 4, ResourceID: 12, CheckOrder: 2},
+				},
+				BuildOutputs: []algorithm.BuildOutput{
+					{
+						ResourceVersion: algorithm.ResourceVersion{VersionID: 1, ResourceID: 11, CheckOrder: 1},
+						BuildID:         98,
+						JobID:           2,
+					},
+				},
+			}
+
+			fakeJob = new(dbfakes.FakeJob)
+			fakeJob.NameReturns("some-job")
+			fakeJob.ConfigReturns(atc.JobConfig{
+				Plan: atc.PlanSequence{
+					{Get: "a", Passed: []string{"upstream"}},
+					{Get: "b"},
+				},
+			})
+
+			fakeTransformer.TransformInputConfigsReturns(algorithm.InputConfigs{
+				{
+					Name:       "a",
+					ResourceID: 11,
+					Passed:     algorithm.JobSet{2: struct{}{}},
+					JobID:      1,
+				},
+				{
+					Name:       "b",
+					ResourceID: 12,
+					Passed:     algorithm.JobSet{},
+					JobID:      1,
+				},
+			}, nil)
+		})
+
+		JustBeforeEach(func() {
+			inputMapping, mappingErr = inputMapper.MapInputsWithOverrides(
+				lagertest.NewTestLogger("test"),
+				versionsDB,
+				fakeJob,
+				overrides,
+			)
+		})
+
+		Context("when an input without passed constraints is given an older version", func() {
+			BeforeEach(func() {
+				overrides = []atc.BuildInputOverride{
+					{Name: "b", Version: atc.Version{"ref": "old"}},
+				}
+
+				fakePipeline.GetVersionedResourceByVersionReturns(db.SavedVersionedResource{ID: 2}, true, nil)
+			})
+
+			It("looks up the version of the input's resource", func() {
+				Expect(fakePipeline.GetVersionedResourceByVersionCallCount()).To(Equal(1))
+				version, resourceName := fakePipeline.GetVersionedResourceByVersionArgsForCall(0)
+				Expect(version).To(Equal(atc.Version{"ref": "old"}))
+				Expect(resourceName).To(Equal("b"))
+			})
+
+			It("uses the chosen version and the latest versions of the rest", func() {
+				Expect(mappingErr).NotTo(HaveOccurred())
+				Expect(inputMapping).To(Equal(algorithm.InputMapping{
+					"a": algorithm.InputVersion{VersionID: 1, FirstOccurrence: true},
+					"b": algorithm.InputVersion{VersionID: 2, FirstOccurrence: true},
+				}))
+			})
+
+			It("does not save the next input mapping of the job", func() {
+				Expect(fakeJob.SaveNextInputMappingCallCount()).To(BeZero())
+				Expect(fakeJob.SaveIndependentInputMappingCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when the chosen version has not passed the constrained jobs", func() {
+			BeforeEach(func() {
+				overrides = []atc.BuildInputOverride{
+					{Name: "a", Version: atc.Version{"ref": "new"}},
+				}
+
+				fakePipeline.GetVersionedResourceByVersionReturns(db.SavedVersionedResource{ID: 3}, true, nil)
+			})
+
+			It("returns an InputOverrideError", func() {
+				Expect(mappingErr).To(BeAssignableToTypeOf(inputmapper.InputOverrideError{}))
+			})
+
+			Context("when the override ignores the passed constraints", func() {
+				BeforeEach(func() {
+					overrides[0].IgnorePassed = true
+				})
+
+				It("uses the chosen version", func() {
+					Expect(mappingErr).NotTo(HaveOccurred())
+					Expect(inputMapping).To(Equal(algorithm.InputMapping{
+						"a": algorithm.InputVersion{VersionID: 3, FirstOccurrence: true},
+						"b": algorithm.InputVersion{VersionID: 4, FirstOccurrence: true},
+					}))
+				})
+			})
+		})
+
+		Context("when the chosen version is not found", func() {
+			BeforeEach(func() {
+				overrides = []atc.BuildInputOverride{
+					{Name: "b", Version: atc.Version{"ref": "missing"}},
+				}
+
+				fakePipeline.GetVersionedResourceByVersionReturns(db.SavedVersionedResource{}, false, nil)
+			})
+
+			It("returns an InputOverrideError", func() {
+				Expect(mappingErr).To(Equal(inputmapper.InputOverrideError{Input: "b", Reason: "version not found"}))
+			})
+		})
+
+		Context("when looking up the chosen version fails", func() {
+			BeforeEach(func() {
+				overrides = []atc.BuildInputOverride{
+					{Name: "b", Version: atc.Version{"ref": "old"}},
+				}
+
+				fakePipeline.GetVersionedResourceByVersionReturns(db.SavedVersionedResource{}, false, disaster)
+			})
+
+			It("returns the error", func() {
+				Expect(mappingErr).To(Equal(disaster))
+			})
+		})
+
+		Context("when the override is not an input of the job", func() {
+			BeforeEach(func() {
+				overrides = []atc.BuildInputOverride{
+					{Name: "bogus", Version: atc.Version{"ref": "old"}},
+				}
+			})
+
+			It("returns an InputOverrideError", func() {
+				Expect(mappingErr).To(Equal(inputmapper.InputOverrideError{Input: "bogus", Reason: "not an input of the job"}))
+			})
+
+			It("does not look up the version", func() {
+				Expect(fakePipeline.GetVersionedResourceByVersionCallCount()).To(BeZero())
+			})
+		})
+	})
 })
@@ -5,6 +5,7 @@ import (
 	"sync"
 
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/algorithm"
 	"github.com/concourse/atc/scheduler/inputmapper"
@@ -26,6 +27,22 @@ type FakeInputMapper struct {
 		result1 algorithm.InputMapping
 		result2 error
 	}
+	MapInputsWithOverridesStub        func(logger lager.Logger, versions *algorithm.VersionsDB, job db.Job, overrides []atc.BuildInputOverride) (algorithm.InputMapping, error)
+	mapInputsWithOverridesMutex       sync.RWMutex
+	mapInputsWithOverridesArgsForCall []struct {
+		logger    lager.Logger
+		versions  *algorithm.VersionsDB
+		job       db.Job
+		overrides []atc.BuildInputOverride
+	}
+	mapInputsWithOverridesReturns struct {
+		result1 algorithm.InputMapping
+		result2 error
+	}
+	mapInputsWithOverridesReturnsOnCall map[int]struct {
+		result1 algorithm.InputMapping
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -83,11 +100,72 @@ func (fake *FakeInputMapper) SaveNextInputMappingReturnsOnCall(i int, result1 al
 	}{result1, result2}
 }
 
+func (fake *FakeInputMapper) MapInputsWithOverrides(logger lager.Logger, versions *algorithm.VersionsDB, job db.Job, overrides []atc.BuildInputOverride) (algorithm.InputMapping, error) {
+	var overridesCopy []atc.BuildInputOverride
+	if overrides != nil {
+		overridesCopy = make([]atc.BuildInputOverride, len(overrides))
+		copy(overridesCopy, overrides)
+	}
+	fake.mapInputsWithOverridesMutex.Lock()
+	ret, specificReturn := fake.mapInputsWithOverridesReturnsOnCall[len(fake.mapInputsWithOverridesArgsForCall)]
+	fake.mapInputsWithOverridesArgsForCall = append(fake.mapInputsWithOverridesArgsForCall, struct {
+		logger    lager.Logger
+		versions  *algorithm.VersionsDB
+		job       db.Job
+		overrides []atc.BuildInputOverride
+	}{logger, versions, job, overridesCopy})
+	fake.recordInvocation("MapInputsWithOverrides", []interface{}{logger, versions, job, overridesCopy})
+	fake.mapInputsWithOverridesMutex.Unlock()
+	if fake.MapInputsWithOverridesStub != nil {
+		return fake.MapInputsWithOverridesStub(logger, versions, job, overrides)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.mapInputsWithOverridesReturns.result1, fake.mapInputsWithOverridesReturns.result2
+}
+
+func (fake *FakeInputMapper) MapInputsWithOverridesCallCount() int {
+	fake.mapInputsWithOverridesMutex.RLock()
+	defer fake.mapInputsWithOverridesMutex.RUnlock()
+	return len(fake.mapInputsWithOverridesArgsForCall)
+}
+
+func (fake *FakeInputMapper) MapInputsWithOverridesArgsForCall(i int) (lager.Logger, *algorithm.VersionsDB, db.Job, []atc.BuildInputOverride) {
+	fake.mapInputsWithOverridesMutex.RLock()
+	defer fake.mapInputsWithOverridesMutex.RUnlock()
+	return fake.mapInputsWithOverridesArgsForCall[i].logger, fake.mapInputsWithOverridesArgsForCall[i].versions, fake.mapInputsWithOverridesArgsForCall[i].job, fake.mapInputsWithOverridesArgsForCall[i].overrides
+}
+
+func (fake *FakeInputMapper) MapInputsWithOverridesReturns(result1 algorithm.InputMapping, result2 error) {
+	fake.MapInputsWithOverridesStub = nil
+	fake.mapInputsWithOverridesReturns = struct {
+		result1 algorithm.InputMapping
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeInputMapper) MapInputsWithOverridesReturnsOnCall(i int, result1 algorithm.InputMapping, result2 error) {
+	fake.MapInputsWithOverridesStub = nil
+	if fake.mapInputsWithOverridesReturnsOnCall == nil {
+		fake.mapInputsWithOverridesReturnsOnCall = make(map[int]struct {
+			result1 algorithm.InputMapping
+			result2 error
+		})
+	}
+	fake.mapInputsWithOverridesReturnsOnCall[i] = struct {
+		result1 algorithm.InputMapping
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeInputMapper) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.saveNextInputMappingMutex.RLock()
 	defer fake.saveNextInputMappingMutex.RUnlock()
+	fake.mapInputsWithOverridesMutex.RLock()
+	defer fake.mapInputsWithOverridesMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -30,6 +30,22 @@ type BuildScheduler interface {
 		resourceTypes atc.VersionedResourceTypes,
 	) (db.Build, Waiter, error)
 
+	TriggerImmediatelyWithInputs(
+		logger lager.Logger,
+		job db.Job,
+		overrides []atc.BuildInputOverride,
+		resources db.Resources,
+		resourceTypes atc.VersionedResourceTypes,
+	) (db.Build, Waiter, error)
+
+	RerunImmediately(
+		logger lager.Logger,
+		job db.Job,
+		build db.Build,
+		resources db.Resources,
+		resourceTypes atc.VersionedResourceTypes,
+	) (db.Build, Waiter, error)
+
 	SaveNextInputMapping(logger lager.Logger, job db.Job) error
 }
 
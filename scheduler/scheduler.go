@@ -9,6 +9,7 @@ import (
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/algorithm"
 	"github.com/concourse/atc/scheduler/inputmapper"
+	"github.com/concourse/atc/tracing"
 )
 
 type Scheduler struct {
@@ -30,6 +31,24 @@ func (s *Scheduler) Schedule(
 	jobs []db.Job,
 	resources db.Resources,
 	resourceTypes atc.VersionedResourceTypes,
+) (map[string]time.Duration, error) {
+	span := tracing.StartSpan("scheduler.schedule", tracing.Attrs{
+		"pipeline": s.Pipeline.Name(),
+	})
+
+	jobSchedulingTime, err := s.schedule(logger, versions, jobs, resources, resourceTypes)
+
+	span.End(err)
+
+	return jobSchedulingTime, err
+}
+
+func (s *Scheduler) schedule(
+	logger lager.Logger,
+	versions *algorithm.VersionsDB,
+	jobs []db.Job,
+	resources db.Resources,
+	resourceTypes atc.VersionedResourceTypes,
 ) (map[string]time.Duration, error) {
 	jobSchedulingTime := map[string]time.Duration{}
 
@@ -112,6 +131,94 @@ func (s *Scheduler) TriggerImmediately(
 		logger.Error("failed-to-create-job-build", err)
 		return nil, nil, err
 	}
+
+	return build, s.startPendingBuilds(logger, job, resources, resourceTypes), nil
+}
+
+// TriggerImmediatelyWithInputs creates a build of the job which uses the chosen
+// versions for the overridden inputs, and the latest versions for the rest, and
+// tries to start it right away. The other inputs' resources are checked first,
+// so that their latest versions are used.
+func (s *Scheduler) TriggerImmediatelyWithInputs(
+	logger lager.Logger,
+	job db.Job,
+	overrides []atc.BuildInputOverride,
+	resources db.Resources,
+	resourceTypes atc.VersionedResourceTypes,
+) (db.Build, Waiter, error) {
+	logger = logger.Session("trigger-immediately-with-inputs", lager.Data{"job_name": job.Name()})
+
+	overridden := map[string]bool{}
+	for _, override := range overrides {
+		overridden[override.Name] = true
+	}
+
+	for _, input := range job.Config().Inputs() {
+		if overridden[input.Name] {
+			continue
+		}
+
+		scanLog := logger.Session("scan", lager.Data{
+			"input":    input.Name,
+			"resource": input.Resource,
+		})
+
+		err := s.Scanner.Scan(scanLog, input.Resource)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	versions, err := s.Pipeline.LoadVersionsDB()
+	if err != nil {
+		logger.Error("failed-to-load-versions-db", err)
+		return nil, nil, err
+	}
+
+	inputMapping, err := s.InputMapper.MapInputsWithOverrides(logger, versions, job, overrides)
+	if err != nil {
+		logger.Info("failed-to-map-inputs-with-overrides", lager.Data{"error": err.Error()})
+		return nil, nil, err
+	}
+
+	build, err := job.CreateBuildWithInputs(inputMapping, overrides)
+	if err != nil {
+		logger.Error("failed-to-create-job-build", err)
+		return nil, nil, err
+	}
+
+	return build, s.startPendingBuilds(logger, job, resources, resourceTypes), nil
+}
+
+// RerunImmediately creates a build of the job which uses the same inputs as
+// the given build, and tries to start it right away.
+func (s *Scheduler) RerunImmediately(
+	logger lager.Logger,
+	job db.Job,
+	build db.Build,
+	resources db.Resources,
+	resourceTypes atc.VersionedResourceTypes,
+) (db.Build, Waiter, error) {
+	logger = logger.Session("rerun-immediately", lager.Data{
+		"job_name": job.Name(),
+		"build_id": build.ID(),
+	})
+
+	rerunBuild, err := job.RerunBuild(build)
+	if err != nil {
+		logger.Error("failed-to-create-rerun-build", err)
+		return nil, nil, err
+	}
+
+	return rerunBuild, s.startPendingBuilds(logger, job, resources, resourceTypes), nil
+}
+
+func (s *Scheduler) startPendingBuilds(
+	logger lager.Logger,
+	job db.Job,
+	resources db.Resources,
+	resourceTypes atc.VersionedResourceTypes,
+) Waiter {
 	wg := new(sync.WaitGroup)
 	wg.Add(1)
 
@@ -131,7 +238,7 @@ func (s *Scheduler) TriggerImmediately(
 		}
 	}()
 
-	return build, wg, nil
+	return wg
 }
 
 func (s *Scheduler) SaveNextInputMapping(logger lager.Logger, job db.Job) error {
@@ -347,6 +347,210 @@ var _ = Describe("Scheduler", func() {
 		})
 	})
 
+	Describe("TriggerImmediatelyWithInputs", func() {
+		var (
+			fakeJob        *dbfakes.FakeJob
+			overrides      []atc.BuildInputOverride
+			versionsDB     *algorithm.VersionsDB
+			inputMapping   algorithm.InputMapping
+			triggeredBuild db.Build
+			triggerErr     error
+		)
+
+		BeforeEach(func() {
+			fakeJob = new(dbfakes.FakeJob)
+			fakeJob.NameReturns("some-job")
+			fakeJob.ConfigReturns(atc.JobConfig{Plan: atc.PlanSequence{
+				{Get: "input-1", Resource: "some-resource"},
+				{Get: "input-2", Resource: "some-other-resource"},
+			}})
+
+			overrides = []atc.BuildInputOverride{
+				{Name: "input-1", Version: atc.Version{"ref": "old"}},
+			}
+
+			versionsDB = &algorithm.VersionsDB{JobIDs: map[string]int{"some-job": 1}}
+			fakePipeline.LoadVersionsDBReturns(versionsDB, nil)
+
+			inputMapping = algorithm.InputMapping{
+				"input-1": algorithm.InputVersion{VersionID: 1},
+				"input-2": algorithm.InputVersion{VersionID: 2},
+			}
+			fakeInputMapper.MapInputsWithOverridesReturns(inputMapping, nil)
+		})
+
+		JustBeforeEach(func() {
+			var waiter Waiter
+			triggeredBuild, waiter, triggerErr = scheduler.TriggerImmediatelyWithInputs(
+				lagertest.NewTestLogger("test"),
+				fakeJob,
+				overrides,
+				db.Resources{},
+				atc.VersionedResourceTypes{},
+			)
+			if waiter != nil {
+				waiter.Wait()
+			}
+		})
+
+		It("checks the resources of the inputs which are not overridden", func() {
+			Expect(fakeScanner.ScanCallCount()).To(Equal(1))
+			_, resourceName := fakeScanner.ScanArgsForCall(0)
+			Expect(resourceName).To(Equal("some-other-resource"))
+		})
+
+		It("maps the inputs with the overrides", func() {
+			Expect(fakeInputMapper.MapInputsWithOverridesCallCount()).To(Equal(1))
+			_, actualVersionsDB, actualJob, actualOverrides := fakeInputMapper.MapInputsWithOverridesArgsForCall(0)
+			Expect(actualVersionsDB).To(Equal(versionsDB))
+			Expect(actualJob).To(Equal(fakeJob))
+			Expect(actualOverrides).To(Equal(overrides))
+		})
+
+		Context("when checking a resource fails", func() {
+			BeforeEach(func() {
+				fakeScanner.ScanReturns(disaster)
+			})
+
+			It("returns the error", func() {
+				Expect(triggerErr).To(Equal(disaster))
+			})
+
+			It("does not create a build", func() {
+				Expect(fakeJob.CreateBuildWithInputsCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when mapping the inputs fails", func() {
+			BeforeEach(func() {
+				fakeInputMapper.MapInputsWithOverridesReturns(nil, disaster)
+			})
+
+			It("returns the error", func() {
+				Expect(triggerErr).To(Equal(disaster))
+			})
+
+			It("does not create a build", func() {
+				Expect(fakeJob.CreateBuildWithInputsCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when creating the build fails", func() {
+			BeforeEach(func() {
+				fakeJob.CreateBuildWithInputsReturns(nil, disaster)
+			})
+
+			It("returns the error", func() {
+				Expect(triggerErr).To(Equal(disaster))
+			})
+
+			It("does not try to start pending builds for job", func() {
+				Expect(fakeBuildStarter.TryStartPendingBuildsForJobCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when creating the build succeeds", func() {
+			var createdBuild *dbfakes.FakeBuild
+
+			BeforeEach(func() {
+				createdBuild = new(dbfakes.FakeBuild)
+				fakeJob.CreateBuildWithInputsReturns(createdBuild, nil)
+				fakeJob.GetPendingBuildsReturns([]db.Build{createdBuild}, nil)
+			})
+
+			It("creates the build with the mapped inputs and the overrides", func() {
+				Expect(fakeJob.CreateBuildWithInputsCallCount()).To(Equal(1))
+				actualMapping, actualOverrides := fakeJob.CreateBuildWithInputsArgsForCall(0)
+				Expect(actualMapping).To(Equal(inputMapping))
+				Expect(actualOverrides).To(Equal(overrides))
+			})
+
+			It("returns the created build", func() {
+				Expect(triggerErr).NotTo(HaveOccurred())
+				Expect(triggeredBuild).To(Equal(createdBuild))
+			})
+
+			It("tries to start the pending builds of the job", func() {
+				Expect(fakeBuildStarter.TryStartPendingBuildsForJobCallCount()).To(Equal(1))
+			})
+		})
+	})
+
+	Describe("RerunImmediately", func() {
+		var (
+			fakeJob       *dbfakes.FakeJob
+			originalBuild *dbfakes.FakeBuild
+			rerunBuild    db.Build
+			rerunErr      error
+			pendingBuilds []db.Build
+		)
+
+		BeforeEach(func() {
+			fakeJob = new(dbfakes.FakeJob)
+			fakeJob.NameReturns("some-job")
+
+			originalBuild = new(dbfakes.FakeBuild)
+			originalBuild.IDReturns(42)
+		})
+
+		JustBeforeEach(func() {
+			var waiter Waiter
+			rerunBuild, waiter, rerunErr = scheduler.RerunImmediately(
+				lagertest.NewTestLogger("test"),
+				fakeJob,
+				originalBuild,
+				db.Resources{},
+				atc.VersionedResourceTypes{},
+			)
+			if waiter != nil {
+				waiter.Wait()
+			}
+		})
+
+		Context("when creating the re-run fails", func() {
+			BeforeEach(func() {
+				fakeJob.RerunBuildReturns(nil, disaster)
+			})
+
+			It("returns the error", func() {
+				Expect(rerunErr).To(Equal(disaster))
+			})
+
+			It("does not try to start pending builds for job", func() {
+				Expect(fakeBuildStarter.TryStartPendingBuildsForJobCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when creating the re-run succeeds", func() {
+			var createdBuild *dbfakes.FakeBuild
+
+			BeforeEach(func() {
+				createdBuild = new(dbfakes.FakeBuild)
+				createdBuild.RerunOfReturns(42)
+				fakeJob.RerunBuildReturns(createdBuild, nil)
+
+				pendingBuilds = []db.Build{createdBuild}
+				fakeJob.GetPendingBuildsReturns(pendingBuilds, nil)
+			})
+
+			It("re-runs the given build", func() {
+				Expect(fakeJob.RerunBuildCallCount()).To(Equal(1))
+				Expect(fakeJob.RerunBuildArgsForCall(0)).To(Equal(originalBuild))
+			})
+
+			It("returns the created build", func() {
+				Expect(rerunErr).NotTo(HaveOccurred())
+				Expect(rerunBuild).To(Equal(createdBuild))
+			})
+
+			It("tries to start the pending builds of the job", func() {
+				Expect(fakeBuildStarter.TryStartPendingBuildsForJobCallCount()).To(Equal(1))
+				_, _, _, _, b := fakeBuildStarter.TryStartPendingBuildsForJobArgsForCall(0)
+				Expect(b).To(Equal(pendingBuilds))
+			})
+		})
+	})
+
 	Describe("SaveNextInputMapping", func() {
 		var saveErr error
 		var fakeJob *dbfakes.FakeJob
@@ -60,6 +60,44 @@ type FakeBuildScheduler struct {
 	saveNextInputMappingReturnsOnCall map[int]struct {
 		result1 error
 	}
+	RerunImmediatelyStub        func(logger lager.Logger, job db.Job, build db.Build, resources db.Resources, resourceTypes atc.VersionedResourceTypes) (db.Build, scheduler.Waiter, error)
+	rerunImmediatelyMutex       sync.RWMutex
+	rerunImmediatelyArgsForCall []struct {
+		logger        lager.Logger
+		job           db.Job
+		build         db.Build
+		resources     db.Resources
+		resourceTypes atc.VersionedResourceTypes
+	}
+	rerunImmediatelyReturns struct {
+		result1 db.Build
+		result2 scheduler.Waiter
+		result3 error
+	}
+	rerunImmediatelyReturnsOnCall map[int]struct {
+		result1 db.Build
+		result2 scheduler.Waiter
+		result3 error
+	}
+	TriggerImmediatelyWithInputsStub        func(logger lager.Logger, job db.Job, overrides []atc.BuildInputOverride, resources db.Resources, resourceTypes atc.VersionedResourceTypes) (db.Build, scheduler.Waiter, error)
+	triggerImmediatelyWithInputsMutex       sync.RWMutex
+	triggerImmediatelyWithInputsArgsForCall []struct {
+		logger        lager.Logger
+		job           db.Job
+		overrides     []atc.BuildInputOverride
+		resources     db.Resources
+		resourceTypes atc.VersionedResourceTypes
+	}
+	triggerImmediatelyWithInputsReturns struct {
+		result1 db.Build
+		result2 scheduler.Waiter
+		result3 error
+	}
+	triggerImmediatelyWithInputsReturnsOnCall map[int]struct {
+		result1 db.Build
+		result2 scheduler.Waiter
+		result3 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -230,6 +268,127 @@ func (fake *FakeBuildScheduler) SaveNextInputMappingReturnsOnCall(i int, result1
 	}{result1}
 }
 
+func (fake *FakeBuildScheduler) RerunImmediately(logger lager.Logger, job db.Job, build db.Build, resources db.Resources, resourceTypes atc.VersionedResourceTypes) (db.Build, scheduler.Waiter, error) {
+	fake.rerunImmediatelyMutex.Lock()
+	ret, specificReturn := fake.rerunImmediatelyReturnsOnCall[len(fake.rerunImmediatelyArgsForCall)]
+	fake.rerunImmediatelyArgsForCall = append(fake.rerunImmediatelyArgsForCall, struct {
+		logger        lager.Logger
+		job           db.Job
+		build         db.Build
+		resources     db.Resources
+		resourceTypes atc.VersionedResourceTypes
+	}{logger, job, build, resources, resourceTypes})
+	fake.recordInvocation("RerunImmediately", []interface{}{logger, job, build, resources, resourceTypes})
+	fake.rerunImmediatelyMutex.Unlock()
+	if fake.RerunImmediatelyStub != nil {
+		return fake.RerunImmediatelyStub(logger, job, build, resources, resourceTypes)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fake.rerunImmediatelyReturns.result1, fake.rerunImmediatelyReturns.result2, fake.rerunImmediatelyReturns.result3
+}
+
+func (fake *FakeBuildScheduler) RerunImmediatelyCallCount() int {
+	fake.rerunImmediatelyMutex.RLock()
+	defer fake.rerunImmediatelyMutex.RUnlock()
+	return len(fake.rerunImmediatelyArgsForCall)
+}
+
+func (fake *FakeBuildScheduler) RerunImmediatelyArgsForCall(i int) (lager.Logger, db.Job, db.Build, db.Resources, atc.VersionedResourceTypes) {
+	fake.rerunImmediatelyMutex.RLock()
+	defer fake.rerunImmediatelyMutex.RUnlock()
+	return fake.rerunImmediatelyArgsForCall[i].logger, fake.rerunImmediatelyArgsForCall[i].job, fake.rerunImmediatelyArgsForCall[i].build, fake.rerunImmediatelyArgsForCall[i].resources, fake.rerunImmediatelyArgsForCall[i].resourceTypes
+}
+
+func (fake *FakeBuildScheduler) RerunImmediatelyReturns(result1 db.Build, result2 scheduler.Waiter, result3 error) {
+	fake.RerunImmediatelyStub = nil
+	fake.rerunImmediatelyReturns = struct {
+		result1 db.Build
+		result2 scheduler.Waiter
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeBuildScheduler) RerunImmediatelyReturnsOnCall(i int, result1 db.Build, result2 scheduler.Waiter, result3 error) {
+	fake.RerunImmediatelyStub = nil
+	if fake.rerunImmediatelyReturnsOnCall == nil {
+		fake.rerunImmediatelyReturnsOnCall = make(map[int]struct {
+			result1 db.Build
+			result2 scheduler.Waiter
+			result3 error
+		})
+	}
+	fake.rerunImmediatelyReturnsOnCall[i] = struct {
+		result1 db.Build
+		result2 scheduler.Waiter
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeBuildScheduler) TriggerImmediatelyWithInputs(logger lager.Logger, job db.Job, overrides []atc.BuildInputOverride, resources db.Resources, resourceTypes atc.VersionedResourceTypes) (db.Build, scheduler.Waiter, error) {
+	var overridesCopy []atc.BuildInputOverride
+	if overrides != nil {
+		overridesCopy = make([]atc.BuildInputOverride, len(overrides))
+		copy(overridesCopy, overrides)
+	}
+	fake.triggerImmediatelyWithInputsMutex.Lock()
+	ret, specificReturn := fake.triggerImmediatelyWithInputsReturnsOnCall[len(fake.triggerImmediatelyWithInputsArgsForCall)]
+	fake.triggerImmediatelyWithInputsArgsForCall = append(fake.triggerImmediatelyWithInputsArgsForCall, struct {
+		logger        lager.Logger
+		job           db.Job
+		overrides     []atc.BuildInputOverride
+		resources     db.Resources
+		resourceTypes atc.VersionedResourceTypes
+	}{logger, job, overridesCopy, resources, resourceTypes})
+	fake.recordInvocation("TriggerImmediatelyWithInputs", []interface{}{logger, job, overridesCopy, resources, resourceTypes})
+	fake.triggerImmediatelyWithInputsMutex.Unlock()
+	if fake.TriggerImmediatelyWithInputsStub != nil {
+		return fake.TriggerImmediatelyWithInputsStub(logger, job, overrides, resources, resourceTypes)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fake.triggerImmediatelyWithInputsReturns.result1, fake.triggerImmediatelyWithInputsReturns.result2, fake.triggerImmediatelyWithInputsReturns.result3
+}
+
+func (fake *FakeBuildScheduler) TriggerImmediatelyWithInputsCallCount() int {
+	fake.triggerImmediatelyWithInputsMutex.RLock()
+	defer fake.triggerImmediatelyWithInputsMutex.RUnlock()
+	return len(fake.triggerImmediatelyWithInputsArgsForCall)
+}
+
+func (fake *FakeBuildScheduler) TriggerImmediatelyWithInputsArgsForCall(i int) (lager.Logger, db.Job, []atc.BuildInputOverride, db.Resources, atc.VersionedResourceTypes) {
+	fake.triggerImmediatelyWithInputsMutex.RLock()
+	defer fake.triggerImmediatelyWithInputsMutex.RUnlock()
+	return fake.triggerImmediatelyWithInputsArgsForCall[i].logger, fake.triggerImmediatelyWithInputsArgsForCall[i].job, fake.triggerImmediatelyWithInputsArgsForCall[i].overrides, fake.triggerImmediatelyWithInputsArgsForCall[i].resources, fake.triggerImmediatelyWithInputsArgsForCall[i].resourceTypes
+}
+
+func (fake *FakeBuildScheduler) TriggerImmediatelyWithInputsReturns(result1 db.Build, result2 scheduler.Waiter, result3 error) {
+	fake.TriggerImmediatelyWithInputsStub = nil
+	fake.triggerImmediatelyWithInputsReturns = struct {
+		result1 db.Build
+		result2 scheduler.Waiter
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeBuildScheduler) TriggerImmediatelyWithInputsReturnsOnCall(i int, result1 db.Build, result2 scheduler.Waiter, result3 error) {
+	fake.TriggerImmediatelyWithInputsStub = nil
+	if fake.triggerImmediatelyWithInputsReturnsOnCall == nil {
+		fake.triggerImmediatelyWithInputsReturnsOnCall = make(map[int]struct {
+			result1 db.Build
+			result2 scheduler.Waiter
+			result3 error
+		})
+	}
+	fake.triggerImmediatelyWithInputsReturnsOnCall[i] = struct {
+		result1 db.Build
+		result2 scheduler.Waiter
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeBuildScheduler) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -239,6 +398,10 @@ func (fake *FakeBuildScheduler) Invocations() map[string][][]interface{} {
 	defer fake.triggerImmediatelyMutex.RUnlock()
 	fake.saveNextInputMappingMutex.RLock()
 	defer fake.saveNextInputMappingMutex.RUnlock()
+	fake.rerunImmediatelyMutex.RLock()
+	defer fake.rerunImmediatelyMutex.RUnlock()
+	fake.triggerImmediatelyWithInputsMutex.RLock()
+	defer fake.triggerImmediatelyWithInputsMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
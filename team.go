@@ -7,4 +7,91 @@ type Team struct {
 	Name string `json:"name,omitempty"`
 
 	Auth map[string]*json.RawMessage `json:"auth,omitempty"`
+
+	Roles TeamRoles `json:"roles,omitempty"`
+}
+
+const (
+	OwnerRole            = "owner"
+	MemberRole           = "member"
+	PipelineOperatorRole = "pipeline-operator"
+	ViewerRole           = "viewer"
+)
+
+// Roles lists the roles a user can have within a team, from most to least
+// privileged. A role grants everything the roles after it grant.
+var Roles = []string{
+	OwnerRole,
+	MemberRole,
+	PipelineOperatorRole,
+	ViewerRole,
+}
+
+// TeamRoles maps a role to the members granted it, per auth provider.
+type TeamRoles map[string]map[string]RoleMembers
+
+type RoleMembers struct {
+	Users  []string `json:"users,omitempty"`
+	Groups []string `json:"groups,omitempty"`
+}
+
+func IsValidRole(role string) bool {
+	return roleRank(role) != -1
+}
+
+// RoleGrants returns true if having the role grants everything the required
+// role does.
+func RoleGrants(role string, required string) bool {
+	rank := roleRank(role)
+	return rank != -1 && rank <= roleRank(required)
+}
+
+// RoleFor returns the role of a user authenticated through the given auth
+// provider. Members of teams that do not configure any roles are owners;
+// otherwise members who are not granted a role are viewers.
+func (team Team) RoleFor(provider string, user string, groups []string) string {
+	if len(team.Roles) == 0 {
+		return OwnerRole
+	}
+
+	for _, role := range Roles {
+		members, found := team.Roles[role][provider]
+		if !found {
+			continue
+		}
+
+		if members.include(user, groups) {
+			return role
+		}
+	}
+
+	return ViewerRole
+}
+
+func (members RoleMembers) include(user string, groups []string) bool {
+	for _, u := range members.Users {
+		if u == user {
+			return true
+		}
+	}
+
+	for _, g := range members.Groups {
+		for _, group := range groups {
+			if g == group {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func roleRank(role string) int {
+	for rank, r := range Roles {
+		if r == role {
+			return rank
+		}
+	}
+
+	return -1
 }
@@ -0,0 +1,78 @@
+package atc_test
+
+import (
+	"github.com/concourse/atc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Team", func() {
+	Describe("RoleFor", func() {
+		var team atc.Team
+
+		BeforeEach(func() {
+			team = atc.Team{Name: "some-team"}
+		})
+
+		Context("when the team has no roles", func() {
+			It("makes every member an owner", func() {
+				Expect(team.RoleFor("github", "some-user", nil)).To(Equal(atc.OwnerRole))
+			})
+		})
+
+		Context("when the team has roles", func() {
+			BeforeEach(func() {
+				team.Roles = atc.TeamRoles{
+					atc.OwnerRole: {
+						"github": {Users: []string{"some-owner"}},
+					},
+					atc.MemberRole: {
+						"github": {Groups: []string{"some-org:some-team"}},
+					},
+					atc.PipelineOperatorRole: {
+						"github": {Users: []string{"some-operator", "some-owner"}},
+					},
+				}
+			})
+
+			It("returns the role granted to the user", func() {
+				Expect(team.RoleFor("github", "some-operator", nil)).To(Equal(atc.PipelineOperatorRole))
+			})
+
+			It("returns the role granted to one of the user's groups", func() {
+				Expect(team.RoleFor("github", "some-user", []string{"some-org:some-team"})).To(Equal(atc.MemberRole))
+			})
+
+			It("returns the most privileged role granted", func() {
+				Expect(team.RoleFor("github", "some-owner", nil)).To(Equal(atc.OwnerRole))
+			})
+
+			It("makes members without a role viewers", func() {
+				Expect(team.RoleFor("github", "some-user", nil)).To(Equal(atc.ViewerRole))
+			})
+
+			It("only considers the provider the user authenticated with", func() {
+				Expect(team.RoleFor("uaa", "some-owner", nil)).To(Equal(atc.ViewerRole))
+			})
+		})
+	})
+
+	Describe("RoleGrants", func() {
+		It("grants roles to themselves", func() {
+			Expect(atc.RoleGrants(atc.MemberRole, atc.MemberRole)).To(BeTrue())
+		})
+
+		It("grants less privileged roles", func() {
+			Expect(atc.RoleGrants(atc.OwnerRole, atc.PipelineOperatorRole)).To(BeTrue())
+		})
+
+		It("does not grant more privileged roles", func() {
+			Expect(atc.RoleGrants(atc.ViewerRole, atc.PipelineOperatorRole)).To(BeFalse())
+		})
+
+		It("does not grant anything to unknown roles", func() {
+			Expect(atc.RoleGrants("bogus", atc.ViewerRole)).To(BeFalse())
+		})
+	})
+})
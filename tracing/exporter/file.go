@@ -0,0 +1,84 @@
+package exporter
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/tracing"
+)
+
+type FileExporter struct {
+	writer io.Writer
+
+	writeL sync.Mutex
+}
+
+type FileConfig struct {
+	Path string `long:"tracing-file" description:"File to append spans to as JSON lines, or '-' for stdout. Meant for local testing."`
+}
+
+func init() {
+	tracing.RegisterExporter(&FileConfig{})
+}
+
+func (config *FileConfig) Description() string { return "File" }
+func (config *FileConfig) IsConfigured() bool  { return config.Path != "" }
+
+func (config *FileConfig) NewExporter() (tracing.Exporter, error) {
+	if config.Path == "-" {
+		return &FileExporter{writer: os.Stdout}, nil
+	}
+
+	file, err := os.OpenFile(config.Path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FileExporter{writer: file}, nil
+}
+
+type fileSpan struct {
+	TraceID    string            `json:"trace_id"`
+	ID         string            `json:"id"`
+	ParentID   string            `json:"parent_id,omitempty"`
+	Name       string            `json:"name"`
+	Attributes map[string]string `json:"attributes,omitempty"`
+	StartTime  int64             `json:"start_time"`
+	EndTime    int64             `json:"end_time"`
+	Duration   string            `json:"duration"`
+	Error      string            `json:"error,omitempty"`
+}
+
+func (exporter *FileExporter) Export(logger lager.Logger, spans []tracing.Span) {
+	lines := []byte{}
+	for _, span := range spans {
+		payload, err := json.Marshal(fileSpan{
+			TraceID:    span.TraceID,
+			ID:         span.ID,
+			ParentID:   span.ParentID,
+			Name:       span.Name,
+			Attributes: span.Attributes,
+			StartTime:  span.StartTime.UnixNano(),
+			EndTime:    span.EndTime.UnixNano(),
+			Duration:   span.EndTime.Sub(span.StartTime).String(),
+			Error:      span.Error,
+		})
+		if err != nil {
+			logger.Error("failed-to-marshal-span", err)
+			continue
+		}
+
+		lines = append(lines, append(payload, '\n')...)
+	}
+
+	exporter.writeL.Lock()
+	defer exporter.writeL.Unlock()
+
+	_, err := exporter.writer.Write(lines)
+	if err != nil {
+		logger.Error("failed-to-write-spans", err)
+	}
+}
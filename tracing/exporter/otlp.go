@@ -0,0 +1,163 @@
+package exporter
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/tracing"
+)
+
+const otlpSpanKindInternal = 1
+const otlpStatusCodeOK = 1
+const otlpStatusCodeError = 2
+
+type OTLPExporter struct {
+	client  *http.Client
+	url     string
+	headers map[string]string
+}
+
+type OTLPConfig struct {
+	Endpoint string            `long:"otlp-endpoint" description:"OTLP/HTTP collector address to export spans to, e.g. http://localhost:4318."`
+	Headers  map[string]string `long:"otlp-header" description:"Header to send along with the exported spans, e.g. for authentication. Can be specified multiple times." value-name:"NAME:VALUE"`
+}
+
+func init() {
+	tracing.RegisterExporter(&OTLPConfig{})
+}
+
+func (config *OTLPConfig) Description() string { return "OTLP" }
+func (config *OTLPConfig) IsConfigured() bool  { return config.Endpoint != "" }
+
+func (config *OTLPConfig) NewExporter() (tracing.Exporter, error) {
+	return &OTLPExporter{
+		client:  &http.Client{Timeout: time.Minute},
+		url:     strings.TrimRight(config.Endpoint, "/") + "/v1/traces",
+		headers: config.Headers,
+	}, nil
+}
+
+type otlpRequest struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpAttribute `json:"attributes"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope  `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpScope struct {
+	Name string `json:"name"`
+}
+
+type otlpSpan struct {
+	TraceID           string          `json:"traceId"`
+	SpanID            string          `json:"spanId"`
+	ParentSpanID      string          `json:"parentSpanId,omitempty"`
+	Name              string          `json:"name"`
+	Kind              int             `json:"kind"`
+	StartTimeUnixNano string          `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string          `json:"endTimeUnixNano"`
+	Attributes        []otlpAttribute `json:"attributes"`
+	Status            otlpStatus      `json:"status"`
+}
+
+type otlpAttribute struct {
+	Key   string          `json:"key"`
+	Value otlpStringValue `json:"value"`
+}
+
+type otlpStringValue struct {
+	StringValue string `json:"stringValue"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+func (exporter *OTLPExporter) Export(logger lager.Logger, spans []tracing.Span) {
+	otlpSpans := []otlpSpan{}
+	for _, span := range spans {
+		attributes := []otlpAttribute{}
+		for k, v := range span.Attributes {
+			attributes = append(attributes, otlpAttribute{Key: k, Value: otlpStringValue{v}})
+		}
+
+		status := otlpStatus{Code: otlpStatusCodeOK}
+		if span.Error != "" {
+			status = otlpStatus{Code: otlpStatusCodeError, Message: span.Error}
+		}
+
+		otlpSpans = append(otlpSpans, otlpSpan{
+			TraceID:           span.TraceID,
+			SpanID:            span.ID,
+			ParentSpanID:      span.ParentID,
+			Name:              span.Name,
+			Kind:              otlpSpanKindInternal,
+			StartTimeUnixNano: fmt.Sprintf("%d", span.StartTime.UnixNano()),
+			EndTimeUnixNano:   fmt.Sprintf("%d", span.EndTime.UnixNano()),
+			Attributes:        attributes,
+			Status:            status,
+		})
+	}
+
+	payload, err := json.Marshal(otlpRequest{
+		ResourceSpans: []otlpResourceSpans{
+			{
+				Resource: otlpResource{
+					Attributes: []otlpAttribute{
+						{Key: "service.name", Value: otlpStringValue{"concourse"}},
+					},
+				},
+				ScopeSpans: []otlpScopeSpans{
+					{
+						Scope: otlpScope{Name: "github.com/concourse/atc"},
+						Spans: otlpSpans,
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		logger.Error("failed-to-marshal-spans", err)
+		return
+	}
+
+	req, err := http.NewRequest("POST", exporter.url, bytes.NewBuffer(payload))
+	if err != nil {
+		logger.Error("failed-to-construct-request", err)
+		return
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	for name, value := range exporter.headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := exporter.client.Do(req)
+	if err != nil {
+		logger.Error("failed-to-send-request", err)
+		return
+	}
+
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		logger.Error("unexpected-status", nil, lager.Data{"status": resp.StatusCode})
+	}
+}
@@ -0,0 +1,235 @@
+package tracing
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	flags "github.com/jessevdk/go-flags"
+)
+
+// Attrs are the attributes of a span, e.g. the build, pipeline and job it
+// belongs to.
+type Attrs map[string]string
+
+// Span is a timed operation within a trace. All spans started for the same
+// build belong to the build's trace, so that the time a build spent being
+// scheduled, running steps and creating containers can be seen together.
+type Span struct {
+	TraceID    string
+	ID         string
+	ParentID   string
+	Name       string
+	Attributes Attrs
+	StartTime  time.Time
+	EndTime    time.Time
+	Error      string
+
+	buildID int
+	root    bool
+}
+
+//go:generate counterfeiter . Exporter
+type Exporter interface {
+	Export(lager.Logger, []Span)
+}
+
+//go:generate counterfeiter . ExporterFactory
+type ExporterFactory interface {
+	Description() string
+	IsConfigured() bool
+	NewExporter() (Exporter, error)
+}
+
+var exporterFactories []ExporterFactory
+
+func RegisterExporter(factory ExporterFactory) {
+	exporterFactories = append(exporterFactories, factory)
+}
+
+func WireExporters(group *flags.Group) {
+	for _, factory := range exporterFactories {
+		_, err := group.AddGroup(fmt.Sprintf("Tracing Exporter (%s)", factory.Description()), "", factory)
+		if err != nil {
+			panic(err)
+		}
+	}
+}
+
+var exporter Exporter
+var exportLogger lager.Logger
+
+var exports = make(chan Span, 1000)
+
+// maxExportBatch is the most spans handed to the exporter at once.
+const maxExportBatch = 512
+
+func Initialize(logger lager.Logger) error {
+	var exporterDescriptions []string
+	for _, factory := range exporterFactories {
+		if factory.IsConfigured() {
+			exporterDescriptions = append(exporterDescriptions, factory.Description())
+		}
+	}
+	if len(exporterDescriptions) > 1 {
+		return fmt.Errorf("Multiple tracing exporters configured: %s", strings.Join(exporterDescriptions, ", "))
+	}
+
+	for _, factory := range exporterFactories {
+		if factory.IsConfigured() {
+			var err error
+			exporter, err = factory.NewExporter()
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	if exporter == nil {
+		return nil
+	}
+
+	exportLogger = logger
+
+	go exportLoop()
+
+	return nil
+}
+
+var buildSpans = map[int]*Span{}
+var buildSpansLock sync.Mutex
+
+// staleBuildRootSpanAge is how long a build's root span is kept around for
+// the build's other spans to be its children, in case the build stops
+// running without ending it.
+const staleBuildRootSpanAge = 24 * time.Hour
+
+// StartSpan starts a span in a new trace.
+func StartSpan(name string, attrs Attrs) *Span {
+	return newSpan(name, randomID(16), "", attrs)
+}
+
+// StartChildSpan starts a span within the same trace as the given span.
+func (span *Span) StartChildSpan(name string, attrs Attrs) *Span {
+	child := newSpan(name, span.TraceID, span.ID, attrs)
+	child.buildID = span.buildID
+	return child
+}
+
+// StartBuildRootSpan starts the span covering the whole run of a build. Spans
+// started for the build afterwards with StartBuildSpan become its children,
+// until it ends.
+func StartBuildRootSpan(name string, buildID int, attrs Attrs) *Span {
+	span := StartBuildSpan(name, buildID, attrs)
+	span.root = true
+
+	buildSpansLock.Lock()
+	for id, root := range buildSpans {
+		if span.StartTime.Sub(root.StartTime) > staleBuildRootSpanAge {
+			delete(buildSpans, id)
+		}
+	}
+
+	buildSpans[buildID] = span
+	buildSpansLock.Unlock()
+
+	return span
+}
+
+// StartBuildSpan starts a span within the trace of the given build. If the
+// build is running on this ATC the span is a child of the build's root span.
+func StartBuildSpan(name string, buildID int, attrs Attrs) *Span {
+	var parentID string
+
+	buildSpansLock.Lock()
+	if root, found := buildSpans[buildID]; found {
+		parentID = root.ID
+	}
+	buildSpansLock.Unlock()
+
+	span := newSpan(name, BuildTraceID(buildID), parentID, attrs)
+	span.buildID = buildID
+	span.Attributes["build_id"] = strconv.Itoa(buildID)
+
+	return span
+}
+
+// BuildTraceID returns the ID of the trace of the given build. It is derived
+// from the build ID so that every ATC puts the build's spans in the same trace.
+func BuildTraceID(buildID int) string {
+	return fmt.Sprintf("%032x", buildID)
+}
+
+// End ends the span and exports it, recording the error, if any.
+func (span *Span) End(err error) {
+	span.EndTime = time.Now()
+
+	if err != nil {
+		span.Error = err.Error()
+	}
+
+	if span.root {
+		buildSpansLock.Lock()
+		if buildSpans[span.buildID] == span {
+			delete(buildSpans, span.buildID)
+		}
+		buildSpansLock.Unlock()
+	}
+
+	if exporter == nil {
+		return
+	}
+
+	select {
+	case exports <- *span:
+	default:
+		exportLogger.Error("queue-full", nil)
+	}
+}
+
+func newSpan(name string, traceID string, parentID string, attrs Attrs) *Span {
+	attributes := Attrs{}
+	for k, v := range attrs {
+		attributes[k] = v
+	}
+
+	return &Span{
+		TraceID:    traceID,
+		ID:         randomID(8),
+		ParentID:   parentID,
+		Name:       name,
+		Attributes: attributes,
+		StartTime:  time.Now(),
+	}
+}
+
+func randomID(size int) string {
+	id := make([]byte, size)
+	_, _ = rand.Read(id)
+	return hex.EncodeToString(id)
+}
+
+// exportLoop exports the spans that have ended. Spans that end while a batch
+// is being exported queue up and are exported together in the next one.
+func exportLoop() {
+	for span := range exports {
+		batch := []Span{span}
+
+	drain:
+		for len(batch) < maxExportBatch {
+			select {
+			case span := <-exports:
+				batch = append(batch, span)
+			default:
+				break drain
+			}
+		}
+
+		exporter.Export(exportLogger.Session("export"), batch)
+	}
+}
@@ -0,0 +1,50 @@
+package tracing_test
+
+import (
+	"sync"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc/tracing"
+	"github.com/concourse/atc/tracing/tracingfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestTracing(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Tracing Suite")
+}
+
+var exportHook func([]tracing.Span)
+var exportHookL sync.Mutex
+
+func setExportHook(hook func([]tracing.Span)) {
+	exportHookL.Lock()
+	exportHook = hook
+	exportHookL.Unlock()
+}
+
+var _ = BeforeSuite(func() {
+	fakeExporter := new(tracingfakes.FakeExporter)
+	fakeExporter.ExportStub = func(_ lager.Logger, spans []tracing.Span) {
+		exportHookL.Lock()
+		hook := exportHook
+		exportHookL.Unlock()
+
+		if hook != nil {
+			hook(spans)
+		}
+	}
+
+	fakeExporterFactory := new(tracingfakes.FakeExporterFactory)
+	fakeExporterFactory.IsConfiguredReturns(true)
+	fakeExporterFactory.NewExporterReturns(fakeExporter, nil)
+
+	tracing.RegisterExporter(fakeExporterFactory)
+
+	Expect(tracing.Initialize(lagertest.NewTestLogger("tracing"))).To(Succeed())
+})
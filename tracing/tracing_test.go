@@ -0,0 +1,170 @@
+package tracing_test
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/concourse/atc/tracing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Tracing", func() {
+	Describe("BuildTraceID", func() {
+		It("derives a 128-bit trace ID from the build ID", func() {
+			Expect(tracing.BuildTraceID(42)).To(Equal("0000000000000000000000000000002a"))
+		})
+	})
+
+	Describe("StartSpan", func() {
+		It("starts a span in a new trace", func() {
+			span := tracing.StartSpan("some-span", tracing.Attrs{"some": "attr"})
+			otherSpan := tracing.StartSpan("some-span", nil)
+
+			Expect(span.Name).To(Equal("some-span"))
+			Expect(span.TraceID).To(HaveLen(32))
+			Expect(span.ID).To(HaveLen(16))
+			Expect(span.ParentID).To(BeEmpty())
+			Expect(span.Attributes).To(Equal(tracing.Attrs{"some": "attr"}))
+			Expect(span.StartTime).NotTo(BeZero())
+
+			Expect(otherSpan.TraceID).NotTo(Equal(span.TraceID))
+		})
+	})
+
+	Describe("StartChildSpan", func() {
+		It("starts a span within the same trace", func() {
+			span := tracing.StartSpan("some-span", nil)
+			child := span.StartChildSpan("some-child", nil)
+
+			Expect(child.TraceID).To(Equal(span.TraceID))
+			Expect(child.ParentID).To(Equal(span.ID))
+		})
+	})
+
+	Describe("StartBuildSpan", func() {
+		It("starts a span in the build's trace", func() {
+			span := tracing.StartBuildSpan("some-span", 42, tracing.Attrs{"job": "some-job"})
+
+			Expect(span.TraceID).To(Equal(tracing.BuildTraceID(42)))
+			Expect(span.ParentID).To(BeEmpty())
+			Expect(span.Attributes).To(Equal(tracing.Attrs{
+				"job":      "some-job",
+				"build_id": "42",
+			}))
+		})
+
+		Context("when the build's root span has been started", func() {
+			var root *tracing.Span
+
+			BeforeEach(func() {
+				root = tracing.StartBuildRootSpan("build", 42, nil)
+			})
+
+			AfterEach(func() {
+				root.End(nil)
+			})
+
+			It("makes the span a child of the root span", func() {
+				span := tracing.StartBuildSpan("some-span", 42, nil)
+				Expect(span.ParentID).To(Equal(root.ID))
+			})
+
+			It("does not affect spans of other builds", func() {
+				span := tracing.StartBuildSpan("some-span", 43, nil)
+				Expect(span.ParentID).To(BeEmpty())
+			})
+
+			Context("when another build's root span starts long after", func() {
+				BeforeEach(func() {
+					root.StartTime = time.Now().Add(-25 * time.Hour)
+
+					tracing.StartBuildRootSpan("build", 43, nil).End(nil)
+				})
+
+				It("forgets the stale root span", func() {
+					span := tracing.StartBuildSpan("some-span", 42, nil)
+					Expect(span.ParentID).To(BeEmpty())
+				})
+			})
+
+			Context("when the root span has ended", func() {
+				BeforeEach(func() {
+					root.End(nil)
+				})
+
+				It("no longer makes spans its children", func() {
+					span := tracing.StartBuildSpan("some-span", 42, nil)
+					Expect(span.ParentID).To(BeEmpty())
+				})
+			})
+		})
+	})
+
+	Describe("End", func() {
+		It("records the end time", func() {
+			span := tracing.StartSpan("some-span", nil)
+			span.End(nil)
+
+			Expect(span.EndTime).NotTo(BeZero())
+			Expect(span.Error).To(BeEmpty())
+		})
+
+		It("records the error", func() {
+			span := tracing.StartSpan("some-span", nil)
+			span.End(errors.New("nope"))
+
+			Expect(span.Error).To(Equal("nope"))
+		})
+
+		Context("when spans end while others are being exported", func() {
+			var exporting chan struct{}
+			var release chan struct{}
+			var batches chan []string
+
+			BeforeEach(func() {
+				exporting = make(chan struct{})
+				release = make(chan struct{})
+				batches = make(chan []string, 10)
+
+				setExportHook(func(spans []tracing.Span) {
+					names := []string{}
+					for _, span := range spans {
+						if strings.HasPrefix(span.Name, "batched-") {
+							names = append(names, span.Name)
+						}
+					}
+
+					if len(names) == 0 {
+						return
+					}
+
+					if names[0] == "batched-first" {
+						close(exporting)
+						<-release
+					}
+
+					batches <- names
+				})
+			})
+
+			AfterEach(func() {
+				setExportHook(nil)
+			})
+
+			It("exports them together in the next batch", func() {
+				tracing.StartSpan("batched-first", nil).End(nil)
+				Eventually(exporting).Should(BeClosed())
+
+				tracing.StartSpan("batched-second", nil).End(nil)
+				tracing.StartSpan("batched-third", nil).End(nil)
+				close(release)
+
+				Eventually(batches).Should(Receive(Equal([]string{"batched-first"})))
+				Eventually(batches).Should(Receive(Equal([]string{"batched-second", "batched-third"})))
+			})
+		})
+	})
+})
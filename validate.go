@@ -3,6 +3,7 @@ package atc
 import (
 	"errors"
 	"fmt"
+	"net/url"
 	"sort"
 	"strings"
 	"time"
@@ -49,6 +50,11 @@ func (c Config) Validate() ([]Warning, []string) {
 	}
 	warnings = append(warnings, jobWarnings...)
 
+	notificationsErr := validateNotifications(c)
+	if notificationsErr != nil {
+		errorMessages = append(errorMessages, formatErr("notifications", notificationsErr))
+	}
+
 	return warnings, errorMessages
 }
 
@@ -224,6 +230,29 @@ func validateJobs(c Config) ([]Warning, error) {
 			)
 		}
 
+		if job.BuildLogRetention != nil {
+			if job.BuildLogRetention.Builds < 0 {
+				errorMessages = append(
+					errorMessages,
+					identifier+fmt.Sprintf(" has negative build_log_retention.builds: %d", job.BuildLogRetention.Builds),
+				)
+			}
+
+			if job.BuildLogRetention.Days < 0 {
+				errorMessages = append(
+					errorMessages,
+					identifier+fmt.Sprintf(" has negative build_log_retention.days: %d", job.BuildLogRetention.Days),
+				)
+			}
+
+			if job.BuildLogRetention.MinimumSucceededBuilds < 0 {
+				errorMessages = append(
+					errorMessages,
+					identifier+fmt.Sprintf(" has negative build_log_retention.minimum_succeeded_builds: %d", job.BuildLogRetention.MinimumSucceededBuilds),
+				)
+			}
+		}
+
 		planWarnings, planErrMessages := validatePlan(c, identifier+".plan", PlanConfig{Do: &job.Plan})
 		warnings = append(warnings, planWarnings...)
 		errorMessages = append(errorMessages, planErrMessages...)
@@ -331,6 +360,14 @@ func validatePlan(c Config, identifier string, plan PlanConfig) ([]Warning, []st
 		foundTypes.Find("try")
 	}
 
+	if plan.SetPipeline != "" {
+		foundTypes.Find("set_pipeline")
+	}
+
+	if plan.LoadVar != "" {
+		foundTypes.Find("load_var")
+	}
+
 	if valid, message := foundTypes.IsValid(); !valid {
 		return []Warning{}, []string{message}
 	}
@@ -359,7 +396,7 @@ func validatePlan(c Config, identifier string, plan PlanConfig) ([]Warning, []st
 		identifier = fmt.Sprintf("%s.get.%s", identifier, plan.Get)
 
 		errorMessages = append(errorMessages, validateInapplicableFields(
-			[]string{"privileged", "config", "file"},
+			[]string{"privileged", "config", "file", "instance_vars"},
 			plan, identifier)...,
 		)
 
@@ -434,7 +471,7 @@ func validatePlan(c Config, identifier string, plan PlanConfig) ([]Warning, []st
 		identifier = fmt.Sprintf("%s.put.%s", identifier, plan.Put)
 
 		errorMessages = append(errorMessages, validateInapplicableFields(
-			[]string{"passed", "trigger", "privileged", "config", "file"},
+			[]string{"passed", "trigger", "privileged", "config", "file", "instance_vars"},
 			plan, identifier)...,
 		)
 
@@ -491,7 +528,37 @@ func validatePlan(c Config, identifier string, plan PlanConfig) ([]Warning, []st
 		}
 
 		errorMessages = append(errorMessages, validateInapplicableFields(
-			[]string{"resource", "passed", "trigger"},
+			[]string{"resource", "passed", "trigger", "instance_vars"},
+			plan, identifier)...,
+		)
+
+	case plan.SetPipeline != "":
+		identifier = fmt.Sprintf("%s.set_pipeline.%s", identifier, plan.SetPipeline)
+
+		if plan.TaskConfigPath == "" {
+			errorMessages = append(errorMessages, identifier+" does not specify a config file")
+		}
+
+		errorMessages = append(errorMessages, validateInapplicableFields(
+			[]string{"resource", "passed", "trigger", "privileged", "config"},
+			plan, identifier)...,
+		)
+
+	case plan.LoadVar != "":
+		identifier = fmt.Sprintf("%s.load_var.%s", identifier, plan.LoadVar)
+
+		if plan.TaskConfigPath == "" {
+			errorMessages = append(errorMessages, identifier+" does not specify a file")
+		}
+
+		switch plan.Format {
+		case "", LoadVarFormatRaw, LoadVarFormatTrim, LoadVarFormatJSON, LoadVarFormatYAML:
+		default:
+			errorMessages = append(errorMessages, identifier+fmt.Sprintf(" has an unknown format ('%s')", plan.Format))
+		}
+
+		errorMessages = append(errorMessages, validateInapplicableFields(
+			[]string{"resource", "passed", "trigger", "privileged", "config", "instance_vars"},
 			plan, identifier)...,
 		)
 
@@ -538,14 +605,64 @@ func validatePlan(c Config, identifier string, plan PlanConfig) ([]Warning, []st
 		}
 	}
 
+	if plan.BuildMetadata != "" && !strings.Contains(plan.BuildMetadata, "/") {
+		subIdentifier := fmt.Sprintf("%s.build_metadata", identifier)
+		errorMessages = append(errorMessages, subIdentifier+fmt.Sprintf(" does not refer to a file in an artifact ('%s')", plan.BuildMetadata))
+	}
+
 	if plan.Attempts < 0 {
 		subIdentifier := fmt.Sprintf("%s.attempts", identifier)
 		errorMessages = append(errorMessages, subIdentifier+fmt.Sprintf(" has an invalid number of attempts (%d)", plan.Attempts))
 	}
 
+	if plan.Across != nil {
+		subIdentifier := fmt.Sprintf("%s.across", identifier)
+		errorMessages = append(errorMessages, validateAcross(subIdentifier, plan)...)
+	}
+
 	return warnings, errorMessages
 }
 
+func validateAcross(identifier string, plan PlanConfig) []string {
+	errorMessages := []string{}
+
+	if plan.Get != "" {
+		errorMessages = append(errorMessages, identifier+" cannot be used on a get step")
+	}
+
+	if len(plan.Across.Vars) == 0 {
+		errorMessages = append(errorMessages, identifier+" has no vars")
+	}
+
+	names := map[string]int{}
+
+	for i, v := range plan.Across.Vars {
+		if v.Name == "" {
+			errorMessages = append(errorMessages, fmt.Sprintf("%s.vars[%d] has no name", identifier, i))
+			continue
+		}
+
+		if other, exists := names[v.Name]; exists {
+			errorMessages = append(errorMessages,
+				fmt.Sprintf(
+					"%s.vars[%d] and %s.vars[%d] have the same name ('%s')",
+					identifier, other, identifier, i, v.Name))
+		} else {
+			names[v.Name] = i
+		}
+
+		if len(v.Values) == 0 {
+			errorMessages = append(errorMessages, fmt.Sprintf("%s.vars.%s has no values", identifier, v.Name))
+		}
+	}
+
+	if plan.Across.MaxInFlight < 0 {
+		errorMessages = append(errorMessages, identifier+fmt.Sprintf(" has an invalid max_in_flight (%d)", plan.Across.MaxInFlight))
+	}
+
+	return errorMessages
+}
+
 func validateInapplicableFields(inapplicableFields []string, plan PlanConfig, identifier string) []string {
 	errorMessages := []string{}
 	foundInapplicableFields := []string{}
@@ -576,6 +693,10 @@ func validateInapplicableFields(inapplicableFields []string, plan PlanConfig, id
 			if plan.TaskConfigPath != "" {
 				foundInapplicableFields = append(foundInapplicableFields, field)
 			}
+		case "instance_vars":
+			if len(plan.InstanceVars) != 0 {
+				foundInapplicableFields = append(foundInapplicableFields, field)
+			}
 		}
 	}
 
@@ -593,6 +714,56 @@ func validateInapplicableFields(inapplicableFields []string, plan PlanConfig, id
 	return errorMessages
 }
 
+func validateNotifications(c Config) error {
+	errorMessages := []string{}
+
+	names := map[string]int{}
+
+	for i, notification := range c.Notifications {
+		var identifier string
+		if notification.Name == "" {
+			identifier = fmt.Sprintf("notifications[%d]", i)
+		} else {
+			identifier = fmt.Sprintf("notifications.%s", notification.Name)
+		}
+
+		if other, exists := names[notification.Name]; exists {
+			errorMessages = append(errorMessages,
+				fmt.Sprintf(
+					"notifications[%d] and notifications[%d] have the same name ('%s')",
+					other, i, notification.Name))
+		} else if notification.Name != "" {
+			names[notification.Name] = i
+		}
+
+		if notification.Name == "" {
+			errorMessages = append(errorMessages, identifier+" has no name")
+		}
+
+		if notification.URL == "" {
+			errorMessages = append(errorMessages, identifier+" has no url")
+		} else if u, err := url.Parse(notification.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			errorMessages = append(errorMessages, identifier+" has an invalid url; it must be an http or https URL")
+		}
+
+		for _, event := range notification.Events {
+			if !containsStatus(NotificationEvents, event) {
+				errorMessages = append(errorMessages,
+					fmt.Sprintf("%s has unknown event '%s'", identifier, event))
+			}
+		}
+
+		for _, job := range notification.Jobs {
+			if _, exists := c.Jobs.Lookup(job); !exists {
+				errorMessages = append(errorMessages,
+					fmt.Sprintf("%s has unknown job '%s'", identifier, job))
+			}
+		}
+	}
+
+	return compositeErr(errorMessages)
+}
+
 func compositeErr(errorMessages []string) error {
 	if len(errorMessages) == 0 {
 		return nil
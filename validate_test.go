@@ -423,6 +423,86 @@ var _ = Describe("ValidateConfig", func() {
 		})
 	})
 
+	Describe("invalid notifications", func() {
+		BeforeEach(func() {
+			config.Notifications = NotificationConfigs{
+				{
+					Name:   "some-notification",
+					URL:    "https://example.com/hook",
+					Events: []BuildStatus{StatusFailed, StatusErrored},
+					Jobs:   []string{"some-job"},
+				},
+			}
+		})
+
+		Context("when the notifications are valid", func() {
+			It("returns no error", func() {
+				Expect(errorMessages).To(HaveLen(0))
+			})
+		})
+
+		Context("when a notification has no name or url", func() {
+			BeforeEach(func() {
+				config.Notifications = append(config.Notifications, NotificationConfig{})
+			})
+
+			It("returns an error describing both errors", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid notifications:"))
+				Expect(errorMessages[0]).To(ContainSubstring("notifications[1] has no name"))
+				Expect(errorMessages[0]).To(ContainSubstring("notifications[1] has no url"))
+			})
+		})
+
+		Context("when a notification's url is not an http url", func() {
+			BeforeEach(func() {
+				config.Notifications[0].URL = "ftp://example.com/hook"
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid notifications:"))
+				Expect(errorMessages[0]).To(ContainSubstring("notifications.some-notification has an invalid url"))
+			})
+		})
+
+		Context("when two notifications have the same name", func() {
+			BeforeEach(func() {
+				config.Notifications = append(config.Notifications, config.Notifications...)
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid notifications:"))
+				Expect(errorMessages[0]).To(ContainSubstring("notifications[0] and notifications[1] have the same name ('some-notification')"))
+			})
+		})
+
+		Context("when a notification has an unknown event", func() {
+			BeforeEach(func() {
+				config.Notifications[0].Events = append(config.Notifications[0].Events, StatusPending)
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid notifications:"))
+				Expect(errorMessages[0]).To(ContainSubstring("notifications.some-notification has unknown event 'pending'"))
+			})
+		})
+
+		Context("when a notification refers to an unknown job", func() {
+			BeforeEach(func() {
+				config.Notifications[0].Jobs = append(config.Notifications[0].Jobs, "bogus-job")
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid notifications:"))
+				Expect(errorMessages[0]).To(ContainSubstring("notifications.some-notification has unknown job 'bogus-job'"))
+			})
+		})
+	})
+
 	Describe("validating a job", func() {
 		var job JobConfig
 
@@ -459,6 +539,25 @@ var _ = Describe("ValidateConfig", func() {
 			})
 		})
 
+		Context("when a job has a negative build_log_retention", func() {
+			BeforeEach(func() {
+				job.BuildLogRetention = &BuildLogRetention{
+					Builds:                 -1,
+					Days:                   -2,
+					MinimumSucceededBuilds: -3,
+				}
+				config.Jobs = append(config.Jobs, job)
+			})
+
+			It("returns an error for each negative rule", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job has negative build_log_retention.builds: -1"))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job has negative build_log_retention.days: -2"))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job has negative build_log_retention.minimum_succeeded_builds: -3"))
+			})
+		})
+
 		Context("when a job has duplicate inputs", func() {
 			BeforeEach(func() {
 				job.Plan = append(job.Plan, PlanConfig{
@@ -635,6 +734,78 @@ var _ = Describe("ValidateConfig", func() {
 				})
 			})
 
+			Context("when a set_pipeline plan has no config file", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						SetPipeline: "some-pipeline",
+						Privileged:  true,
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].set_pipeline.some-pipeline does not specify a config file"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].set_pipeline.some-pipeline has invalid fields specified (privileged)"))
+				})
+			})
+
+			Context("when a load_var plan has no file", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						LoadVar: "some-var",
+						Format:  "toml",
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].load_var.some-var does not specify a file"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].load_var.some-var has an unknown format ('toml')"))
+				})
+			})
+
+			Context("when a step other than set_pipeline has instance vars", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						LoadVar:        "some-var",
+						TaskConfigPath: "some-input/some-file",
+						InstanceVars:   InstanceVars{"branch": "some-branch"},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].load_var.some-var has invalid fields specified (instance_vars)"))
+				})
+			})
+
+			Context("when a step's build_metadata is not a file in an artifact", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Task:           "some-task",
+						TaskConfigPath: "some-input/task.yml",
+						BuildMetadata:  "metadata.json",
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].task.some-task.build_metadata does not refer to a file in an artifact ('metadata.json')"))
+				})
+			})
+
 			Context("when a task plan has neither a config or a path set", func() {
 				BeforeEach(func() {
 					job.Plan = append(job.Plan, PlanConfig{
@@ -1116,6 +1287,69 @@ var _ = Describe("ValidateConfig", func() {
 				})
 			})
 
+			Context("when a plan fans out across vars", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Put: "some-resource",
+						Across: &AcrossConfig{
+							Vars: []AcrossVarConfig{
+								{Name: "some-var", Values: []string{"a", "b"}},
+							},
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("does not return an error", func() {
+					Expect(errorMessages).To(HaveLen(0))
+				})
+			})
+
+			Context("when an across plan has invalid vars", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Put: "some-resource",
+						Across: &AcrossConfig{
+							Vars: []AcrossVarConfig{
+								{Name: "some-var", Values: []string{"a"}},
+								{Name: "some-var"},
+							},
+							MaxInFlight: -1,
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("does return an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].put.some-resource.across.vars[0] and jobs.some-other-job.plan[0].put.some-resource.across.vars[1] have the same name ('some-var')"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].put.some-resource.across.vars.some-var has no values"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].put.some-resource.across has an invalid max_in_flight (-1)"))
+				})
+			})
+
+			Context("when a get plan fans out across vars", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Get: "some-resource",
+						Across: &AcrossConfig{
+							Vars: []AcrossVarConfig{
+								{Name: "some-var", Values: []string{"a"}},
+							},
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("does return an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].get.some-resource.across cannot be used on a get step"))
+				})
+			})
+
 			Context("when a put plan has a custom name but refers to a resource that does not exist", func() {
 				BeforeEach(func() {
 					job.Plan = append(job.Plan, PlanConfig{
@@ -18,6 +18,7 @@ type Worker struct {
 
 	ActiveContainers int `json:"active_containers"`
 	ActiveVolumes    int `json:"active_volumes"`
+	MaxContainers    int `json:"max_containers,omitempty"`
 
 	ResourceTypes []WorkerResourceType `json:"resource_types"`
 
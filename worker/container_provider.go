@@ -12,6 +12,7 @@ import (
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/lock"
 	"github.com/concourse/atc/metric"
+	"github.com/concourse/atc/tracing"
 	"github.com/concourse/baggageclaim"
 )
 
@@ -28,6 +29,7 @@ func NewContainerProvider(
 	dbVolumeFactory db.VolumeFactory,
 	dbTeamFactory db.TeamFactory,
 	lockFactory lock.LockFactory,
+	deduplicateStreamedVolumes bool,
 ) ContainerProvider {
 
 	return &containerProvider{
@@ -43,6 +45,8 @@ func NewContainerProvider(
 		noProxy:            dbWorker.NoProxy(),
 		clock:              clock,
 		worker:             dbWorker,
+
+		deduplicateStreamedVolumes: deduplicateStreamedVolumes,
 	}
 }
 
@@ -82,6 +86,8 @@ type containerProvider struct {
 	noProxy       string
 
 	clock clock.Clock
+
+	deduplicateStreamedVolumes bool
 }
 
 func (p *containerProvider) FindOrCreateContainer(
@@ -92,6 +98,26 @@ func (p *containerProvider) FindOrCreateContainer(
 	metadata db.ContainerMetadata,
 	spec ContainerSpec,
 	resourceTypes creds.VersionedResourceTypes,
+) (Container, error) {
+	span := startContainerSpan("worker.create-container", metadata, tracing.Attrs{
+		"worker": p.worker.Name(),
+	})
+
+	container, err := p.findOrCreateContainer(logger, cancel, owner, delegate, metadata, spec, resourceTypes)
+
+	span.End(err)
+
+	return container, err
+}
+
+func (p *containerProvider) findOrCreateContainer(
+	logger lager.Logger,
+	cancel <-chan os.Signal,
+	owner db.ContainerOwner,
+	delegate ImageFetchingDelegate,
+	metadata db.ContainerMetadata,
+	spec ContainerSpec,
+	resourceTypes creds.VersionedResourceTypes,
 ) (Container, error) {
 	for {
 		var gardenContainer garden.Container
@@ -397,25 +423,75 @@ func (p *containerProvider) createGardenContainer(
 			if err != nil {
 				return nil, err
 			}
+
+			if _, ok := localVolume.(DeduplicatedVolume); ok {
+				metric.VolumeStreamDeduplicated{
+					WorkerName: p.worker.Name(),
+				}.Emit(logger)
+			}
 		} else {
-			inputVolume, err = p.volumeClient.FindOrCreateVolumeForContainer(
+			streamedVolumeSpec := VolumeSpec{
+				Strategy:   baggageclaim.EmptyStrategy{},
+				Privileged: fetchedImage.Privileged,
+			}
+
+			streamedVolumePath := inputSource.DestinationPath()
+
+			if p.deduplicateStreamedVolumes {
+				// the input is streamed into a copy which is never mounted, so
+				// that its content hash stays true when the container writes to
+				// the input
+				streamedVolumeSpec.Properties = VolumeProperties{StreamedCopyProperty: "true"}
+				streamedVolumePath = streamedCopyPath(inputSource.DestinationPath())
+			}
+
+			streamedVolume, err := p.volumeClient.FindOrCreateVolumeForContainer(
 				logger,
-				VolumeSpec{
-					Strategy:   baggageclaim.EmptyStrategy{},
-					Privileged: fetchedImage.Privileged,
-				},
+				streamedVolumeSpec,
 				creatingContainer,
 				spec.TeamID,
-				inputSource.DestinationPath(),
+				streamedVolumePath,
 			)
 			if err != nil {
 				return nil, err
 			}
 
-			err = inputSource.Source().StreamTo(inputVolume)
+			span := startContainerSpan("worker.stream-volume", creatingContainer.Metadata(), tracing.Attrs{
+				"worker":      p.worker.Name(),
+				"destination": inputSource.DestinationPath(),
+			})
+
+			var destination ArtifactDestination = streamedVolume
+			if p.deduplicateStreamedVolumes {
+				destination = StreamedCopy{streamedVolume}
+			}
+
+			err = inputSource.Source().StreamTo(destination)
+
+			span.End(err)
+
 			if err != nil {
 				return nil, err
 			}
+
+			inputVolume = streamedVolume
+
+			if p.deduplicateStreamedVolumes {
+				inputVolume, err = p.volumeClient.FindOrCreateCOWVolumeForContainer(
+					logger,
+					VolumeSpec{
+						Strategy:   streamedVolume.COWStrategy(),
+						Privileged: fetchedImage.Privileged,
+					},
+					creatingContainer,
+					streamedVolume,
+					spec.TeamID,
+					inputSource.DestinationPath(),
+				)
+				if err != nil {
+					return nil, err
+				}
+			}
 		}
 
 		volumeMounts = append(volumeMounts, VolumeMount{
@@ -499,6 +575,12 @@ func (p *containerProvider) createGardenContainer(
 	})
 }
 
+// streamedCopyPath is the path the streamed copy of the input at mountPath is
+// recorded with. It is not mounted, so it must not look like a mount path.
+func streamedCopyPath(mountPath string) string {
+	return "streamed:" + mountPath
+}
+
 func (p *containerProvider) anyMountTo(path string, inputs []InputSource) bool {
 	for _, input := range inputs {
 		if input.DestinationPath() == path {
@@ -508,3 +590,18 @@ func (p *containerProvider) anyMountTo(path string, inputs []InputSource) bool {
 
 	return false
 }
+
+// startContainerSpan starts a span for work done for a container, within the
+// trace of the container's build if it belongs to one.
+func startContainerSpan(name string, metadata db.ContainerMetadata, attrs tracing.Attrs) *tracing.Span {
+	attrs["type"] = string(metadata.Type)
+	attrs["step"] = metadata.StepName
+	attrs["pipeline"] = metadata.PipelineName
+	attrs["job"] = metadata.JobName
+
+	if metadata.BuildID == 0 {
+		return tracing.StartSpan(name, attrs)
+	}
+
+	return tracing.StartBuildSpan(name, metadata.BuildID, attrs)
+}
@@ -46,6 +46,11 @@ var _ = Describe("ContainerProvider", func() {
 		fakeDBResourceCacheFactory  *dbfakes.FakeResourceCacheFactory
 		fakeDBResourceConfigFactory *dbfakes.FakeResourceConfigFactory
 		fakeLockFactory             *lockfakes.FakeLockFactory
+		fakeDBTeamFactory           *dbfakes.FakeTeamFactory
+		fakeDBWorker                *dbfakes.FakeWorker
+		fakeClock                   *fakeclock.FakeClock
+
+		deduplicateStreamedVolumes bool
 
 		containerProvider ContainerProvider
 
@@ -98,32 +103,22 @@ var _ = Describe("ContainerProvider", func() {
 		fakeImageFactory.GetImageReturns(fakeImage, nil)
 		fakeLockFactory = new(lockfakes.FakeLockFactory)
 
-		fakeDBTeamFactory := new(dbfakes.FakeTeamFactory)
+		fakeDBTeamFactory = new(dbfakes.FakeTeamFactory)
 		fakeDBTeam = new(dbfakes.FakeTeam)
 		fakeDBTeamFactory.GetByIDReturns(fakeDBTeam)
 		fakeDBVolumeFactory = new(dbfakes.FakeVolumeFactory)
-		fakeClock := fakeclock.NewFakeClock(time.Unix(0, 123))
+		fakeClock = fakeclock.NewFakeClock(time.Unix(0, 123))
 		fakeDBResourceCacheFactory = new(dbfakes.FakeResourceCacheFactory)
 		fakeDBResourceConfigFactory = new(dbfakes.FakeResourceConfigFactory)
 		fakeGardenContainer = new(gardenfakes.FakeContainer)
 		fakeGardenClient.CreateReturns(fakeGardenContainer, nil)
 
-		fakeDBWorker := new(dbfakes.FakeWorker)
+		fakeDBWorker = new(dbfakes.FakeWorker)
 		fakeDBWorker.HTTPProxyURLReturns("http://proxy.com")
 		fakeDBWorker.HTTPSProxyURLReturns("https://proxy.com")
 		fakeDBWorker.NoProxyReturns("http://noproxy.com")
 
-		containerProvider = NewContainerProvider(
-			fakeGardenClient,
-			fakeBaggageclaimClient,
-			fakeVolumeClient,
-			fakeDBWorker,
-			fakeClock,
-			fakeImageFactory,
-			fakeDBVolumeFactory,
-			fakeDBTeamFactory,
-			fakeLockFactory,
-		)
+		deduplicateStreamedVolumes = false
 
 		fakeLocalInput = new(workerfakes.FakeInputSource)
 		fakeLocalInput.DestinationPathReturns("/some/work-dir/local-input")
@@ -250,6 +245,21 @@ var _ = Describe("ContainerProvider", func() {
 		})
 	})
 
+	JustBeforeEach(func() {
+		containerProvider = NewContainerProvider(
+			fakeGardenClient,
+			fakeBaggageclaimClient,
+			fakeVolumeClient,
+			fakeDBWorker,
+			fakeClock,
+			fakeImageFactory,
+			fakeDBVolumeFactory,
+			fakeDBTeamFactory,
+			fakeLockFactory,
+			deduplicateStreamedVolumes,
+		)
+	})
+
 	CertsVolumeExists := func() {
 		fakeCertsVolume := new(baggageclaimfakes.FakeVolume)
 		fakeBaggageclaimClient.LookupVolumeReturns(fakeCertsVolume, true, nil)
@@ -558,6 +568,68 @@ var _ = Describe("ContainerProvider", func() {
 			})
 		})
 
+		Context("when streamed volumes are deduplicated", func() {
+			var (
+				fakeStreamedCopyVolume *workerfakes.FakeVolume
+				cowParents             map[string]Volume
+			)
+
+			BeforeEach(func() {
+				deduplicateStreamedVolumes = true
+
+				fakeStreamedCopyVolume = new(workerfakes.FakeVolume)
+				fakeStreamedCopyVolume.PathReturns("/fake/streamed/copy/volume")
+				fakeStreamedCopyVolume.COWStrategyReturns(baggageclaim.COWStrategy{
+					Parent: new(baggageclaimfakes.FakeVolume),
+				})
+
+				stubbedVolumes["streamed:/some/work-dir/remote-input"] = fakeStreamedCopyVolume
+
+				cowParents = map[string]Volume{}
+
+				fakeVolumeClient.FindOrCreateCOWVolumeForContainerStub = func(logger lager.Logger, volumeSpec VolumeSpec, creatingContainer db.CreatingContainer, parent Volume, teamID int, mountPath string) (Volume, error) {
+					volume, found := stubbedVolumes[mountPath]
+					if !found {
+						panic("unknown container volume: " + mountPath)
+					}
+
+					cowParents[mountPath] = parent
+					volumeSpecs[mountPath] = volumeSpec
+
+					return volume, nil
+				}
+			})
+
+			It("streams remote inputs into a streamed copy", func() {
+				Expect(volumeSpecs["streamed:/some/work-dir/remote-input"]).To(Equal(VolumeSpec{
+					Strategy:   baggageclaim.EmptyStrategy{},
+					Properties: VolumeProperties{StreamedCopyProperty: "true"},
+				}))
+
+				Expect(fakeRemoteInputAS.StreamToCallCount()).To(Equal(1))
+				Expect(fakeRemoteInputAS.StreamToArgsForCall(0)).To(Equal(StreamedCopy{Volume: fakeStreamedCopyVolume}))
+			})
+
+			It("mounts a copy-on-write volume of the streamed copy", func() {
+				Expect(cowParents["/some/work-dir/remote-input"]).To(BeIdenticalTo(fakeStreamedCopyVolume))
+				Expect(volumeSpecs["/some/work-dir/remote-input"]).To(Equal(VolumeSpec{
+					Strategy: fakeStreamedCopyVolume.COWStrategy(),
+				}))
+
+				actualSpec := fakeGardenClient.CreateArgsForCall(0)
+				Expect(actualSpec.BindMounts).To(ContainElement(garden.BindMount{
+					SrcPath: "/fake/remote/input/container/volume",
+					DstPath: "/some/work-dir/remote-input",
+					Mode:    garden.BindMountModeRW,
+				}))
+				Expect(actualSpec.BindMounts).ToNot(ContainElement(garden.BindMount{
+					SrcPath: "/fake/streamed/copy/volume",
+					DstPath: "/some/work-dir/remote-input",
+					Mode:    garden.BindMountModeRW,
+				}))
+			})
+		})
+
 		Context("when getting image fails", func() {
 			BeforeEach(func() {
 				fakeImageFactory.GetImageReturns(nil, disasterErr)
@@ -32,6 +32,7 @@ type dbWorkerProvider struct {
 	dbWorkerFactory                   db.WorkerFactory
 	workerVersion                     *version.Version
 	baggageclaimResponseHeaderTimeout time.Duration
+	volumeStreaming                   VolumeStreamingConfig
 }
 
 func NewDBWorkerProvider(
@@ -47,6 +48,7 @@ func NewDBWorkerProvider(
 	workerFactory db.WorkerFactory,
 	workerVersion *version.Version,
 	baggageclaimResponseHeaderTimeout time.Duration,
+	volumeStreaming VolumeStreamingConfig,
 ) WorkerProvider {
 	return &dbWorkerProvider{
 		lockFactory:                       lockFactory,
@@ -61,6 +63,7 @@ func NewDBWorkerProvider(
 		dbWorkerFactory:                   workerFactory,
 		workerVersion:                     workerVersion,
 		baggageclaimResponseHeaderTimeout: baggageclaimResponseHeaderTimeout,
+		volumeStreaming:                   volumeStreaming,
 	}
 }
 
@@ -160,6 +163,22 @@ func (provider *dbWorkerProvider) NewGardenWorker(logger lager.Logger, tikTok cl
 		},
 	))
 
+	// the response to a P2P stream only comes once the whole volume has been
+	// streamed, so rather than waiting for the response header like the other
+	// baggageclaim requests, the whole request is given the streaming timeout
+	var streamingClient *http.Client
+	if provider.volumeStreaming.P2P || provider.volumeStreaming.Encoding != StreamEncodingRaw {
+		streamingClient = &http.Client{
+			Timeout: provider.volumeStreaming.Timeout,
+			Transport: transport.NewBaggageclaimRoundTripper(
+				savedWorker.Name(),
+				savedWorker.BaggageclaimURL(),
+				provider.dbWorkerFactory,
+				&http.Transport{DisableKeepAlives: true},
+			),
+		}
+	}
+
 	volumeClient := NewVolumeClient(
 		bClient,
 		savedWorker,
@@ -168,6 +187,8 @@ func (provider *dbWorkerProvider) NewGardenWorker(logger lager.Logger, tikTok cl
 		provider.dbVolumeFactory,
 		provider.dbWorkerBaseResourceTypeFactory,
 		provider.dbWorkerTaskCacheFactory,
+		provider.volumeStreaming,
+		streamingClient,
 	)
 
 	containerProvider := NewContainerProvider(
@@ -180,6 +201,7 @@ func (provider *dbWorkerProvider) NewGardenWorker(logger lager.Logger, tikTok cl
 		provider.dbVolumeFactory,
 		provider.dbTeamFactory,
 		provider.lockFactory,
+		provider.volumeStreaming.Deduplicate,
 	)
 
 	return NewGardenWorker(
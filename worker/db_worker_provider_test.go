@@ -170,6 +170,7 @@ var _ = Describe("DBProvider", func() {
 			fakeDBWorkerFactory,
 			&wantWorkerVersion,
 			baggageclaimResponseHeaderTimeout,
+			VolumeStreamingConfig{Encoding: StreamEncodingRaw},
 		)
 		baggageclaimURL = baggageclaimServer.URL()
 	})
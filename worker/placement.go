@@ -9,32 +9,39 @@ type ContainerPlacementStrategy interface {
 	Choose([]Worker, ContainerSpec) (Worker, error)
 }
 
+// CandidatePlacementStrategy is a placement strategy that can tell which of
+// the workers it considers equally good, so that another strategy can choose
+// between them. This is what allows strategies to be chained.
+type CandidatePlacementStrategy interface {
+	ContainerPlacementStrategy
+
+	Candidates([]Worker, ContainerSpec) ([]Worker, error)
+}
+
 type VolumeLocalityPlacementStrategy struct {
 	rand *rand.Rand
 }
 
-func NewVolumeLocalityPlacementStrategy() ContainerPlacementStrategy {
+func NewVolumeLocalityPlacementStrategy() CandidatePlacementStrategy {
 	return &VolumeLocalityPlacementStrategy{
 		rand: rand.New(rand.NewSource(time.Now().UnixNano())),
 	}
 }
 
 func (strategy *VolumeLocalityPlacementStrategy) Choose(workers []Worker, spec ContainerSpec) (Worker, error) {
+	candidates, err := strategy.Candidates(workers, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	return candidates[strategy.rand.Intn(len(candidates))], nil
+}
+
+func (strategy *VolumeLocalityPlacementStrategy) Candidates(workers []Worker, spec ContainerSpec) ([]Worker, error) {
 	workersByCount := map[int][]Worker{}
 	var highestCount int
 	for _, w := range workers {
-		candidateInputCount := 0
-
-		for _, inputSource := range spec.Inputs {
-			_, found, err := inputSource.Source().VolumeOn(w)
-			if err != nil {
-				return nil, err
-			}
-
-			if found {
-				candidateInputCount++
-			}
-		}
+		candidateInputCount := localInputCount(w, spec)
 
 		workersByCount[candidateInputCount] = append(workersByCount[candidateInputCount], w)
 
@@ -43,16 +50,14 @@ func (strategy *VolumeLocalityPlacementStrategy) Choose(workers []Worker, spec C
 		}
 	}
 
-	highestLocalityWorkers := workersByCount[highestCount]
-
-	return highestLocalityWorkers[strategy.rand.Intn(len(highestLocalityWorkers))], nil
+	return workersByCount[highestCount], nil
 }
 
 type RandomPlacementStrategy struct {
 	rand *rand.Rand
 }
 
-func NewRandomPlacementStrategy() ContainerPlacementStrategy {
+func NewRandomPlacementStrategy() CandidatePlacementStrategy {
 	return &RandomPlacementStrategy{
 		rand: rand.New(rand.NewSource(time.Now().UnixNano())),
 	}
@@ -61,3 +66,186 @@ func NewRandomPlacementStrategy() ContainerPlacementStrategy {
 func (strategy *RandomPlacementStrategy) Choose(workers []Worker, spec ContainerSpec) (Worker, error) {
 	return workers[strategy.rand.Intn(len(workers))], nil
 }
+
+func (strategy *RandomPlacementStrategy) Candidates(workers []Worker, spec ContainerSpec) ([]Worker, error) {
+	return workers, nil
+}
+
+type FewestBuildContainersPlacementStrategy struct {
+	rand *rand.Rand
+}
+
+func NewFewestBuildContainersPlacementStrategy() CandidatePlacementStrategy {
+	return &FewestBuildContainersPlacementStrategy{
+		rand: rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+func (strategy *FewestBuildContainersPlacementStrategy) Choose(workers []Worker, spec ContainerSpec) (Worker, error) {
+	candidates, err := strategy.Candidates(workers, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	return candidates[strategy.rand.Intn(len(candidates))], nil
+}
+
+func (strategy *FewestBuildContainersPlacementStrategy) Candidates(workers []Worker, spec ContainerSpec) ([]Worker, error) {
+	return fewest(workers, Worker.ActiveContainers), nil
+}
+
+type FewestVolumesPlacementStrategy struct {
+	rand *rand.Rand
+}
+
+func NewFewestVolumesPlacementStrategy() CandidatePlacementStrategy {
+	return &FewestVolumesPlacementStrategy{
+		rand: rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+func (strategy *FewestVolumesPlacementStrategy) Choose(workers []Worker, spec ContainerSpec) (Worker, error) {
+	candidates, err := strategy.Candidates(workers, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	return candidates[strategy.rand.Intn(len(candidates))], nil
+}
+
+func (strategy *FewestVolumesPlacementStrategy) Candidates(workers []Worker, spec ContainerSpec) ([]Worker, error) {
+	return fewest(workers, Worker.ActiveVolumes), nil
+}
+
+// WeightedPlacementStrategy scores each worker by the share of the
+// container's inputs that are already on it, weighted by LocalityWeight,
+// minus its share of the active containers of all of the workers, weighted
+// by LoadWeight. The workers with the highest score are the candidates.
+type WeightedPlacementStrategy struct {
+	LocalityWeight float64
+	LoadWeight     float64
+
+	rand *rand.Rand
+}
+
+func NewWeightedPlacementStrategy(localityWeight float64, loadWeight float64) CandidatePlacementStrategy {
+	return &WeightedPlacementStrategy{
+		LocalityWeight: localityWeight,
+		LoadWeight:     loadWeight,
+
+		rand: rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+func (strategy *WeightedPlacementStrategy) Choose(workers []Worker, spec ContainerSpec) (Worker, error) {
+	candidates, err := strategy.Candidates(workers, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	return candidates[strategy.rand.Intn(len(candidates))], nil
+}
+
+func (strategy *WeightedPlacementStrategy) Candidates(workers []Worker, spec ContainerSpec) ([]Worker, error) {
+	var totalContainers int
+	for _, w := range workers {
+		totalContainers += w.ActiveContainers()
+	}
+
+	var candidates []Worker
+	var highestScore float64
+	for i, w := range workers {
+		var locality float64
+		if len(spec.Inputs) > 0 {
+			inputCount := localInputCount(w, spec)
+
+			locality = float64(inputCount) / float64(len(spec.Inputs))
+		}
+
+		var load float64
+		if totalContainers > 0 {
+			load = float64(w.ActiveContainers()) / float64(totalContainers)
+		}
+
+		score := strategy.LocalityWeight*locality - strategy.LoadWeight*load
+
+		if i == 0 || score > highestScore {
+			highestScore = score
+			candidates = []Worker{w}
+		} else if score == highestScore {
+			candidates = append(candidates, w)
+		}
+	}
+
+	return candidates, nil
+}
+
+// ChainedPlacementStrategy narrows the workers down with each of its
+// strategies in turn, so that each strategy only has to choose between the
+// workers the strategies before it consider equally good. The final choice
+// between the remaining candidates is random.
+type ChainedPlacementStrategy struct {
+	strategies []CandidatePlacementStrategy
+
+	rand *rand.Rand
+}
+
+func NewChainedPlacementStrategy(strategies ...CandidatePlacementStrategy) ContainerPlacementStrategy {
+	return &ChainedPlacementStrategy{
+		strategies: strategies,
+
+		rand: rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+func (strategy *ChainedPlacementStrategy) Choose(workers []Worker, spec ContainerSpec) (Worker, error) {
+	candidates := workers
+
+	for _, s := range strategy.strategies {
+		if len(candidates) == 1 {
+			break
+		}
+
+		var err error
+		candidates, err = s.Candidates(candidates, spec)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return candidates[strategy.rand.Intn(len(candidates))], nil
+}
+
+// localInputCount counts the container's inputs which are on the worker. An
+// input which can not be looked up on the worker, e.g. because it can not be
+// reached, is counted as not being on it, so that one worker can not keep the
+// container from being placed on the others.
+func localInputCount(w Worker, spec ContainerSpec) int {
+	count := 0
+
+	for _, inputSource := range spec.Inputs {
+		_, found, err := inputSource.Source().VolumeOn(w)
+		if err == nil && found {
+			count++
+		}
+	}
+
+	return count
+}
+
+func fewest(workers []Worker, count func(Worker) int) []Worker {
+	var candidates []Worker
+	var lowestCount int
+	for i, w := range workers {
+		c := count(w)
+
+		if i == 0 || c < lowestCount {
+			lowestCount = c
+			candidates = []Worker{w}
+		} else if c == lowestCount {
+			candidates = append(candidates, w)
+		}
+	}
+
+	return candidates
+}
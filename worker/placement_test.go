@@ -1,6 +1,8 @@
 package worker_test
 
 import (
+	"errors"
+
 	. "github.com/concourse/atc/worker"
 	"github.com/concourse/atc/worker/workerfakes"
 
@@ -208,3 +210,256 @@ var _ = Describe("RandomPlacementStrategy", func() {
 		})
 	})
 })
+
+var _ = Describe("FewestBuildContainersPlacementStrategy", func() {
+	Describe("Choose", func() {
+		var (
+			busyWorker  *workerfakes.FakeWorker
+			idleWorker1 *workerfakes.FakeWorker
+			idleWorker2 *workerfakes.FakeWorker
+		)
+
+		JustBeforeEach(func() {
+			chosenWorker, chooseErr = strategy.Choose(
+				workers,
+				spec,
+			)
+		})
+
+		BeforeEach(func() {
+			strategy = NewFewestBuildContainersPlacementStrategy()
+
+			busyWorker = new(workerfakes.FakeWorker)
+			busyWorker.ActiveContainersReturns(20)
+
+			idleWorker1 = new(workerfakes.FakeWorker)
+			idleWorker1.ActiveContainersReturns(2)
+
+			idleWorker2 = new(workerfakes.FakeWorker)
+			idleWorker2.ActiveContainersReturns(2)
+
+			spec = ContainerSpec{}
+		})
+
+		Context("with one having the fewest containers", func() {
+			BeforeEach(func() {
+				workers = []Worker{busyWorker, idleWorker1}
+			})
+
+			It("creates it on the worker with the fewest containers", func() {
+				Expect(chooseErr).ToNot(HaveOccurred())
+				Expect(chosenWorker).To(Equal(idleWorker1))
+			})
+		})
+
+		Context("with multiple having the fewest containers", func() {
+			BeforeEach(func() {
+				workers = []Worker{busyWorker, idleWorker1, idleWorker2}
+			})
+
+			It("creates it on a random one of them", func() {
+				workerChoiceCounts := map[Worker]int{}
+
+				for i := 0; i < 100; i++ {
+					worker, err := strategy.Choose(
+						workers,
+						spec,
+					)
+					Expect(err).ToNot(HaveOccurred())
+					workerChoiceCounts[worker]++
+				}
+
+				Expect(workerChoiceCounts[idleWorker1]).ToNot(BeZero())
+				Expect(workerChoiceCounts[idleWorker2]).ToNot(BeZero())
+				Expect(workerChoiceCounts[busyWorker]).To(BeZero())
+			})
+		})
+	})
+})
+
+var _ = Describe("FewestVolumesPlacementStrategy", func() {
+	Describe("Choose", func() {
+		var (
+			fullWorker  *workerfakes.FakeWorker
+			emptyWorker *workerfakes.FakeWorker
+		)
+
+		JustBeforeEach(func() {
+			chosenWorker, chooseErr = strategy.Choose(
+				workers,
+				spec,
+			)
+		})
+
+		BeforeEach(func() {
+			strategy = NewFewestVolumesPlacementStrategy()
+
+			fullWorker = new(workerfakes.FakeWorker)
+			fullWorker.ActiveVolumesReturns(200)
+			fullWorker.ActiveContainersReturns(1)
+
+			emptyWorker = new(workerfakes.FakeWorker)
+			emptyWorker.ActiveVolumesReturns(10)
+			emptyWorker.ActiveContainersReturns(5)
+
+			spec = ContainerSpec{}
+			workers = []Worker{fullWorker, emptyWorker}
+		})
+
+		It("creates it on the worker with the fewest volumes", func() {
+			Expect(chooseErr).ToNot(HaveOccurred())
+			Expect(chosenWorker).To(Equal(emptyWorker))
+		})
+	})
+})
+
+var _ = Describe("WeightedPlacementStrategy", func() {
+	Describe("Choose", func() {
+		var (
+			localityWeight float64
+			loadWeight     float64
+
+			busyLocalWorker *workerfakes.FakeWorker
+			idleWorker      *workerfakes.FakeWorker
+		)
+
+		JustBeforeEach(func() {
+			strategy = NewWeightedPlacementStrategy(localityWeight, loadWeight)
+
+			chosenWorker, chooseErr = strategy.Choose(
+				workers,
+				spec,
+			)
+		})
+
+		BeforeEach(func() {
+			busyLocalWorker = new(workerfakes.FakeWorker)
+			busyLocalWorker.ActiveContainersReturns(30)
+
+			idleWorker = new(workerfakes.FakeWorker)
+			idleWorker.ActiveContainersReturns(10)
+
+			fakeInput := new(workerfakes.FakeInputSource)
+			fakeInputAS := new(workerfakes.FakeArtifactSource)
+			fakeInputAS.VolumeOnStub = func(worker Worker) (Volume, bool, error) {
+				if worker == busyLocalWorker {
+					return new(workerfakes.FakeVolume), true, nil
+				}
+
+				return nil, false, nil
+			}
+			fakeInput.SourceReturns(fakeInputAS)
+
+			spec = ContainerSpec{
+				Inputs: []InputSource{fakeInput},
+			}
+
+			workers = []Worker{busyLocalWorker, idleWorker}
+		})
+
+		Context("when locality outweighs load", func() {
+			BeforeEach(func() {
+				localityWeight = 1
+				loadWeight = 1
+			})
+
+			It("creates it on the worker with the inputs", func() {
+				Expect(chooseErr).ToNot(HaveOccurred())
+				Expect(chosenWorker).To(Equal(busyLocalWorker))
+			})
+		})
+
+		Context("when load outweighs locality", func() {
+			BeforeEach(func() {
+				localityWeight = 1
+				loadWeight = 4
+			})
+
+			It("creates it on the less busy worker", func() {
+				Expect(chooseErr).ToNot(HaveOccurred())
+				Expect(chosenWorker).To(Equal(idleWorker))
+			})
+		})
+
+		Context("when looking up an input on a worker fails", func() {
+			BeforeEach(func() {
+				localityWeight = 1
+				loadWeight = 1
+
+				failingInput := new(workerfakes.FakeInputSource)
+				failingInputAS := new(workerfakes.FakeArtifactSource)
+				failingInputAS.VolumeOnStub = func(worker Worker) (Volume, bool, error) {
+					if worker == busyLocalWorker {
+						return nil, false, errors.New("nope")
+					}
+
+					return new(workerfakes.FakeVolume), true, nil
+				}
+				failingInput.SourceReturns(failingInputAS)
+
+				spec.Inputs = []InputSource{failingInput}
+			})
+
+			It("counts the input as not being on that worker", func() {
+				Expect(chooseErr).ToNot(HaveOccurred())
+				Expect(chosenWorker).To(Equal(idleWorker))
+			})
+		})
+	})
+})
+
+var _ = Describe("ChainedPlacementStrategy", func() {
+	Describe("Choose", func() {
+		var (
+			busyLocalWorker *workerfakes.FakeWorker
+			idleLocalWorker *workerfakes.FakeWorker
+			idleWorker      *workerfakes.FakeWorker
+		)
+
+		JustBeforeEach(func() {
+			chosenWorker, chooseErr = strategy.Choose(
+				workers,
+				spec,
+			)
+		})
+
+		BeforeEach(func() {
+			strategy = NewChainedPlacementStrategy(
+				NewVolumeLocalityPlacementStrategy(),
+				NewFewestBuildContainersPlacementStrategy(),
+			)
+
+			busyLocalWorker = new(workerfakes.FakeWorker)
+			busyLocalWorker.ActiveContainersReturns(30)
+
+			idleLocalWorker = new(workerfakes.FakeWorker)
+			idleLocalWorker.ActiveContainersReturns(10)
+
+			idleWorker = new(workerfakes.FakeWorker)
+			idleWorker.ActiveContainersReturns(1)
+
+			fakeInput := new(workerfakes.FakeInputSource)
+			fakeInputAS := new(workerfakes.FakeArtifactSource)
+			fakeInputAS.VolumeOnStub = func(worker Worker) (Volume, bool, error) {
+				switch worker {
+				case busyLocalWorker, idleLocalWorker:
+					return new(workerfakes.FakeVolume), true, nil
+				default:
+					return nil, false, nil
+				}
+			}
+			fakeInput.SourceReturns(fakeInputAS)
+
+			spec = ContainerSpec{
+				Inputs: []InputSource{fakeInput},
+			}
+
+			workers = []Worker{busyLocalWorker, idleLocalWorker, idleWorker}
+		})
+
+		It("falls back to the next strategy to choose between equally good workers", func() {
+			Expect(chooseErr).ToNot(HaveOccurred())
+			Expect(chosenWorker).To(Equal(idleLocalWorker))
+		})
+	})
+})
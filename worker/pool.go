@@ -6,6 +6,7 @@ import (
 	"math/rand"
 	"os"
 	"path/filepath"
+	"sync"
 	"time"
 
 	"code.cloudfoundry.org/clock"
@@ -14,6 +15,7 @@ import (
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/metric"
 	"github.com/concourse/baggageclaim"
 )
 
@@ -44,8 +46,15 @@ type WorkerProvider interface {
 var (
 	ErrNoWorkers     = errors.New("no workers")
 	ErrMissingWorker = errors.New("worker for container is missing")
+	ErrAborted       = errors.New("aborted while waiting for a worker")
 )
 
+// workerWaitInterval is how often a step waiting for a worker with room for
+// its container checks the workers again. Workers only report how many
+// containers they have when they heartbeat, so there is no point in checking
+// much more often than that.
+const workerWaitInterval = 5 * time.Second
+
 type NoCompatibleWorkersError struct {
 	Spec    WorkerSpec
 	Workers []Worker
@@ -69,13 +78,34 @@ type pool struct {
 
 	rand     *rand.Rand
 	strategy ContainerPlacementStrategy
+
+	clock         clock.Clock
+	maxContainers int
+	teamFactory   db.TeamFactory
+	queue         *waitQueue
 }
 
-func NewPool(provider WorkerProvider, strategy ContainerPlacementStrategy) Client {
+// NewPool returns a Client which places containers on the workers given by
+// the provider. Workers which do not advertise their own limit are given
+// maxContainers as theirs; a limit of 0 means the workers are unlimited.
+// Containers placed on limited workers are reserved through the teamFactory
+// before they are created.
+func NewPool(
+	provider WorkerProvider,
+	strategy ContainerPlacementStrategy,
+	clock clock.Clock,
+	maxContainers int,
+	teamFactory db.TeamFactory,
+) Client {
 	return &pool{
 		provider: provider,
 		rand:     rand.New(rand.NewSource(time.Now().UnixNano())),
 		strategy: strategy,
+
+		clock:         clock,
+		maxContainers: maxContainers,
+		teamFactory:   teamFactory,
+		queue:         newWaitQueue(),
 	}
 }
 
@@ -148,12 +178,7 @@ func (pool *pool) FindOrCreateContainer(
 	}
 
 	if !found {
-		compatibleWorkers, err := pool.AllSatisfying(logger, spec.WorkerSpec(), resourceTypes)
-		if err != nil {
-			return nil, err
-		}
-
-		worker, err = pool.strategy.Choose(compatibleWorkers, spec)
+		worker, err = pool.chooseWorker(logger, signals, delegate, owner, metadata, spec, resourceTypes)
 		if err != nil {
 			return nil, err
 		}
@@ -170,6 +195,148 @@ func (pool *pool) FindOrCreateContainer(
 	)
 }
 
+// chooseWorker lets the strategy choose between the satisfying workers that
+// have room for another container, and reserves the container on the chosen
+// one. When all of them are full it waits in line, behind the steps which
+// started waiting for the same kind of worker earlier, until one of them has
+// room.
+func (pool *pool) chooseWorker(
+	logger lager.Logger,
+	signals <-chan os.Signal,
+	delegate ImageFetchingDelegate,
+	owner db.ContainerOwner,
+	metadata db.ContainerMetadata,
+	spec ContainerSpec,
+	resourceTypes creds.VersionedResourceTypes,
+) (Worker, error) {
+	line := fmt.Sprintf("%d: %s", spec.TeamID, spec.WorkerSpec().Description())
+
+	var ticket *waitTicket
+	defer func() {
+		if ticket != nil {
+			pool.queue.Leave(ticket)
+			metric.ContainersWaitingForWorker.Dec()
+		}
+	}()
+
+	for {
+		compatibleWorkers, err := pool.AllSatisfying(logger, spec.WorkerSpec(), resourceTypes)
+		if err != nil {
+			return nil, err
+		}
+
+		availableWorkers := pool.withRoom(compatibleWorkers)
+		if len(availableWorkers) > 0 && pool.queue.IsNext(line, ticket) {
+			worker, err := pool.reserve(logger, availableWorkers, owner, metadata, spec)
+			if err != nil {
+				return nil, err
+			}
+
+			if worker != nil {
+				return worker, nil
+			}
+		}
+
+		if ticket == nil {
+			logger.Info("waiting-for-worker", lager.Data{"workers": len(compatibleWorkers)})
+
+			ticket = pool.queue.Join(line)
+			metric.ContainersWaitingForWorker.Inc()
+
+			err = delegate.WaitingForWorker()
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		timer := pool.clock.NewTimer(workerWaitInterval)
+
+		select {
+		case <-signals:
+			timer.Stop()
+			return nil, ErrAborted
+		case <-timer.C():
+		}
+	}
+}
+
+// withRoom returns the workers which had room for another container when
+// they last heartbeated. It is only a hint; the room is checked again when
+// the container is reserved.
+func (pool *pool) withRoom(workers []Worker) []Worker {
+	var available []Worker
+	for _, worker := range workers {
+		maxContainers := pool.maxContainersOn(worker)
+		if maxContainers == 0 || worker.ActiveContainers() < maxContainers {
+			available = append(available, worker)
+		}
+	}
+
+	return available
+}
+
+// reserve lets the strategy choose between the workers and, if the chosen
+// worker is limited, reserves the container on it by creating it in the
+// database, counting the worker's containers there. Workers found to be full
+// are left out and the strategy chooses again. It returns nil if all of them
+// are full.
+func (pool *pool) reserve(
+	logger lager.Logger,
+	workers []Worker,
+	owner db.ContainerOwner,
+	metadata db.ContainerMetadata,
+	spec ContainerSpec,
+) (Worker, error) {
+	for len(workers) > 0 {
+		worker, err := pool.strategy.Choose(workers, spec)
+		if err != nil {
+			return nil, err
+		}
+
+		maxContainers := pool.maxContainersOn(worker)
+		if maxContainers == 0 {
+			return worker, nil
+		}
+
+		_, reserved, err := pool.teamFactory.GetByID(spec.TeamID).ReserveContainer(
+			worker.Name(),
+			owner,
+			metadata,
+			maxContainers,
+		)
+		if err != nil {
+			logger.Error("failed-to-reserve-container", err, lager.Data{"worker": worker.Name()})
+			return nil, err
+		}
+
+		if reserved {
+			return worker, nil
+		}
+
+		logger.Debug("worker-full", lager.Data{"worker": worker.Name()})
+
+		var others []Worker
+		for _, w := range workers {
+			if w != worker {
+				others = append(others, w)
+			}
+		}
+
+		workers = others
+	}
+
+	return nil, nil
+}
+
+func (pool *pool) maxContainersOn(worker Worker) int {
+	maxContainers := worker.MaxContainers()
+	if maxContainers == 0 {
+		maxContainers = pool.maxContainers
+	}
+
+	return maxContainers
+}
+
 func (pool *pool) FindContainerByHandle(logger lager.Logger, teamID int, handle string) (Container, bool, error) {
 	worker, found, err := pool.provider.FindWorkerForContainer(
 		logger.Session("find-worker"),
@@ -203,6 +370,70 @@ func (*pool) BaggageclaimClient() baggageclaim.Client {
 	panic("BaggageclaimClient not implemented for pool")
 }
 
+// waitQueue keeps the steps waiting for a worker in line, one line per kind of
+// worker, so that they get a worker in the order they started waiting.
+//
+// The queue only orders the steps waiting on this ATC. Steps waiting on
+// different ATCs race for the room that frees up, but the reservation made in
+// the database keeps them from exceeding the workers' limits.
+type waitQueue struct {
+	lines map[string][]*waitTicket
+	lock  sync.Mutex
+}
+
+type waitTicket struct {
+	line string
+}
+
+func newWaitQueue() *waitQueue {
+	return &waitQueue{
+		lines: map[string][]*waitTicket{},
+	}
+}
+
+func (queue *waitQueue) Join(line string) *waitTicket {
+	queue.lock.Lock()
+	defer queue.lock.Unlock()
+
+	ticket := &waitTicket{line: line}
+	queue.lines[line] = append(queue.lines[line], ticket)
+
+	return ticket
+}
+
+// IsNext returns whether the ticket is at the front of the line. A nil ticket
+// is only next if nobody is waiting in the line.
+func (queue *waitQueue) IsNext(line string, ticket *waitTicket) bool {
+	queue.lock.Lock()
+	defer queue.lock.Unlock()
+
+	waiting := queue.lines[line]
+	if len(waiting) == 0 {
+		return true
+	}
+
+	return waiting[0] == ticket
+}
+
+func (queue *waitQueue) Leave(ticket *waitTicket) {
+	queue.lock.Lock()
+	defer queue.lock.Unlock()
+
+	waiting := queue.lines[ticket.line]
+	for i, t := range waiting {
+		if t == ticket {
+			waiting = append(waiting[:i], waiting[i+1:]...)
+			break
+		}
+	}
+
+	if len(waiting) == 0 {
+		delete(queue.lines, ticket.line)
+	} else {
+		queue.lines[ticket.line] = waiting
+	}
+}
+
 func resourcesDir(suffix string) string {
 	return filepath.Join("/tmp", "build", suffix)
 }
@@ -3,7 +3,9 @@ package worker_test
 import (
 	"errors"
 	"os"
+	"time"
 
+	"code.cloudfoundry.org/clock/fakeclock"
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/cloudfoundry/bosh-cli/director/template"
 	"github.com/concourse/atc"
@@ -22,15 +24,26 @@ var _ = Describe("Pool", func() {
 		logger       *lagertest.TestLogger
 		fakeProvider *workerfakes.FakeWorkerProvider
 		fakeStrategy *workerfakes.FakeContainerPlacementStrategy
+		fakeClock    *fakeclock.FakeClock
+		fakeTeam     *dbfakes.FakeTeam
 		pool         Client
+
+		fakeTeamFactory *dbfakes.FakeTeamFactory
 	)
 
 	BeforeEach(func() {
 		logger = lagertest.NewTestLogger("test")
 		fakeProvider = new(workerfakes.FakeWorkerProvider)
 		fakeStrategy = new(workerfakes.FakeContainerPlacementStrategy)
+		fakeClock = fakeclock.NewFakeClock(time.Unix(123, 456))
+
+		fakeTeam = new(dbfakes.FakeTeam)
+		fakeTeam.ReserveContainerReturns(new(dbfakes.FakeCreatingContainer), true, nil)
+
+		fakeTeamFactory = new(dbfakes.FakeTeamFactory)
+		fakeTeamFactory.GetByIDReturns(fakeTeam)
 
-		pool = NewPool(fakeProvider, fakeStrategy)
+		pool = NewPool(fakeProvider, fakeStrategy, fakeClock, 10, fakeTeamFactory)
 	})
 
 	Describe("Satisfying", func() {
@@ -463,6 +476,7 @@ var _ = Describe("Pool", func() {
 			incompatibleWorker.SatisfyingReturns(nil, ErrIncompatiblePlatform)
 
 			compatibleWorker = new(workerfakes.FakeWorker)
+			compatibleWorker.NameReturns("some-compatible-worker")
 			compatibleWorker.SatisfyingReturns(compatibleWorker, nil)
 			compatibleWorker.FindOrCreateContainerReturns(fakeContainer, nil)
 		})
@@ -552,6 +566,77 @@ var _ = Describe("Pool", func() {
 						Expect(compatibleWorker.FindOrCreateContainerCallCount()).To(Equal(1))
 						Expect(createdContainer).To(Equal(fakeContainer))
 					})
+
+					It("reserves the container on the worker before creating it", func() {
+						Expect(fakeTeamFactory.GetByIDArgsForCall(0)).To(Equal(4567))
+						Expect(fakeTeam.ReserveContainerCallCount()).To(Equal(1))
+
+						workerName, owner, actualMetadata, maxContainers := fakeTeam.ReserveContainerArgsForCall(0)
+						Expect(workerName).To(Equal("some-compatible-worker"))
+						Expect(owner).To(Equal(fakeOwner))
+						Expect(actualMetadata).To(Equal(metadata))
+						Expect(maxContainers).To(Equal(10))
+					})
+
+					Context("when reserving the container fails", func() {
+						disaster := errors.New("nope")
+
+						BeforeEach(func() {
+							fakeTeam.ReserveContainerReturns(nil, false, disaster)
+						})
+
+						It("returns the error", func() {
+							Expect(createErr).To(Equal(disaster))
+							Expect(compatibleWorker.FindOrCreateContainerCallCount()).To(BeZero())
+						})
+					})
+
+					Context("when the workers are unlimited", func() {
+						BeforeEach(func() {
+							pool = NewPool(fakeProvider, fakeStrategy, fakeClock, 0, fakeTeamFactory)
+						})
+
+						It("does not reserve the container", func() {
+							Expect(createErr).ToNot(HaveOccurred())
+							Expect(fakeTeam.ReserveContainerCallCount()).To(BeZero())
+							Expect(compatibleWorker.FindOrCreateContainerCallCount()).To(Equal(1))
+						})
+					})
+				})
+
+				Context("when the chosen worker has no room left in the database", func() {
+					var otherWorker *workerfakes.FakeWorker
+
+					BeforeEach(func() {
+						otherWorker = new(workerfakes.FakeWorker)
+						otherWorker.NameReturns("some-other-worker")
+						otherWorker.SatisfyingReturns(otherWorker, nil)
+						otherWorker.FindOrCreateContainerReturns(fakeContainer, nil)
+
+						fakeProvider.RunningWorkersReturns([]Worker{
+							compatibleWorker,
+							otherWorker,
+						}, nil)
+
+						fakeStrategy.ChooseReturnsOnCall(0, compatibleWorker, nil)
+						fakeStrategy.ChooseReturnsOnCall(1, otherWorker, nil)
+
+						fakeTeam.ReserveContainerReturnsOnCall(0, nil, false, nil)
+					})
+
+					It("lets the strategy choose between the other workers", func() {
+						Expect(createErr).ToNot(HaveOccurred())
+						Expect(fakeStrategy.ChooseCallCount()).To(Equal(2))
+
+						workers, _ := fakeStrategy.ChooseArgsForCall(1)
+						Expect(workers).To(Equal([]Worker{otherWorker}))
+
+						workerName, _, _, _ := fakeTeam.ReserveContainerArgsForCall(1)
+						Expect(workerName).To(Equal("some-other-worker"))
+
+						Expect(compatibleWorker.FindOrCreateContainerCallCount()).To(BeZero())
+						Expect(otherWorker.FindOrCreateContainerCallCount()).To(Equal(1))
+					})
 				})
 
 				Context("when strategy errors", func() {
@@ -569,6 +654,106 @@ var _ = Describe("Pool", func() {
 					})
 				})
 			})
+
+			Context("with compatible workers that are full", func() {
+				var fullWorker *workerfakes.FakeWorker
+
+				BeforeEach(func() {
+					fullWorker = new(workerfakes.FakeWorker)
+					fullWorker.SatisfyingReturns(fullWorker, nil)
+					fullWorker.ActiveContainersReturns(10)
+
+					compatibleWorker.ActiveContainersReturns(9)
+
+					fakeProvider.RunningWorkersReturns([]Worker{
+						fullWorker,
+						compatibleWorker,
+					}, nil)
+
+					fakeStrategy.ChooseReturns(compatibleWorker, nil)
+				})
+
+				It("only lets the strategy choose between the workers with room", func() {
+					Expect(createErr).ToNot(HaveOccurred())
+					Expect(fakeStrategy.ChooseCallCount()).To(Equal(1))
+
+					workers, _ := fakeStrategy.ChooseArgsForCall(0)
+					Expect(workers).To(Equal([]Worker{compatibleWorker}))
+				})
+
+				Context("when a worker advertises its own limit", func() {
+					BeforeEach(func() {
+						fullWorker.MaxContainersReturns(20)
+					})
+
+					It("uses it instead of the default", func() {
+						workers, _ := fakeStrategy.ChooseArgsForCall(0)
+						Expect(workers).To(Equal([]Worker{fullWorker, compatibleWorker}))
+					})
+				})
+
+				Context("when all of them are full", func() {
+					BeforeEach(func() {
+						compatibleWorker.ActiveContainersReturnsOnCall(0, 10)
+						compatibleWorker.ActiveContainersReturnsOnCall(1, 10)
+						compatibleWorker.ActiveContainersReturns(3)
+					})
+
+					Context("when one of them gets room", func() {
+						BeforeEach(func() {
+							go func() {
+								defer GinkgoRecover()
+
+								fakeClock.WaitForWatcherAndIncrement(time.Minute)
+								fakeClock.WaitForWatcherAndIncrement(time.Minute)
+							}()
+						})
+
+						It("waits until then", func() {
+							Expect(createErr).ToNot(HaveOccurred())
+							Expect(fakeProvider.RunningWorkersCallCount()).To(Equal(3))
+							Expect(compatibleWorker.FindOrCreateContainerCallCount()).To(Equal(1))
+							Expect(createdContainer).To(Equal(fakeContainer))
+						})
+
+						It("lets the build know it is waiting for a worker, once", func() {
+							Expect(fakeImageFetchingDelegate.WaitingForWorkerCallCount()).To(Equal(1))
+						})
+					})
+
+					Context("when letting the build know fails", func() {
+						disaster := errors.New("nope")
+
+						BeforeEach(func() {
+							fakeImageFetchingDelegate.WaitingForWorkerReturns(disaster)
+							compatibleWorker.ActiveContainersReturns(10)
+						})
+
+						It("returns the error", func() {
+							Expect(createErr).To(Equal(disaster))
+						})
+					})
+				})
+
+				Context("when signalled while waiting", func() {
+					BeforeEach(func() {
+						compatibleWorker.ActiveContainersReturns(10)
+
+						interrupt := make(chan os.Signal, 1)
+						interrupt <- os.Interrupt
+						signals = interrupt
+					})
+
+					AfterEach(func() {
+						signals = nil
+					})
+
+					It("returns ErrAborted", func() {
+						Expect(createErr).To(Equal(ErrAborted))
+						Expect(compatibleWorker.FindOrCreateContainerCallCount()).To(BeZero())
+					})
+				})
+			})
 		})
 	})
 })
@@ -2,6 +2,7 @@ package worker
 
 import (
 	"io"
+	"sync"
 
 	"code.cloudfoundry.org/lager"
 
@@ -14,15 +15,24 @@ import (
 type Volume interface {
 	Handle() string
 	Path() string
+	WorkerName() string
 
 	SetProperty(key string, value string) error
 	Properties() (baggageclaim.VolumeProperties, error)
 
+	ContentHash() (string, error)
+
 	SetPrivileged(bool) error
 
 	StreamIn(path string, tarStream io.Reader) error
 	StreamOut(path string) (io.ReadCloser, error)
 
+	StreamOutP2PURL(path string) (string, bool)
+	StreamInP2P(logger lager.Logger, path string, sourceURL string) (int, string, error)
+
+	StreamOutCompressed(path string) (io.ReadCloser, string, error)
+	StreamInCompressed(path string, encoding string, tarStream io.Reader) error
+
 	COWStrategy() baggageclaim.COWStrategy
 
 	InitializeResourceCache(*db.UsedResourceCache) error
@@ -42,6 +52,9 @@ type volume struct {
 	bcVolume     baggageclaim.Volume
 	dbVolume     db.CreatedVolume
 	volumeClient VolumeClient
+
+	contentHashLock sync.Mutex
+	contentHash     *string
 }
 
 func NewVolume(
@@ -60,8 +73,44 @@ func (v *volume) Handle() string { return v.bcVolume.Handle() }
 
 func (v *volume) Path() string { return v.bcVolume.Path() }
 
+func (v *volume) WorkerName() string { return v.dbVolume.WorkerName() }
+
 func (v *volume) SetProperty(key string, value string) error {
-	return v.bcVolume.SetProperty(key, value)
+	err := v.bcVolume.SetProperty(key, value)
+	if err != nil {
+		return err
+	}
+
+	if key == ContentHashProperty {
+		v.contentHashLock.Lock()
+		v.contentHash = &value
+		v.contentHashLock.Unlock()
+	}
+
+	return nil
+}
+
+// ContentHash returns the content hash recorded on the volume, or "" if it
+// has none. It is only read from the worker once, as the volumes which are
+// hashed are the ones being streamed from, and streaming records the hash
+// through the same volume.
+func (v *volume) ContentHash() (string, error) {
+	v.contentHashLock.Lock()
+	defer v.contentHashLock.Unlock()
+
+	if v.contentHash != nil {
+		return *v.contentHash, nil
+	}
+
+	properties, err := v.bcVolume.Properties()
+	if err != nil {
+		return "", err
+	}
+
+	contentHash := properties[ContentHashProperty]
+	v.contentHash = &contentHash
+
+	return contentHash, nil
 }
 
 func (v *volume) SetPrivileged(privileged bool) error {
@@ -76,6 +125,42 @@ func (v *volume) StreamOut(path string) (io.ReadCloser, error) {
 	return v.bcVolume.StreamOut(path)
 }
 
+// StreamOutP2PURL returns the URL other workers can stream the volume's
+// contents at path from, if P2P streaming is enabled.
+func (v *volume) StreamOutP2PURL(path string) (string, bool) {
+	return v.volumeClient.StreamOutP2PURL(v.Handle(), path)
+}
+
+// StreamInP2P has the volume's worker stream the tar stream at the URL into
+// path, without it going through the ATC. It returns the number of bytes
+// streamed and their SHA-256, uncompressed.
+func (v *volume) StreamInP2P(logger lager.Logger, path string, sourceURL string) (int, string, error) {
+	return v.volumeClient.StreamInP2P(logger, v.Handle(), path, sourceURL)
+}
+
+// StreamOutCompressed streams out the volume's contents at path compressed,
+// if compression is enabled and the worker supports it. It returns the
+// encoding the stream is in.
+func (v *volume) StreamOutCompressed(path string) (io.ReadCloser, string, error) {
+	out, encoding, err := v.volumeClient.StreamOutCompressed(v.Handle(), path)
+	if err == ErrCompressionDisabled {
+		out, err = v.bcVolume.StreamOut(path)
+		return out, StreamEncodingRaw, err
+	}
+
+	return out, encoding, err
+}
+
+// StreamInCompressed streams the tar stream, compressed with the encoding,
+// into path.
+func (v *volume) StreamInCompressed(path string, encoding string, tarStream io.Reader) error {
+	if encoding == StreamEncodingRaw {
+		return v.bcVolume.StreamIn(path, tarStream)
+	}
+
+	return v.volumeClient.StreamInCompressed(v.Handle(), path, encoding, tarStream)
+}
+
 func (v *volume) Properties() (baggageclaim.VolumeProperties, error) {
 	return v.bcVolume.Properties()
 }
@@ -1,8 +1,14 @@
 package worker
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
 	"time"
 
 	"code.cloudfoundry.org/clock"
@@ -11,6 +17,7 @@ import (
 	"github.com/concourse/atc/db/lock"
 	"github.com/concourse/atc/metric"
 	"github.com/concourse/baggageclaim"
+	"github.com/cppforlife/go-semi-semantic/version"
 )
 
 const creatingVolumeRetryDelay = 1 * time.Second
@@ -63,6 +70,14 @@ type VolumeClient interface {
 	) (volume Volume, found bool, err error)
 
 	LookupVolume(lager.Logger, string) (Volume, bool, error)
+
+	FindVolumeWithSameContent(lager.Logger, Volume) (Volume, bool, error)
+
+	StreamOutP2PURL(handle string, path string) (string, bool)
+	StreamInP2P(logger lager.Logger, handle string, path string, sourceURL string) (int, string, error)
+
+	StreamOutCompressed(handle string, path string) (io.ReadCloser, string, error)
+	StreamInCompressed(handle string, path string, encoding string, tarStream io.Reader) error
 }
 
 var ErrVolumeExpiredImmediately = errors.New("volume expired immediately after saving")
@@ -75,6 +90,19 @@ func (e ErrCreatedVolumeNotFound) Error() string {
 	return fmt.Sprintf("failed to find created volume in baggageclaim. Volume handle: %s", e.Handle)
 }
 
+var ErrP2PStreamingDisabled = errors.New("p2p volume streaming is disabled")
+var ErrP2PStreamingNotSupported = errors.New("worker does not support p2p volume streaming")
+var ErrCompressionDisabled = errors.New("volume streaming compression is disabled")
+
+type StreamFailedError struct {
+	StatusCode int
+	Body       string
+}
+
+func (err StreamFailedError) Error() string {
+	return fmt.Sprintf("streaming failed with status %d: %s", err.StatusCode, err.Body)
+}
+
 var ErrBaseResourceTypeNotFound = errors.New("base resource type not found")
 
 type volumeClient struct {
@@ -85,6 +113,11 @@ type volumeClient struct {
 	dbWorkerTaskCacheFactory        db.WorkerTaskCacheFactory
 	clock                           clock.Clock
 	dbWorker                        db.Worker
+
+	streaming VolumeStreamingConfig
+
+	// nil unless P2P streaming or compression is enabled
+	streamingClient *http.Client
 }
 
 func NewVolumeClient(
@@ -96,6 +129,8 @@ func NewVolumeClient(
 	dbVolumeFactory db.VolumeFactory,
 	dbWorkerBaseResourceTypeFactory db.WorkerBaseResourceTypeFactory,
 	dbWorkerTaskCacheFactory db.WorkerTaskCacheFactory,
+	streaming VolumeStreamingConfig,
+	streamingClient *http.Client,
 ) VolumeClient {
 	return &volumeClient{
 		baggageclaimClient:              baggageclaimClient,
@@ -103,8 +138,11 @@ func NewVolumeClient(
 		dbVolumeFactory:                 dbVolumeFactory,
 		dbWorkerBaseResourceTypeFactory: dbWorkerBaseResourceTypeFactory,
 		dbWorkerTaskCacheFactory:        dbWorkerTaskCacheFactory,
-		clock:    clock,
-		dbWorker: dbWorker,
+		clock:                           clock,
+		dbWorker:                        dbWorker,
+
+		streaming:       streaming,
+		streamingClient: streamingClient,
 	}
 }
 
@@ -330,6 +368,250 @@ func (c *volumeClient) LookupVolume(logger lager.Logger, handle string) (Volume,
 	return NewVolume(bcVolume, dbVolume, c), true, nil
 }
 
+// FindVolumeWithSameContent finds a volume on the worker whose content hash
+// is the same as the given volume's, if deduplication is enabled. Only volumes
+// which are not written to once hashed have a content hash, so the volume
+// found can be used in place of streaming the given one to the worker, and is
+// returned as a DeduplicatedVolume.
+func (c *volumeClient) FindVolumeWithSameContent(logger lager.Logger, volume Volume) (Volume, bool, error) {
+	if !c.streaming.Deduplicate {
+		return nil, false, nil
+	}
+
+	logger = logger.Session("find-volume-with-same-content", lager.Data{
+		"volume": volume.Handle(),
+	})
+
+	contentHash, err := volume.ContentHash()
+	if err != nil {
+		logger.Error("failed-to-get-content-hash", err)
+		return nil, false, err
+	}
+
+	if contentHash == "" {
+		return nil, false, nil
+	}
+
+	bcVolumes, err := c.baggageclaimClient.ListVolumes(logger, baggageclaim.VolumeProperties{
+		ContentHashProperty: contentHash,
+	})
+	if err != nil {
+		logger.Error("failed-to-list-volumes", err)
+		return nil, false, err
+	}
+
+	for _, bcVolume := range bcVolumes {
+		dbVolume, found, err := c.dbVolumeFactory.FindCreatedVolume(bcVolume.Handle())
+		if err != nil {
+			logger.Error("failed-to-find-volume-in-db", err)
+			return nil, false, err
+		}
+
+		if !found {
+			continue
+		}
+
+		logger.Debug("found-volume", lager.Data{"handle": bcVolume.Handle()})
+
+		return DeduplicatedVolume{NewVolume(bcVolume, dbVolume, c)}, true, nil
+	}
+
+	return nil, false, nil
+}
+
+// P2PStreamingWorkerVersion is the first worker version whose baggageclaim
+// can stream a volume in from another worker's baggageclaim.
+var P2PStreamingWorkerVersion = version.MustNewVersionFromString("2.1")
+
+func (c *volumeClient) p2pEnabled() bool {
+	return c.streamingClient != nil && c.streaming.P2P
+}
+
+func (c *volumeClient) p2pSupported() bool {
+	workerVersion := c.dbWorker.Version()
+	if workerVersion == nil {
+		return false
+	}
+
+	v, err := version.NewVersionFromString(*workerVersion)
+	if err != nil {
+		return false
+	}
+
+	return v.Release.Compare(P2PStreamingWorkerVersion.Release) >= 0
+}
+
+func (c *volumeClient) compressionEnabled() bool {
+	return c.streamingClient != nil && c.streaming.Encoding != "" && c.streaming.Encoding != StreamEncodingRaw
+}
+
+// StreamOutP2PURL returns the URL on the worker's baggageclaim which streams
+// out the volume's contents at path. It is only known if P2P streaming is
+// enabled and the worker supports it. The URL is the one the worker
+// registered; for workers forwarded through the TSA it is an address on the
+// TSA, which other workers may not be able to reach.
+func (c *volumeClient) StreamOutP2PURL(handle string, path string) (string, bool) {
+	if !c.p2pEnabled() || !c.p2pSupported() {
+		return "", false
+	}
+
+	baggageclaimURL := c.dbWorker.BaggageclaimURL()
+	if baggageclaimURL == nil {
+		return "", false
+	}
+
+	return fmt.Sprintf(
+		"%s/volumes/%s/stream-out?path=%s",
+		strings.TrimSuffix(*baggageclaimURL, "/"),
+		handle,
+		url.QueryEscape(path),
+	), true
+}
+
+type streamP2PInResponse struct {
+	BytesStreamed int    `json:"bytes_streamed"`
+	SHA256        string `json:"sha256"`
+}
+
+// StreamInP2P asks the worker's baggageclaim to stream the contents at
+// sourceURL into the volume at path itself, compressed if compression is
+// enabled. The request only returns once the contents have been streamed, or
+// the streaming client times out. It returns the size and the SHA-256 of the
+// tar stream as the worker extracted it, i.e. uncompressed.
+func (c *volumeClient) StreamInP2P(logger lager.Logger, handle string, path string, sourceURL string) (int, string, error) {
+	if !c.p2pEnabled() {
+		return 0, "", ErrP2PStreamingDisabled
+	}
+
+	if !c.p2pSupported() {
+		return 0, "", ErrP2PStreamingNotSupported
+	}
+
+	logger = logger.Session("stream-in-p2p", lager.Data{
+		"handle": handle,
+		"source": sourceURL,
+	})
+
+	query := url.Values{}
+	query.Set("path", path)
+	query.Set("source", sourceURL)
+
+	if c.compressionEnabled() {
+		query.Set("encoding", c.streaming.Encoding)
+	}
+
+	// the baggageclaim round tripper fills in the worker's address
+	request, err := http.NewRequest(
+		"PUT",
+		fmt.Sprintf("/volumes/%s/stream-p2p-in?%s", handle, query.Encode()),
+		nil,
+	)
+	if err != nil {
+		return 0, "", err
+	}
+
+	response, err := c.streamingClient.Do(request)
+	if err != nil {
+		logger.Error("failed-to-request-stream", err)
+		return 0, "", err
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return 0, "", streamFailedError(response)
+	}
+
+	var streamed streamP2PInResponse
+	err = json.NewDecoder(response.Body).Decode(&streamed)
+	if err != nil {
+		logger.Error("failed-to-decode-response", err)
+		return 0, "", err
+	}
+
+	return streamed.BytesStreamed, streamed.SHA256, nil
+}
+
+// StreamOutCompressed asks the worker's baggageclaim to stream out the
+// volume's contents at path in the configured encoding. A baggageclaim which
+// does not support the encoding streams them out uncompressed; the encoding
+// the stream is in is returned.
+func (c *volumeClient) StreamOutCompressed(handle string, path string) (io.ReadCloser, string, error) {
+	if !c.compressionEnabled() {
+		return nil, "", ErrCompressionDisabled
+	}
+
+	request, err := http.NewRequest(
+		"PUT",
+		fmt.Sprintf("/volumes/%s/stream-out?path=%s", handle, url.QueryEscape(path)),
+		nil,
+	)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// setting the header ourselves keeps the transport from decompressing
+	// the response
+	request.Header.Set("Accept-Encoding", c.streaming.Encoding)
+
+	response, err := c.streamingClient.Do(request)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if response.StatusCode != http.StatusOK {
+		defer response.Body.Close()
+		return nil, "", streamFailedError(response)
+	}
+
+	encoding := response.Header.Get("Content-Encoding")
+	if encoding == "" || encoding == "identity" {
+		encoding = StreamEncodingRaw
+	}
+
+	return response.Body, encoding, nil
+}
+
+// StreamInCompressed streams the tar stream, compressed with the encoding,
+// into the volume at path.
+func (c *volumeClient) StreamInCompressed(handle string, path string, encoding string, tarStream io.Reader) error {
+	if !c.compressionEnabled() {
+		return ErrCompressionDisabled
+	}
+
+	request, err := http.NewRequest(
+		"PUT",
+		fmt.Sprintf("/volumes/%s/stream-in?path=%s", handle, url.QueryEscape(path)),
+		tarStream,
+	)
+	if err != nil {
+		return err
+	}
+
+	request.Header.Set("Content-Encoding", encoding)
+
+	response, err := c.streamingClient.Do(request)
+	if err != nil {
+		return err
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK && response.StatusCode != http.StatusNoContent {
+		return streamFailedError(response)
+	}
+
+	return nil
+}
+
+func streamFailedError(response *http.Response) error {
+	body, _ := ioutil.ReadAll(response.Body)
+	return StreamFailedError{
+		StatusCode: response.StatusCode,
+		Body:       strings.TrimSpace(string(body)),
+	}
+}
+
 func (c *volumeClient) findOrCreateVolume(
 	logger lager.Logger,
 	volumeSpec VolumeSpec,
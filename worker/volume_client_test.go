@@ -2,6 +2,10 @@ package worker_test
 
 import (
 	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
 	"time"
 
 	"code.cloudfoundry.org/clock/fakeclock"
@@ -12,6 +16,7 @@ import (
 	"github.com/concourse/atc/db/lock"
 	"github.com/concourse/atc/db/lock/lockfakes"
 	"github.com/concourse/atc/worker"
+	"github.com/concourse/atc/worker/transport"
 	"github.com/concourse/baggageclaim"
 
 	"github.com/concourse/atc/worker/workerfakes"
@@ -19,6 +24,7 @@ import (
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
 )
 
 var _ = Describe("VolumeClient", func() {
@@ -60,6 +66,8 @@ var _ = Describe("VolumeClient", func() {
 			fakeDBVolumeFactory,
 			fakeWorkerBaseResourceTypeFactory,
 			fakeWorkerTaskCacheFactory,
+			worker.VolumeStreamingConfig{},
+			nil,
 		)
 	})
 
@@ -657,6 +665,8 @@ var _ = Describe("VolumeClient", func() {
 				fakeDBVolumeFactory,
 				fakeWorkerBaseResourceTypeFactory,
 				fakeWorkerTaskCacheFactory,
+				worker.VolumeStreamingConfig{},
+				nil,
 			).LookupVolume(testLogger, handle)
 		})
 
@@ -721,4 +731,394 @@ var _ = Describe("VolumeClient", func() {
 			})
 		})
 	})
+
+	Describe("streaming", func() {
+		var (
+			baggageclaimServer *ghttp.Server
+			baggageclaimURL    string
+			streaming          worker.VolumeStreamingConfig
+			streamingClient    *http.Client
+		)
+
+		BeforeEach(func() {
+			baggageclaimServer = ghttp.NewServer()
+			baggageclaimURL = baggageclaimServer.URL()
+			dbWorker.BaggageclaimURLReturns(&baggageclaimURL)
+
+			workerVersion := "2.1"
+			dbWorker.VersionReturns(&workerVersion)
+
+			streaming = worker.VolumeStreamingConfig{
+				P2P:      true,
+				Encoding: worker.StreamEncodingZstd,
+			}
+
+			streamingClient = &http.Client{
+				Transport: transport.NewBaggageclaimRoundTripper(
+					"some-worker",
+					&baggageclaimURL,
+					new(dbfakes.FakeWorkerFactory),
+					&http.Transport{DisableKeepAlives: true},
+				),
+			}
+		})
+
+		JustBeforeEach(func() {
+			volumeClient = worker.NewVolumeClient(
+				fakeBaggageclaimClient,
+				dbWorker,
+				fakeClock,
+
+				fakeLockFactory,
+				fakeDBVolumeFactory,
+				fakeWorkerBaseResourceTypeFactory,
+				fakeWorkerTaskCacheFactory,
+				streaming,
+				streamingClient,
+			)
+		})
+
+		AfterEach(func() {
+			baggageclaimServer.Close()
+		})
+
+		Describe("StreamOutP2PURL", func() {
+			It("returns the stream-out URL on the worker's baggageclaim", func() {
+				streamURL, ok := volumeClient.StreamOutP2PURL("some-handle", "some/path")
+				Expect(ok).To(BeTrue())
+				Expect(streamURL).To(Equal(baggageclaimURL + "/volumes/some-handle/stream-out?path=some%2Fpath"))
+			})
+
+			Context("when P2P streaming is disabled", func() {
+				BeforeEach(func() {
+					streaming.P2P = false
+				})
+
+				It("returns false", func() {
+					_, ok := volumeClient.StreamOutP2PURL("some-handle", ".")
+					Expect(ok).To(BeFalse())
+				})
+			})
+
+			Context("when the worker has no baggageclaim URL", func() {
+				BeforeEach(func() {
+					dbWorker.BaggageclaimURLReturns(nil)
+				})
+
+				It("returns false", func() {
+					_, ok := volumeClient.StreamOutP2PURL("some-handle", ".")
+					Expect(ok).To(BeFalse())
+				})
+			})
+
+			Context("when the worker is older than P2P streaming", func() {
+				BeforeEach(func() {
+					workerVersion := "2.0"
+					dbWorker.VersionReturns(&workerVersion)
+				})
+
+				It("returns false", func() {
+					_, ok := volumeClient.StreamOutP2PURL("some-handle", ".")
+					Expect(ok).To(BeFalse())
+				})
+			})
+
+			Context("when the worker has no version", func() {
+				BeforeEach(func() {
+					dbWorker.VersionReturns(nil)
+				})
+
+				It("returns false", func() {
+					_, ok := volumeClient.StreamOutP2PURL("some-handle", ".")
+					Expect(ok).To(BeFalse())
+				})
+			})
+		})
+
+		Describe("StreamInP2P", func() {
+			var (
+				bytesStreamed int
+				sha256Sum     string
+				streamErr     error
+			)
+
+			JustBeforeEach(func() {
+				bytesStreamed, sha256Sum, streamErr = volumeClient.StreamInP2P(testLogger, "some-handle", ".", "http://other-worker/volumes/other-handle/stream-out?path=.")
+			})
+
+			Context("when the worker streams the volume", func() {
+				BeforeEach(func() {
+					baggageclaimServer.AppendHandlers(
+						ghttp.CombineHandlers(
+							ghttp.VerifyRequest("PUT", "/volumes/some-handle/stream-p2p-in", "encoding=zstd&path=.&source=http%3A%2F%2Fother-worker%2Fvolumes%2Fother-handle%2Fstream-out%3Fpath%3D."),
+							ghttp.RespondWithJSONEncoded(http.StatusOK, map[string]interface{}{
+								"bytes_streamed": 1024,
+								"sha256":         "abc",
+							}),
+						),
+					)
+				})
+
+				It("returns the number of bytes streamed and their hash", func() {
+					Expect(streamErr).NotTo(HaveOccurred())
+					Expect(bytesStreamed).To(Equal(1024))
+					Expect(sha256Sum).To(Equal("abc"))
+					Expect(baggageclaimServer.ReceivedRequests()).To(HaveLen(1))
+				})
+			})
+
+			Context("when compression is disabled", func() {
+				BeforeEach(func() {
+					streaming.Encoding = worker.StreamEncodingRaw
+
+					baggageclaimServer.AppendHandlers(
+						ghttp.CombineHandlers(
+							ghttp.VerifyRequest("PUT", "/volumes/some-handle/stream-p2p-in", "path=.&source=http%3A%2F%2Fother-worker%2Fvolumes%2Fother-handle%2Fstream-out%3Fpath%3D."),
+							ghttp.RespondWithJSONEncoded(http.StatusOK, map[string]int{"bytes_streamed": 4096}),
+						),
+					)
+				})
+
+				It("does not ask for an encoding", func() {
+					Expect(streamErr).NotTo(HaveOccurred())
+					Expect(bytesStreamed).To(Equal(4096))
+				})
+			})
+
+			Context("when the worker fails to stream the volume", func() {
+				BeforeEach(func() {
+					baggageclaimServer.AppendHandlers(
+						ghttp.RespondWith(http.StatusBadGateway, "could not reach other-worker\n"),
+					)
+				})
+
+				It("returns the status and the message", func() {
+					Expect(streamErr).To(Equal(worker.StreamFailedError{
+						StatusCode: http.StatusBadGateway,
+						Body:       "could not reach other-worker",
+					}))
+				})
+			})
+
+			Context("when P2P streaming is disabled", func() {
+				BeforeEach(func() {
+					streaming.P2P = false
+				})
+
+				It("does not make a request", func() {
+					Expect(streamErr).To(Equal(worker.ErrP2PStreamingDisabled))
+					Expect(baggageclaimServer.ReceivedRequests()).To(BeEmpty())
+				})
+			})
+
+			Context("when the worker is older than P2P streaming", func() {
+				BeforeEach(func() {
+					workerVersion := "1.2.4"
+					dbWorker.VersionReturns(&workerVersion)
+				})
+
+				It("does not make a request", func() {
+					Expect(streamErr).To(Equal(worker.ErrP2PStreamingNotSupported))
+					Expect(baggageclaimServer.ReceivedRequests()).To(BeEmpty())
+				})
+			})
+		})
+
+		Describe("StreamOutCompressed", func() {
+			var (
+				out       io.ReadCloser
+				encoding  string
+				streamErr error
+			)
+
+			JustBeforeEach(func() {
+				out, encoding, streamErr = volumeClient.StreamOutCompressed("some-handle", ".")
+			})
+
+			Context("when the worker supports the encoding", func() {
+				BeforeEach(func() {
+					baggageclaimServer.AppendHandlers(
+						ghttp.CombineHandlers(
+							ghttp.VerifyRequest("PUT", "/volumes/some-handle/stream-out", "path=."),
+							ghttp.VerifyHeaderKV("Accept-Encoding", "zstd"),
+							ghttp.RespondWith(http.StatusOK, "some-zstd-stream", http.Header{
+								"Content-Encoding": []string{"zstd"},
+							}),
+						),
+					)
+				})
+
+				It("returns the stream as it was sent, with its encoding", func() {
+					Expect(streamErr).NotTo(HaveOccurred())
+					Expect(encoding).To(Equal(worker.StreamEncodingZstd))
+					Expect(ioutil.ReadAll(out)).To(Equal([]byte("some-zstd-stream")))
+				})
+			})
+
+			Context("when the worker does not support the encoding", func() {
+				BeforeEach(func() {
+					baggageclaimServer.AppendHandlers(
+						ghttp.RespondWith(http.StatusOK, "some-tar-stream"),
+					)
+				})
+
+				It("returns the stream uncompressed", func() {
+					Expect(streamErr).NotTo(HaveOccurred())
+					Expect(encoding).To(Equal(worker.StreamEncodingRaw))
+					Expect(ioutil.ReadAll(out)).To(Equal([]byte("some-tar-stream")))
+				})
+			})
+
+			Context("when the worker fails to stream the volume", func() {
+				BeforeEach(func() {
+					baggageclaimServer.AppendHandlers(
+						ghttp.RespondWith(http.StatusNotFound, "volume not found"),
+					)
+				})
+
+				It("returns the status and the message", func() {
+					Expect(streamErr).To(Equal(worker.StreamFailedError{
+						StatusCode: http.StatusNotFound,
+						Body:       "volume not found",
+					}))
+				})
+			})
+
+			Context("when compression is disabled", func() {
+				BeforeEach(func() {
+					streaming.Encoding = worker.StreamEncodingRaw
+				})
+
+				It("does not make a request", func() {
+					Expect(streamErr).To(Equal(worker.ErrCompressionDisabled))
+					Expect(baggageclaimServer.ReceivedRequests()).To(BeEmpty())
+				})
+			})
+		})
+
+		Describe("StreamInCompressed", func() {
+			var streamErr error
+
+			JustBeforeEach(func() {
+				streamErr = volumeClient.StreamInCompressed("some-handle", ".", worker.StreamEncodingGzip, strings.NewReader("some-gzip-stream"))
+			})
+
+			Context("when the worker streams in the volume", func() {
+				BeforeEach(func() {
+					baggageclaimServer.AppendHandlers(
+						ghttp.CombineHandlers(
+							ghttp.VerifyRequest("PUT", "/volumes/some-handle/stream-in", "path=."),
+							ghttp.VerifyHeaderKV("Content-Encoding", "gzip"),
+							ghttp.VerifyBody([]byte("some-gzip-stream")),
+							ghttp.RespondWith(http.StatusNoContent, nil),
+						),
+					)
+				})
+
+				It("succeeds", func() {
+					Expect(streamErr).NotTo(HaveOccurred())
+					Expect(baggageclaimServer.ReceivedRequests()).To(HaveLen(1))
+				})
+			})
+
+			Context("when the worker does not support the encoding", func() {
+				BeforeEach(func() {
+					baggageclaimServer.AppendHandlers(
+						ghttp.RespondWith(http.StatusUnsupportedMediaType, "unsupported encoding"),
+					)
+				})
+
+				It("returns the status and the message", func() {
+					Expect(streamErr).To(Equal(worker.StreamFailedError{
+						StatusCode: http.StatusUnsupportedMediaType,
+						Body:       "unsupported encoding",
+					}))
+				})
+			})
+		})
+
+		Describe("FindVolumeWithSameContent", func() {
+			var (
+				fakeVolume *workerfakes.FakeVolume
+
+				foundVolume worker.Volume
+				found       bool
+				findErr     error
+			)
+
+			BeforeEach(func() {
+				streaming.Deduplicate = true
+
+				fakeVolume = new(workerfakes.FakeVolume)
+				fakeVolume.HandleReturns("some-handle")
+				fakeVolume.ContentHashReturns("gzip:sha256:abc", nil)
+			})
+
+			JustBeforeEach(func() {
+				foundVolume, found, findErr = volumeClient.FindVolumeWithSameContent(testLogger, fakeVolume)
+			})
+
+			Context("when the worker has a created volume with the same content hash", func() {
+				var fakeBaggageclaimVolume *baggageclaimfakes.FakeVolume
+
+				BeforeEach(func() {
+					fakeBaggageclaimVolume = new(baggageclaimfakes.FakeVolume)
+					fakeBaggageclaimVolume.HandleReturns("streamed-copy-handle")
+					fakeBaggageclaimClient.ListVolumesReturns(baggageclaim.Volumes{fakeBaggageclaimVolume}, nil)
+
+					fakeDBVolumeFactory.FindCreatedVolumeReturns(new(dbfakes.FakeCreatedVolume), true, nil)
+				})
+
+				It("returns it as a deduplicated volume", func() {
+					Expect(findErr).NotTo(HaveOccurred())
+					Expect(found).To(BeTrue())
+					Expect(foundVolume).To(BeAssignableToTypeOf(worker.DeduplicatedVolume{}))
+					Expect(foundVolume.Handle()).To(Equal("streamed-copy-handle"))
+
+					_, properties := fakeBaggageclaimClient.ListVolumesArgsForCall(0)
+					Expect(properties).To(Equal(baggageclaim.VolumeProperties{
+						worker.ContentHashProperty: "gzip:sha256:abc",
+					}))
+
+					Expect(fakeDBVolumeFactory.FindCreatedVolumeArgsForCall(0)).To(Equal("streamed-copy-handle"))
+				})
+
+				Context("when the volume is not created in the database", func() {
+					BeforeEach(func() {
+						fakeDBVolumeFactory.FindCreatedVolumeReturns(nil, false, nil)
+					})
+
+					It("does not return it", func() {
+						Expect(findErr).NotTo(HaveOccurred())
+						Expect(found).To(BeFalse())
+					})
+				})
+			})
+
+			Context("when the volume has no content hash", func() {
+				BeforeEach(func() {
+					fakeVolume.ContentHashReturns("", nil)
+				})
+
+				It("does not look for volumes", func() {
+					Expect(findErr).NotTo(HaveOccurred())
+					Expect(found).To(BeFalse())
+					Expect(fakeBaggageclaimClient.ListVolumesCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when deduplication is disabled", func() {
+				BeforeEach(func() {
+					streaming.Deduplicate = false
+				})
+
+				It("does not look for volumes", func() {
+					Expect(findErr).NotTo(HaveOccurred())
+					Expect(found).To(BeFalse())
+					Expect(fakeVolume.ContentHashCallCount()).To(BeZero())
+					Expect(fakeBaggageclaimClient.ListVolumesCallCount()).To(BeZero())
+				})
+			})
+		})
+	})
 })
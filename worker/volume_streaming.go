@@ -0,0 +1,225 @@
+package worker
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"hash"
+	"io"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/metric"
+)
+
+const (
+	StreamEncodingRaw  = "raw"
+	StreamEncodingGzip = "gzip"
+	StreamEncodingZstd = "zstd"
+)
+
+const (
+	// ContentHashProperty is the volume property recording the hash of the
+	// volume's content, as it was last streamed. It is only recorded on
+	// volumes which are not written to afterwards.
+	ContentHashProperty = "concourse:content-hash"
+
+	// StreamedCopyProperty marks a volume which was created to be streamed
+	// into and is only ever mounted copy-on-write, so that its content hash
+	// stays true.
+	StreamedCopyProperty = "concourse:streamed-copy"
+)
+
+// StreamedCopy is a volume created for deduplication to be streamed into. The
+// hash of the content streamed into it is recorded on it and on the source,
+// so that it can be found in place of streaming the same content to its
+// worker again.
+type StreamedCopy struct {
+	Volume
+}
+
+// DeduplicatedVolume is a volume found on a worker with the same content as
+// another volume, which can be used in place of streaming that one to it.
+type DeduplicatedVolume struct {
+	Volume
+}
+
+// VolumeStreamingConfig configures how volumes are streamed to the workers
+// which need them.
+type VolumeStreamingConfig struct {
+	// P2P has workers stream volumes from each other directly.
+	P2P bool
+
+	// Encoding is the compression to ask the workers for when streaming
+	// through the ATC.
+	Encoding string
+
+	// Timeout bounds each request streaming a volume between workers,
+	// including reading or writing the stream.
+	Timeout time.Duration
+
+	// Deduplicate keeps a copy of the volumes streamed to a worker, so that
+	// the same content is not streamed to it again.
+	Deduplicate bool
+}
+
+// StreamVolume streams the contents of the source volume into the destination
+// volume.
+//
+// If P2P streaming is enabled the destination's worker is asked to pull the
+// contents from the source's worker directly. If that can not be done, e.g.
+// because the workers can not reach each other, the contents are streamed
+// through the ATC instead, compressed if the workers support it.
+//
+// If the destination is a StreamedCopy, the hash of the content is recorded
+// on the source and on the destination.
+func StreamVolume(logger lager.Logger, source Volume, destination Volume) error {
+	logger = logger.Session("stream-volume", lager.Data{
+		"source":      source.Handle(),
+		"destination": destination.Handle(),
+	})
+
+	_, deduplicate := destination.(StreamedCopy)
+
+	sourceURL, ok := source.StreamOutP2PURL(".")
+	if ok {
+		start := time.Now()
+
+		bytes, sha256Sum, err := destination.StreamInP2P(logger, ".", sourceURL)
+		if err == nil {
+			emitVolumeStreamed(logger, source, destination, metric.VolumeStreamedP2P, "", bytes, time.Since(start))
+
+			// the worker hashes the stream uncompressed, so the hash matches
+			// the ones of streams through the ATC which were not compressed
+			if deduplicate && sha256Sum != "" {
+				recordContentHash(logger, source, destination, contentHash(StreamEncodingRaw, sha256Sum))
+			}
+
+			return nil
+		}
+
+		if err == ErrP2PStreamingNotSupported {
+			logger.Debug("destination-does-not-support-p2p")
+		} else {
+			logger.Error("failed-to-stream-p2p-falling-back", err)
+		}
+	}
+
+	encoding, err := streamThroughATC(logger, source, destination, true, deduplicate)
+	if err != nil && encoding != "" && encoding != StreamEncodingRaw {
+		// the destination's worker may not support the encoding the source's
+		// did; the contents are streamed again, as the stream has been read
+		logger.Error("failed-to-stream-compressed-falling-back", err, lager.Data{"encoding": encoding})
+		_, err = streamThroughATC(logger, source, destination, false, deduplicate)
+	}
+
+	return err
+}
+
+// streamThroughATC streams the source's contents into the destination,
+// negotiating compression if compressed is true, and hashing them if
+// deduplicate is true. It returns the encoding the contents were streamed
+// with, once it is known.
+func streamThroughATC(logger lager.Logger, source Volume, destination Volume, compressed bool, deduplicate bool) (string, error) {
+	start := time.Now()
+
+	var (
+		out      io.ReadCloser
+		encoding = StreamEncodingRaw
+		err      error
+	)
+
+	if compressed {
+		out, encoding, err = source.StreamOutCompressed(".")
+	} else {
+		out, err = source.StreamOut(".")
+	}
+	if err != nil {
+		return "", err
+	}
+
+	defer out.Close()
+
+	counter := &countingReader{reader: out}
+
+	var hasher hash.Hash
+	if deduplicate {
+		hasher = sha256.New()
+		counter.reader = io.TeeReader(out, hasher)
+	}
+
+	if compressed {
+		err = destination.StreamInCompressed(".", encoding, counter)
+	} else {
+		err = destination.StreamIn(".", counter)
+	}
+	if err != nil {
+		return encoding, err
+	}
+
+	emitVolumeStreamed(logger, source, destination, metric.VolumeStreamedATC, encoding, counter.bytes, time.Since(start))
+
+	if deduplicate {
+		// the hash is of the stream as it was sent, so streams of the same
+		// content in different encodings have different hashes
+		recordContentHash(logger, source, destination, contentHash(encoding, fmt.Sprintf("%x", hasher.Sum(nil))))
+	}
+
+	return encoding, nil
+}
+
+func contentHash(encoding string, sha256Sum string) string {
+	return fmt.Sprintf("%s:sha256:%s", encoding, sha256Sum)
+}
+
+// recordContentHash records the hash on the source, which is not written to
+// once it is streamed from, and on the destination streamed copy. Failing to
+// record it only means the content may be streamed again.
+func recordContentHash(logger lager.Logger, source Volume, destination Volume, value string) {
+	err := source.SetProperty(ContentHashProperty, value)
+	if err != nil {
+		logger.Error("failed-to-record-source-content-hash", err)
+		return
+	}
+
+	err = destination.SetProperty(ContentHashProperty, value)
+	if err != nil {
+		logger.Error("failed-to-record-destination-content-hash", err)
+	}
+}
+
+// emitVolumeStreamed counts the bytes as they were sent through the ATC, in
+// the encoding they were compressed with. The bytes of P2P streams are
+// counted by the destination's worker as it extracts them, uncompressed.
+func emitVolumeStreamed(logger lager.Logger, source Volume, destination Volume, mode string, encoding string, bytes int, duration time.Duration) {
+	bytesEncoding := encoding
+	if mode == metric.VolumeStreamedP2P {
+		bytesEncoding = StreamEncodingRaw
+	}
+
+	metric.VolumeStreamed{
+		SourceWorker:      source.WorkerName(),
+		DestinationWorker: destination.WorkerName(),
+		Mode:              mode,
+		Encoding:          bytesEncoding,
+		Bytes:             bytes,
+	}.Emit(logger)
+
+	metric.VolumeStreamingDuration{
+		SourceWorker:      source.WorkerName(),
+		DestinationWorker: destination.WorkerName(),
+		Mode:              mode,
+		Encoding:          encoding,
+		Duration:          duration,
+	}.Emit(logger)
+}
+
+type countingReader struct {
+	reader io.Reader
+	bytes  int
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.bytes += n
+	return n, err
+}
@@ -0,0 +1,264 @@
+package worker_test
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc/worker"
+	"github.com/concourse/atc/worker/workerfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("StreamVolume", func() {
+	var (
+		logger *lagertest.TestLogger
+
+		source      *workerfakes.FakeVolume
+		destination *workerfakes.FakeVolume
+
+		destinationVolume worker.Volume
+
+		streamErr error
+	)
+
+	BeforeEach(func() {
+		logger = lagertest.NewTestLogger("test")
+
+		source = new(workerfakes.FakeVolume)
+		source.HandleReturns("source-handle")
+		source.WorkerNameReturns("source-worker")
+		source.StreamOutCompressedReturns(ioutil.NopCloser(strings.NewReader("some-gzip-stream")), worker.StreamEncodingGzip, nil)
+		source.StreamOutReturns(ioutil.NopCloser(strings.NewReader("some-tar-stream")), nil)
+
+		destination = new(workerfakes.FakeVolume)
+		destination.HandleReturns("destination-handle")
+		destination.WorkerNameReturns("destination-worker")
+
+		destinationVolume = destination
+	})
+
+	JustBeforeEach(func() {
+		streamErr = worker.StreamVolume(logger, source, destinationVolume)
+	})
+
+	Context("when P2P streaming is available", func() {
+		BeforeEach(func() {
+			source.StreamOutP2PURLReturns("http://source-worker/volumes/source-handle/stream-out?path=.", true)
+		})
+
+		Context("when the destination streams from the source", func() {
+			BeforeEach(func() {
+				destination.StreamInP2PReturns(1024, "abc", nil)
+			})
+
+			It("has the destination pull the source's contents", func() {
+				Expect(streamErr).NotTo(HaveOccurred())
+
+				Expect(source.StreamOutP2PURLArgsForCall(0)).To(Equal("."))
+
+				Expect(destination.StreamInP2PCallCount()).To(Equal(1))
+				_, path, sourceURL := destination.StreamInP2PArgsForCall(0)
+				Expect(path).To(Equal("."))
+				Expect(sourceURL).To(Equal("http://source-worker/volumes/source-handle/stream-out?path=."))
+			})
+
+			It("does not stream through the ATC", func() {
+				Expect(source.StreamOutCompressedCallCount()).To(BeZero())
+				Expect(destination.StreamInCompressedCallCount()).To(BeZero())
+			})
+
+			It("does not record a content hash", func() {
+				Expect(source.SetPropertyCallCount()).To(BeZero())
+				Expect(destination.SetPropertyCallCount()).To(BeZero())
+			})
+
+			Context("when the destination is a streamed copy", func() {
+				BeforeEach(func() {
+					destinationVolume = worker.StreamedCopy{Volume: destination}
+				})
+
+				It("records the hash the destination's worker reported on both", func() {
+					Expect(source.SetPropertyCallCount()).To(Equal(1))
+					name, value := source.SetPropertyArgsForCall(0)
+					Expect(name).To(Equal(worker.ContentHashProperty))
+					Expect(value).To(Equal("raw:sha256:abc"))
+
+					Expect(destination.SetPropertyCallCount()).To(Equal(1))
+					name, value = destination.SetPropertyArgsForCall(0)
+					Expect(name).To(Equal(worker.ContentHashProperty))
+					Expect(value).To(Equal("raw:sha256:abc"))
+				})
+
+				Context("when the destination's worker reports no hash", func() {
+					BeforeEach(func() {
+						destination.StreamInP2PReturns(1024, "", nil)
+					})
+
+					It("does not record a content hash", func() {
+						Expect(source.SetPropertyCallCount()).To(BeZero())
+						Expect(destination.SetPropertyCallCount()).To(BeZero())
+					})
+				})
+			})
+		})
+
+		Context("when the destination fails to stream from the source", func() {
+			BeforeEach(func() {
+				destination.StreamInP2PReturns(0, "", errors.New("no route to host"))
+			})
+
+			It("falls back to streaming through the ATC", func() {
+				Expect(streamErr).NotTo(HaveOccurred())
+
+				Expect(source.StreamOutCompressedCallCount()).To(Equal(1))
+				Expect(source.StreamOutCompressedArgsForCall(0)).To(Equal("."))
+
+				Expect(destination.StreamInCompressedCallCount()).To(Equal(1))
+				path, encoding, tarStream := destination.StreamInCompressedArgsForCall(0)
+				Expect(path).To(Equal("."))
+				Expect(encoding).To(Equal(worker.StreamEncodingGzip))
+				Expect(ioutil.ReadAll(tarStream)).To(Equal([]byte("some-gzip-stream")))
+			})
+		})
+	})
+
+	Context("when P2P streaming is not available", func() {
+		BeforeEach(func() {
+			source.StreamOutP2PURLReturns("", false)
+		})
+
+		It("streams through the ATC with the source's encoding", func() {
+			Expect(streamErr).NotTo(HaveOccurred())
+
+			Expect(destination.StreamInP2PCallCount()).To(BeZero())
+			Expect(destination.StreamInCompressedCallCount()).To(Equal(1))
+			Expect(destination.StreamInCallCount()).To(BeZero())
+		})
+
+		It("does not record a content hash on a destination which is not a streamed copy", func() {
+			Expect(source.SetPropertyCallCount()).To(BeZero())
+			Expect(destination.SetPropertyCallCount()).To(BeZero())
+		})
+
+		Context("when the destination is a streamed copy", func() {
+			BeforeEach(func() {
+				destinationVolume = worker.StreamedCopy{Volume: destination}
+			})
+
+			It("records the hash of the stream on the source", func() {
+				Expect(source.SetPropertyCallCount()).To(Equal(1))
+				name, value := source.SetPropertyArgsForCall(0)
+				Expect(name).To(Equal(worker.ContentHashProperty))
+				Expect(value).To(Equal(fmt.Sprintf("gzip:sha256:%x", sha256.Sum256([]byte("some-gzip-stream")))))
+			})
+
+			It("records the same hash on the destination", func() {
+				Expect(destination.SetPropertyCallCount()).To(Equal(1))
+				name, value := destination.SetPropertyArgsForCall(0)
+				Expect(name).To(Equal(worker.ContentHashProperty))
+
+				_, sourceValue := source.SetPropertyArgsForCall(0)
+				Expect(value).To(Equal(sourceValue))
+			})
+
+			Context("when recording the hash fails", func() {
+				BeforeEach(func() {
+					source.SetPropertyReturns(errors.New("nope"))
+				})
+
+				It("still succeeds", func() {
+					Expect(streamErr).NotTo(HaveOccurred())
+				})
+			})
+		})
+
+		Context("when the source does not compress the stream", func() {
+			BeforeEach(func() {
+				source.StreamOutCompressedReturns(ioutil.NopCloser(strings.NewReader("some-tar-stream")), worker.StreamEncodingRaw, nil)
+			})
+
+			It("streams it in as it is", func() {
+				Expect(streamErr).NotTo(HaveOccurred())
+
+				_, encoding, _ := destination.StreamInCompressedArgsForCall(0)
+				Expect(encoding).To(Equal(worker.StreamEncodingRaw))
+			})
+
+			Context("when streaming in fails", func() {
+				disaster := errors.New("nope")
+
+				BeforeEach(func() {
+					destination.StreamInCompressedReturns(disaster)
+				})
+
+				It("returns the error without streaming again", func() {
+					Expect(streamErr).To(Equal(disaster))
+					Expect(source.StreamOutCallCount()).To(BeZero())
+				})
+			})
+		})
+
+		Context("when streaming out fails", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				source.StreamOutCompressedReturns(nil, "", disaster)
+			})
+
+			It("returns the error", func() {
+				Expect(streamErr).To(Equal(disaster))
+			})
+		})
+
+		Context("when streaming in the compressed stream fails", func() {
+			BeforeEach(func() {
+				destination.StreamInCompressedReturns(worker.StreamFailedError{
+					StatusCode: 415,
+					Body:       "unsupported encoding",
+				})
+			})
+
+			It("streams the contents again uncompressed", func() {
+				Expect(streamErr).NotTo(HaveOccurred())
+
+				Expect(source.StreamOutCallCount()).To(Equal(1))
+				Expect(source.StreamOutArgsForCall(0)).To(Equal("."))
+
+				Expect(destination.StreamInCallCount()).To(Equal(1))
+				path, tarStream := destination.StreamInArgsForCall(0)
+				Expect(path).To(Equal("."))
+				Expect(ioutil.ReadAll(tarStream)).To(Equal([]byte("some-tar-stream")))
+			})
+
+			Context("when the destination is a streamed copy", func() {
+				BeforeEach(func() {
+					destinationVolume = worker.StreamedCopy{Volume: destination}
+				})
+
+				It("records the hash of the uncompressed stream", func() {
+					Expect(source.SetPropertyCallCount()).To(Equal(1))
+					_, value := source.SetPropertyArgsForCall(0)
+					Expect(value).To(Equal(fmt.Sprintf("raw:sha256:%x", sha256.Sum256([]byte("some-tar-stream")))))
+				})
+			})
+
+			Context("when streaming in uncompressed fails too", func() {
+				disaster := errors.New("nope")
+
+				BeforeEach(func() {
+					destination.StreamInReturns(disaster)
+				})
+
+				It("returns the error", func() {
+					Expect(streamErr).To(Equal(disaster))
+				})
+			})
+		})
+	})
+})
@@ -0,0 +1,75 @@
+package worker_test
+
+import (
+	"errors"
+
+	"github.com/concourse/atc/db/dbfakes"
+	"github.com/concourse/atc/worker"
+	"github.com/concourse/atc/worker/workerfakes"
+	"github.com/concourse/baggageclaim"
+	"github.com/concourse/baggageclaim/baggageclaimfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Volume", func() {
+	var (
+		fakeBaggageclaimVolume *baggageclaimfakes.FakeVolume
+
+		volume worker.Volume
+	)
+
+	BeforeEach(func() {
+		fakeBaggageclaimVolume = new(baggageclaimfakes.FakeVolume)
+		fakeBaggageclaimVolume.PropertiesReturns(baggageclaim.VolumeProperties{
+			worker.ContentHashProperty: "raw:sha256:abc",
+		}, nil)
+
+		volume = worker.NewVolume(
+			fakeBaggageclaimVolume,
+			new(dbfakes.FakeCreatedVolume),
+			new(workerfakes.FakeVolumeClient),
+		)
+	})
+
+	Describe("ContentHash", func() {
+		It("reads the hash from the worker once", func() {
+			Expect(volume.ContentHash()).To(Equal("raw:sha256:abc"))
+			Expect(volume.ContentHash()).To(Equal("raw:sha256:abc"))
+
+			Expect(fakeBaggageclaimVolume.PropertiesCallCount()).To(Equal(1))
+		})
+
+		Context("when the volume has no hash", func() {
+			BeforeEach(func() {
+				fakeBaggageclaimVolume.PropertiesReturns(baggageclaim.VolumeProperties{}, nil)
+			})
+
+			It("returns the hash once it is set", func() {
+				Expect(volume.ContentHash()).To(BeEmpty())
+
+				Expect(volume.SetProperty(worker.ContentHashProperty, "gzip:sha256:def")).To(Succeed())
+
+				Expect(volume.ContentHash()).To(Equal("gzip:sha256:def"))
+				Expect(fakeBaggageclaimVolume.PropertiesCallCount()).To(Equal(1))
+			})
+		})
+
+		Context("when reading the properties fails", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				fakeBaggageclaimVolume.PropertiesReturnsOnCall(0, nil, disaster)
+			})
+
+			It("returns the error, and reads them again next time", func() {
+				_, err := volume.ContentHash()
+				Expect(err).To(Equal(disaster))
+
+				Expect(volume.ContentHash()).To(Equal("raw:sha256:abc"))
+				Expect(fakeBaggageclaimVolume.PropertiesCallCount()).To(Equal(2))
+			})
+		})
+	})
+})
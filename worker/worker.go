@@ -48,6 +48,8 @@ type Worker interface {
 	Client
 
 	ActiveContainers() int
+	ActiveVolumes() int
+	MaxContainers() int
 
 	Description() string
 	Name() string
@@ -59,6 +61,7 @@ type Worker interface {
 
 	FindVolumeForResourceCache(logger lager.Logger, resourceCache *db.UsedResourceCache) (Volume, bool, error)
 	FindVolumeForTaskCache(lager.Logger, int, int, string, string) (Volume, bool, error)
+	FindVolumeWithSameContent(lager.Logger, Volume) (Volume, bool, error)
 
 	CertsVolume(lager.Logger) (volume Volume, found bool, err error)
 
@@ -76,6 +79,8 @@ type gardenWorker struct {
 	clock clock.Clock
 
 	activeContainers int
+	activeVolumes    int
+	maxContainers    int
 	resourceTypes    []atc.WorkerResourceType
 	platform         string
 	tags             atc.Tags
@@ -102,6 +107,8 @@ func NewGardenWorker(
 
 		clock:            clock,
 		activeContainers: dbWorker.ActiveContainers(),
+		activeVolumes:    dbWorker.ActiveVolumes(),
+		maxContainers:    dbWorker.MaxContainers(),
 		resourceTypes:    dbWorker.ResourceTypes(),
 		platform:         dbWorker.Platform(),
 		tags:             dbWorker.Tags(),
@@ -173,6 +180,10 @@ func (worker *gardenWorker) FindVolumeForTaskCache(logger lager.Logger, teamID i
 	return worker.volumeClient.FindVolumeForTaskCache(logger, teamID, jobID, stepName, path)
 }
 
+func (worker *gardenWorker) FindVolumeWithSameContent(logger lager.Logger, volume Volume) (Volume, bool, error) {
+	return worker.volumeClient.FindVolumeWithSameContent(logger, volume)
+}
+
 func (worker *gardenWorker) CertsVolume(logger lager.Logger) (Volume, bool, error) {
 	return worker.volumeClient.FindOrCreateVolumeForResourceCerts(logger.Session("find-or-create"))
 }
@@ -210,6 +221,14 @@ func (worker *gardenWorker) ActiveContainers() int {
 	return worker.activeContainers
 }
 
+func (worker *gardenWorker) ActiveVolumes() int {
+	return worker.activeVolumes
+}
+
+func (worker *gardenWorker) MaxContainers() int {
+	return worker.maxContainers
+}
+
 func (worker *gardenWorker) Satisfying(logger lager.Logger, spec WorkerSpec, resourceTypes creds.VersionedResourceTypes) (Worker, error) {
 	if spec.TeamID != worker.teamID && worker.teamID != 0 {
 		return nil, ErrTeamMismatch
@@ -39,6 +39,15 @@ type FakeImageFetchingDelegate struct {
 	imageVersionDeterminedReturnsOnCall map[int]struct {
 		result1 error
 	}
+	WaitingForWorkerStub        func() error
+	waitingForWorkerMutex       sync.RWMutex
+	waitingForWorkerArgsForCall []struct{}
+	waitingForWorkerReturns     struct {
+		result1 error
+	}
+	waitingForWorkerReturnsOnCall map[int]struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -171,6 +180,46 @@ func (fake *FakeImageFetchingDelegate) ImageVersionDeterminedReturnsOnCall(i int
 	}{result1}
 }
 
+func (fake *FakeImageFetchingDelegate) WaitingForWorker() error {
+	fake.waitingForWorkerMutex.Lock()
+	ret, specificReturn := fake.waitingForWorkerReturnsOnCall[len(fake.waitingForWorkerArgsForCall)]
+	fake.waitingForWorkerArgsForCall = append(fake.waitingForWorkerArgsForCall, struct{}{})
+	fake.recordInvocation("WaitingForWorker", []interface{}{})
+	fake.waitingForWorkerMutex.Unlock()
+	if fake.WaitingForWorkerStub != nil {
+		return fake.WaitingForWorkerStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.waitingForWorkerReturns.result1
+}
+
+func (fake *FakeImageFetchingDelegate) WaitingForWorkerCallCount() int {
+	fake.waitingForWorkerMutex.RLock()
+	defer fake.waitingForWorkerMutex.RUnlock()
+	return len(fake.waitingForWorkerArgsForCall)
+}
+
+func (fake *FakeImageFetchingDelegate) WaitingForWorkerReturns(result1 error) {
+	fake.WaitingForWorkerStub = nil
+	fake.waitingForWorkerReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImageFetchingDelegate) WaitingForWorkerReturnsOnCall(i int, result1 error) {
+	fake.WaitingForWorkerStub = nil
+	if fake.waitingForWorkerReturnsOnCall == nil {
+		fake.waitingForWorkerReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.waitingForWorkerReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeImageFetchingDelegate) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -180,6 +229,8 @@ func (fake *FakeImageFetchingDelegate) Invocations() map[string][][]interface{}
 	defer fake.stderrMutex.RUnlock()
 	fake.imageVersionDeterminedMutex.RLock()
 	defer fake.imageVersionDeterminedMutex.RUnlock()
+	fake.waitingForWorkerMutex.RLock()
+	defer fake.waitingForWorkerMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -147,6 +147,84 @@ type FakeVolume struct {
 	destroyReturnsOnCall map[int]struct {
 		result1 error
 	}
+	WorkerNameStub        func() string
+	workerNameMutex       sync.RWMutex
+	workerNameArgsForCall []struct{}
+	workerNameReturns     struct {
+		result1 string
+	}
+	workerNameReturnsOnCall map[int]struct {
+		result1 string
+	}
+	StreamOutP2PURLStub        func(path string) (string, bool)
+	streamOutP2PURLMutex       sync.RWMutex
+	streamOutP2PURLArgsForCall []struct {
+		path string
+	}
+	streamOutP2PURLReturns struct {
+		result1 string
+		result2 bool
+	}
+	streamOutP2PURLReturnsOnCall map[int]struct {
+		result1 string
+		result2 bool
+	}
+	StreamOutCompressedStub        func(path string) (io.ReadCloser, string, error)
+	streamOutCompressedMutex       sync.RWMutex
+	streamOutCompressedArgsForCall []struct {
+		path string
+	}
+	streamOutCompressedReturns struct {
+		result1 io.ReadCloser
+		result2 string
+		result3 error
+	}
+	streamOutCompressedReturnsOnCall map[int]struct {
+		result1 io.ReadCloser
+		result2 string
+		result3 error
+	}
+	StreamInCompressedStub        func(path string, encoding string, tarStream io.Reader) error
+	streamInCompressedMutex       sync.RWMutex
+	streamInCompressedArgsForCall []struct {
+		path      string
+		encoding  string
+		tarStream io.Reader
+	}
+	streamInCompressedReturns struct {
+		result1 error
+	}
+	streamInCompressedReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ContentHashStub        func() (string, error)
+	contentHashMutex       sync.RWMutex
+	contentHashArgsForCall []struct{}
+	contentHashReturns     struct {
+		result1 string
+		result2 error
+	}
+	contentHashReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	StreamInP2PStub        func(logger lager.Logger, path string, sourceURL string) (int, string, error)
+	streamInP2PMutex       sync.RWMutex
+	streamInP2PArgsForCall []struct {
+		logger    lager.Logger
+		path      string
+		sourceURL string
+	}
+	streamInP2PReturns struct {
+		result1 int
+		result2 string
+		result3 error
+	}
+	streamInP2PReturnsOnCall map[int]struct {
+		result1 int
+		result2 string
+		result3 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -703,6 +781,300 @@ func (fake *FakeVolume) DestroyReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeVolume) WorkerName() string {
+	fake.workerNameMutex.Lock()
+	ret, specificReturn := fake.workerNameReturnsOnCall[len(fake.workerNameArgsForCall)]
+	fake.workerNameArgsForCall = append(fake.workerNameArgsForCall, struct{}{})
+	fake.recordInvocation("WorkerName", []interface{}{})
+	fake.workerNameMutex.Unlock()
+	if fake.WorkerNameStub != nil {
+		return fake.WorkerNameStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.workerNameReturns.result1
+}
+
+func (fake *FakeVolume) WorkerNameCallCount() int {
+	fake.workerNameMutex.RLock()
+	defer fake.workerNameMutex.RUnlock()
+	return len(fake.workerNameArgsForCall)
+}
+
+func (fake *FakeVolume) WorkerNameReturns(result1 string) {
+	fake.WorkerNameStub = nil
+	fake.workerNameReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeVolume) WorkerNameReturnsOnCall(i int, result1 string) {
+	fake.WorkerNameStub = nil
+	if fake.workerNameReturnsOnCall == nil {
+		fake.workerNameReturnsOnCall = make(map[int]struct {
+			result1 string
+		})
+	}
+	fake.workerNameReturnsOnCall[i] = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeVolume) StreamOutP2PURL(path string) (string, bool) {
+	fake.streamOutP2PURLMutex.Lock()
+	ret, specificReturn := fake.streamOutP2PURLReturnsOnCall[len(fake.streamOutP2PURLArgsForCall)]
+	fake.streamOutP2PURLArgsForCall = append(fake.streamOutP2PURLArgsForCall, struct {
+		path string
+	}{path})
+	fake.recordInvocation("StreamOutP2PURL", []interface{}{path})
+	fake.streamOutP2PURLMutex.Unlock()
+	if fake.StreamOutP2PURLStub != nil {
+		return fake.StreamOutP2PURLStub(path)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.streamOutP2PURLReturns.result1, fake.streamOutP2PURLReturns.result2
+}
+
+func (fake *FakeVolume) StreamOutP2PURLCallCount() int {
+	fake.streamOutP2PURLMutex.RLock()
+	defer fake.streamOutP2PURLMutex.RUnlock()
+	return len(fake.streamOutP2PURLArgsForCall)
+}
+
+func (fake *FakeVolume) StreamOutP2PURLArgsForCall(i int) string {
+	fake.streamOutP2PURLMutex.RLock()
+	defer fake.streamOutP2PURLMutex.RUnlock()
+	return fake.streamOutP2PURLArgsForCall[i].path
+}
+
+func (fake *FakeVolume) StreamOutP2PURLReturns(result1 string, result2 bool) {
+	fake.StreamOutP2PURLStub = nil
+	fake.streamOutP2PURLReturns = struct {
+		result1 string
+		result2 bool
+	}{result1, result2}
+}
+
+func (fake *FakeVolume) StreamOutP2PURLReturnsOnCall(i int, result1 string, result2 bool) {
+	fake.StreamOutP2PURLStub = nil
+	if fake.streamOutP2PURLReturnsOnCall == nil {
+		fake.streamOutP2PURLReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 bool
+		})
+	}
+	fake.streamOutP2PURLReturnsOnCall[i] = struct {
+		result1 string
+		result2 bool
+	}{result1, result2}
+}
+
+func (fake *FakeVolume) StreamOutCompressed(path string) (io.ReadCloser, string, error) {
+	fake.streamOutCompressedMutex.Lock()
+	ret, specificReturn := fake.streamOutCompressedReturnsOnCall[len(fake.streamOutCompressedArgsForCall)]
+	fake.streamOutCompressedArgsForCall = append(fake.streamOutCompressedArgsForCall, struct {
+		path string
+	}{path})
+	fake.recordInvocation("StreamOutCompressed", []interface{}{path})
+	fake.streamOutCompressedMutex.Unlock()
+	if fake.StreamOutCompressedStub != nil {
+		return fake.StreamOutCompressedStub(path)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fake.streamOutCompressedReturns.result1, fake.streamOutCompressedReturns.result2, fake.streamOutCompressedReturns.result3
+}
+
+func (fake *FakeVolume) StreamOutCompressedCallCount() int {
+	fake.streamOutCompressedMutex.RLock()
+	defer fake.streamOutCompressedMutex.RUnlock()
+	return len(fake.streamOutCompressedArgsForCall)
+}
+
+func (fake *FakeVolume) StreamOutCompressedArgsForCall(i int) string {
+	fake.streamOutCompressedMutex.RLock()
+	defer fake.streamOutCompressedMutex.RUnlock()
+	return fake.streamOutCompressedArgsForCall[i].path
+}
+
+func (fake *FakeVolume) StreamOutCompressedReturns(result1 io.ReadCloser, result2 string, result3 error) {
+	fake.StreamOutCompressedStub = nil
+	fake.streamOutCompressedReturns = struct {
+		result1 io.ReadCloser
+		result2 string
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeVolume) StreamOutCompressedReturnsOnCall(i int, result1 io.ReadCloser, result2 string, result3 error) {
+	fake.StreamOutCompressedStub = nil
+	if fake.streamOutCompressedReturnsOnCall == nil {
+		fake.streamOutCompressedReturnsOnCall = make(map[int]struct {
+			result1 io.ReadCloser
+			result2 string
+			result3 error
+		})
+	}
+	fake.streamOutCompressedReturnsOnCall[i] = struct {
+		result1 io.ReadCloser
+		result2 string
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeVolume) StreamInCompressed(path string, encoding string, tarStream io.Reader) error {
+	fake.streamInCompressedMutex.Lock()
+	ret, specificReturn := fake.streamInCompressedReturnsOnCall[len(fake.streamInCompressedArgsForCall)]
+	fake.streamInCompressedArgsForCall = append(fake.streamInCompressedArgsForCall, struct {
+		path      string
+		encoding  string
+		tarStream io.Reader
+	}{path, encoding, tarStream})
+	fake.recordInvocation("StreamInCompressed", []interface{}{path, encoding, tarStream})
+	fake.streamInCompressedMutex.Unlock()
+	if fake.StreamInCompressedStub != nil {
+		return fake.StreamInCompressedStub(path, encoding, tarStream)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.streamInCompressedReturns.result1
+}
+
+func (fake *FakeVolume) StreamInCompressedCallCount() int {
+	fake.streamInCompressedMutex.RLock()
+	defer fake.streamInCompressedMutex.RUnlock()
+	return len(fake.streamInCompressedArgsForCall)
+}
+
+func (fake *FakeVolume) StreamInCompressedArgsForCall(i int) (string, string, io.Reader) {
+	fake.streamInCompressedMutex.RLock()
+	defer fake.streamInCompressedMutex.RUnlock()
+	return fake.streamInCompressedArgsForCall[i].path, fake.streamInCompressedArgsForCall[i].encoding, fake.streamInCompressedArgsForCall[i].tarStream
+}
+
+func (fake *FakeVolume) StreamInCompressedReturns(result1 error) {
+	fake.StreamInCompressedStub = nil
+	fake.streamInCompressedReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeVolume) StreamInCompressedReturnsOnCall(i int, result1 error) {
+	fake.StreamInCompressedStub = nil
+	if fake.streamInCompressedReturnsOnCall == nil {
+		fake.streamInCompressedReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.streamInCompressedReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeVolume) ContentHash() (string, error) {
+	fake.contentHashMutex.Lock()
+	ret, specificReturn := fake.contentHashReturnsOnCall[len(fake.contentHashArgsForCall)]
+	fake.contentHashArgsForCall = append(fake.contentHashArgsForCall, struct{}{})
+	fake.recordInvocation("ContentHash", []interface{}{})
+	fake.contentHashMutex.Unlock()
+	if fake.ContentHashStub != nil {
+		return fake.ContentHashStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.contentHashReturns.result1, fake.contentHashReturns.result2
+}
+
+func (fake *FakeVolume) ContentHashCallCount() int {
+	fake.contentHashMutex.RLock()
+	defer fake.contentHashMutex.RUnlock()
+	return len(fake.contentHashArgsForCall)
+}
+
+func (fake *FakeVolume) ContentHashReturns(result1 string, result2 error) {
+	fake.ContentHashStub = nil
+	fake.contentHashReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeVolume) ContentHashReturnsOnCall(i int, result1 string, result2 error) {
+	fake.ContentHashStub = nil
+	if fake.contentHashReturnsOnCall == nil {
+		fake.contentHashReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.contentHashReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeVolume) StreamInP2P(logger lager.Logger, path string, sourceURL string) (int, string, error) {
+	fake.streamInP2PMutex.Lock()
+	ret, specificReturn := fake.streamInP2PReturnsOnCall[len(fake.streamInP2PArgsForCall)]
+	fake.streamInP2PArgsForCall = append(fake.streamInP2PArgsForCall, struct {
+		logger    lager.Logger
+		path      string
+		sourceURL string
+	}{logger, path, sourceURL})
+	fake.recordInvocation("StreamInP2P", []interface{}{logger, path, sourceURL})
+	fake.streamInP2PMutex.Unlock()
+	if fake.StreamInP2PStub != nil {
+		return fake.StreamInP2PStub(logger, path, sourceURL)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fake.streamInP2PReturns.result1, fake.streamInP2PReturns.result2, fake.streamInP2PReturns.result3
+}
+
+func (fake *FakeVolume) StreamInP2PCallCount() int {
+	fake.streamInP2PMutex.RLock()
+	defer fake.streamInP2PMutex.RUnlock()
+	return len(fake.streamInP2PArgsForCall)
+}
+
+func (fake *FakeVolume) StreamInP2PArgsForCall(i int) (lager.Logger, string, string) {
+	fake.streamInP2PMutex.RLock()
+	defer fake.streamInP2PMutex.RUnlock()
+	return fake.streamInP2PArgsForCall[i].logger, fake.streamInP2PArgsForCall[i].path, fake.streamInP2PArgsForCall[i].sourceURL
+}
+
+func (fake *FakeVolume) StreamInP2PReturns(result1 int, result2 string, result3 error) {
+	fake.StreamInP2PStub = nil
+	fake.streamInP2PReturns = struct {
+		result1 int
+		result2 string
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeVolume) StreamInP2PReturnsOnCall(i int, result1 int, result2 string, result3 error) {
+	fake.StreamInP2PStub = nil
+	if fake.streamInP2PReturnsOnCall == nil {
+		fake.streamInP2PReturnsOnCall = make(map[int]struct {
+			result1 int
+			result2 string
+			result3 error
+		})
+	}
+	fake.streamInP2PReturnsOnCall[i] = struct {
+		result1 int
+		result2 string
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeVolume) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -730,6 +1102,18 @@ func (fake *FakeVolume) Invocations() map[string][][]interface{} {
 	defer fake.createChildForContainerMutex.RUnlock()
 	fake.destroyMutex.RLock()
 	defer fake.destroyMutex.RUnlock()
+	fake.workerNameMutex.RLock()
+	defer fake.workerNameMutex.RUnlock()
+	fake.streamOutP2PURLMutex.RLock()
+	defer fake.streamOutP2PURLMutex.RUnlock()
+	fake.streamOutCompressedMutex.RLock()
+	defer fake.streamOutCompressedMutex.RUnlock()
+	fake.streamInCompressedMutex.RLock()
+	defer fake.streamInCompressedMutex.RUnlock()
+	fake.contentHashMutex.RLock()
+	defer fake.contentHashMutex.RUnlock()
+	fake.streamInP2PMutex.RLock()
+	defer fake.streamInP2PMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -2,6 +2,7 @@
 package workerfakes
 
 import (
+	"io"
 	"sync"
 
 	"code.cloudfoundry.org/lager"
@@ -145,6 +146,84 @@ type FakeVolumeClient struct {
 		result2 bool
 		result3 error
 	}
+	StreamOutP2PURLStub        func(handle string, path string) (string, bool)
+	streamOutP2PURLMutex       sync.RWMutex
+	streamOutP2PURLArgsForCall []struct {
+		handle string
+		path   string
+	}
+	streamOutP2PURLReturns struct {
+		result1 string
+		result2 bool
+	}
+	streamOutP2PURLReturnsOnCall map[int]struct {
+		result1 string
+		result2 bool
+	}
+	FindVolumeWithSameContentStub        func(arg1 lager.Logger, arg2 worker.Volume) (worker.Volume, bool, error)
+	findVolumeWithSameContentMutex       sync.RWMutex
+	findVolumeWithSameContentArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 worker.Volume
+	}
+	findVolumeWithSameContentReturns struct {
+		result1 worker.Volume
+		result2 bool
+		result3 error
+	}
+	findVolumeWithSameContentReturnsOnCall map[int]struct {
+		result1 worker.Volume
+		result2 bool
+		result3 error
+	}
+	StreamOutCompressedStub        func(handle string, path string) (io.ReadCloser, string, error)
+	streamOutCompressedMutex       sync.RWMutex
+	streamOutCompressedArgsForCall []struct {
+		handle string
+		path   string
+	}
+	streamOutCompressedReturns struct {
+		result1 io.ReadCloser
+		result2 string
+		result3 error
+	}
+	streamOutCompressedReturnsOnCall map[int]struct {
+		result1 io.ReadCloser
+		result2 string
+		result3 error
+	}
+	StreamInCompressedStub        func(handle string, path string, encoding string, tarStream io.Reader) error
+	streamInCompressedMutex       sync.RWMutex
+	streamInCompressedArgsForCall []struct {
+		handle    string
+		path      string
+		encoding  string
+		tarStream io.Reader
+	}
+	streamInCompressedReturns struct {
+		result1 error
+	}
+	streamInCompressedReturnsOnCall map[int]struct {
+		result1 error
+	}
+	StreamInP2PStub        func(logger lager.Logger, handle string, path string, sourceURL string) (int, string, error)
+	streamInP2PMutex       sync.RWMutex
+	streamInP2PArgsForCall []struct {
+		logger    lager.Logger
+		handle    string
+		path      string
+		sourceURL string
+	}
+	streamInP2PReturns struct {
+		result1 int
+		result2 string
+		result3 error
+	}
+	streamInP2PReturnsOnCall map[int]struct {
+		result1 int
+		result2 string
+		result3 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -592,6 +671,276 @@ func (fake *FakeVolumeClient) LookupVolumeReturnsOnCall(i int, result1 worker.Vo
 	}{result1, result2, result3}
 }
 
+func (fake *FakeVolumeClient) StreamOutP2PURL(handle string, path string) (string, bool) {
+	fake.streamOutP2PURLMutex.Lock()
+	ret, specificReturn := fake.streamOutP2PURLReturnsOnCall[len(fake.streamOutP2PURLArgsForCall)]
+	fake.streamOutP2PURLArgsForCall = append(fake.streamOutP2PURLArgsForCall, struct {
+		handle string
+		path   string
+	}{handle, path})
+	fake.recordInvocation("StreamOutP2PURL", []interface{}{handle, path})
+	fake.streamOutP2PURLMutex.Unlock()
+	if fake.StreamOutP2PURLStub != nil {
+		return fake.StreamOutP2PURLStub(handle, path)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.streamOutP2PURLReturns.result1, fake.streamOutP2PURLReturns.result2
+}
+
+func (fake *FakeVolumeClient) StreamOutP2PURLCallCount() int {
+	fake.streamOutP2PURLMutex.RLock()
+	defer fake.streamOutP2PURLMutex.RUnlock()
+	return len(fake.streamOutP2PURLArgsForCall)
+}
+
+func (fake *FakeVolumeClient) StreamOutP2PURLArgsForCall(i int) (string, string) {
+	fake.streamOutP2PURLMutex.RLock()
+	defer fake.streamOutP2PURLMutex.RUnlock()
+	return fake.streamOutP2PURLArgsForCall[i].handle, fake.streamOutP2PURLArgsForCall[i].path
+}
+
+func (fake *FakeVolumeClient) StreamOutP2PURLReturns(result1 string, result2 bool) {
+	fake.StreamOutP2PURLStub = nil
+	fake.streamOutP2PURLReturns = struct {
+		result1 string
+		result2 bool
+	}{result1, result2}
+}
+
+func (fake *FakeVolumeClient) StreamOutP2PURLReturnsOnCall(i int, result1 string, result2 bool) {
+	fake.StreamOutP2PURLStub = nil
+	if fake.streamOutP2PURLReturnsOnCall == nil {
+		fake.streamOutP2PURLReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 bool
+		})
+	}
+	fake.streamOutP2PURLReturnsOnCall[i] = struct {
+		result1 string
+		result2 bool
+	}{result1, result2}
+}
+
+func (fake *FakeVolumeClient) FindVolumeWithSameContent(arg1 lager.Logger, arg2 worker.Volume) (worker.Volume, bool, error) {
+	fake.findVolumeWithSameContentMutex.Lock()
+	ret, specificReturn := fake.findVolumeWithSameContentReturnsOnCall[len(fake.findVolumeWithSameContentArgsForCall)]
+	fake.findVolumeWithSameContentArgsForCall = append(fake.findVolumeWithSameContentArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 worker.Volume
+	}{arg1, arg2})
+	fake.recordInvocation("FindVolumeWithSameContent", []interface{}{arg1, arg2})
+	fake.findVolumeWithSameContentMutex.Unlock()
+	if fake.FindVolumeWithSameContentStub != nil {
+		return fake.FindVolumeWithSameContentStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fake.findVolumeWithSameContentReturns.result1, fake.findVolumeWithSameContentReturns.result2, fake.findVolumeWithSameContentReturns.result3
+}
+
+func (fake *FakeVolumeClient) FindVolumeWithSameContentCallCount() int {
+	fake.findVolumeWithSameContentMutex.RLock()
+	defer fake.findVolumeWithSameContentMutex.RUnlock()
+	return len(fake.findVolumeWithSameContentArgsForCall)
+}
+
+func (fake *FakeVolumeClient) FindVolumeWithSameContentArgsForCall(i int) (lager.Logger, worker.Volume) {
+	fake.findVolumeWithSameContentMutex.RLock()
+	defer fake.findVolumeWithSameContentMutex.RUnlock()
+	return fake.findVolumeWithSameContentArgsForCall[i].arg1, fake.findVolumeWithSameContentArgsForCall[i].arg2
+}
+
+func (fake *FakeVolumeClient) FindVolumeWithSameContentReturns(result1 worker.Volume, result2 bool, result3 error) {
+	fake.FindVolumeWithSameContentStub = nil
+	fake.findVolumeWithSameContentReturns = struct {
+		result1 worker.Volume
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeVolumeClient) FindVolumeWithSameContentReturnsOnCall(i int, result1 worker.Volume, result2 bool, result3 error) {
+	fake.FindVolumeWithSameContentStub = nil
+	if fake.findVolumeWithSameContentReturnsOnCall == nil {
+		fake.findVolumeWithSameContentReturnsOnCall = make(map[int]struct {
+			result1 worker.Volume
+			result2 bool
+			result3 error
+		})
+	}
+	fake.findVolumeWithSameContentReturnsOnCall[i] = struct {
+		result1 worker.Volume
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeVolumeClient) StreamOutCompressed(handle string, path string) (io.ReadCloser, string, error) {
+	fake.streamOutCompressedMutex.Lock()
+	ret, specificReturn := fake.streamOutCompressedReturnsOnCall[len(fake.streamOutCompressedArgsForCall)]
+	fake.streamOutCompressedArgsForCall = append(fake.streamOutCompressedArgsForCall, struct {
+		handle string
+		path   string
+	}{handle, path})
+	fake.recordInvocation("StreamOutCompressed", []interface{}{handle, path})
+	fake.streamOutCompressedMutex.Unlock()
+	if fake.StreamOutCompressedStub != nil {
+		return fake.StreamOutCompressedStub(handle, path)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fake.streamOutCompressedReturns.result1, fake.streamOutCompressedReturns.result2, fake.streamOutCompressedReturns.result3
+}
+
+func (fake *FakeVolumeClient) StreamOutCompressedCallCount() int {
+	fake.streamOutCompressedMutex.RLock()
+	defer fake.streamOutCompressedMutex.RUnlock()
+	return len(fake.streamOutCompressedArgsForCall)
+}
+
+func (fake *FakeVolumeClient) StreamOutCompressedArgsForCall(i int) (string, string) {
+	fake.streamOutCompressedMutex.RLock()
+	defer fake.streamOutCompressedMutex.RUnlock()
+	return fake.streamOutCompressedArgsForCall[i].handle, fake.streamOutCompressedArgsForCall[i].path
+}
+
+func (fake *FakeVolumeClient) StreamOutCompressedReturns(result1 io.ReadCloser, result2 string, result3 error) {
+	fake.StreamOutCompressedStub = nil
+	fake.streamOutCompressedReturns = struct {
+		result1 io.ReadCloser
+		result2 string
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeVolumeClient) StreamOutCompressedReturnsOnCall(i int, result1 io.ReadCloser, result2 string, result3 error) {
+	fake.StreamOutCompressedStub = nil
+	if fake.streamOutCompressedReturnsOnCall == nil {
+		fake.streamOutCompressedReturnsOnCall = make(map[int]struct {
+			result1 io.ReadCloser
+			result2 string
+			result3 error
+		})
+	}
+	fake.streamOutCompressedReturnsOnCall[i] = struct {
+		result1 io.ReadCloser
+		result2 string
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeVolumeClient) StreamInCompressed(handle string, path string, encoding string, tarStream io.Reader) error {
+	fake.streamInCompressedMutex.Lock()
+	ret, specificReturn := fake.streamInCompressedReturnsOnCall[len(fake.streamInCompressedArgsForCall)]
+	fake.streamInCompressedArgsForCall = append(fake.streamInCompressedArgsForCall, struct {
+		handle    string
+		path      string
+		encoding  string
+		tarStream io.Reader
+	}{handle, path, encoding, tarStream})
+	fake.recordInvocation("StreamInCompressed", []interface{}{handle, path, encoding, tarStream})
+	fake.streamInCompressedMutex.Unlock()
+	if fake.StreamInCompressedStub != nil {
+		return fake.StreamInCompressedStub(handle, path, encoding, tarStream)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.streamInCompressedReturns.result1
+}
+
+func (fake *FakeVolumeClient) StreamInCompressedCallCount() int {
+	fake.streamInCompressedMutex.RLock()
+	defer fake.streamInCompressedMutex.RUnlock()
+	return len(fake.streamInCompressedArgsForCall)
+}
+
+func (fake *FakeVolumeClient) StreamInCompressedArgsForCall(i int) (string, string, string, io.Reader) {
+	fake.streamInCompressedMutex.RLock()
+	defer fake.streamInCompressedMutex.RUnlock()
+	return fake.streamInCompressedArgsForCall[i].handle, fake.streamInCompressedArgsForCall[i].path, fake.streamInCompressedArgsForCall[i].encoding, fake.streamInCompressedArgsForCall[i].tarStream
+}
+
+func (fake *FakeVolumeClient) StreamInCompressedReturns(result1 error) {
+	fake.StreamInCompressedStub = nil
+	fake.streamInCompressedReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeVolumeClient) StreamInCompressedReturnsOnCall(i int, result1 error) {
+	fake.StreamInCompressedStub = nil
+	if fake.streamInCompressedReturnsOnCall == nil {
+		fake.streamInCompressedReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.streamInCompressedReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeVolumeClient) StreamInP2P(logger lager.Logger, handle string, path string, sourceURL string) (int, string, error) {
+	fake.streamInP2PMutex.Lock()
+	ret, specificReturn := fake.streamInP2PReturnsOnCall[len(fake.streamInP2PArgsForCall)]
+	fake.streamInP2PArgsForCall = append(fake.streamInP2PArgsForCall, struct {
+		logger    lager.Logger
+		handle    string
+		path      string
+		sourceURL string
+	}{logger, handle, path, sourceURL})
+	fake.recordInvocation("StreamInP2P", []interface{}{logger, handle, path, sourceURL})
+	fake.streamInP2PMutex.Unlock()
+	if fake.StreamInP2PStub != nil {
+		return fake.StreamInP2PStub(logger, handle, path, sourceURL)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fake.streamInP2PReturns.result1, fake.streamInP2PReturns.result2, fake.streamInP2PReturns.result3
+}
+
+func (fake *FakeVolumeClient) StreamInP2PCallCount() int {
+	fake.streamInP2PMutex.RLock()
+	defer fake.streamInP2PMutex.RUnlock()
+	return len(fake.streamInP2PArgsForCall)
+}
+
+func (fake *FakeVolumeClient) StreamInP2PArgsForCall(i int) (lager.Logger, string, string, string) {
+	fake.streamInP2PMutex.RLock()
+	defer fake.streamInP2PMutex.RUnlock()
+	return fake.streamInP2PArgsForCall[i].logger, fake.streamInP2PArgsForCall[i].handle, fake.streamInP2PArgsForCall[i].path, fake.streamInP2PArgsForCall[i].sourceURL
+}
+
+func (fake *FakeVolumeClient) StreamInP2PReturns(result1 int, result2 string, result3 error) {
+	fake.StreamInP2PStub = nil
+	fake.streamInP2PReturns = struct {
+		result1 int
+		result2 string
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeVolumeClient) StreamInP2PReturnsOnCall(i int, result1 int, result2 string, result3 error) {
+	fake.StreamInP2PStub = nil
+	if fake.streamInP2PReturnsOnCall == nil {
+		fake.streamInP2PReturnsOnCall = make(map[int]struct {
+			result1 int
+			result2 string
+			result3 error
+		})
+	}
+	fake.streamInP2PReturnsOnCall[i] = struct {
+		result1 int
+		result2 string
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeVolumeClient) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -611,6 +960,16 @@ func (fake *FakeVolumeClient) Invocations() map[string][][]interface{} {
 	defer fake.findOrCreateVolumeForResourceCertsMutex.RUnlock()
 	fake.lookupVolumeMutex.RLock()
 	defer fake.lookupVolumeMutex.RUnlock()
+	fake.streamOutP2PURLMutex.RLock()
+	defer fake.streamOutP2PURLMutex.RUnlock()
+	fake.findVolumeWithSameContentMutex.RLock()
+	defer fake.findVolumeWithSameContentMutex.RUnlock()
+	fake.streamOutCompressedMutex.RLock()
+	defer fake.streamOutCompressedMutex.RUnlock()
+	fake.streamInCompressedMutex.RLock()
+	defer fake.streamInCompressedMutex.RUnlock()
+	fake.streamInP2PMutex.RLock()
+	defer fake.streamInP2PMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
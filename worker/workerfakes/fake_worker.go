@@ -279,6 +279,40 @@ type FakeWorker struct {
 	baggageclaimClientReturnsOnCall map[int]struct {
 		result1 baggageclaim.Client
 	}
+	ActiveVolumesStub        func() int
+	activeVolumesMutex       sync.RWMutex
+	activeVolumesArgsForCall []struct{}
+	activeVolumesReturns     struct {
+		result1 int
+	}
+	activeVolumesReturnsOnCall map[int]struct {
+		result1 int
+	}
+	MaxContainersStub        func() int
+	maxContainersMutex       sync.RWMutex
+	maxContainersArgsForCall []struct{}
+	maxContainersReturns     struct {
+		result1 int
+	}
+	maxContainersReturnsOnCall map[int]struct {
+		result1 int
+	}
+	FindVolumeWithSameContentStub        func(arg1 lager.Logger, arg2 worker.Volume) (worker.Volume, bool, error)
+	findVolumeWithSameContentMutex       sync.RWMutex
+	findVolumeWithSameContentArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 worker.Volume
+	}
+	findVolumeWithSameContentReturns struct {
+		result1 worker.Volume
+		result2 bool
+		result3 error
+	}
+	findVolumeWithSameContentReturnsOnCall map[int]struct {
+		result1 worker.Volume
+		result2 bool
+		result3 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -1283,6 +1317,141 @@ func (fake *FakeWorker) BaggageclaimClientReturnsOnCall(i int, result1 baggagecl
 	}{result1}
 }
 
+func (fake *FakeWorker) ActiveVolumes() int {
+	fake.activeVolumesMutex.Lock()
+	ret, specificReturn := fake.activeVolumesReturnsOnCall[len(fake.activeVolumesArgsForCall)]
+	fake.activeVolumesArgsForCall = append(fake.activeVolumesArgsForCall, struct{}{})
+	fake.recordInvocation("ActiveVolumes", []interface{}{})
+	fake.activeVolumesMutex.Unlock()
+	if fake.ActiveVolumesStub != nil {
+		return fake.ActiveVolumesStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.activeVolumesReturns.result1
+}
+
+func (fake *FakeWorker) ActiveVolumesCallCount() int {
+	fake.activeVolumesMutex.RLock()
+	defer fake.activeVolumesMutex.RUnlock()
+	return len(fake.activeVolumesArgsForCall)
+}
+
+func (fake *FakeWorker) ActiveVolumesReturns(result1 int) {
+	fake.ActiveVolumesStub = nil
+	fake.activeVolumesReturns = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeWorker) ActiveVolumesReturnsOnCall(i int, result1 int) {
+	fake.ActiveVolumesStub = nil
+	if fake.activeVolumesReturnsOnCall == nil {
+		fake.activeVolumesReturnsOnCall = make(map[int]struct {
+			result1 int
+		})
+	}
+	fake.activeVolumesReturnsOnCall[i] = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeWorker) MaxContainers() int {
+	fake.maxContainersMutex.Lock()
+	ret, specificReturn := fake.maxContainersReturnsOnCall[len(fake.maxContainersArgsForCall)]
+	fake.maxContainersArgsForCall = append(fake.maxContainersArgsForCall, struct{}{})
+	fake.recordInvocation("MaxContainers", []interface{}{})
+	fake.maxContainersMutex.Unlock()
+	if fake.MaxContainersStub != nil {
+		return fake.MaxContainersStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.maxContainersReturns.result1
+}
+
+func (fake *FakeWorker) MaxContainersCallCount() int {
+	fake.maxContainersMutex.RLock()
+	defer fake.maxContainersMutex.RUnlock()
+	return len(fake.maxContainersArgsForCall)
+}
+
+func (fake *FakeWorker) MaxContainersReturns(result1 int) {
+	fake.MaxContainersStub = nil
+	fake.maxContainersReturns = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeWorker) MaxContainersReturnsOnCall(i int, result1 int) {
+	fake.MaxContainersStub = nil
+	if fake.maxContainersReturnsOnCall == nil {
+		fake.maxContainersReturnsOnCall = make(map[int]struct {
+			result1 int
+		})
+	}
+	fake.maxContainersReturnsOnCall[i] = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeWorker) FindVolumeWithSameContent(arg1 lager.Logger, arg2 worker.Volume) (worker.Volume, bool, error) {
+	fake.findVolumeWithSameContentMutex.Lock()
+	ret, specificReturn := fake.findVolumeWithSameContentReturnsOnCall[len(fake.findVolumeWithSameContentArgsForCall)]
+	fake.findVolumeWithSameContentArgsForCall = append(fake.findVolumeWithSameContentArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 worker.Volume
+	}{arg1, arg2})
+	fake.recordInvocation("FindVolumeWithSameContent", []interface{}{arg1, arg2})
+	fake.findVolumeWithSameContentMutex.Unlock()
+	if fake.FindVolumeWithSameContentStub != nil {
+		return fake.FindVolumeWithSameContentStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fake.findVolumeWithSameContentReturns.result1, fake.findVolumeWithSameContentReturns.result2, fake.findVolumeWithSameContentReturns.result3
+}
+
+func (fake *FakeWorker) FindVolumeWithSameContentCallCount() int {
+	fake.findVolumeWithSameContentMutex.RLock()
+	defer fake.findVolumeWithSameContentMutex.RUnlock()
+	return len(fake.findVolumeWithSameContentArgsForCall)
+}
+
+func (fake *FakeWorker) FindVolumeWithSameContentArgsForCall(i int) (lager.Logger, worker.Volume) {
+	fake.findVolumeWithSameContentMutex.RLock()
+	defer fake.findVolumeWithSameContentMutex.RUnlock()
+	return fake.findVolumeWithSameContentArgsForCall[i].arg1, fake.findVolumeWithSameContentArgsForCall[i].arg2
+}
+
+func (fake *FakeWorker) FindVolumeWithSameContentReturns(result1 worker.Volume, result2 bool, result3 error) {
+	fake.FindVolumeWithSameContentStub = nil
+	fake.findVolumeWithSameContentReturns = struct {
+		result1 worker.Volume
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeWorker) FindVolumeWithSameContentReturnsOnCall(i int, result1 worker.Volume, result2 bool, result3 error) {
+	fake.FindVolumeWithSameContentStub = nil
+	if fake.findVolumeWithSameContentReturnsOnCall == nil {
+		fake.findVolumeWithSameContentReturnsOnCall = make(map[int]struct {
+			result1 worker.Volume
+			result2 bool
+			result3 error
+		})
+	}
+	fake.findVolumeWithSameContentReturnsOnCall[i] = struct {
+		result1 worker.Volume
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeWorker) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -1328,6 +1497,12 @@ func (fake *FakeWorker) Invocations() map[string][][]interface{} {
 	defer fake.gardenClientMutex.RUnlock()
 	fake.baggageclaimClientMutex.RLock()
 	defer fake.baggageclaimClientMutex.RUnlock()
+	fake.activeVolumesMutex.RLock()
+	defer fake.activeVolumesMutex.RUnlock()
+	fake.maxContainersMutex.RLock()
+	defer fake.maxContainersMutex.RUnlock()
+	fake.findVolumeWithSameContentMutex.RLock()
+	defer fake.findVolumeWithSameContentMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
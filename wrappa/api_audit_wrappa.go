@@ -0,0 +1,61 @@
+package wrappa
+
+import (
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/auth"
+	"github.com/concourse/atc/db"
+	"github.com/tedsuo/rata"
+)
+
+type APIAuditWrappa struct {
+	logger            lager.Logger
+	auditLog          db.AuditLog
+	userContextReader auth.UserContextReader
+}
+
+func NewAPIAuditWrappa(
+	logger lager.Logger,
+	auditLog db.AuditLog,
+	userContextReader auth.UserContextReader,
+) Wrappa {
+	return APIAuditWrappa{
+		logger:            logger,
+		auditLog:          auditLog,
+		userContextReader: userContextReader,
+	}
+}
+
+// auditedGETRoutes are the GET routes which are audited all the same, as they
+// give access to something rather than only reading it.
+var auditedGETRoutes = map[string]bool{
+	atc.HijackContainer: true,
+}
+
+// Wrap records an audit event for every request to a route that is not a
+// GET, i.e. every request that may change something, and for hijacks.
+func (wrappa APIAuditWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
+	methods := map[string]string{}
+	for _, route := range atc.Routes {
+		methods[route.Name] = route.Method
+	}
+
+	wrapped := rata.Handlers{}
+
+	for name, handler := range handlers {
+		if methods[name] == "GET" && !auditedGETRoutes[name] {
+			wrapped[name] = handler
+			continue
+		}
+
+		wrapped[name] = AuditHandler{
+			Logger:            wrappa.logger,
+			AuditLog:          wrappa.auditLog,
+			UserContextReader: wrappa.userContextReader,
+			Route:             name,
+			Handler:           handler,
+		}
+	}
+
+	return wrapped
+}
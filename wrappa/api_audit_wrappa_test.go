@@ -0,0 +1,83 @@
+package wrappa_test
+
+import (
+	"net/http"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/auth/authfakes"
+	"github.com/concourse/atc/db/dbfakes"
+	"github.com/concourse/atc/wrappa"
+	"github.com/tedsuo/rata"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("APIAuditWrappa", func() {
+	var (
+		logger                *lagertest.TestLogger
+		fakeAuditLog          *dbfakes.FakeAuditLog
+		fakeUserContextReader *authfakes.FakeUserContextReader
+	)
+
+	BeforeEach(func() {
+		logger = lagertest.NewTestLogger("test")
+		fakeAuditLog = new(dbfakes.FakeAuditLog)
+		fakeUserContextReader = new(authfakes.FakeUserContextReader)
+	})
+
+	audited := func(route string, handler http.Handler) http.Handler {
+		return wrappa.AuditHandler{
+			Logger:            logger,
+			AuditLog:          fakeAuditLog,
+			UserContextReader: fakeUserContextReader,
+			Route:             route,
+			Handler:           handler,
+		}
+	}
+
+	Describe("Wrap", func() {
+		var (
+			inputHandlers    rata.Handlers
+			expectedHandlers rata.Handlers
+			wrappedHandlers  rata.Handlers
+		)
+
+		BeforeEach(func() {
+			inputHandlers = rata.Handlers{}
+
+			for _, route := range atc.Routes {
+				inputHandlers[route.Name] = &stupidHandler{}
+			}
+
+			expectedHandlers = rata.Handlers{}
+
+			for _, route := range atc.Routes {
+				if route.Method == "GET" && route.Name != atc.HijackContainer {
+					expectedHandlers[route.Name] = inputHandlers[route.Name]
+				} else {
+					expectedHandlers[route.Name] = audited(route.Name, inputHandlers[route.Name])
+				}
+			}
+		})
+
+		JustBeforeEach(func() {
+			wrappedHandlers = wrappa.NewAPIAuditWrappa(
+				logger,
+				fakeAuditLog,
+				fakeUserContextReader,
+			).Wrap(inputHandlers)
+		})
+
+		It("audits every route that is not a GET", func() {
+			for name := range inputHandlers {
+				Expect(wrappedHandlers[name]).To(BeIdenticalTo(expectedHandlers[name]))
+			}
+		})
+
+		It("audits container hijacks", func() {
+			Expect(wrappedHandlers[atc.HijackContainer]).To(Equal(audited(atc.HijackContainer, inputHandlers[atc.HijackContainer])))
+		})
+	})
+})
@@ -3,6 +3,7 @@ package wrappa
 import (
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/api/auth"
+	"github.com/concourse/atc/db"
 	"github.com/tedsuo/rata"
 )
 
@@ -13,6 +14,7 @@ type APIAuthWrappa struct {
 	checkBuildReadAccessHandlerFactory  auth.CheckBuildReadAccessHandlerFactory
 	checkBuildWriteAccessHandlerFactory auth.CheckBuildWriteAccessHandlerFactory
 	checkWorkerTeamAccessHandlerFactory auth.CheckWorkerTeamAccessHandlerFactory
+	teamFactory                         db.TeamFactory
 }
 
 func NewAPIAuthWrappa(
@@ -22,6 +24,7 @@ func NewAPIAuthWrappa(
 	checkBuildReadAccessHandlerFactory auth.CheckBuildReadAccessHandlerFactory,
 	checkBuildWriteAccessHandlerFactory auth.CheckBuildWriteAccessHandlerFactory,
 	checkWorkerTeamAccessHandlerFactory auth.CheckWorkerTeamAccessHandlerFactory,
+	teamFactory db.TeamFactory,
 ) *APIAuthWrappa {
 	return &APIAuthWrappa{
 		authValidator:                       authValidator,
@@ -30,15 +33,65 @@ func NewAPIAuthWrappa(
 		checkBuildReadAccessHandlerFactory:  checkBuildReadAccessHandlerFactory,
 		checkBuildWriteAccessHandlerFactory: checkBuildWriteAccessHandlerFactory,
 		checkWorkerTeamAccessHandlerFactory: checkWorkerTeamAccessHandlerFactory,
+		teamFactory:                         teamFactory,
 	}
 }
 
+// routeRoles lists the least privileged team role allowed to use each route.
+// Routes that are not listed can be used by any member of the team,
+// including viewers.
+var routeRoles = map[string]string{
+	atc.SetTeam:     atc.OwnerRole,
+	atc.RenameTeam:  atc.OwnerRole,
+	atc.DestroyTeam: atc.OwnerRole,
+
+	atc.SaveConfig:          atc.MemberRole,
+	atc.DeletePipeline:      atc.MemberRole,
+	atc.ArchivePipeline:     atc.MemberRole,
+	atc.RenamePipeline:      atc.MemberRole,
+	atc.OrderPipelines:      atc.MemberRole,
+	atc.ExposePipeline:      atc.MemberRole,
+	atc.HidePipeline:        atc.MemberRole,
+	atc.CreateBuild:         atc.MemberRole,
+	atc.CreatePipelineBuild: atc.MemberRole,
+	atc.HijackContainer:     atc.MemberRole,
+	atc.CreatePipe:          atc.MemberRole,
+	atc.ReadPipe:            atc.MemberRole,
+	atc.WritePipe:           atc.MemberRole,
+	atc.RegisterWorker:      atc.MemberRole,
+	atc.HeartbeatWorker:     atc.MemberRole,
+	atc.LandWorker:          atc.MemberRole,
+	atc.RetireWorker:        atc.MemberRole,
+	atc.PruneWorker:         atc.MemberRole,
+	atc.DeleteWorker:        atc.MemberRole,
+
+	atc.CreateJobBuild:         atc.PipelineOperatorRole,
+	atc.RerunJobBuild:          atc.PipelineOperatorRole,
+	atc.AbortBuild:             atc.PipelineOperatorRole,
+	atc.SetBuildMetadata:       atc.PipelineOperatorRole,
+	atc.PauseJob:               atc.PipelineOperatorRole,
+	atc.UnpauseJob:             atc.PipelineOperatorRole,
+	atc.PausePipeline:          atc.PipelineOperatorRole,
+	atc.UnpausePipeline:        atc.PipelineOperatorRole,
+	atc.PauseResource:          atc.PipelineOperatorRole,
+	atc.UnpauseResource:        atc.PipelineOperatorRole,
+	atc.CheckResource:          atc.PipelineOperatorRole,
+	atc.EnableResourceVersion:  atc.PipelineOperatorRole,
+	atc.DisableResourceVersion: atc.PipelineOperatorRole,
+	atc.PinResourceVersion:     atc.PipelineOperatorRole,
+	atc.UnpinResource:          atc.PipelineOperatorRole,
+}
+
 func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 	wrapped := rata.Handlers{}
 
 	rejector := auth.UnauthorizedRejector{}
 
 	for name, handler := range handlers {
+		if role, found := routeRoles[name]; found {
+			handler = auth.CheckRoleHandler(handler, role, wrappa.teamFactory, rejector)
+		}
+
 		newHandler := handler
 
 		switch name {
@@ -47,6 +100,7 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.CheckResourceWebHook,
 			atc.GetInfo,
 			atc.ListTeams,
+			atc.TeamEvents,
 			atc.ListAllPipelines,
 			atc.ListPipelines,
 			atc.ListBuilds,
@@ -67,7 +121,8 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			newHandler = wrappa.checkBuildReadAccessHandlerFactory.CheckIfPrivateJobHandler(handler, rejector)
 
 		// resource belongs to authorized team
-		case atc.AbortBuild:
+		case atc.AbortBuild,
+			atc.SetBuildMetadata:
 			newHandler = wrappa.checkBuildWriteAccessHandlerFactory.HandlerFor(handler, rejector)
 
 		// requester is system, admin team, or worker owning team
@@ -108,15 +163,19 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.RenameTeam,
 			atc.DestroyTeam,
 			atc.WritePipe,
-			atc.ListVolumes:
+			atc.ListVolumes,
+			atc.SearchBuildLogs:
 			newHandler = auth.CheckAuthenticationHandler(handler, rejector)
 
 		case atc.GetLogLevel,
-			atc.SetLogLevel:
+			atc.SetLogLevel,
+			atc.ListAuditEvents,
+			atc.GetKeyRotationStatus:
 			newHandler = auth.CheckAdminHandler(handler, rejector)
 
 		// authorized (requested team matches resource team)
-		case atc.CheckResource,
+		case atc.ArchivePipeline,
+			atc.CheckResource,
 			atc.CreateJobBuild,
 			atc.CreatePipelineBuild,
 			atc.DeletePipeline,
@@ -125,14 +184,18 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.GetConfig,
 			atc.GetVersionsDB,
 			atc.ListJobInputs,
+			atc.ListPipelineNotifications,
 			atc.OrderPipelines,
 			atc.PauseJob,
 			atc.PausePipeline,
 			atc.PauseResource,
+			atc.PinResourceVersion,
 			atc.RenamePipeline,
+			atc.RerunJobBuild,
 			atc.UnpauseJob,
 			atc.UnpausePipeline,
 			atc.UnpauseResource,
+			atc.UnpinResource,
 			atc.ExposePipeline,
 			atc.HidePipeline,
 			atc.SaveConfig:
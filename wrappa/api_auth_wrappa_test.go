@@ -1,7 +1,11 @@
 package wrappa_test
 
 import (
+	"context"
 	"net/http"
+	"net/http/httptest"
+
+	"code.cloudfoundry.org/lager/lagertest"
 
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/api/auth"
@@ -24,12 +28,13 @@ var _ = Describe("APIAuthWrappa", func() {
 		fakeCheckBuildWriteAccessHandlerFactory auth.CheckBuildWriteAccessHandlerFactory
 		fakeCheckWorkerTeamAccessHandlerFactory auth.CheckWorkerTeamAccessHandlerFactory
 		fakeBuildFactory                        *dbfakes.FakeBuildFactory
+		fakeTeamFactory                         *dbfakes.FakeTeamFactory
 	)
 
 	BeforeEach(func() {
 		fakeAuthValidator = new(authfakes.FakeValidator)
 		fakeUserContextReader = new(authfakes.FakeUserContextReader)
-		fakeTeamFactory := new(dbfakes.FakeTeamFactory)
+		fakeTeamFactory = new(dbfakes.FakeTeamFactory)
 		workerFactory := new(dbfakes.FakeWorkerFactory)
 		fakeBuildFactory = new(dbfakes.FakeBuildFactory)
 		fakeCheckPipelineAccessHandlerFactory = auth.NewCheckPipelineAccessHandlerFactory(
@@ -42,6 +47,10 @@ var _ = Describe("APIAuthWrappa", func() {
 		fakeCheckWorkerTeamAccessHandlerFactory = auth.NewCheckWorkerTeamAccessHandlerFactory(workerFactory)
 	})
 
+	requiresRole := func(role string, handler http.Handler) http.Handler {
+		return auth.CheckRoleHandler(handler, role, fakeTeamFactory, rejector)
+	}
+
 	unauthenticated := func(handler http.Handler) http.Handler {
 		return auth.CSRFValidationHandler(
 			auth.WrapHandler(
@@ -198,6 +207,7 @@ var _ = Describe("APIAuthWrappa", func() {
 				atc.ListBuilds:            unauthenticated(inputHandlers[atc.ListBuilds]),
 				atc.ListPipelines:         unauthenticated(inputHandlers[atc.ListPipelines]),
 				atc.ListTeams:             unauthenticated(inputHandlers[atc.ListTeams]),
+				atc.TeamEvents:            unauthenticated(inputHandlers[atc.TeamEvents]),
 				atc.MainJobBadge:          unauthenticated(inputHandlers[atc.MainJobBadge]),
 				atc.LegacyListAuthMethods: unauthenticated(inputHandlers[atc.LegacyListAuthMethods]),
 				atc.LegacyGetAuthToken:    unauthenticated(inputHandlers[atc.LegacyGetAuthToken]),
@@ -213,12 +223,13 @@ var _ = Describe("APIAuthWrappa", func() {
 				atc.GetBuildPreparation: checksIfPrivateJob(inputHandlers[atc.GetBuildPreparation]),
 
 				// resource belongs to authorized team
-				atc.AbortBuild: checkWritePermissionForBuild(inputHandlers[atc.AbortBuild]),
+				atc.AbortBuild:       checkWritePermissionForBuild(requiresRole(atc.PipelineOperatorRole, inputHandlers[atc.AbortBuild])),
+				atc.SetBuildMetadata: checkWritePermissionForBuild(requiresRole(atc.PipelineOperatorRole, inputHandlers[atc.SetBuildMetadata])),
 
 				// resource belongs to authorized team
-				atc.PruneWorker:  checkTeamAccessForWorker(inputHandlers[atc.PruneWorker]),
-				atc.LandWorker:   checkTeamAccessForWorker(inputHandlers[atc.LandWorker]),
-				atc.RetireWorker: checkTeamAccessForWorker(inputHandlers[atc.RetireWorker]),
+				atc.PruneWorker:  checkTeamAccessForWorker(requiresRole(atc.MemberRole, inputHandlers[atc.PruneWorker])),
+				atc.LandWorker:   checkTeamAccessForWorker(requiresRole(atc.MemberRole, inputHandlers[atc.LandWorker])),
+				atc.RetireWorker: checkTeamAccessForWorker(requiresRole(atc.MemberRole, inputHandlers[atc.RetireWorker])),
 
 				// belongs to public pipeline or authorized
 				atc.GetPipeline:                   openForPublicPipelineOrAuthorized(inputHandlers[atc.GetPipeline]),
@@ -237,48 +248,58 @@ var _ = Describe("APIAuthWrappa", func() {
 				atc.GetResourceVersion:            openForPublicPipelineOrAuthorized(inputHandlers[atc.GetResourceVersion]),
 
 				// authenticated
-				atc.CreateBuild:     authenticated(inputHandlers[atc.CreateBuild]),
-				atc.CreatePipe:      authenticated(inputHandlers[atc.CreatePipe]),
+				atc.CreateBuild:     authenticated(requiresRole(atc.MemberRole, inputHandlers[atc.CreateBuild])),
+				atc.CreatePipe:      authenticated(requiresRole(atc.MemberRole, inputHandlers[atc.CreatePipe])),
 				atc.GetContainer:    authenticated(inputHandlers[atc.GetContainer]),
-				atc.HijackContainer: authenticated(inputHandlers[atc.HijackContainer]),
+				atc.HijackContainer: authenticated(requiresRole(atc.MemberRole, inputHandlers[atc.HijackContainer])),
 				atc.ListContainers:  authenticated(inputHandlers[atc.ListContainers]),
 				atc.ListVolumes:     authenticated(inputHandlers[atc.ListVolumes]),
 				atc.ListWorkers:     authenticated(inputHandlers[atc.ListWorkers]),
-				atc.ReadPipe:        authenticated(inputHandlers[atc.ReadPipe]),
-				atc.RegisterWorker:  authenticated(inputHandlers[atc.RegisterWorker]),
-				atc.HeartbeatWorker: authenticated(inputHandlers[atc.HeartbeatWorker]),
-				atc.DeleteWorker:    authenticated(inputHandlers[atc.DeleteWorker]),
+				atc.SearchBuildLogs: authenticated(inputHandlers[atc.SearchBuildLogs]),
+				atc.ReadPipe:        authenticated(requiresRole(atc.MemberRole, inputHandlers[atc.ReadPipe])),
+				atc.RegisterWorker:  authenticated(requiresRole(atc.MemberRole, inputHandlers[atc.RegisterWorker])),
+				atc.HeartbeatWorker: authenticated(requiresRole(atc.MemberRole, inputHandlers[atc.HeartbeatWorker])),
+				atc.DeleteWorker:    authenticated(requiresRole(atc.MemberRole, inputHandlers[atc.DeleteWorker])),
 
-				atc.SetTeam:     authenticated(inputHandlers[atc.SetTeam]),
-				atc.RenameTeam:  authenticated(inputHandlers[atc.RenameTeam]),
-				atc.DestroyTeam: authenticated(inputHandlers[atc.DestroyTeam]),
-				atc.WritePipe:   authenticated(inputHandlers[atc.WritePipe]),
+				atc.SetTeam:     authenticated(requiresRole(atc.OwnerRole, inputHandlers[atc.SetTeam])),
+				atc.RenameTeam:  authenticated(requiresRole(atc.OwnerRole, inputHandlers[atc.RenameTeam])),
+				atc.DestroyTeam: authenticated(requiresRole(atc.OwnerRole, inputHandlers[atc.DestroyTeam])),
+				atc.WritePipe:   authenticated(requiresRole(atc.MemberRole, inputHandlers[atc.WritePipe])),
 
 				// authenticated and is admin
 				atc.GetLogLevel: authenticatedAndAdmin(inputHandlers[atc.GetLogLevel]),
 				atc.SetLogLevel: authenticatedAndAdmin(inputHandlers[atc.SetLogLevel]),
 
+				atc.ListAuditEvents:      authenticatedAndAdmin(inputHandlers[atc.ListAuditEvents]),
+				atc.GetKeyRotationStatus: authenticatedAndAdmin(inputHandlers[atc.GetKeyRotationStatus]),
+
 				// authorized (requested team matches resource team)
-				atc.CheckResource:          authorized(inputHandlers[atc.CheckResource]),
-				atc.CreateJobBuild:         authorized(inputHandlers[atc.CreateJobBuild]),
-				atc.DeletePipeline:         authorized(inputHandlers[atc.DeletePipeline]),
-				atc.DisableResourceVersion: authorized(inputHandlers[atc.DisableResourceVersion]),
-				atc.EnableResourceVersion:  authorized(inputHandlers[atc.EnableResourceVersion]),
+				atc.CheckResource:          authorized(requiresRole(atc.PipelineOperatorRole, inputHandlers[atc.CheckResource])),
+				atc.CreateJobBuild:         authorized(requiresRole(atc.PipelineOperatorRole, inputHandlers[atc.CreateJobBuild])),
+				atc.DeletePipeline:         authorized(requiresRole(atc.MemberRole, inputHandlers[atc.DeletePipeline])),
+				atc.ArchivePipeline:        authorized(requiresRole(atc.MemberRole, inputHandlers[atc.ArchivePipeline])),
+				atc.DisableResourceVersion: authorized(requiresRole(atc.PipelineOperatorRole, inputHandlers[atc.DisableResourceVersion])),
+				atc.EnableResourceVersion:  authorized(requiresRole(atc.PipelineOperatorRole, inputHandlers[atc.EnableResourceVersion])),
 				atc.GetConfig:              authorized(inputHandlers[atc.GetConfig]),
 				atc.GetVersionsDB:          authorized(inputHandlers[atc.GetVersionsDB]),
 				atc.ListJobInputs:          authorized(inputHandlers[atc.ListJobInputs]),
-				atc.OrderPipelines:         authorized(inputHandlers[atc.OrderPipelines]),
-				atc.PauseJob:               authorized(inputHandlers[atc.PauseJob]),
-				atc.PausePipeline:          authorized(inputHandlers[atc.PausePipeline]),
-				atc.PauseResource:          authorized(inputHandlers[atc.PauseResource]),
-				atc.RenamePipeline:         authorized(inputHandlers[atc.RenamePipeline]),
-				atc.SaveConfig:             authorized(inputHandlers[atc.SaveConfig]),
-				atc.UnpauseJob:             authorized(inputHandlers[atc.UnpauseJob]),
-				atc.UnpausePipeline:        authorized(inputHandlers[atc.UnpausePipeline]),
-				atc.UnpauseResource:        authorized(inputHandlers[atc.UnpauseResource]),
-				atc.ExposePipeline:         authorized(inputHandlers[atc.ExposePipeline]),
-				atc.HidePipeline:           authorized(inputHandlers[atc.HidePipeline]),
-				atc.CreatePipelineBuild:    authorized(inputHandlers[atc.CreatePipelineBuild]),
+				atc.OrderPipelines:         authorized(requiresRole(atc.MemberRole, inputHandlers[atc.OrderPipelines])),
+				atc.PauseJob:               authorized(requiresRole(atc.PipelineOperatorRole, inputHandlers[atc.PauseJob])),
+				atc.PausePipeline:          authorized(requiresRole(atc.PipelineOperatorRole, inputHandlers[atc.PausePipeline])),
+				atc.PauseResource:          authorized(requiresRole(atc.PipelineOperatorRole, inputHandlers[atc.PauseResource])),
+				atc.PinResourceVersion:     authorized(requiresRole(atc.PipelineOperatorRole, inputHandlers[atc.PinResourceVersion])),
+				atc.RenamePipeline:         authorized(requiresRole(atc.MemberRole, inputHandlers[atc.RenamePipeline])),
+				atc.RerunJobBuild:          authorized(requiresRole(atc.PipelineOperatorRole, inputHandlers[atc.RerunJobBuild])),
+				atc.SaveConfig:             authorized(requiresRole(atc.MemberRole, inputHandlers[atc.SaveConfig])),
+				atc.UnpauseJob:             authorized(requiresRole(atc.PipelineOperatorRole, inputHandlers[atc.UnpauseJob])),
+				atc.UnpausePipeline:        authorized(requiresRole(atc.PipelineOperatorRole, inputHandlers[atc.UnpausePipeline])),
+				atc.UnpauseResource:        authorized(requiresRole(atc.PipelineOperatorRole, inputHandlers[atc.UnpauseResource])),
+				atc.UnpinResource:          authorized(requiresRole(atc.PipelineOperatorRole, inputHandlers[atc.UnpinResource])),
+				atc.ExposePipeline:         authorized(requiresRole(atc.MemberRole, inputHandlers[atc.ExposePipeline])),
+				atc.HidePipeline:           authorized(requiresRole(atc.MemberRole, inputHandlers[atc.HidePipeline])),
+				atc.CreatePipelineBuild:    authorized(requiresRole(atc.MemberRole, inputHandlers[atc.CreatePipelineBuild])),
+
+				atc.ListPipelineNotifications: authorized(inputHandlers[atc.ListPipelineNotifications]),
 			}
 		})
 
@@ -290,6 +311,7 @@ var _ = Describe("APIAuthWrappa", func() {
 				fakeCheckBuildReadAccessHandlerFactory,
 				fakeCheckBuildWriteAccessHandlerFactory,
 				fakeCheckWorkerTeamAccessHandlerFactory,
+				fakeTeamFactory,
 			).Wrap(inputHandlers)
 		})
 
@@ -299,4 +321,108 @@ var _ = Describe("APIAuthWrappa", func() {
 			}
 		})
 	})
+
+	Describe("owner-only routes", func() {
+		var (
+			fakeValidator   *authfakes.FakeValidator
+			wrappedHandlers rata.Handlers
+		)
+
+		ownerOnlyRoutes := []string{
+			atc.SetTeam,
+			atc.RenameTeam,
+			atc.DestroyTeam,
+		}
+
+		BeforeEach(func() {
+			fakeValidator = new(authfakes.FakeValidator)
+			fakeValidator.IsAuthenticatedReturns(true)
+
+			fakeUserContextReader.GetTeamReturns("some-team", false, true)
+
+			inputHandlers := rata.Handlers{}
+			for _, route := range ownerOnlyRoutes {
+				inputHandlers[route] = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					w.WriteHeader(http.StatusOK)
+				})
+			}
+
+			wrappedHandlers = wrappa.NewAPIAuthWrappa(
+				fakeValidator,
+				fakeUserContextReader,
+				fakeCheckPipelineAccessHandlerFactory,
+				fakeCheckBuildReadAccessHandlerFactory,
+				fakeCheckBuildWriteAccessHandlerFactory,
+				fakeCheckWorkerTeamAccessHandlerFactory,
+				fakeTeamFactory,
+			).Wrap(inputHandlers)
+		})
+
+		serve := func(route string) int {
+			request, err := http.NewRequest("PUT", "/api/v1/teams/some-team?:team_name=some-team", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			request = request.WithContext(context.WithValue(request.Context(), "logger", lagertest.NewTestLogger("test")))
+
+			recorder := httptest.NewRecorder()
+			wrappedHandlers[route].ServeHTTP(recorder, request)
+
+			return recorder.Code
+		}
+
+		for _, role := range []string{atc.MemberRole, atc.ViewerRole} {
+			role := role
+
+			Context("with a "+role+" token", func() {
+				BeforeEach(func() {
+					fakeUserContextReader.GetRoleReturns(role, true)
+				})
+
+				It("rejects the request", func() {
+					for _, route := range ownerOnlyRoutes {
+						Expect(serve(route)).To(Equal(http.StatusForbidden), route)
+					}
+				})
+			})
+		}
+
+		Context("with an owner token", func() {
+			BeforeEach(func() {
+				fakeUserContextReader.GetRoleReturns(atc.OwnerRole, true)
+			})
+
+			It("handles the request", func() {
+				for _, route := range ownerOnlyRoutes {
+					Expect(serve(route)).To(Equal(http.StatusOK), route)
+				}
+			})
+		})
+
+		Context("with a token that has no role", func() {
+			BeforeEach(func() {
+				fakeUserContextReader.GetRoleReturns("", false)
+				fakeUserContextReader.GetIdentityReturns(auth.Identity{
+					Provider: "github",
+					User:     "some-member",
+				}, true)
+
+				fakeTeam := new(dbfakes.FakeTeam)
+				fakeTeam.RolesReturns(atc.TeamRoles{
+					atc.OwnerRole: {
+						"github": atc.RoleMembers{Users: []string{"some-owner"}},
+					},
+					atc.MemberRole: {
+						"github": atc.RoleMembers{Users: []string{"some-member"}},
+					},
+				})
+				fakeTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+			})
+
+			It("rejects a user the team does not make an owner", func() {
+				for _, route := range ownerOnlyRoutes {
+					Expect(serve(route)).To(Equal(http.StatusForbidden), route)
+				}
+			})
+		})
+	})
 })
@@ -0,0 +1,81 @@
+package wrappa
+
+import (
+	"bufio"
+	"net"
+	"net/http"
+	"strings"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/api/auth"
+	"github.com/concourse/atc/db"
+)
+
+type AuditHandler struct {
+	Logger            lager.Logger
+	AuditLog          db.AuditLog
+	UserContextReader auth.UserContextReader
+	Route             string
+	Handler           http.Handler
+}
+
+func (handler AuditHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+
+	handler.Handler.ServeHTTP(recorder, r)
+
+	event := db.AuditEvent{
+		Route:  handler.Route,
+		Params: map[string]string{},
+		Status: recorder.status,
+	}
+
+	if user, found := handler.UserContextReader.GetUser(r); found {
+		event.User = user
+	}
+
+	if teamName, _, found := handler.UserContextReader.GetTeam(r); found {
+		event.TeamName = teamName
+	}
+
+	// route parameters are passed along as query parameters prefixed with ':';
+	// the request's own query parameters are left out, as they may carry
+	// secrets such as webhook tokens
+	for key, values := range r.URL.Query() {
+		if strings.HasPrefix(key, ":") && len(values) > 0 {
+			event.Params[strings.TrimPrefix(key, ":")] = values[0]
+		}
+	}
+
+	err := handler.AuditLog.Record(event)
+	if err != nil {
+		handler.Logger.Error("failed-to-record-audit-event", err, lager.Data{"route": handler.Route})
+	}
+}
+
+type statusRecorder struct {
+	http.ResponseWriter
+
+	status int
+}
+
+func (recorder *statusRecorder) WriteHeader(status int) {
+	recorder.status = status
+	recorder.ResponseWriter.WriteHeader(status)
+}
+
+// Hijack lets handlers such as the container hijack handler take over the
+// connection, which is recorded as them switching protocols.
+func (recorder *statusRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := recorder.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, http.ErrNotSupported
+	}
+
+	conn, rw, err := hijacker.Hijack()
+	if err == nil {
+		recorder.status = http.StatusSwitchingProtocols
+	}
+
+	return conn, rw, err
+}
@@ -0,0 +1,118 @@
+package wrappa_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/auth/authfakes"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/dbfakes"
+	"github.com/concourse/atc/wrappa"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AuditHandler", func() {
+	var (
+		fakeAuditLog          *dbfakes.FakeAuditLog
+		fakeUserContextReader *authfakes.FakeUserContextReader
+
+		route      string
+		requestURL string
+		status     int
+		recorder   *httptest.ResponseRecorder
+	)
+
+	BeforeEach(func() {
+		fakeAuditLog = new(dbfakes.FakeAuditLog)
+		fakeUserContextReader = new(authfakes.FakeUserContextReader)
+
+		route = atc.PausePipeline
+		requestURL = "http://example.com/api/v1/teams/some-team/pipelines/some-pipeline/pause?:team_name=some-team&:pipeline_name=some-pipeline"
+		status = http.StatusOK
+		recorder = httptest.NewRecorder()
+	})
+
+	JustBeforeEach(func() {
+		handler := wrappa.AuditHandler{
+			Logger:            lagertest.NewTestLogger("test"),
+			AuditLog:          fakeAuditLog,
+			UserContextReader: fakeUserContextReader,
+			Route:             route,
+			Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(status)
+			}),
+		}
+
+		request, err := http.NewRequest("PUT", requestURL, nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		handler.ServeHTTP(recorder, request)
+	})
+
+	Context("when the request is made by a user", func() {
+		BeforeEach(func() {
+			fakeUserContextReader.GetUserReturns("some-user", true)
+			fakeUserContextReader.GetTeamReturns("some-team", false, true)
+		})
+
+		It("records who made the request, where, and with which parameters", func() {
+			Expect(fakeAuditLog.RecordCallCount()).To(Equal(1))
+			Expect(fakeAuditLog.RecordArgsForCall(0)).To(Equal(db.AuditEvent{
+				User:     "some-user",
+				TeamName: "some-team",
+				Route:    atc.PausePipeline,
+				Params: map[string]string{
+					"team_name":     "some-team",
+					"pipeline_name": "some-pipeline",
+				},
+				Status: http.StatusOK,
+			}))
+		})
+	})
+
+	Context("when the request is a resource webhook", func() {
+		BeforeEach(func() {
+			route = atc.CheckResourceWebHook
+			requestURL = "http://example.com/api/v1/teams/some-team/pipelines/some-pipeline/resources/some-resource/check/webhook?webhook_token=some-secret&:team_name=some-team&:pipeline_name=some-pipeline&:resource_name=some-resource"
+		})
+
+		It("records the route parameters but not the webhook token", func() {
+			Expect(fakeAuditLog.RecordCallCount()).To(Equal(1))
+			Expect(fakeAuditLog.RecordArgsForCall(0).Params).To(Equal(map[string]string{
+				"team_name":     "some-team",
+				"pipeline_name": "some-pipeline",
+				"resource_name": "some-resource",
+			}))
+		})
+	})
+
+	Context("when the request fails", func() {
+		BeforeEach(func() {
+			status = http.StatusForbidden
+		})
+
+		It("records the outcome", func() {
+			Expect(fakeAuditLog.RecordCallCount()).To(Equal(1))
+			Expect(fakeAuditLog.RecordArgsForCall(0).Status).To(Equal(http.StatusForbidden))
+		})
+
+		It("responds with the handler's status", func() {
+			Expect(recorder.Code).To(Equal(http.StatusForbidden))
+		})
+	})
+
+	Context("when recording the event fails", func() {
+		BeforeEach(func() {
+			fakeAuditLog.RecordReturns(errors.New("nope"))
+		})
+
+		It("still responds", func() {
+			Expect(recorder.Code).To(Equal(http.StatusOK))
+		})
+	})
+})